@@ -0,0 +1,198 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package addrs provides canonical, structured addresses for Terraform/
+// OpenTofu state resources, modeled on Terraform's own internal addrs
+// package (AbsResource, ResourceInstance, ModuleInstance, InstanceKey).
+// Where internal/command/si and internal/diff used to build and compare
+// "module.foo.data.type.name[index]" strings by hand, callers should instead
+// build one of these types and join or dedupe on its UniqueKey, so two
+// addresses are only ever considered equal because their structured fields
+// match, not because two independently-built strings happened to.
+package addrs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// UniqueKey is a comparable value derived from a ResourceInstance or
+// AbsResource's structured fields, safe to use as a map key. Two addresses
+// produce equal UniqueKeys if and only if they refer to the same resource
+// (instance), regardless of how each was originally parsed or formatted.
+type UniqueKey string
+
+// InstanceKey identifies one instance of a resource declared with count or
+// for_each: NoKey for a resource declared without either, IntKey for
+// count, StringKey for for_each.
+type InstanceKey interface {
+	instanceKey()
+	// String renders the key's bracketed suffix, e.g. "[0]" or `["web"]`,
+	// or "" for NoKey.
+	String() string
+}
+
+// NoKey is the InstanceKey of a resource declared without count or
+// for_each.
+type NoKey struct{}
+
+func (NoKey) instanceKey()   {}
+func (NoKey) String() string { return "" }
+
+// IntKey is the InstanceKey of one instance of a resource declared with
+// count.
+type IntKey int
+
+func (IntKey) instanceKey() {}
+func (k IntKey) String() string {
+	return fmt.Sprintf("[%d]", int(k))
+}
+
+// StringKey is the InstanceKey of one instance of a resource declared with
+// for_each.
+type StringKey string
+
+func (StringKey) instanceKey() {}
+func (k StringKey) String() string {
+	return fmt.Sprintf("[%q]", string(k))
+}
+
+// ModuleInstance is the sequence of module call names leading from the
+// root module to a resource, e.g. ["a", "b"] for "module.a.module.b".
+type ModuleInstance []string
+
+// String renders m as "module.a.module.b", or "" for the root module.
+func (m ModuleInstance) String() string {
+	if len(m) == 0 {
+		return ""
+	}
+	parts := make([]string, len(m))
+	for i, name := range m {
+		parts[i] = "module." + name
+	}
+	return strings.Join(parts, ".")
+}
+
+// AbsResource is a resource address absolute to the root module: a module
+// path, the managed/data mode, and the resource's type and name.
+type AbsResource struct {
+	Module ModuleInstance
+	Mode   string // "managed" or "data"
+	Type   string
+	Name   string
+}
+
+// String renders r as "module.a.module.b.data.type.name", omitting the
+// module prefix for root-module resources and the "data" segment for
+// managed resources.
+func (r AbsResource) String() string {
+	var parts []string
+	if mod := r.Module.String(); mod != "" {
+		parts = append(parts, mod)
+	}
+	if r.Mode == "data" {
+		parts = append(parts, "data")
+	}
+	parts = append(parts, r.Type+"."+r.Name)
+	return strings.Join(parts, ".")
+}
+
+// UniqueKey returns a comparable value identifying r, ignoring any
+// particular instance.
+func (r AbsResource) UniqueKey() UniqueKey {
+	return UniqueKey(r.String())
+}
+
+// ResourceInstance is one instance of an AbsResource, keyed by Key when the
+// resource was declared with count or for_each.
+type ResourceInstance struct {
+	Resource AbsResource
+	Key      InstanceKey
+}
+
+// String renders ri as its Resource's address followed by Key's bracketed
+// suffix, e.g. "aws_instance.web[0]".
+func (ri ResourceInstance) String() string {
+	key := ""
+	if ri.Key != nil {
+		key = ri.Key.String()
+	}
+	return ri.Resource.String() + key
+}
+
+// UniqueKey returns a comparable value identifying ri, suitable as a map
+// key for deduping matches or joining two states' resource instances by
+// address instead of by string comparison.
+func (ri ResourceInstance) UniqueKey() UniqueKey {
+	return UniqueKey(ri.String())
+}
+
+// ParseAbsResourceInstanceStr parses a fully-qualified resource instance
+// address in the "module.a.module.b.data.type.name[index]" shape produced
+// by String, the same shape internal/command/si and internal/diff have
+// always built by hand. Unlike si.ParseQuery, every component is required
+// and glob metacharacters have no special meaning (they are kept as literal
+// characters of the type, name or key) -- this parses one concrete address,
+// not a query pattern.
+func ParseAbsResourceInstanceStr(s string) (ResourceInstance, error) {
+	parts := strings.Split(s, ".")
+	pos := 0
+
+	var module ModuleInstance
+	for pos < len(parts) && parts[pos] == "module" {
+		if pos+1 >= len(parts) {
+			return ResourceInstance{}, fmt.Errorf("invalid address %q: 'module' must be followed by a module name", s)
+		}
+		module = append(module, parts[pos+1])
+		pos += 2
+	}
+
+	mode := "managed"
+	if pos < len(parts) && parts[pos] == "data" {
+		mode = "data"
+		pos++
+	}
+
+	if pos+1 >= len(parts) {
+		return ResourceInstance{}, fmt.Errorf("invalid address %q: expected type.name", s)
+	}
+	resType := parts[pos]
+	pos++
+
+	nameAndIndex := strings.Join(parts[pos:], ".")
+	if nameAndIndex == "" {
+		return ResourceInstance{}, fmt.Errorf("invalid address %q: missing resource name", s)
+	}
+
+	name := nameAndIndex
+	var key InstanceKey = NoKey{}
+	if idx := strings.Index(nameAndIndex, "["); idx != -1 {
+		if !strings.HasSuffix(nameAndIndex, "]") {
+			return ResourceInstance{}, fmt.Errorf("invalid address %q: unterminated index", s)
+		}
+		name = nameAndIndex[:idx]
+		indexStr := nameAndIndex[idx+1 : len(nameAndIndex)-1]
+
+		switch {
+		case strings.HasPrefix(indexStr, `"`) && strings.HasSuffix(indexStr, `"`):
+			key = StringKey(indexStr[1 : len(indexStr)-1])
+		default:
+			n, err := strconv.Atoi(indexStr)
+			if err != nil {
+				return ResourceInstance{}, fmt.Errorf("invalid address %q: index %q is neither a quoted string nor an integer", s, indexStr)
+			}
+			key = IntKey(n)
+		}
+	}
+
+	return ResourceInstance{
+		Resource: AbsResource{
+			Module: module,
+			Mode:   mode,
+			Type:   resType,
+			Name:   name,
+		},
+		Key: key,
+	}, nil
+}
@@ -29,21 +29,45 @@ type Attr struct {
 	OutputKey string `yaml:"outputKey" json:"OutputKey"`
 	// Transformation spec to apply to the output value.
 	TransformSpec string `yaml:"transformSpec" json:"TransformSpec"`
+	// MinWidth/MaxWidth are optional column-width hints for table output:
+	// TableWriter never shrinks this column below MinWidth when fitting to
+	// the terminal, and caps it at MaxWidth even when there's room to spare.
+	// Zero means no hint. Set via the fourth, width, field of an --attrs
+	// spec (e.g. "id:id::10-40"); see AttrList.Set.
+	MinWidth int `yaml:"minWidth" json:"MinWidth"`
+	MaxWidth int `yaml:"maxWidth" json:"MaxWidth"`
 }
 
+// numericTokenRegex matches the numeric humanization tokens in a transform
+// spec: "f<n>" for fixed-point, or a single b/B/,/o token.
+var numericTokenRegex = regexp.MustCompile(`f\d+|[bB,o]`)
+
 // Transform applies the attribute's transform spec to a value and returns the
 // transformed result.
 func (a *Attr) Transform(value interface{}) interface{} {
 
-	// TODO Currently only string values can be transformed.
-	result, ok := value.(string)
-	if !ok {
+	result, isString := value.(string)
+
+	var numValue float64
+	isNumeric := false
+
+	if !isString {
 		if mapValue, ok := value.(map[string]interface{}); ok {
 			log.Tracef("map value: value=%v", value)
 			return mapValue
 		}
-		log.Tracef("non-string value: value=%v", value)
-		return value
+		if num, ok := toFloat64(value); ok {
+			numValue = num
+			isNumeric = true
+			result = strconv.FormatFloat(num, 'f', -1, 64)
+			log.Tracef("numeric coerced: value=%v, result=%s", value, result)
+		} else {
+			log.Tracef("non-string value: value=%v", value)
+			return value
+		}
+	} else if num, err := strconv.ParseFloat(result, 64); err == nil {
+		numValue = num
+		isNumeric = true
 	}
 
 	// Convert UTC time to local or time ago.
@@ -71,6 +95,16 @@ func (a *Attr) Transform(value interface{}) interface{} {
 		}
 	}
 
+	// Humanize numeric values: bytes (b/B), thousands separator (,), ordinals
+	// (o), or fixed-point (f<n>). "Last wins" so a per-attr token appended
+	// after a prepended global spec takes precedence.
+	if isNumeric {
+		if matches := numericTokenRegex.FindAllString(a.TransformSpec, -1); len(matches) != 0 {
+			result = humanizeNumeric(matches[len(matches)-1], numValue)
+			log.Tracef("numeric humanize: token=%s, result=%s", matches[len(matches)-1], result)
+		}
+	}
+
 	// We need to know which case transformation appears last. This covers the
 	// case where there has been a global case transformation prepended to the
 	// attrs transformation and allows the attr's to carry more weight.
@@ -89,9 +123,12 @@ func (a *Attr) Transform(value interface{}) interface{} {
 	// Is it a length-based transformation?
 	if a.TransformSpec != "" {
 		re := regexp.MustCompile(`-?\d+`)
+		// Strip f<n> numeric tokens first so their digit doesn't get mistaken
+		// for a length spec.
+		lengthSpec := numericTokenRegex.ReplaceAllString(a.TransformSpec, "")
 		// Same logic as above re: case. This allows a more specific length
 		// transformation to override a global one.
-		match := re.FindAllString(a.TransformSpec, -1)
+		match := re.FindAllString(lengthSpec, -1)
 		if len(match) != 0 {
 			// Take the last (overriding) match.
 			l, _ := strconv.Atoi(match[len(match)-1])
@@ -128,13 +165,16 @@ func (a *AttrList) Set(value string) error {
 		jsonIdx = iota
 		outputIdx
 		transformIdx
+		widthIdx
 	)
 
-	// There are three : delimited fields in each spec. The first is the key to
-	// extract from the JSON object. The second is the key to use in the output.
-	// The third is the transformation spec to apply to the output value. The
-	// latter two are optional. The output key defaults to the last
-	// section of the JSON key.
+	// There are up to four : delimited fields in each spec. The first is the
+	// key to extract from the JSON object. The second is the key to use in
+	// the output. The third is the transformation spec to apply to the
+	// output value. The fourth is a column-width hint of the form
+	// "min-max" (either side optional; a bare number is taken as a max-only
+	// hint). All but the first are optional. The output key defaults to the
+	// last section of the JSON key.
 	specs := strings.Split(value, ",")
 	log.Debugf("specs split: specs=%v", specs)
 specloop:
@@ -182,6 +222,11 @@ specloop:
 		}
 		log.Tracef("transform set: spec=%s", attr.TransformSpec)
 
+		if len(fields) > widthIdx {
+			attr.MinWidth, attr.MaxWidth = parseWidthSpec(strings.TrimSpace(fields[widthIdx]))
+		}
+		log.Tracef("width set: min=%d, max=%d", attr.MinWidth, attr.MaxWidth)
+
 		// If the attr already exists in the list (because it is a default for
 		// a command or the user double-entered it), apply the OutputKey, Include
 		// and TransformSpec to the existing Attr.
@@ -191,6 +236,8 @@ specloop:
 				(*a)[i].Include = attr.Include
 				(*a)[i].OutputKey = attr.OutputKey
 				(*a)[i].TransformSpec = attr.TransformSpec
+				(*a)[i].MinWidth = attr.MinWidth
+				(*a)[i].MaxWidth = attr.MaxWidth
 				log.Tracef("existing updated: i=%d", i)
 				continue specloop
 			}
@@ -214,6 +261,36 @@ specloop:
 	return nil
 }
 
+// parseWidthSpec parses the fourth, width, field of an --attrs spec: "min-max"
+// with either side optional ("-40" is max-only, "10-" is min-only), or a
+// bare number taken as a max-only hint ("40" is shorthand for "-40").
+// Unparseable or empty sides are left at zero (no hint).
+func parseWidthSpec(spec string) (minWidth, maxWidth int) {
+	if spec == "" {
+		return 0, 0
+	}
+
+	if !strings.Contains(spec, "-") {
+		if n, err := strconv.Atoi(spec); err == nil {
+			return 0, n
+		}
+		return 0, 0
+	}
+
+	parts := strings.SplitN(spec, "-", 2)
+	if parts[0] != "" {
+		if n, err := strconv.Atoi(parts[0]); err == nil {
+			minWidth = n
+		}
+	}
+	if parts[1] != "" {
+		if n, err := strconv.Atoi(parts[1]); err == nil {
+			maxWidth = n
+		}
+	}
+	return minWidth, maxWidth
+}
+
 // SetGlobalTransformSpec inserts a global transform spec at the front of all
 // attrs in the list.
 func (a *AttrList) SetGlobalTransformSpec() error {
@@ -244,11 +321,25 @@ func (a *AttrList) SetGlobalTransformSpec() error {
 }
 
 // String returns a string representation of the AttrList. This matches the
-// format of the original --attrs flag.
+// format of the original --attrs flag. The width field is only appended
+// when a min or max hint is actually set, so attrs without one round-trip
+// through the original three-field form.
 func (a *AttrList) String() string {
 	result := make([]string, 0, len(*a))
 	for _, attr := range *a {
-		result = append(result, fmt.Sprintf("%s:%s:%s", attr.Key, attr.OutputKey, attr.TransformSpec))
+		spec := fmt.Sprintf("%s:%s:%s", attr.Key, attr.OutputKey, attr.TransformSpec)
+		if attr.MinWidth != 0 || attr.MaxWidth != 0 {
+			width := ""
+			if attr.MinWidth != 0 {
+				width = strconv.Itoa(attr.MinWidth)
+			}
+			width += "-"
+			if attr.MaxWidth != 0 {
+				width += strconv.Itoa(attr.MaxWidth)
+			}
+			spec += ":" + width
+		}
+		result = append(result, spec)
 	}
 
 	resultStr := strings.Join(result, ",")
@@ -258,3 +349,63 @@ func (a *AttrList) String() string {
 
 // Type returns the flag type for use with the flag.Value interface.
 func (a *AttrList) Type() string { return "list" }
+
+// toFloat64 attempts to normalize various numeric types to float64. Mirrors
+// internal/filters.toFloat64 so numeric JSON values from driller.Driller are
+// eligible for Transform's numeric humanization tokens.
+// Returns (0, false) if v is not a recognized numeric type.
+func toFloat64(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int8:
+		return float64(n), true
+	case int16:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint8:
+		return float64(n), true
+	case uint16:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	default:
+		return 0, false
+	}
+}
+
+// humanizeNumeric renders num using the humanize function selected by tok:
+// "b" (binary bytes), "B" (decimal bytes), "," (thousands separator), "o"
+// (ordinal), or "f<n>" (fixed-point with n decimals).
+func humanizeNumeric(tok string, num float64) string {
+	switch {
+	case tok == "b":
+		return humanize.IBytes(uint64(num))
+	case tok == "B":
+		return humanize.Bytes(uint64(num))
+	case tok == ",":
+		return humanize.Comma(int64(num))
+	case tok == "o":
+		return humanize.Ordinal(int(num))
+	case strings.HasPrefix(tok, "f"):
+		n, _ := strconv.Atoi(tok[1:])
+		format := "#,###."
+		if n > 0 {
+			format += strings.Repeat("#", n)
+		}
+		return humanize.FormatFloat(format, num)
+	default:
+		return strconv.FormatFloat(num, 'f', -1, 64)
+	}
+}
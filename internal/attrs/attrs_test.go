@@ -181,6 +181,62 @@ func TestAttrList_Type(t *testing.T) {
 	assert.Equal(t, "list", a.Type())
 }
 
+// TestAttr_TransformNumericHumanize exercises the numeric humanization tokens
+// (b, B, ",", o, f<n>) against int, float, and string inputs. Their
+// interaction with case and length truncation is covered by
+// TestAttr_TransformNumericHumanizeOverride.
+func TestAttr_TransformNumericHumanize(t *testing.T) {
+	tests := []struct {
+		name          string
+		transformSpec string
+		input         interface{}
+		want          interface{}
+	}{
+		{"bytes binary int", "b", 1610612736, "1.5 GiB"},
+		{"bytes binary float", "b", float64(1610612736), "1.5 GiB"},
+		{"bytes binary string", "b", "1610612736", "1.5 GiB"},
+		{"bytes decimal int", "B", 1500000, "1.5 MB"},
+		{"comma int", ",", 1234567, "1,234,567"},
+		{"comma float", ",", float64(1234567), "1,234,567"},
+		{"comma string", ",", "1234567", "1,234,567"},
+		{"ordinal int", "o", 3, "3rd"},
+		{"ordinal string", "o", "22", "22nd"},
+		{"fixed point 2 decimals", "f2", 3.14159, "3.14"},
+		{"fixed point 0 decimals", "f0", 3.9, "4"},
+		{"non-numeric string unaffected", ",", "not-a-number", "not-a-number"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attr := Attr{TransformSpec: tt.transformSpec}
+			got := attr.Transform(tt.input)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// TestAttr_TransformNumericHumanizeOverride verifies last-wins semantics
+// between a prepended global spec and a per-attr numeric token, and that
+// case/length transforms still apply after humanization.
+func TestAttr_TransformNumericHumanizeOverride(t *testing.T) {
+	// A global "," spec followed by a per-attr "b" override: b wins.
+	attr := Attr{TransformSpec: ",,b"}
+	assert.Equal(t, "1.5 GiB", attr.Transform(1610612736))
+
+	// Case transform still applies to the humanized result.
+	attr = Attr{TransformSpec: ",,U"}
+	assert.Equal(t, "1,234,567", attr.Transform(1234567))
+
+	attr = Attr{TransformSpec: ",Bl"}
+	assert.Equal(t, "1.5 mb", fmt.Sprintf("%v", attr.Transform(1500000)))
+
+	// Length truncation still applies after humanization. (The f<n> cases in
+	// TestAttr_TransformNumericHumanize cover the f<n> digit not being
+	// mistaken for a length spec.)
+	attr = Attr{TransformSpec: "b5"}
+	assert.Equal(t, "1.5 G", attr.Transform(1610612736))
+}
+
 // We validate local time transformation using the system's current location
 // only, with no dependence on TZ environment variables.
 func TestAttr_Transform_Time_LocalUsesSystemZone(t *testing.T) {
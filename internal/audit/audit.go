@@ -0,0 +1,113 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package audit provides a durable, JSON-lines trail of the TFE API calls
+// remote queries issue, for cost/rate-limit forensics and compliance. A
+// Handle wraps an http.RoundTripper so every request made through it emits
+// one Record, and callers that short-circuit a request via a cache hit can
+// still log one by calling EmitCacheHit directly.
+package audit
+
+import (
+	"time"
+
+	"github.com/apex/log"
+
+	"github.com/tfctl/tfctl/internal/config"
+)
+
+// Record is one audited API call (or cache hit standing in for one).
+type Record struct {
+	Timestamp time.Time `json:"timestamp"`
+	Host      string    `json:"host"`
+	Org       string    `json:"org,omitempty"`
+	Method    string    `json:"method,omitempty"`
+	Endpoint  string    `json:"endpoint"`
+	Query     string    `json:"query,omitempty"`
+	Status    int       `json:"status,omitempty"`
+	Page      int       `json:"page,omitempty"`
+	ItemCount int       `json:"itemCount,omitempty"`
+	ElapsedMS int64     `json:"elapsedMs,omitempty"`
+	CacheHit  bool      `json:"cacheHit,omitempty"`
+}
+
+// Sink persists Records somewhere (a file, a syslog daemon, an HTTP
+// collector). Emit is called on every audited request and should not block
+// the caller for long; slow sinks (http) deliver asynchronously and drop
+// records rather than apply backpressure.
+type Sink interface {
+	Emit(Record)
+	Close() error
+}
+
+// Handle is the audit subsystem's entry point, carried on meta.Meta so
+// non-query commands can adopt it later. A nil *Handle is valid and audits
+// nothing, matching tfctl's usual "disabled means do nothing" convention
+// (see internal/cacheutil.Enabled).
+type Handle struct {
+	sink Sink
+}
+
+// NewHandle builds a Handle from config.Type's "audit.sink" key
+// ("file", "stderr", "syslog", "http", or unset/"none" for no auditing).
+func NewHandle() (*Handle, error) {
+	sinkKind, _ := config.GetString("audit.sink", "none")
+
+	var sink Sink
+	var err error
+	switch sinkKind {
+	case "", "none":
+		return nil, nil
+	case "file":
+		path, _ := config.GetString("audit.file.path", "tfctl-audit.jsonl")
+		maxSizeMB, _ := config.GetInt("audit.file.max-size-mb", 100)
+		sink, err = newFileSink(path, maxSizeMB)
+	case "stderr":
+		sink = newStderrSink()
+	case "syslog":
+		tag, _ := config.GetString("audit.syslog.tag", "tfctl")
+		sink, err = newSyslogSink(tag)
+	case "http":
+		url, urlErr := config.GetString("audit.http.url")
+		if urlErr != nil {
+			return nil, urlErr
+		}
+		sink = newHTTPSink(url)
+	default:
+		log.Warnf("unknown audit.sink %q, auditing disabled", sinkKind)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &Handle{sink: sink}, nil
+}
+
+// Emit records r, if the handle has a sink. Safe to call on a nil Handle.
+func (h *Handle) Emit(r Record) {
+	if h == nil || h.sink == nil {
+		return
+	}
+	h.sink.Emit(r)
+}
+
+// EmitCacheHit records a request that was satisfied from cache and never
+// reached the network, so it still appears in the audit trail.
+func (h *Handle) EmitCacheHit(host, org, endpoint string) {
+	h.Emit(Record{
+		Timestamp: time.Now(),
+		Host:      host,
+		Org:       org,
+		Endpoint:  endpoint,
+		CacheHit:  true,
+	})
+}
+
+// Close flushes and releases the underlying sink, if any.
+func (h *Handle) Close() error {
+	if h == nil || h.sink == nil {
+		return nil
+	}
+	return h.sink.Close()
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/apex/log"
+)
+
+// fileSink appends one JSON line per Record to path, rotating to
+// path.1, path.2, ... (oldest last) once the file exceeds maxSizeMB.
+type fileSink struct {
+	mu       sync.Mutex
+	path     string
+	maxBytes int64
+	f        *os.File
+	curBytes int64
+}
+
+func newFileSink(path string, maxSizeMB int) (*fileSink, error) {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("audit: can't create log dir %s: %w", dir, err)
+		}
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("audit: can't open log file %s: %w", path, err)
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("audit: can't stat log file %s: %w", path, err)
+	}
+
+	return &fileSink{
+		path:     path,
+		maxBytes: int64(maxSizeMB) * 1024 * 1024,
+		f:        f,
+		curBytes: info.Size(),
+	}, nil
+}
+
+func (s *fileSink) Emit(r Record) {
+	line, err := json.Marshal(r)
+	if err != nil {
+		log.WithError(err).Warn("audit: failed to marshal record")
+		return
+	}
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.maxBytes > 0 && s.curBytes+int64(len(line)) > s.maxBytes {
+		if err := s.rotate(); err != nil {
+			log.WithError(err).Warn("audit: failed to rotate log file")
+		}
+	}
+
+	n, err := s.f.Write(line)
+	if err != nil {
+		log.WithError(err).Warn("audit: failed to write record")
+		return
+	}
+	s.curBytes += int64(n)
+}
+
+// rotate renames the current file to path.1 (bumping any existing
+// path.1..path.N up by one) and opens a fresh file at path. Callers must
+// hold s.mu.
+func (s *fileSink) rotate() error {
+	if err := s.f.Close(); err != nil {
+		return err
+	}
+
+	for i := 9; i >= 1; i-- {
+		older := fmt.Sprintf("%s.%d", s.path, i)
+		newer := fmt.Sprintf("%s.%d", s.path, i+1)
+		if _, err := os.Stat(older); err == nil {
+			_ = os.Rename(older, newer)
+		}
+	}
+	if err := os.Rename(s.path, s.path+".1"); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	s.f = f
+	s.curBytes = 0
+	return nil
+}
+
+func (s *fileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.f.Close()
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/apex/log"
+)
+
+// httpQueueSize bounds how many pending records an httpSink will buffer
+// before dropping new ones; auditing must never apply backpressure to the
+// command it's observing.
+const httpQueueSize = 1024
+
+// httpSink POSTs one JSON body per Record to url, asynchronously, on a
+// single background worker. Delivery is best-effort: send failures are
+// logged and dropped, never retried.
+type httpSink struct {
+	url    string
+	client *http.Client
+	queue  chan Record
+	done   chan struct{}
+}
+
+func newHTTPSink(url string) *httpSink {
+	s := &httpSink{
+		url:    url,
+		client: &http.Client{Timeout: 10 * time.Second},
+		queue:  make(chan Record, httpQueueSize),
+		done:   make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+func (s *httpSink) Emit(r Record) {
+	select {
+	case s.queue <- r:
+	default:
+		log.Warn("audit: http sink queue full, dropping record")
+	}
+}
+
+func (s *httpSink) run() {
+	defer close(s.done)
+	for r := range s.queue {
+		s.deliver(r)
+	}
+}
+
+func (s *httpSink) deliver(r Record) {
+	body, err := json.Marshal(r)
+	if err != nil {
+		log.WithError(err).Warn("audit: failed to marshal record")
+		return
+	}
+
+	resp, err := s.client.Post(s.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		log.WithError(err).Warn("audit: failed to deliver record")
+		return
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		log.Warnf("audit: http sink received status %s", resp.Status)
+	}
+}
+
+func (s *httpSink) Close() error {
+	close(s.queue)
+	<-s.done
+	return nil
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/apex/log"
+)
+
+// stderrSink writes one JSON line per Record to os.Stderr, for local
+// debugging or when running under a supervisor that already collects
+// stderr into its own log pipeline.
+type stderrSink struct {
+	mu sync.Mutex
+}
+
+func newStderrSink() *stderrSink {
+	return &stderrSink{}
+}
+
+func (s *stderrSink) Emit(r Record) {
+	line, err := json.Marshal(r)
+	if err != nil {
+		log.WithError(err).Warn("audit: failed to marshal record")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fmt.Fprintln(os.Stderr, string(line))
+}
+
+func (s *stderrSink) Close() error {
+	return nil
+}
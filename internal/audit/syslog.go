@@ -0,0 +1,40 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"encoding/json"
+	"log/syslog"
+
+	"github.com/apex/log"
+)
+
+// syslogSink writes one JSON line per Record to the local syslog daemon at
+// LOG_INFO, tagged with tag. Unix-only, like Go's log/syslog itself.
+type syslogSink struct {
+	w *syslog.Writer
+}
+
+func newSyslogSink(tag string) (*syslogSink, error) {
+	w, err := syslog.New(syslog.LOG_INFO, tag)
+	if err != nil {
+		return nil, err
+	}
+	return &syslogSink{w: w}, nil
+}
+
+func (s *syslogSink) Emit(r Record) {
+	line, err := json.Marshal(r)
+	if err != nil {
+		log.WithError(err).Warn("audit: failed to marshal record")
+		return
+	}
+	if err := s.w.Info(string(line)); err != nil {
+		log.WithError(err).Warn("audit: failed to write record to syslog")
+	}
+}
+
+func (s *syslogSink) Close() error {
+	return s.w.Close()
+}
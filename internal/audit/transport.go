@@ -0,0 +1,111 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"regexp"
+	"time"
+)
+
+// orgPathPattern extracts the organization name from TFE API paths of the
+// form /api/v2/organizations/{org}/..., so the transport doesn't need the
+// org threaded through explicitly at every call site.
+var orgPathPattern = regexp.MustCompile(`/organizations/([^/]+)`)
+
+// jsonAPIEnvelope is the minimal shape of a go-tfe JSON:API response body
+// this package cares about: how many items came back, and which page.
+type jsonAPIEnvelope struct {
+	Data json.RawMessage `json:"data"`
+	Meta struct {
+		Pagination struct {
+			CurrentPage int `json:"current-page"`
+		} `json:"pagination"`
+	} `json:"meta"`
+}
+
+// transport wraps an http.RoundTripper, emitting one Record per request to
+// h. A nil Handle never wraps anything (see WrapTransport).
+type transport struct {
+	next http.RoundTripper
+	h    *Handle
+	host string
+}
+
+// WrapTransport returns a RoundTripper that audits every request through
+// next via h, tagged with host (the request's organization, if any, is
+// parsed from the URL path). If h is nil, next is returned unwrapped so
+// disabled auditing costs nothing.
+func (h *Handle) WrapTransport(next http.RoundTripper, host string) http.RoundTripper {
+	if h == nil {
+		return next
+	}
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &transport{next: next, h: h, host: host}
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	elapsed := time.Since(start)
+	if err != nil {
+		return resp, err
+	}
+
+	rec := Record{
+		Timestamp: start,
+		Host:      t.host,
+		Org:       orgFromPath(req.URL.Path),
+		Method:    req.Method,
+		Endpoint:  req.URL.Path,
+		Query:     req.URL.RawQuery,
+		Status:    resp.StatusCode,
+		ElapsedMS: elapsed.Milliseconds(),
+	}
+
+	body, readErr := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if readErr == nil {
+		var env jsonAPIEnvelope
+		if json.Unmarshal(body, &env) == nil {
+			rec.Page = env.Meta.Pagination.CurrentPage
+			rec.ItemCount = jsonArrayLen(env.Data)
+		}
+	}
+
+	t.h.Emit(rec)
+	return resp, nil
+}
+
+func orgFromPath(path string) string {
+	m := orgPathPattern.FindStringSubmatch(path)
+	if len(m) == 2 {
+		return m[1]
+	}
+	return ""
+}
+
+// jsonArrayLen reports how many top-level elements are in a JSON:API "data"
+// member: the length if it's an array, 1 if it's a single object, 0 if
+// absent or unparseable.
+func jsonArrayLen(data json.RawMessage) int {
+	if len(data) == 0 {
+		return 0
+	}
+	var arr []json.RawMessage
+	if err := json.Unmarshal(data, &arr); err == nil {
+		return len(arr)
+	}
+	var obj json.RawMessage
+	if err := json.Unmarshal(data, &obj); err == nil {
+		return 1
+	}
+	return 0
+}
@@ -4,20 +4,59 @@
 package aws
 
 import (
+	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"strings"
+	"time"
 
 	awsv2 "github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	kmsv2 "github.com/aws/aws-sdk-go-v2/service/kms"
 	s3v2 "github.com/aws/aws-sdk-go-v2/service/s3"
+	stsv2 "github.com/aws/aws-sdk-go-v2/service/sts"
+	stsv2types "github.com/aws/aws-sdk-go-v2/service/sts/types"
 
 	"github.com/tfctl/tfctl/internal/log"
 )
 
+// AssumeRoleConfig carries the subset of Terraform's S3 backend assume_role
+// block that tfctl needs to read state cross-account. SourceProfile, if set,
+// is loaded first and used to build the STS client that assumes RoleARN;
+// otherwise the default config chain is used.
+type AssumeRoleConfig struct {
+	RoleARN       string
+	SessionName   string
+	ExternalID    string
+	Duration      time.Duration
+	Policy        string
+	PolicyARNs    []string
+	SourceProfile string
+	MFASerial     string
+	TokenProvider func() (string, error)
+}
+
 // options holds optional overrides for AWS config loading.
 type options struct {
-	profile string
-	region  string
-	retryer func() awsv2.Retryer
+	profile              string
+	region               string
+	retryer              func() awsv2.Retryer
+	assumeRole           *AssumeRoleConfig
+	webIdentityRoleARN   string
+	webIdentityTokenFile string
+	ssoSession           string
+	credentialSource     CredentialSource
+	httpProxyURL         string
+	httpTransport        http.RoundTripper
+	caBundle             []byte
 }
 
 // Option customizes how AWS config is loaded.
@@ -45,6 +84,16 @@ func LoadAWSConfig(ctx context.Context, opts ...Option) (awsv2.Config, error) {
 	if o.retryer != nil {
 		loadOpts = append(loadOpts, config.WithRetryer(o.retryer))
 	}
+	if o.ssoSession != "" {
+		loadOpts = append(loadOpts, config.WithSSOSessionName(o.ssoSession))
+	}
+	if o.httpProxyURL != "" || o.httpTransport != nil || len(o.caBundle) > 0 {
+		httpClient, err := buildHTTPClient(o)
+		if err != nil {
+			return awsv2.Config{}, err
+		}
+		loadOpts = append(loadOpts, config.WithHTTPClient(httpClient))
+	}
 	log.Debugf("loadOpts built: len=%d", len(loadOpts))
 
 	cfg, err := config.LoadDefaultConfig(ctx, loadOpts...)
@@ -53,9 +102,69 @@ func LoadAWSConfig(ctx context.Context, opts ...Option) (awsv2.Config, error) {
 		return awsv2.Config{}, err
 	}
 	log.Debugf("config loaded")
+
+	if o.credentialSource != nil {
+		cfg.Credentials = awsv2.NewCredentialsCache(credentialSourceProvider{o.credentialSource})
+	} else if o.assumeRole != nil {
+		creds, err := assumeRoleCredentials(ctx, cfg, *o.assumeRole)
+		if err != nil {
+			return awsv2.Config{}, err
+		}
+		cfg.Credentials = creds
+	} else if o.webIdentityRoleARN != "" {
+		stsClient := stsv2.NewFromConfig(cfg)
+		provider := stscreds.NewWebIdentityRoleProvider(stsClient, o.webIdentityRoleARN,
+			stscreds.IdentityTokenFile(o.webIdentityTokenFile))
+		cfg.Credentials = awsv2.NewCredentialsCache(provider)
+	}
+
 	return cfg, nil
 }
 
+// assumeRoleCredentials builds a cached credentials provider that assumes
+// ac.RoleARN. If ac.SourceProfile is set, a separate config is loaded with
+// that profile and used to build the STS client; otherwise baseCfg (the
+// already-resolved default chain) is used.
+func assumeRoleCredentials(ctx context.Context, baseCfg awsv2.Config, ac AssumeRoleConfig) (awsv2.CredentialsProvider, error) {
+	stsCfg := baseCfg
+	if ac.SourceProfile != "" {
+		var err error
+		stsCfg, err = config.LoadDefaultConfig(ctx, config.WithSharedConfigProfile(ac.SourceProfile))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	stsClient := stsv2.NewFromConfig(stsCfg)
+	provider := stscreds.NewAssumeRoleProvider(stsClient, ac.RoleARN, func(o *stscreds.AssumeRoleOptions) {
+		if ac.SessionName != "" {
+			o.RoleSessionName = ac.SessionName
+		}
+		if ac.ExternalID != "" {
+			o.ExternalID = awsv2.String(ac.ExternalID)
+		}
+		if ac.Duration > 0 {
+			o.Duration = ac.Duration
+		}
+		if ac.Policy != "" {
+			o.Policy = awsv2.String(ac.Policy)
+		}
+		if len(ac.PolicyARNs) > 0 {
+			for _, arn := range ac.PolicyARNs {
+				o.PolicyARNs = append(o.PolicyARNs, stsv2types.PolicyDescriptorType{Arn: awsv2.String(arn)})
+			}
+		}
+		if ac.MFASerial != "" {
+			o.SerialNumber = awsv2.String(ac.MFASerial)
+		}
+		if ac.TokenProvider != nil {
+			o.TokenProvider = ac.TokenProvider
+		}
+	})
+
+	return awsv2.NewCredentialsCache(provider), nil
+}
+
 // NewS3 constructs a v2 S3 client from the provided config. Additional service
 // options can be supplied via optFns.
 func NewS3(cfg awsv2.Config, optFns ...func(*s3v2.Options)) *s3v2.Client {
@@ -64,6 +173,126 @@ func NewS3(cfg awsv2.Config, optFns ...func(*s3v2.Options)) *s3v2.Client {
 	return client
 }
 
+// NewKMS constructs a v2 KMS client from the provided config. Additional
+// service options can be supplied via optFns.
+func NewKMS(cfg awsv2.Config, optFns ...func(*kmsv2.Options)) *kmsv2.Client {
+	client := kmsv2.NewFromConfig(cfg, optFns...)
+	log.Debugf("kms client created")
+	return client
+}
+
+// ObjectVersion is one entry from ListObjectVersions, trimmed to the fields
+// tfctl's state-history callers need. IsDeleteMarker distinguishes an S3
+// delete marker (the key didn't exist as of LastModified) from an actual
+// object version; ListObjectVersions's own API returns these as two
+// separate lists for the same reason.
+type ObjectVersion struct {
+	Key            string
+	VersionID      string
+	LastModified   time.Time
+	IsLatest       bool
+	IsDeleteMarker bool
+}
+
+// ListObjectVersions lists every version (and delete marker) of objects
+// under prefix in bucket, paginating until exhausted.
+func ListObjectVersions(ctx context.Context, svc *s3v2.Client, bucket, prefix string) ([]ObjectVersion, error) {
+	paginator := s3v2.NewListObjectVersionsPaginator(svc, &s3v2.ListObjectVersionsInput{
+		Bucket: awsv2.String(bucket),
+		Prefix: awsv2.String(prefix),
+	})
+
+	var results []ObjectVersion
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, v := range page.Versions {
+			if v.Key == nil || v.VersionId == nil || v.LastModified == nil {
+				continue
+			}
+			results = append(results, ObjectVersion{
+				Key:          *v.Key,
+				VersionID:    *v.VersionId,
+				LastModified: *v.LastModified,
+				IsLatest:     v.IsLatest != nil && *v.IsLatest,
+			})
+		}
+
+		for _, d := range page.DeleteMarkers {
+			if d.Key == nil || d.VersionId == nil || d.LastModified == nil {
+				continue
+			}
+			results = append(results, ObjectVersion{
+				Key:            *d.Key,
+				VersionID:      *d.VersionId,
+				LastModified:   *d.LastModified,
+				IsLatest:       d.IsLatest != nil && *d.IsLatest,
+				IsDeleteMarker: true,
+			})
+		}
+	}
+
+	return results, nil
+}
+
+// S3ObjectGetter is the subset of *s3v2.Client's API that GetObjectVersion
+// and GetObjectVersionRange need, extracted so callers that fan GetObject
+// calls out across a worker pool (or exercise them in a benchmark) can
+// supply a fake in place of a real client.
+type S3ObjectGetter interface {
+	GetObject(ctx context.Context, params *s3v2.GetObjectInput, optFns ...func(*s3v2.Options)) (*s3v2.GetObjectOutput, error)
+}
+
+// GetObjectVersion fetches one specific version of bucket/key. sseCustomerKey,
+// if non-empty, is sent as the SSE-C customer key (AES256), matching the S3
+// backend's sse_customer_key config. The caller must close the returned body.
+func GetObjectVersion(ctx context.Context, svc S3ObjectGetter, bucket, key, versionID, sseCustomerKey string) (io.ReadCloser, error) {
+	input := &s3v2.GetObjectInput{
+		Bucket:    awsv2.String(bucket),
+		Key:       awsv2.String(key),
+		VersionId: awsv2.String(versionID),
+	}
+	if sseCustomerKey != "" {
+		input.SSECustomerAlgorithm = awsv2.String("AES256")
+		input.SSECustomerKey = awsv2.String(sseCustomerKey)
+	}
+
+	out, err := svc.GetObject(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return out.Body, nil
+}
+
+// GetObjectVersionRange fetches only the first n bytes of one specific
+// version of bucket/key via an S3 Range GetObject, for callers that need to
+// inspect a small leading portion of a large object (e.g. the top-level
+// fields of a Terraform state document) without downloading it in full.
+func GetObjectVersionRange(ctx context.Context, svc S3ObjectGetter, bucket, key, versionID, sseCustomerKey string, n int64) ([]byte, error) {
+	input := &s3v2.GetObjectInput{
+		Bucket:    awsv2.String(bucket),
+		Key:       awsv2.String(key),
+		VersionId: awsv2.String(versionID),
+		Range:     awsv2.String(fmt.Sprintf("bytes=0-%d", n-1)),
+	}
+	if sseCustomerKey != "" {
+		input.SSECustomerAlgorithm = awsv2.String("AES256")
+		input.SSECustomerKey = awsv2.String(sseCustomerKey)
+	}
+
+	out, err := svc.GetObject(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	defer out.Body.Close()
+
+	return io.ReadAll(out.Body)
+}
+
 // WithProfile sets the shared config profile. Defaults to AWS_PROFILE/env chain.
 func WithProfile(profile string) Option {
 	return func(o *options) { o.profile = profile }
@@ -79,6 +308,157 @@ func WithRetryer(newRetryer func() awsv2.Retryer) Option {
 	return func(o *options) { o.retryer = newRetryer }
 }
 
+// WithAssumeRole configures LoadAWSConfig to assume the given role after
+// resolving the base config (or ac.SourceProfile's config, if set), mirroring
+// Terraform's S3 backend assume_role block.
+func WithAssumeRole(ac AssumeRoleConfig) Option {
+	return func(o *options) { o.assumeRole = &ac }
+}
+
+// WithWebIdentityRoleARN configures LoadAWSConfig to assume roleARN using the
+// OIDC token found in tokenFile, for IRSA/GitHub Actions-style workload
+// identity flows.
+func WithWebIdentityRoleARN(roleARN, tokenFile string) Option {
+	return func(o *options) {
+		o.webIdentityRoleARN = roleARN
+		o.webIdentityTokenFile = tokenFile
+	}
+}
+
+// CredentialSource fetches AWS credentials from somewhere other than the
+// SDK's own chain (env, shared config, IMDS, credential_process, ...).
+// WithCredentialSource wraps it in an aws.CredentialsCache, so Fetch is only
+// called again once the credentials it last returned are near expiry --
+// implementations don't need their own caching or refresh logic.
+type CredentialSource interface {
+	Fetch(ctx context.Context) (awsv2.Credentials, error)
+}
+
+// credentialSourceProvider adapts a CredentialSource to aws.CredentialsProvider.
+type credentialSourceProvider struct {
+	src CredentialSource
+}
+
+func (p credentialSourceProvider) Retrieve(ctx context.Context) (awsv2.Credentials, error) {
+	return p.src.Fetch(ctx)
+}
+
+// WithCredentialSource configures LoadAWSConfig to fetch credentials from src
+// instead of the default chain, env, or an assumed/web-identity role. It
+// takes precedence over WithAssumeRole/WithWebIdentityRoleARN if more than
+// one is given.
+func WithCredentialSource(src CredentialSource) Option {
+	return func(o *options) { o.credentialSource = src }
+}
+
+// ExecCredentialSource is a CredentialSource that runs Command and parses its
+// stdout as JSON, using the same {Version, AccessKeyId, SecretAccessKey,
+// SessionToken, Expiration} schema the AWS CLI's own credential_process
+// setting uses. This is deliberately the only concrete CredentialSource
+// tfctl ships: rather than vendoring a client-go or Vault SDK dependency
+// (neither of which anything else in this tree needs) for Kubernetes
+// Secret- or Vault-backed credentials, point Command at `kubectl`, `vault`,
+// or any other CLI that can emit this schema -- the same way `aws sts
+// assume-role`/`aws-vault exec` already do for the AWS CLI itself.
+type ExecCredentialSource struct {
+	Command string
+	Args    []string
+}
+
+// Fetch runs the command and parses its credential_process-shaped stdout.
+func (e ExecCredentialSource) Fetch(ctx context.Context) (awsv2.Credentials, error) {
+	cmd := exec.CommandContext(ctx, e.Command, e.Args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return awsv2.Credentials{}, fmt.Errorf("credential source command %q failed: %w (%s)",
+			e.Command, err, strings.TrimSpace(stderr.String()))
+	}
+
+	var resp struct {
+		AccessKeyID     string    `json:"AccessKeyId"`
+		SecretAccessKey string    `json:"SecretAccessKey"`
+		SessionToken    string    `json:"SessionToken"`
+		Expiration      time.Time `json:"Expiration"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &resp); err != nil {
+		return awsv2.Credentials{}, fmt.Errorf("failed to parse credential source output: %w", err)
+	}
+	if resp.AccessKeyID == "" || resp.SecretAccessKey == "" {
+		return awsv2.Credentials{}, fmt.Errorf("credential source command %q returned no AccessKeyId/SecretAccessKey", e.Command)
+	}
+
+	return awsv2.Credentials{
+		AccessKeyID:     resp.AccessKeyID,
+		SecretAccessKey: resp.SecretAccessKey,
+		SessionToken:    resp.SessionToken,
+		CanExpire:       !resp.Expiration.IsZero(),
+		Expires:         resp.Expiration,
+	}, nil
+}
+
+// WithSSOSession selects an AWS SSO session by name, honoring the matching
+// [sso-session ...] block in ~/.aws/config.
+func WithSSOSession(name string) Option {
+	return func(o *options) { o.ssoSession = name }
+}
+
+// WithHTTPProxy routes every AWS SDK request (S3, STS, KMS, ...) through
+// proxyURL, via a dedicated *http.Client passed to config.WithHTTPClient --
+// unlike HTTPS_PROXY, this only affects AWS traffic, leaving everything
+// else tfctl talks to (e.g. a TFE API on a split-horizon network) alone.
+func WithHTTPProxy(proxyURL string) Option {
+	return func(o *options) { o.httpProxyURL = proxyURL }
+}
+
+// WithHTTPTransport overrides the *http.Transport (or any other
+// http.RoundTripper) used for AWS SDK requests entirely, taking precedence
+// over WithHTTPProxy/WithCABundle if more than one is given.
+func WithHTTPTransport(rt http.RoundTripper) Option {
+	return func(o *options) { o.httpTransport = rt }
+}
+
+// WithCABundle trusts the PEM-encoded certificates in bundle (in addition
+// to, not instead of, the transport's own TLS setup) for AWS SDK requests,
+// for corporate proxies/endpoints fronted by a private CA.
+func WithCABundle(bundle []byte) Option {
+	return func(o *options) { o.caBundle = bundle }
+}
+
+// buildHTTPClient builds the *http.Client LoadAWSConfig passes to AWS SDK v2
+// via config.WithHTTPClient when any of WithHTTPProxy/WithHTTPTransport/
+// WithCABundle is set.
+func buildHTTPClient(o options) (*http.Client, error) {
+	if o.httpTransport != nil {
+		return &http.Client{Transport: o.httpTransport}, nil
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if o.httpProxyURL != "" {
+		proxyURL, err := url.Parse(o.httpProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse proxy URL %q: %w", o.httpProxyURL, err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if len(o.caBundle) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(o.caBundle) {
+			return nil, fmt.Errorf("CA bundle contains no valid PEM certificates")
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+
+	return &http.Client{Transport: transport}, nil
+}
+
 // Endpoint resolution is service-specific in AWS SDK v2.
 // For S3, pass an option to NewS3 that sets Options.EndpointResolverV2.
 
@@ -6,6 +6,8 @@ package aws
 
 import (
 	"context"
+	"crypto/x509"
+	"net/http"
 	"testing"
 
 	awsv2 "github.com/aws/aws-sdk-go-v2/aws"
@@ -15,6 +17,29 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+// testCABundlePEM is a throwaway self-signed certificate used only to
+// exercise the CA-bundle-loading path; it's never expected to validate
+// against anything.
+const testCABundlePEM = `-----BEGIN CERTIFICATE-----
+MIIDETCCAfmgAwIBAgIUEYuurMht/TK2xZTNsdSa5jWcF5UwDQYJKoZIhvcNAQEL
+BQAwGDEWMBQGA1UEAwwNdGZjdGwtdGVzdC1jYTAeFw0yNjA3MjgwMTMyMTBaFw0z
+NjA3MjUwMTMyMTBaMBgxFjAUBgNVBAMMDXRmY3RsLXRlc3QtY2EwggEiMA0GCSqG
+SIb3DQEBAQUAA4IBDwAwggEKAoIBAQDBZwm84CXdstaxitb7gKU3C0zEXTC5hRrg
+1elCzUqM2E+hdDbyeSc3p51J4xCXWcWFfAofDFYM0Rfmr3XGaVFvJcxGsGqqXSBP
+HfuSrfyuij8A8OBHAzHZMuEItENS5VKS/AbzjojRy4CKOfA9nrnJ4Vr4qQ8cN4zo
+hHT7NyfrlU5Ujd5rk8dc1rzOTciljC/4wN0gGVQtgbIS7Zc0xOtk1+0H0tWYgEjk
+cnel/p1CfzZxuhDe74dvCqMMSybRfqsKRFGv033PmSpx7W1xzhT89FU2wKOYisLx
+Q68k39Pa4IQjvrr8Brn96NdXyF6wfDZXm7WzzZbUNvVYcfs63zChAgMBAAGjUzBR
+MB0GA1UdDgQWBBSdhm4+cUXJy76jSP0whD+enrcnCDAfBgNVHSMEGDAWgBSdhm4+
+cUXJy76jSP0whD+enrcnCDAPBgNVHRMBAf8EBTADAQH/MA0GCSqGSIb3DQEBCwUA
+A4IBAQBf/dRi+w3V/K1cQXFayOGVLhFaC8+fonfEpAw4g+rxb35shrysaEC0Bo5m
+asP+1vhyF1bzCpiIYEcAZis44SDuQzexJOkNg/TWsbG9+98JtWp2MEcZh6+jdeDQ
+/eLe1KoO0Yx2ZQdCxcVCs6x2soGJg59ViE1rw+JUc6GeddKKihRg/aVCHKu+9Ixt
+oqTJScRXeUj8Shd3wn1HI7sLrGxM/4UDev2tFUWPCXKxOqxXddM6jLLCxmYwDcVX
+LQNOWOSO55xwutA3X23Xk0liOTllbFQQj2Fozdi7XdoOfoSCPSgmZVpIiznJpe7c
+C/cWPV047yHCJYx7ki4khU1NIZc6
+-----END CERTIFICATE-----`
+
 // TestWithProfile verifies that WithProfile sets the profile option
 // correctly.
 func TestWithProfile(t *testing.T) {
@@ -101,6 +126,140 @@ func TestWithRetryer(t *testing.T) {
 	assert.NotNil(t, result)
 }
 
+// TestWithAssumeRole verifies that WithAssumeRole sets the assumeRole option.
+func TestWithAssumeRole(t *testing.T) {
+	ac := AssumeRoleConfig{
+		RoleARN:     "arn:aws:iam::123456789012:role/test",
+		SessionName: "tfctl",
+		ExternalID:  "ext-id",
+	}
+
+	var opts options
+	opt := WithAssumeRole(ac)
+	opt(&opts)
+
+	require.NotNil(t, opts.assumeRole)
+	assert.Equal(t, ac.RoleARN, opts.assumeRole.RoleARN)
+	assert.Equal(t, ac.SessionName, opts.assumeRole.SessionName)
+	assert.Equal(t, ac.ExternalID, opts.assumeRole.ExternalID)
+}
+
+// TestWithWebIdentityRoleARN verifies that WithWebIdentityRoleARN sets both
+// the role ARN and token file options.
+func TestWithWebIdentityRoleARN(t *testing.T) {
+	var opts options
+	opt := WithWebIdentityRoleARN("arn:aws:iam::123456789012:role/test", "/var/run/token")
+	opt(&opts)
+
+	assert.Equal(t, "arn:aws:iam::123456789012:role/test", opts.webIdentityRoleARN)
+	assert.Equal(t, "/var/run/token", opts.webIdentityTokenFile)
+}
+
+// TestWithSSOSession verifies that WithSSOSession sets the sso session name.
+func TestWithSSOSession(t *testing.T) {
+	var opts options
+	opt := WithSSOSession("my-sso-session")
+	opt(&opts)
+
+	assert.Equal(t, "my-sso-session", opts.ssoSession)
+}
+
+// TestWithHTTPProxy verifies that WithHTTPProxy sets the proxy URL option.
+func TestWithHTTPProxy(t *testing.T) {
+	var opts options
+	opt := WithHTTPProxy("http://proxy.example.com:8080")
+	opt(&opts)
+
+	assert.Equal(t, "http://proxy.example.com:8080", opts.httpProxyURL)
+}
+
+// TestWithHTTPTransport verifies that WithHTTPTransport sets the transport
+// option to the given RoundTripper.
+func TestWithHTTPTransport(t *testing.T) {
+	rt := http.DefaultTransport
+
+	var opts options
+	opt := WithHTTPTransport(rt)
+	opt(&opts)
+
+	assert.Same(t, rt, opts.httpTransport)
+}
+
+// TestWithCABundle verifies that WithCABundle sets the CA bundle option.
+func TestWithCABundle(t *testing.T) {
+	bundle := []byte("-----BEGIN CERTIFICATE-----\n...\n-----END CERTIFICATE-----\n")
+
+	var opts options
+	opt := WithCABundle(bundle)
+	opt(&opts)
+
+	assert.Equal(t, bundle, opts.caBundle)
+}
+
+// TestBuildHTTPClient_Transport verifies that an explicit WithHTTPTransport
+// takes precedence over proxy/CA bundle settings.
+func TestBuildHTTPClient_Transport(t *testing.T) {
+	rt := http.DefaultTransport
+
+	client, err := buildHTTPClient(options{httpTransport: rt, httpProxyURL: "http://proxy.example.com:8080"})
+
+	require.NoError(t, err)
+	assert.Same(t, rt, client.Transport)
+}
+
+// TestBuildHTTPClient_Proxy verifies that a valid proxy URL is applied to
+// the built transport's Proxy func.
+func TestBuildHTTPClient_Proxy(t *testing.T) {
+	client, err := buildHTTPClient(options{httpProxyURL: "http://proxy.example.com:8080"})
+
+	require.NoError(t, err)
+	transport, ok := client.Transport.(*http.Transport)
+	require.True(t, ok)
+	require.NotNil(t, transport.Proxy)
+
+	req, _ := http.NewRequest(http.MethodGet, "https://s3.amazonaws.com", nil)
+	proxyURL, err := transport.Proxy(req)
+	require.NoError(t, err)
+	assert.Equal(t, "proxy.example.com:8080", proxyURL.Host)
+}
+
+// TestBuildHTTPClient_InvalidProxy verifies that an unparseable proxy URL
+// is reported as an error rather than silently ignored.
+func TestBuildHTTPClient_InvalidProxy(t *testing.T) {
+	_, err := buildHTTPClient(options{httpProxyURL: "://not-a-url"})
+	assert.Error(t, err)
+}
+
+// TestBuildHTTPClient_InvalidCABundle verifies that a CA bundle containing
+// no valid PEM certificates is reported as an error.
+func TestBuildHTTPClient_InvalidCABundle(t *testing.T) {
+	_, err := buildHTTPClient(options{caBundle: []byte("not a certificate")})
+	assert.Error(t, err)
+}
+
+// TestBuildHTTPClient_CABundlePreservesSystemRoots verifies that applying a
+// CA bundle augments the system trust store rather than replacing it, so a
+// standard CA-signed certificate still validates afterward.
+func TestBuildHTTPClient_CABundlePreservesSystemRoots(t *testing.T) {
+	sysPool, err := x509.SystemCertPool()
+	require.NoError(t, err)
+	if sysPool == nil || len(sysPool.Subjects()) == 0 { //nolint:staticcheck
+		t.Skip("no system cert pool available in this environment")
+	}
+
+	client, err := buildHTTPClient(options{caBundle: []byte(testCABundlePEM)})
+	require.NoError(t, err)
+
+	transport, ok := client.Transport.(*http.Transport)
+	require.True(t, ok)
+	require.NotNil(t, transport.TLSClientConfig)
+	require.NotNil(t, transport.TLSClientConfig.RootCAs)
+
+	gotSubjects := len(transport.TLSClientConfig.RootCAs.Subjects()) //nolint:staticcheck
+	assert.Greater(t, gotSubjects, 1,
+		"RootCAs should contain the system roots in addition to the bundled cert")
+}
+
 // TestLoadAWSConfig_NoOptions verifies LoadAWSConfig loads successfully
 // with no overrides, relying on defaults and environment.
 func TestLoadAWSConfig_NoOptions(t *testing.T) {
@@ -0,0 +1,362 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package artifactory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/apex/log"
+	"github.com/hashicorp/go-tfe"
+	"github.com/urfave/cli/v3"
+
+	"github.com/tfctl/tfctl/internal/differ"
+	"github.com/tfctl/tfctl/internal/svutil"
+)
+
+// BackendArtifactory is a struct that represents a JFrog Artifactory generic
+// repository backend configuration. Unlike the other backends in this
+// package tree, artifactory isn't a Terraform-native backend type: it's a
+// tfctl extension for teams that publish Terraform state to an Artifactory
+// generic repo (e.g. from a CI pipeline) rather than a backend Terraform
+// itself understands, so that tfctl sq/sd can still query and diff it.
+type BackendArtifactory struct {
+	Ctx              context.Context
+	Cmd              *cli.Command
+	RootDir          string `json:"-" validate:"dir"`
+	EnvOverride      string
+	Version          int    `json:"version" validate:"gte=4"`
+	TerraformVersion string `json:"terraform_version" validate:"semver"`
+	Backend          struct {
+		Type   string `json:"type" validate:"eq=artifactory"`
+		Config struct {
+			URL      string `json:"url" validate:"required,url"`
+			Repo     string `json:"repo" validate:"required"`
+			Subpath  string `json:"subpath"`
+			Username string `json:"username"`
+		} `json:"config"`
+		Hash int `json:"hash"`
+	} `json:"backend"`
+}
+
+// artifactoryFile is one entry from the repo's ?list&deep=1 response.
+type artifactoryFile struct {
+	URI    string `json:"uri"`
+	Folder bool   `json:"folder"`
+}
+
+// artifactoryProperties is the subset of Artifactory's ?properties response
+// tfctl cares about. Property values are arrays in the Artifactory API even
+// when, as here, only a single value is ever set.
+type artifactoryProperties struct {
+	SHA256  string
+	Created time.Time
+}
+
+// dir is the repo-relative directory this workspace's state revisions live
+// under, following the same "<prefix>/<workspace>" layout the other
+// object-storage-backed backends use.
+func (be *BackendArtifactory) dir() string {
+	workspace := "default"
+	if be.EnvOverride != "" {
+		workspace = be.EnvOverride
+	}
+	return path.Join(be.Backend.Config.Subpath, workspace)
+}
+
+// authenticate attaches credentials to req. An access token read from
+// ARTIFACTORY_ACCESS_TOKEN takes precedence; Artifactory accepts it either as
+// a Basic Auth password (if a username is also configured) or as a bearer
+// token, matching how Artifactory's own documentation describes access
+// token usage.
+func (be *BackendArtifactory) authenticate(req *http.Request) {
+	token := os.Getenv("ARTIFACTORY_ACCESS_TOKEN")
+	if token == "" {
+		return
+	}
+	if be.Backend.Config.Username != "" {
+		req.SetBasicAuth(be.Backend.Config.Username, token)
+		return
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+}
+
+// get issues an authenticated GET against url and returns the response body.
+func (be *BackendArtifactory) get(url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(be.Ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build artifactory request: %w", err)
+	}
+	be.authenticate(req)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("artifactory request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("artifactory request to %s failed: %s", url, resp.Status)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+// listFiles deep-lists every file under this workspace's directory via
+// Artifactory's storage API.
+func (be *BackendArtifactory) listFiles() ([]artifactoryFile, error) {
+	url := fmt.Sprintf("%s/api/storage/%s/%s?list&deep=1",
+		strings.TrimSuffix(be.Backend.Config.URL, "/"), be.Backend.Config.Repo, be.dir())
+
+	body, err := be.get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list artifactory revisions: %w", err)
+	}
+
+	var listing struct {
+		Files []artifactoryFile `json:"files"`
+	}
+	if err := json.Unmarshal(body, &listing); err != nil {
+		return nil, fmt.Errorf("failed to parse artifactory listing: %w", err)
+	}
+
+	return listing.Files, nil
+}
+
+// fileProperties fetches the sha256 and created properties Artifactory
+// tracks for the file at uri (relative to this workspace's directory).
+func (be *BackendArtifactory) fileProperties(uri string) (artifactoryProperties, error) {
+	url := fmt.Sprintf("%s/api/storage/%s/%s%s?properties",
+		strings.TrimSuffix(be.Backend.Config.URL, "/"), be.Backend.Config.Repo, be.dir(), uri)
+
+	body, err := be.get(url)
+	if err != nil {
+		return artifactoryProperties{}, fmt.Errorf("failed to get artifactory properties for %s: %w", uri, err)
+	}
+
+	var parsed struct {
+		Properties map[string][]string `json:"properties"`
+	}
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return artifactoryProperties{}, fmt.Errorf("failed to parse artifactory properties for %s: %w", uri, err)
+	}
+
+	var props artifactoryProperties
+	if v := parsed.Properties["sha256"]; len(v) > 0 {
+		props.SHA256 = v[0]
+	}
+	if v := parsed.Properties["created"]; len(v) > 0 {
+		props.Created, _ = time.Parse(time.RFC3339, v[0])
+	}
+
+	return props, nil
+}
+
+// fetchContent downloads the raw file content at uri (relative to this
+// workspace's directory) straight from the repo, as opposed to the
+// metadata-only api/storage endpoints used by listFiles/fileProperties.
+func (be *BackendArtifactory) fetchContent(uri string) ([]byte, error) {
+	url := fmt.Sprintf("%s/%s/%s%s",
+		strings.TrimSuffix(be.Backend.Config.URL, "/"), be.Backend.Config.Repo, be.dir(), uri)
+
+	body, err := be.get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download artifactory revision %s: %w", uri, err)
+	}
+	return body, nil
+}
+
+// StateBody returns the state document whose sha256 matches sv, re-walking
+// the directory listing to resolve which file currently holds that hash.
+// Results are cached by hash via CacheReader/CacheWriter, so a StateVersions
+// call immediately followed by States (the common sq/sd path) only downloads
+// each revision's content once.
+func (be *BackendArtifactory) StateBody(sv string) ([]byte, error) {
+	if err := PurgeCache(); err != nil {
+		log.WithError(err).Warn("failed to purge cache")
+	}
+
+	if entry, ok := CacheReader(be, sv); ok {
+		return entry.Data, nil
+	}
+
+	files, err := be.listFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range files {
+		if f.Folder {
+			continue
+		}
+		props, err := be.fileProperties(f.URI)
+		if err != nil {
+			log.WithError(err).Error("artifactory read properties failed")
+			continue
+		}
+		if props.SHA256 != sv {
+			continue
+		}
+
+		body, err := be.fetchContent(f.URI)
+		if err != nil {
+			return nil, err
+		}
+		if err := CacheWriter(be, sv, body); err != nil {
+			log.WithError(err).Error("error writing to cache")
+		}
+		return body, nil
+	}
+
+	return nil, fmt.Errorf("no artifactory revision found for hash %s", sv)
+}
+
+// DiffStates implements backend.SelfDiffer, resolving the pair (or list) of
+// revisions to diff the same way the s3 backend does: the last two revisions
+// by default, a single explicit spec in place of CSV~1, a "+N"
+// pinned/cursor selection via differ.SelectStateVersions, or an explicit
+// list of specs.
+func (be *BackendArtifactory) DiffStates(ctx context.Context, cmd *cli.Command) ([][]byte, error) {
+	svSpecs := []string{"CSV~1", "CSV~0"}
+
+	diffArgs := differ.ParseDiffArgs(ctx, cmd)
+
+	switch len(diffArgs) {
+	case 0:
+		// No args, so use the last two states.
+	case 1:
+		if strings.HasPrefix(diffArgs[0], "+") {
+			stateVersionList, err := be.StateVersions()
+			if err != nil {
+				return nil, fmt.Errorf("failed to get state version list: %v", err)
+			}
+
+			selection := differ.SelectStateVersions(stateVersionList)
+
+			log.Debugf("selection: %d", len(selection.Versions))
+
+			if len(selection.Versions) < 2 {
+				return nil, nil
+			}
+
+			if selection.Mode == "pinned" {
+				_ = cmd.Set("diff_mode", "pinned")
+			}
+
+			svSpecs = differ.SpecsForSelection(selection)
+		} else {
+			svSpecs[0] = diffArgs[0]
+		}
+	default:
+		svSpecs = diffArgs
+	}
+
+	states, _ := be.States(svSpecs...)
+
+	return states, nil
+}
+
+func (be *BackendArtifactory) Runs() ([]*tfe.Run, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (be *BackendArtifactory) State() ([]byte, error) {
+	states, err := be.States("CSV~0")
+	if err != nil {
+		return nil, err
+	}
+	return states[0], nil
+}
+
+// StateVersions implements backend.Backend. It lists every file under this
+// workspace's directory, newest first, mapping each one's sha256 property to
+// tfe.StateVersion.ID and its created property to CreatedAt.
+func (be *BackendArtifactory) StateVersions(augmenter ...func(context.Context, *cli.Command, *tfe.StateVersionListOptions) error) ([]*tfe.StateVersion, error) {
+	files, err := be.listFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []*tfe.StateVersion
+	for _, f := range files {
+		if f.Folder {
+			continue
+		}
+
+		props, err := be.fileProperties(f.URI)
+		if err != nil {
+			log.WithError(err).Error("artifactory read properties failed")
+			continue
+		}
+		if props.SHA256 == "" {
+			continue
+		}
+
+		body, err := be.StateBody(props.SHA256)
+		if err != nil {
+			log.WithError(err).Error("artifactory read revision failed")
+			continue
+		}
+
+		var doc map[string]interface{}
+		_ = json.Unmarshal(body, &doc)
+		var serial int64
+		if s, ok := doc["serial"].(float64); ok {
+			serial = int64(s)
+		}
+
+		versions = append(versions, &tfe.StateVersion{
+			ID:        props.SHA256,
+			CreatedAt: props.Created,
+			Serial:    serial,
+		})
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].CreatedAt.After(versions[j].CreatedAt)
+	})
+
+	limit := be.Cmd.Int("limit")
+	if len(versions) > limit {
+		versions = versions[:limit]
+	}
+
+	return versions, nil
+}
+
+func (be *BackendArtifactory) States(specs ...string) ([][]byte, error) {
+	var results [][]byte
+
+	candidates, _ := be.StateVersions()
+	versions, err := svutil.Resolve(candidates, specs...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range versions {
+		body, err := be.StateBody(v.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get state: %w", err)
+		}
+		results = append(results, body)
+	}
+
+	return results, nil
+}
+
+func (be *BackendArtifactory) String() string {
+	return "backend-artifactory"
+}
+
+func (be *BackendArtifactory) Type() (string, error) {
+	return be.Backend.Type, nil
+}
@@ -0,0 +1,281 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package azurerm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
+	"github.com/apex/log"
+	"github.com/hashicorp/go-tfe"
+	"github.com/urfave/cli/v3"
+
+	"github.com/tfctl/tfctl/internal/differ"
+	"github.com/tfctl/tfctl/internal/svutil"
+)
+
+// BackendAzurerm is a struct that represents an azurerm backend
+// configuration.
+// https://developer.hashicorp.com/terraform/language/backend/azurerm
+type BackendAzurerm struct {
+	Ctx              context.Context
+	Cmd              *cli.Command
+	RootDir          string `json:"-" validate:"dir"`
+	EnvOverride      string
+	Version          int    `json:"version" validate:"gte=4"`
+	TerraformVersion string `json:"terraform_version" validate:"semver"`
+	Backend          struct {
+		Type   string `json:"type" validate:"eq=azurerm"`
+		Config struct {
+			StorageAccountName string `json:"storage_account_name" validate:"required"`
+			ContainerName      string `json:"container_name" validate:"required"`
+			Key                string `json:"key" validate:"required"`
+			Endpoint           string `json:"endpoint"`
+			AccessKey          string `json:"access_key"`
+			SasToken           string `json:"sas_token"`
+			UseAzureadAuth     bool   `json:"use_azuread_auth"`
+		} `json:"config"`
+		Hash int `json:"hash"`
+	} `json:"backend"`
+}
+
+// serviceURL returns the container URL, preferring an explicit endpoint
+// override (e.g. for Azure Stack or sovereign clouds) over the public cloud
+// default.
+func (be *BackendAzurerm) serviceURL() string {
+	if be.Backend.Config.Endpoint != "" {
+		return fmt.Sprintf("%s/%s", be.Backend.Config.Endpoint, be.Backend.Config.ContainerName)
+	}
+	return fmt.Sprintf("https://%s.blob.core.windows.net/%s",
+		be.Backend.Config.StorageAccountName, be.Backend.Config.ContainerName)
+}
+
+// containerClient builds a container.Client, trying credentials in the same
+// order Terraform's azurerm backend documents: a SAS token, a shared access
+// key, then Azure AD default credentials (az login, managed identity, etc.).
+func (be *BackendAzurerm) containerClient() (*container.Client, error) {
+	url := be.serviceURL()
+
+	if sas := be.Backend.Config.SasToken; sas != "" {
+		return container.NewClientWithNoCredential(url+"?"+sas, nil)
+	}
+
+	if key := be.Backend.Config.AccessKey; key != "" && !be.Backend.Config.UseAzureadAuth {
+		cred, err := azblob.NewSharedKeyCredential(be.Backend.Config.StorageAccountName, key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build shared key credential: %w", err)
+		}
+		return container.NewClientWithSharedKeyCredential(url, cred, nil)
+	}
+
+	cred, err := azidentity.NewDefaultAzureCredential(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build default Azure credential: %w", err)
+	}
+	return container.NewClient(url, cred, nil)
+}
+
+// DiffStates implements backend.SelfDiffer, resolving the pair (or list) of
+// blob versions to diff the same way the s3 backend does: the last two
+// versions by default, a single explicit spec in place of CSV~1, a "+N"
+// pinned/cursor selection via differ.SelectStateVersions, or an explicit list
+// of specs.
+func (be *BackendAzurerm) DiffStates(ctx context.Context, cmd *cli.Command) ([][]byte, error) {
+	svSpecs := []string{"CSV~1", "CSV~0"}
+
+	diffArgs := differ.ParseDiffArgs(ctx, cmd)
+
+	switch len(diffArgs) {
+	case 0:
+		// No args, so use the last two states.
+	case 1:
+		if strings.HasPrefix(diffArgs[0], "+") {
+			stateVersionList, err := be.StateVersions()
+			if err != nil {
+				return nil, fmt.Errorf("failed to get state version list: %v", err)
+			}
+
+			selection := differ.SelectStateVersions(stateVersionList)
+
+			log.Debugf("selection: %d", len(selection.Versions))
+
+			if len(selection.Versions) < 2 {
+				return nil, nil
+			}
+
+			if selection.Mode == "pinned" {
+				_ = cmd.Set("diff_mode", "pinned")
+			}
+
+			svSpecs = differ.SpecsForSelection(selection)
+		} else {
+			svSpecs[0] = diffArgs[0]
+		}
+	default:
+		svSpecs = diffArgs
+	}
+
+	states, _ := be.States(svSpecs...)
+
+	return states, nil
+}
+
+func (be *BackendAzurerm) Runs() ([]*tfe.Run, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (be *BackendAzurerm) State() ([]byte, error) {
+	sv := be.Cmd.String("sv")
+	states, err := be.States(sv)
+	if err != nil {
+		return nil, err
+	}
+	return states[0], nil
+}
+
+// stateBody downloads the blob at the given version; an empty versionID
+// means the current blob.
+func (be *BackendAzurerm) stateBody(versionID string) ([]byte, error) {
+	client, err := be.containerClient()
+	if err != nil {
+		return nil, err
+	}
+
+	blobClient := client.NewBlobClient(be.Backend.Config.Key)
+	if versionID != "" {
+		versioned, err := blobClient.WithVersionID(versionID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to select blob version: %w", err)
+		}
+		blobClient = versioned
+	}
+
+	resp, err := blobClient.DownloadStream(be.Ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download blob: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if _, err := buf.ReadFrom(resp.Body); err != nil {
+		return nil, fmt.Errorf("failed to read blob body: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// StateVersions implements backend.Backend. It lists every version of the
+// state blob, newest first. Storage accounts without blob versioning
+// enabled will only ever have the single current blob.
+func (be *BackendAzurerm) StateVersions(augmenter ...func(context.Context, *cli.Command, *tfe.StateVersionListOptions) error) ([]*tfe.StateVersion, error) {
+	client, err := be.containerClient()
+	if err != nil {
+		return nil, err
+	}
+
+	pager := client.NewListBlobsFlatPager(&container.ListBlobsFlatOptions{
+		Prefix: azcore.String(be.Backend.Config.Key),
+		Include: container.ListBlobsInclude{
+			Versions: true,
+		},
+	})
+
+	var versions []*tfe.StateVersion
+	for pager.More() {
+		page, err := pager.NextPage(be.Ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list blob versions: %w", err)
+		}
+		for _, item := range page.Segment.BlobItems {
+			if item.Name == nil || *item.Name != be.Backend.Config.Key {
+				continue
+			}
+
+			versionID := ""
+			if item.VersionID != nil {
+				versionID = *item.VersionID
+			}
+
+			body, err := be.stateBody(versionID)
+			if err != nil {
+				log.WithError(err).Error("azurerm read blob version failed")
+				continue
+			}
+
+			var doc map[string]interface{}
+			_ = json.Unmarshal(body, &doc)
+			var serial int64
+			if s, ok := doc["serial"].(float64); ok {
+				serial = int64(s)
+			}
+
+			id := versionID
+			if id == "" {
+				id = "CSV~0"
+			}
+
+			var createdAt time.Time
+			if item.Properties != nil && item.Properties.LastModified != nil {
+				createdAt = *item.Properties.LastModified
+			}
+
+			versions = append(versions, &tfe.StateVersion{
+				ID:        id,
+				CreatedAt: createdAt,
+				Serial:    serial,
+			})
+		}
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].CreatedAt.After(versions[j].CreatedAt)
+	})
+
+	limit := be.Cmd.Int("limit")
+	if len(versions) > limit {
+		versions = versions[:limit]
+	}
+
+	return versions, nil
+}
+
+func (be *BackendAzurerm) States(specs ...string) ([][]byte, error) {
+	var results [][]byte
+
+	candidates, _ := be.StateVersions()
+	versions, err := svutil.Resolve(candidates, specs...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range versions {
+		versionID := v.ID
+		if versionID == "CSV~0" {
+			versionID = ""
+		}
+		body, err := be.stateBody(versionID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get state: %w", err)
+		}
+		results = append(results, body)
+	}
+
+	return results, nil
+}
+
+func (be *BackendAzurerm) String() string {
+	return "backend-azurerm"
+}
+
+func (be *BackendAzurerm) Type() (string, error) {
+	return be.Backend.Type, nil
+}
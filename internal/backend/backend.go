@@ -9,16 +9,24 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/apex/log"
 	"github.com/hashicorp/go-tfe"
 	"github.com/urfave/cli/v3"
 
+	"github.com/tfctl/tfctl/internal/backend/artifactory"
+	"github.com/tfctl/tfctl/internal/backend/azurerm"
 	"github.com/tfctl/tfctl/internal/backend/cloud"
+	"github.com/tfctl/tfctl/internal/backend/consul"
+	"github.com/tfctl/tfctl/internal/backend/gcs"
+	"github.com/tfctl/tfctl/internal/backend/http"
 	"github.com/tfctl/tfctl/internal/backend/local"
 	"github.com/tfctl/tfctl/internal/backend/remote"
 	"github.com/tfctl/tfctl/internal/backend/s3"
 	"github.com/tfctl/tfctl/internal/meta"
+	"github.com/tfctl/tfctl/internal/releases"
 )
 
 // Type holds common backend resolution context and flags.
@@ -46,26 +54,115 @@ type Backend interface {
 	Type() (string, error)
 }
 
-// SelfDiffer is implemented by backends that can diff state snapshots without
-// an external differ.
+// SelfDiffer is implemented by backends that can resolve the state
+// documents a diff should run against without an external differ. The
+// returned documents are raw state JSON; internal/command/sq.go pairs them
+// up (via differ.Pairs) and renders each pair through the shared
+// internal/diff engine, so remote, s3, and local all get the same
+// structured, schema-aware diff output from this one method.
 type SelfDiffer interface {
 	DiffStates(ctx context.Context, cmd *cli.Command) ([][]byte, error)
 }
 
+var (
+	_ Configurable = (*local.BackendLocal)(nil)
+	_ Configurable = (*remote.BackendRemote)(nil)
+	_ Configurable = (*s3.BackendS3)(nil)
+)
+
+func init() {
+	Register("cloud", func(ctx context.Context, cmd *cli.Command, meta meta.Meta) (Backend, error) {
+		beCloud, err := cloud.NewBackendCloud(ctx, cmd,
+			cloud.FromRootDir(meta.RootDir),
+			cloud.WithEnvOverride(meta.Env),
+		)
+		if err != nil {
+			return nil, err
+		}
+		return beCloud.Transform2Remote(ctx, cmd), nil
+	})
+	Register("local", func(ctx context.Context, cmd *cli.Command, meta meta.Meta) (Backend, error) {
+		return local.NewBackendLocal(ctx, cmd,
+			local.FromRootDir(meta.RootDir),
+			local.WithEnvOverride(meta.Env),
+			local.WithWorkspace(cmd.String("workspace")),
+		)
+	})
+	Register("remote", func(ctx context.Context, cmd *cli.Command, meta meta.Meta) (Backend, error) {
+		return remote.NewBackendRemote(ctx, cmd,
+			remote.FromRootDir(meta.RootDir),
+			remote.WithEnvOverride(meta.Env),
+			remote.WithSvOverride(),
+		)
+	})
+	Register("s3", func(ctx context.Context, cmd *cli.Command, meta meta.Meta) (Backend, error) {
+		return s3.NewBackendS3(ctx, cmd,
+			s3.FromRootDir(meta.RootDir),
+			s3.WithEnvOverride(meta.Env),
+			s3.WithSvOverride(),
+		)
+	})
+	Register("http", func(ctx context.Context, cmd *cli.Command, meta meta.Meta) (Backend, error) {
+		return http.NewBackendHttp(ctx, cmd,
+			http.FromRootDir(meta.RootDir),
+			http.WithEnvOverride(meta.Env),
+		)
+	})
+	Register("gcs", func(ctx context.Context, cmd *cli.Command, meta meta.Meta) (Backend, error) {
+		return gcs.NewBackendGcs(ctx, cmd,
+			gcs.FromRootDir(meta.RootDir),
+			gcs.WithEnvOverride(meta.Env),
+		)
+	})
+	Register("azurerm", func(ctx context.Context, cmd *cli.Command, meta meta.Meta) (Backend, error) {
+		return azurerm.NewBackendAzurerm(ctx, cmd,
+			azurerm.FromRootDir(meta.RootDir),
+			azurerm.WithEnvOverride(meta.Env),
+		)
+	})
+	Register("consul", func(ctx context.Context, cmd *cli.Command, meta meta.Meta) (Backend, error) {
+		return consul.NewBackendConsul(ctx, cmd,
+			consul.FromRootDir(meta.RootDir),
+			consul.WithEnvOverride(meta.Env),
+		)
+	})
+	Register("artifactory", func(ctx context.Context, cmd *cli.Command, meta meta.Meta) (Backend, error) {
+		return artifactory.NewBackendArtifactory(ctx, cmd,
+			artifactory.FromRootDir(meta.RootDir),
+			artifactory.WithEnvOverride(meta.Env),
+		)
+	})
+}
+
 // NewBackend returns the appropriate Backend implementation for the working
 // directory represented by the resolved root dir in command metadata.
 func NewBackend(ctx context.Context, cmd cli.Command) (Backend, error) {
 	meta := cmd.Metadata["meta"].(meta.Meta)
 	log.Debugf("NewBackend: meta: %v", meta)
 
+	// --no-init means "never trust .terraform/terraform.tfstate", so discover
+	// the backend straight from the root module's HCL regardless of whether
+	// that file happens to exist.
+	if cmd.Bool("no-init") {
+		if beCloud, err := cloud.NewBackendCloud(ctx, &cmd, cloud.FromHCL(meta.RootDir)); err == nil {
+			return beCloud.Transform2Remote(ctx, &cmd), nil
+		}
+	}
+
 	cFile, cErr := os.Stat(filepath.Join(meta.RootDir, ".terraform", "terraform.tfstate"))
 	sFile, sErr := os.Stat(filepath.Join(meta.RootDir, "terraform.tfstate"))
 	eFile, eErr := os.Stat(filepath.Join(meta.RootDir, ".terraform", "environment"))
 	_, _, _ = cFile, sFile, eFile // HACK
 
-	// Maybe we're in a non-sq command and just need a naked remote. This will be
-	// when c, s and e are all in error meaning none of them exist.
+	// Maybe we're in a non-sq command and just need a naked remote, or we're in
+	// a freshly-cloned repo that hasn't had "terraform init" run yet. This will
+	// be when c, s and e are all in error meaning none of them exist. Try
+	// discovering a cloud/remote backend from the root module's HCL before
+	// falling back to a naked remote.
 	if cErr != nil && sErr != nil && eErr != nil {
+		if beCloud, err := cloud.NewBackendCloud(ctx, &cmd, cloud.FromHCL(meta.RootDir)); err == nil {
+			return beCloud.Transform2Remote(ctx, &cmd), nil
+		}
 		return remote.NewBackendRemote(ctx, &cmd, remote.BuckNaked())
 	}
 
@@ -75,6 +172,7 @@ func NewBackend(ctx context.Context, cmd cli.Command) (Backend, error) {
 		return local.NewBackendLocal(ctx, &cmd,
 			local.FromRootDir(meta.RootDir),
 			local.WithEnvOverride(meta.Env),
+			local.WithWorkspace(cmd.String("workspace")),
 		)
 	}
 
@@ -85,71 +183,74 @@ func NewBackend(ctx context.Context, cmd cli.Command) (Backend, error) {
 		return local.NewBackendLocal(ctx, &cmd,
 			local.FromRootDir(meta.RootDir),
 			local.WithEnvOverride(meta.Env),
+			local.WithWorkspace(cmd.String("workspace")),
 		)
 	}
 
-	// Peek at the backend type so we can switch on it.
+	// Peek at the backend type so we can dispatch through the registry.
 	// TODO We're double reading the file. Once in peek() and once in the New().
-	typ, err := peek(meta)
+	typ, _, err := peek(meta)
 	if err != nil {
 		return nil, err
 	}
 
-	var result Backend
-	switch typ {
-	case "cloud":
-		var beCloud *cloud.BackendCloud
-		beCloud, err = cloud.NewBackendCloud(ctx, &cmd,
-			cloud.FromRootDir(meta.RootDir),
-			cloud.WithEnvOverride(meta.Env),
-		)
-		// Preserve prior behavior: return transformed backend alongside any error
-		result = beCloud.Transform2Remote(ctx, &cmd)
-	case "local":
-		result, err = local.NewBackendLocal(ctx, &cmd,
-			local.FromRootDir(meta.RootDir),
-			local.WithEnvOverride(meta.Env),
-		)
-	case "remote":
-		result, err = remote.NewBackendRemote(ctx, &cmd,
-			remote.FromRootDir(meta.RootDir),
-			remote.WithEnvOverride(meta.Env),
-			remote.WithSvOverride(),
-		)
-	case "s3":
-		result, err = s3.NewBackendS3(ctx, &cmd,
-			s3.FromRootDir(meta.RootDir),
-			s3.WithEnvOverride(meta.Env),
-			s3.WithSvOverride(),
-		)
-	default:
-		return nil, fmt.Errorf("unknown type %s: %w", typ, err)
+	factory, ok := Lookup(typ)
+	if !ok {
+		known := make([]string, 0, len(registry))
+		for name := range registry {
+			known = append(known, name)
+		}
+		sort.Strings(known)
+		return nil, fmt.Errorf("unknown backend type %q, registered types: %s", typ, strings.Join(known, ", "))
 	}
 
-	return result, err
+	return factory(ctx, &cmd, meta)
 }
 
-// peek returns the backend type by reading the local terraform state file.
-func peek(meta meta.Meta) (string, error) {
+// peek returns the backend type and the pinned terraform_version, both read
+// from the local terraform state file in a single pass.
+func peek(meta meta.Meta) (string, string, error) {
 	raw, err := os.ReadFile(filepath.Join(meta.RootDir, ".terraform", "terraform.tfstate"))
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 
-	var peeker map[string]json.RawMessage
-	if err := json.Unmarshal(raw, &peeker); err != nil {
-		return "", fmt.Errorf("can't peek: %w", err)
+	var top map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &top); err != nil {
+		return "", "", fmt.Errorf("can't peek: %w", err)
 	}
 
-	if err := json.Unmarshal(peeker["backend"], &peeker); err != nil {
-		return "", fmt.Errorf("can't peek: %w", err)
+	var tfVersion string
+	_ = json.Unmarshal(top["terraform_version"], &tfVersion)
+
+	var backend map[string]json.RawMessage
+	if err := json.Unmarshal(top["backend"], &backend); err != nil {
+		return "", "", fmt.Errorf("can't peek: %w", err)
 	}
 
 	var typ string
-	if err := json.Unmarshal(peeker["type"], &typ); err != nil {
-		return "", fmt.Errorf("can't peek: %w", err)
+	if err := json.Unmarshal(backend["type"], &typ); err != nil {
+		return "", "", fmt.Errorf("can't peek: %w", err)
+	}
+	log.Debugf("type: %s terraform_version: %s", typ, tfVersion)
+
+	return typ, tfVersion, nil
+}
+
+// Binary resolves the path to the terraform binary pinned by the working
+// directory's .terraform/terraform.tfstate, downloading and caching it via
+// internal/releases if it isn't already present. It's the extension point
+// future shell-out subcommands (e.g. "tfctl plan"/"validate") use to run the
+// exact version the state was last written with rather than whatever
+// "terraform" happens to be on PATH.
+func Binary(ctx context.Context, meta meta.Meta) (string, error) {
+	_, tfVersion, err := peek(meta)
+	if err != nil {
+		return "", err
+	}
+	if tfVersion == "" {
+		return "", fmt.Errorf("no terraform_version recorded in %s", filepath.Join(meta.RootDir, ".terraform", "terraform.tfstate"))
 	}
-	log.Debugf("type: %s", typ)
 
-	return typ, nil
+	return releases.Get(ctx, releases.Terraform, tfVersion)
 }
@@ -14,6 +14,7 @@ import (
 
 	"github.com/tfctl/tfctl/internal/backend/remote"
 	"github.com/tfctl/tfctl/internal/config"
+	"github.com/tfctl/tfctl/internal/credhelper"
 )
 
 type BackendCloud struct {
@@ -31,9 +32,9 @@ type BackendCloud struct {
 			Organization string `json:"organization" validate:"required"`
 			Token        any    `json:"token"`
 			Workspaces   struct {
-				Name    string            `json:"name"`
-				Project string            `json:"project"`
-				Tags    map[string]string `json:"-"`
+				Name    string   `json:"name"`
+				Project string   `json:"project"`
+				Tags    []string `json:"tags"`
 			} `json:"workspaces"`
 		} `json:"config"`
 	} `json:"backend"`
@@ -65,7 +66,8 @@ func (be *BackendCloud) Token() (string, error) {
 
 	// Once we're here, token may have existed already in the config file or it
 	// may have been overridden by an environment variable. If it's still empty,
-	// we need to try to get it from the credentials file.
+	// we need to try to get it from the credentials file, and failing that,
+	// from a .terraformrc credentials_helper.
 	if token == "" {
 		home, err := os.UserHomeDir()
 		if err != nil {
@@ -73,23 +75,32 @@ func (be *BackendCloud) Token() (string, error) {
 		}
 
 		credsFile := home + "/.terraform.d/credentials.tfrc.json"
-		data, err := os.ReadFile(credsFile)
-		if err != nil {
-			return "", fmt.Errorf("failed to read credentials file: %w", err)
-		}
-
-		var creds struct {
-			Credentials map[string]struct {
-				Token string `json:"token"`
-			} `json:"credentials"`
-		}
-
-		if err := json.Unmarshal(data, &creds); err != nil {
-			return "", fmt.Errorf("failed to unmarshal credentials file: %w", err)
+		if data, err := os.ReadFile(credsFile); err == nil {
+			var creds struct {
+				Credentials map[string]struct {
+					Token string `json:"token"`
+				} `json:"credentials"`
+			}
+
+			if err := json.Unmarshal(data, &creds); err != nil {
+				return "", fmt.Errorf("failed to unmarshal credentials file: %w", err)
+			}
+
+			if cred, ok := creds.Credentials[be.Backend.Config.Hostname]; ok {
+				return cred.Token, nil
+			}
 		}
 
-		if cred, ok := creds.Credentials[be.Backend.Config.Hostname]; ok {
-			return cred.Token, nil
+		if be.Cmd == nil || !be.Cmd.Bool("no-credentials-helper") {
+			if cfg, ok, err := credhelper.Load(); err == nil && ok {
+				ctx := be.Ctx
+				if ctx == nil {
+					ctx = context.Background()
+				}
+				if t, err := credhelper.Get(ctx, cfg, be.Backend.Config.Hostname); err == nil {
+					return t, nil
+				}
+			}
 		}
 	}
 
@@ -141,6 +152,8 @@ func (be *BackendCloud) Transform2Remote(ctx context.Context, cmd *cli.Command)
 	beRemote.Backend.Config.Organization = org
 
 	beRemote.Backend.Config.Workspaces.Name = be.Backend.Config.Workspaces.Name
+	beRemote.Backend.Config.Workspaces.Project = be.Backend.Config.Workspaces.Project
+	beRemote.Backend.Config.Workspaces.Tags = be.Backend.Config.Workspaces.Tags
 	beRemote.Backend.Config.Token, _ = beRemote.Token()
 
 	return &beRemote
@@ -0,0 +1,238 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/urfave/cli/v3"
+)
+
+var rootSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "terraform"},
+	},
+}
+
+var terraformBodySchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "cloud"},
+		{Type: "backend", LabelNames: []string{"type"}},
+	},
+}
+
+var cloudBodySchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "hostname"},
+		{Name: "organization"},
+	},
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "workspaces"},
+	},
+}
+
+var remoteBackendBodySchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "hostname"},
+		{Name: "organization"},
+	},
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "workspaces"},
+	},
+}
+
+var workspacesBodySchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "name"},
+		{Name: "tags"},
+		{Name: "project"},
+	},
+}
+
+// FromHCL discovers a `terraform { cloud { ... } }` or
+// `terraform { backend "remote" { ... } }` block from the *.tf files
+// directly under rootDir (root module only; it does not walk into child
+// modules) and synthesizes the equivalent BackendCloud in memory. It's the
+// fallback NewBackendCloud reaches for when .terraform/terraform.tfstate is
+// missing entirely (a freshly-cloned repo that hasn't had "terraform init"
+// run) or when --no-init asks to ignore it, so si/sq/cloud-backed commands
+// can still resolve a backend.
+func FromHCL(rootDir string) BackendCloudOption {
+	return func(ctx context.Context, cmd *cli.Command, be *BackendCloud) error {
+		files, err := filepath.Glob(filepath.Join(rootDir, "*.tf"))
+		if err != nil {
+			return fmt.Errorf("failed to glob *.tf in %s: %w", rootDir, err)
+		}
+
+		evalCtx := buildHCLEvalContext()
+		parser := hclparse.NewParser()
+
+		for _, path := range files {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				continue
+			}
+
+			f, diags := parser.ParseHCL(data, path)
+			if diags.HasErrors() {
+				continue
+			}
+
+			found, err := scanTerraformBlock(f.Body, evalCtx, be)
+			if err != nil {
+				return fmt.Errorf("%s: %w", path, err)
+			}
+			if found {
+				be.RootDir = rootDir
+				be.Version = 4
+				be.Backend.Type = "cloud"
+				return nil
+			}
+		}
+
+		return fmt.Errorf("no terraform { cloud {} } or backend \"remote\" {} block found in *.tf files under %s", rootDir)
+	}
+}
+
+// scanTerraformBlock looks for a cloud or backend "remote" block inside
+// body's terraform {} blocks and populates be from the first one found.
+func scanTerraformBlock(body hcl.Body, evalCtx *hcl.EvalContext, be *BackendCloud) (bool, error) {
+	content, _, _ := body.PartialContent(rootSchema)
+
+	for _, tfBlock := range content.Blocks {
+		inner, _, _ := tfBlock.Body.PartialContent(terraformBodySchema)
+
+		for _, b := range inner.Blocks {
+			switch {
+			case b.Type == "cloud":
+				if err := populateFromCloudBlock(b.Body, evalCtx, be); err != nil {
+					return true, err
+				}
+				return true, nil
+			case b.Type == "backend" && len(b.Labels) == 1 && b.Labels[0] == "remote":
+				if err := populateFromRemoteBackendBlock(b.Body, evalCtx, be); err != nil {
+					return true, err
+				}
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+func populateFromCloudBlock(body hcl.Body, evalCtx *hcl.EvalContext, be *BackendCloud) error {
+	content, diags := body.PartialContent(cloudBodySchema)
+	if diags.HasErrors() {
+		return fmt.Errorf("failed to parse cloud block: %w", diags)
+	}
+
+	if attr, ok := content.Attributes["hostname"]; ok {
+		v, err := evalStringAttr(attr, evalCtx)
+		if err != nil {
+			return err
+		}
+		be.Backend.Config.Hostname = v
+	}
+	if attr, ok := content.Attributes["organization"]; ok {
+		v, err := evalStringAttr(attr, evalCtx)
+		if err != nil {
+			return err
+		}
+		be.Backend.Config.Organization = v
+	}
+
+	for _, b := range content.Blocks {
+		if b.Type != "workspaces" {
+			continue
+		}
+		if err := populateWorkspacesBlock(b.Body, evalCtx, be); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// populateFromRemoteBackendBlock handles the legacy `backend "remote" {}`
+// form. Only workspaces.name is meaningful here: workspaces.prefix selects a
+// workspace via the CLI-selected Terraform workspace rather than a literal
+// HCL attribute, so prefix-based remote backends still require
+// .terraform/terraform.tfstate (or --workspace) to resolve.
+func populateFromRemoteBackendBlock(body hcl.Body, evalCtx *hcl.EvalContext, be *BackendCloud) error {
+	content, diags := body.PartialContent(remoteBackendBodySchema)
+	if diags.HasErrors() {
+		return fmt.Errorf(`failed to parse backend "remote" block: %w`, diags)
+	}
+
+	if attr, ok := content.Attributes["hostname"]; ok {
+		v, err := evalStringAttr(attr, evalCtx)
+		if err != nil {
+			return err
+		}
+		be.Backend.Config.Hostname = v
+	}
+	if attr, ok := content.Attributes["organization"]; ok {
+		v, err := evalStringAttr(attr, evalCtx)
+		if err != nil {
+			return err
+		}
+		be.Backend.Config.Organization = v
+	}
+
+	for _, b := range content.Blocks {
+		if b.Type != "workspaces" {
+			continue
+		}
+		wsContent, diags := b.Body.PartialContent(workspacesBodySchema)
+		if diags.HasErrors() {
+			return fmt.Errorf("failed to parse workspaces block: %w", diags)
+		}
+		if attr, ok := wsContent.Attributes["name"]; ok {
+			v, err := evalStringAttr(attr, evalCtx)
+			if err != nil {
+				return err
+			}
+			be.Backend.Config.Workspaces.Name = v
+		}
+	}
+
+	return nil
+}
+
+func populateWorkspacesBlock(body hcl.Body, evalCtx *hcl.EvalContext, be *BackendCloud) error {
+	content, diags := body.PartialContent(workspacesBodySchema)
+	if diags.HasErrors() {
+		return fmt.Errorf("failed to parse workspaces block: %w", diags)
+	}
+
+	if attr, ok := content.Attributes["name"]; ok {
+		v, err := evalStringAttr(attr, evalCtx)
+		if err != nil {
+			return err
+		}
+		be.Backend.Config.Workspaces.Name = v
+	}
+	if attr, ok := content.Attributes["project"]; ok {
+		v, err := evalStringAttr(attr, evalCtx)
+		if err != nil {
+			return err
+		}
+		be.Backend.Config.Workspaces.Project = v
+	}
+	if attr, ok := content.Attributes["tags"]; ok {
+		v, err := evalStringListAttr(attr, evalCtx)
+		if err != nil {
+			return err
+		}
+		be.Backend.Config.Workspaces.Tags = v
+	}
+
+	return nil
+}
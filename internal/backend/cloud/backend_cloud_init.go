@@ -29,7 +29,26 @@ func FromRootDir(rootDir string, required ...bool) BackendCloudOption {
 
 		log.Debugf("NewBackendCloud FromRootDir(): rootDir = %s", be.RootDir)
 
-		err := be.load(ctx, cmd)
+		// --no-init skips .terraform/terraform.tfstate entirely and discovers
+		// the backend straight from the root module's HCL, as if "terraform
+		// init" had never been run.
+		noInit := cmd != nil && cmd.Bool("no-init")
+
+		var err error
+		if !noInit {
+			err = be.load(ctx, cmd)
+		}
+
+		// Fall back to discovering the backend from HCL, either because
+		// --no-init asked for it or because the tfstate-based load failed (no
+		// .terraform/terraform.tfstate, e.g. a freshly-cloned repo).
+		if noInit || err != nil {
+			if hclErr := FromHCL(be.RootDir)(ctx, cmd, be); hclErr == nil {
+				err = nil
+			} else if noInit {
+				err = hclErr
+			}
+		}
 
 		// Return no error is required is present and false.
 		if len(required) > 0 && !required[0] {
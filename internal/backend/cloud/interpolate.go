@@ -0,0 +1,93 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package cloud
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+)
+
+// buildHCLEvalContext builds the hcl.EvalContext used by FromHCL to evaluate
+// backend-config attribute expressions. It exposes var.* from TF_VAR_*
+// environment variables and terraform.workspace from TF_WORKSPACE (default
+// "default"), the two interpolations a terraform { cloud {} } or
+// backend "remote" {} block can realistically reference before Terraform
+// itself has ever read any other source of variables.
+func buildHCLEvalContext() *hcl.EvalContext {
+	vars := map[string]cty.Value{}
+	for _, kv := range os.Environ() {
+		rest, ok := strings.CutPrefix(kv, "TF_VAR_")
+		if !ok {
+			continue
+		}
+		parts := strings.SplitN(rest, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		vars[parts[0]] = cty.StringVal(parts[1])
+	}
+
+	workspace := os.Getenv("TF_WORKSPACE")
+	if workspace == "" {
+		workspace = "default"
+	}
+
+	return &hcl.EvalContext{
+		Variables: map[string]cty.Value{
+			"var":       cty.ObjectVal(vars),
+			"terraform": cty.ObjectVal(map[string]cty.Value{"workspace": cty.StringVal(workspace)}),
+		},
+	}
+}
+
+// evalStringAttr evaluates attr's expression to a string. Backend-config
+// attributes (hostname, organization, workspaces.name/project) are always
+// plain strings or var.*/terraform.workspace references, never collections.
+func evalStringAttr(attr *hcl.Attribute, ctx *hcl.EvalContext) (string, error) {
+	v, diags := attr.Expr.Value(ctx)
+	if diags.HasErrors() {
+		return "", fmt.Errorf("failed to evaluate %s: %w", attr.Name, diags)
+	}
+
+	sv, err := convert.Convert(v, cty.String)
+	if err != nil {
+		return "", fmt.Errorf("attribute %s must be a string: %w", attr.Name, err)
+	}
+	if sv.IsNull() {
+		return "", nil
+	}
+	return sv.AsString(), nil
+}
+
+// evalStringListAttr evaluates attr's expression to a list of strings, for
+// workspaces.tags.
+func evalStringListAttr(attr *hcl.Attribute, ctx *hcl.EvalContext) ([]string, error) {
+	v, diags := attr.Expr.Value(ctx)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("failed to evaluate %s: %w", attr.Name, diags)
+	}
+	if v.IsNull() {
+		return nil, nil
+	}
+	if !v.CanIterateElements() {
+		return nil, fmt.Errorf("attribute %s must be a list of strings", attr.Name)
+	}
+
+	var out []string
+	it := v.ElementIterator()
+	for it.Next() {
+		_, ev := it.Element()
+		sv, err := convert.Convert(ev, cty.String)
+		if err != nil {
+			return nil, fmt.Errorf("attribute %s must be a list of strings: %w", attr.Name, err)
+		}
+		out = append(out, sv.AsString())
+	}
+	return out, nil
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package configschema provides a small, declarative description of a
+// backend's configuration options, modeled on Terraform's own
+// configs/configschema package. It lets each backend.Backend implementation
+// describe its options (name, type, required/optional) without the CLI
+// layer needing to know about them ahead of time.
+package configschema
+
+// AttributeType identifies the primitive shape of an Attribute's value.
+type AttributeType string
+
+const (
+	TypeString AttributeType = "string"
+	TypeBool   AttributeType = "bool"
+	TypeNumber AttributeType = "number"
+)
+
+// Attribute describes a single backend configuration option.
+type Attribute struct {
+	Type        AttributeType
+	Description string
+	Required    bool
+	Optional    bool
+	Sensitive   bool
+}
+
+// Block is a named set of Attributes, analogous to Terraform's
+// configschema.Block but trimmed to what tfctl's read-only backends need:
+// no nested blocks, since none of our backend configs require them.
+type Block struct {
+	Attributes map[string]*Attribute
+}
+
+// Attribute looks up a named attribute, returning nil if it is not part of
+// the block.
+func (b *Block) Attribute(name string) *Attribute {
+	if b == nil {
+		return nil
+	}
+	return b.Attributes[name]
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package consul
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/apex/log"
+	"github.com/urfave/cli/v3"
+)
+
+type BackendConsulOption = func(ctx context.Context, cmd *cli.Command, be *BackendConsul) error
+
+func FromRootDir(rootDir string, required ...bool) BackendConsulOption {
+	return func(ctx context.Context, cmd *cli.Command, be *BackendConsul) error {
+		if filepath.IsAbs(rootDir) {
+			be.RootDir = rootDir
+		} else {
+			cwd, _ := os.Getwd()
+			be.RootDir = filepath.Join(cwd, rootDir)
+		}
+
+		log.Debugf("NewBackendConsul FromRootDir(): rootDir = %s", be.RootDir)
+
+		err := be.load()
+
+		if len(required) > 0 && !required[0] {
+			return nil
+		}
+		return err
+	}
+}
+
+// NewBackendConsul returns a BackendConsul object that implements the
+// Backend interface. It is load()ed from the config file found in the
+// rootDir.
+func NewBackendConsul(ctx context.Context, cmd *cli.Command, options ...BackendConsulOption) (*BackendConsul, error) {
+	options = append([]BackendConsulOption{WithDefaults()}, options...)
+
+	be := &BackendConsul{Ctx: ctx, Cmd: cmd}
+
+	for _, opt := range options {
+		if err := opt(ctx, cmd, be); err != nil {
+			return nil, err
+		}
+	}
+
+	return be, nil
+}
+
+func WithDefaults() BackendConsulOption {
+	return func(ctx context.Context, cmd *cli.Command, be *BackendConsul) error {
+		cwd, _ := os.Getwd()
+		be.RootDir = cwd
+
+		be.Version = 4
+		be.TerraformVersion = "0.0.0"
+		be.Backend.Type = "consul"
+
+		log.Debugf("NewBackendConsul WithDefaults():")
+
+		return nil
+	}
+}
+
+func WithEnvOverride(env string) BackendConsulOption {
+	return func(ctx context.Context, cmd *cli.Command, be *BackendConsul) error {
+		if env != "" {
+			be.EnvOverride = env
+		}
+		return nil
+	}
+}
+
+func (be *BackendConsul) load() error {
+	tfFile := be.RootDir + "/.terraform/terraform.tfstate"
+	data, err := os.ReadFile(tfFile)
+	if err != nil {
+		return fmt.Errorf("failed to read local config file: %w", err)
+	}
+
+	var temp BackendConsul
+	if err := json.Unmarshal(data, &temp); err != nil {
+		return fmt.Errorf("failed to unmarshal local config file: %w", err)
+	}
+
+	if temp.Backend.Type != "consul" {
+		return fmt.Errorf("%w: backend type is not consul: %s", errors.New("bad"), temp.Backend.Type)
+	}
+
+	be.Version = temp.Version
+	be.TerraformVersion = temp.TerraformVersion
+	be.Backend = temp.Backend
+
+	return nil
+}
@@ -0,0 +1,255 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package gcs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"github.com/apex/log"
+	"github.com/hashicorp/go-tfe"
+	"github.com/urfave/cli/v3"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+
+	"github.com/tfctl/tfctl/internal/differ"
+	"github.com/tfctl/tfctl/internal/svutil"
+)
+
+// BackendGcs is a struct that represents a Google Cloud Storage backend
+// configuration.
+// https://developer.hashicorp.com/terraform/language/backend/gcs
+type BackendGcs struct {
+	Ctx              context.Context
+	Cmd              *cli.Command
+	RootDir          string `json:"-" validate:"dir"`
+	EnvOverride      string
+	Version          int    `json:"version" validate:"gte=4"`
+	TerraformVersion string `json:"terraform_version" validate:"semver"`
+	Backend          struct {
+		Type   string `json:"type" validate:"eq=gcs"`
+		Config struct {
+			Bucket                    string `json:"bucket" validate:"required"`
+			Prefix                    string `json:"prefix"`
+			Credentials               string `json:"credentials"`
+			AccessToken               string `json:"access_token"`
+			ImpersonateServiceAccount string `json:"impersonate_service_account"`
+			EncryptionKey             string `json:"encryption_key"`
+			StorageCustomEndpoint     string `json:"storage_custom_endpoint"`
+		} `json:"config"`
+		Hash int `json:"hash"`
+	} `json:"backend"`
+}
+
+// key is the object name the backend reads state from, following
+// Terraform's "<prefix>/<workspace>.tfstate" layout.
+func (be *BackendGcs) key() string {
+	workspace := "default"
+	if be.EnvOverride != "" {
+		workspace = be.EnvOverride
+	}
+	return path.Join(be.Backend.Config.Prefix, workspace+".tfstate")
+}
+
+// client builds a storage.Client, honoring explicit credentials/custom
+// endpoint config when set and otherwise falling back to the ambient
+// Application Default Credentials, same precedence Terraform's own gcs
+// backend uses.
+func (be *BackendGcs) client() (*storage.Client, error) {
+	var opts []option.ClientOption
+	if be.Backend.Config.Credentials != "" {
+		opts = append(opts, option.WithCredentialsFile(be.Backend.Config.Credentials))
+	}
+	if be.Backend.Config.StorageCustomEndpoint != "" {
+		opts = append(opts, option.WithEndpoint(be.Backend.Config.StorageCustomEndpoint))
+	}
+
+	client, err := storage.NewClient(be.Ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCS client: %w", err)
+	}
+	return client, nil
+}
+
+// DiffStates implements backend.SelfDiffer, resolving the pair (or list) of
+// generations to diff the same way the s3 backend does: the last two
+// generations by default, a single explicit spec in place of CSV~1, a "+N"
+// pinned/cursor selection via differ.SelectStateVersions, or an explicit list
+// of specs.
+func (be *BackendGcs) DiffStates(ctx context.Context, cmd *cli.Command) ([][]byte, error) {
+	svSpecs := []string{"CSV~1", "CSV~0"}
+
+	diffArgs := differ.ParseDiffArgs(ctx, cmd)
+
+	switch len(diffArgs) {
+	case 0:
+		// No args, so use the last two states.
+	case 1:
+		if strings.HasPrefix(diffArgs[0], "+") {
+			stateVersionList, err := be.StateVersions()
+			if err != nil {
+				return nil, fmt.Errorf("failed to get state version list: %v", err)
+			}
+
+			selection := differ.SelectStateVersions(stateVersionList)
+
+			log.Debugf("selection: %d", len(selection.Versions))
+
+			if len(selection.Versions) < 2 {
+				return nil, nil
+			}
+
+			if selection.Mode == "pinned" {
+				_ = cmd.Set("diff_mode", "pinned")
+			}
+
+			svSpecs = differ.SpecsForSelection(selection)
+		} else {
+			svSpecs[0] = diffArgs[0]
+		}
+	default:
+		svSpecs = diffArgs
+	}
+
+	states, _ := be.States(svSpecs...)
+
+	return states, nil
+}
+
+func (be *BackendGcs) Runs() ([]*tfe.Run, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (be *BackendGcs) State() ([]byte, error) {
+	sv := be.Cmd.String("sv")
+	states, err := be.States(sv)
+	if err != nil {
+		return nil, err
+	}
+	return states[0], nil
+}
+
+// stateBody fetches the object body at the given generation; generation == 0
+// means the live (current) object.
+func (be *BackendGcs) stateBody(generation int64) ([]byte, error) {
+	client, err := be.client()
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	obj := client.Bucket(be.Backend.Config.Bucket).Object(be.key())
+	if generation != 0 {
+		obj = obj.Generation(generation)
+	}
+
+	r, err := obj.NewReader(be.Ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read GCS object: %w", err)
+	}
+	defer r.Close()
+
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read GCS object body: %w", err)
+	}
+
+	return body, nil
+}
+
+// StateVersions implements backend.Backend. It lists every live and
+// noncurrent generation of the state object, newest first. Buckets without
+// object versioning enabled will only ever have the single live generation.
+func (be *BackendGcs) StateVersions(augmenter ...func(context.Context, *cli.Command, *tfe.StateVersionListOptions) error) ([]*tfe.StateVersion, error) {
+	client, err := be.client()
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	it := client.Bucket(be.Backend.Config.Bucket).Objects(be.Ctx, &storage.Query{
+		Prefix:   be.key(),
+		Versions: true,
+	})
+
+	var versions []*tfe.StateVersion
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list GCS object generations: %w", err)
+		}
+		if attrs.Name != be.key() {
+			continue
+		}
+
+		body, err := be.stateBody(attrs.Generation)
+		if err != nil {
+			log.WithError(err).Error("gcs read generation failed")
+			continue
+		}
+
+		var doc map[string]interface{}
+		_ = json.Unmarshal(body, &doc)
+		var serial int64
+		if s, ok := doc["serial"].(float64); ok {
+			serial = int64(s)
+		}
+
+		versions = append(versions, &tfe.StateVersion{
+			ID:        strconv.FormatInt(attrs.Generation, 10),
+			CreatedAt: attrs.Updated,
+			Serial:    serial,
+		})
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].CreatedAt.After(versions[j].CreatedAt)
+	})
+
+	limit := be.Cmd.Int("limit")
+	if len(versions) > limit {
+		versions = versions[:limit]
+	}
+
+	return versions, nil
+}
+
+func (be *BackendGcs) States(specs ...string) ([][]byte, error) {
+	var results [][]byte
+
+	candidates, _ := be.StateVersions()
+	versions, err := svutil.Resolve(candidates, specs...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range versions {
+		generation, _ := strconv.ParseInt(v.ID, 10, 64)
+		body, err := be.stateBody(generation)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get state: %w", err)
+		}
+		results = append(results, body)
+	}
+
+	return results, nil
+}
+
+func (be *BackendGcs) String() string {
+	return "backend-gcs"
+}
+
+func (be *BackendGcs) Type() (string, error) {
+	return be.Backend.Type, nil
+}
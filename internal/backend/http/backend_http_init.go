@@ -0,0 +1,101 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/apex/log"
+	"github.com/urfave/cli/v3"
+)
+
+type BackendHttpOption = func(ctx context.Context, cmd *cli.Command, be *BackendHttp) error
+
+func FromRootDir(rootDir string, required ...bool) BackendHttpOption {
+	return func(ctx context.Context, cmd *cli.Command, be *BackendHttp) error {
+		if filepath.IsAbs(rootDir) {
+			be.RootDir = rootDir
+		} else {
+			cwd, _ := os.Getwd()
+			be.RootDir = filepath.Join(cwd, rootDir)
+		}
+
+		log.Debugf("NewBackendHttp FromRootDir(): rootDir = %s", be.RootDir)
+
+		err := be.load()
+
+		if len(required) > 0 && !required[0] {
+			return nil
+		}
+		return err
+	}
+}
+
+// NewBackendHttp returns a BackendHttp object that implements the Backend
+// interface. It is load()ed from the config file found in the rootDir.
+func NewBackendHttp(ctx context.Context, cmd *cli.Command, options ...BackendHttpOption) (*BackendHttp, error) {
+	options = append([]BackendHttpOption{WithDefaults()}, options...)
+
+	be := &BackendHttp{Ctx: ctx, Cmd: cmd}
+
+	for _, opt := range options {
+		if err := opt(ctx, cmd, be); err != nil {
+			return nil, err
+		}
+	}
+
+	return be, nil
+}
+
+func WithDefaults() BackendHttpOption {
+	return func(ctx context.Context, cmd *cli.Command, be *BackendHttp) error {
+		cwd, _ := os.Getwd()
+		be.RootDir = cwd
+
+		be.Version = 4
+		be.TerraformVersion = "0.0.0"
+		be.Backend.Type = "http"
+
+		log.Debugf("NewBackendHttp WithDefaults():")
+
+		return nil
+	}
+}
+
+func WithEnvOverride(env string) BackendHttpOption {
+	return func(ctx context.Context, cmd *cli.Command, be *BackendHttp) error {
+		if env != "" {
+			be.EnvOverride = env
+		}
+		return nil
+	}
+}
+
+func (be *BackendHttp) load() error {
+	tfFile := be.RootDir + "/.terraform/terraform.tfstate"
+	data, err := os.ReadFile(tfFile)
+	if err != nil {
+		return fmt.Errorf("failed to read local config file: %w", err)
+	}
+
+	var temp BackendHttp
+	if err := json.Unmarshal(data, &temp); err != nil {
+		return fmt.Errorf("failed to unmarshal local config file: %w", err)
+	}
+
+	if temp.Backend.Type != "http" {
+		return fmt.Errorf("%w: backend type is not http: %s", errors.New("bad"), temp.Backend.Type)
+	}
+
+	be.Version = temp.Version
+	be.TerraformVersion = temp.TerraformVersion
+	be.Backend = temp.Backend
+
+	return nil
+}
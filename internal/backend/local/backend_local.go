@@ -4,9 +4,9 @@
 package local
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -21,13 +21,37 @@ import (
 	"github.com/tfctl/tfctl/internal/svutil"
 )
 
+// ErrWorkspaceNotFound is returned by StatePath when the requested workspace
+// has no terraform.tfstate file on disk, mirroring Terraform's own
+// ErrWorkspacesNotSupported/ErrDefaultStateNotSupported sentinels so callers
+// in command can render a helpful message instead of a raw os.PathError.
+var ErrWorkspaceNotFound = errors.New("workspace not found")
+
+// defaultWorkspace is the name Terraform uses for the implicit workspace
+// that reads/writes terraform.tfstate directly, as opposed to
+// terraform.tfstate.d/<name>/terraform.tfstate.
+const defaultWorkspace = "default"
+
 // BackendLocal is a struct that represents a local backend configuration.
 // https://developer.hashicorp.com/terraform/language/backend/local
 type BackendLocal struct {
-	Ctx              context.Context
-	Cmd              *cli.Command
-	RootDir          string `json:"-" validate:"dir"`
-	EnvOverride      string
+	Ctx context.Context
+	Cmd *cli.Command
+	// RootDir is the working tree StateVersions/States/Workspaces scan. When
+	// Source is set (via WithSource), RootDir instead points at the
+	// cache-keyed directory that Source was materialized into, not a real IAC
+	// root, and the usual .terraform/terraform.tfstate backend config file
+	// there is expected to be absent.
+	RootDir string `json:"-" validate:"dir"`
+	// Source is an optional remote URL (s3://, gs://, git::ssh://,
+	// https://...) resolved via go-getter and materialized into RootDir.
+	// Empty means RootDir is used as-is, the original local-only behavior.
+	Source      string `json:"-"`
+	EnvOverride string
+	// Workspace is an explicit workspace selection, set via WithWorkspace
+	// (typically from the --workspace/-w flag). It takes precedence over
+	// EnvOverride and the .terraform/environment file.
+	Workspace        string
 	Version          int    `json:"version" validate:"gte=4"`
 	TerraformVersion string `json:"terraform_version" validate:"semver"`
 	Backend          struct {
@@ -61,24 +85,27 @@ func (be *BackendLocal) DiffStates(ctx context.Context, cmd *cli.Command) ([][]b
 				return nil, fmt.Errorf("failed to get state version list: %v", err)
 			}
 
-			selectedVersions := differ.SelectStateVersions(stateVersionList)
+			selection := differ.SelectStateVersions(stateVersionList)
 
-			log.Debugf("selectedVersions: %d", len(selectedVersions))
+			log.Debugf("selection: %d", len(selection.Versions))
 
-			if len(selectedVersions) == 0 {
+			if len(selection.Versions) < 2 {
 				return nil, nil
-			} else if len(selectedVersions) == 2 {
-				svSpecs[0] = selectedVersions[1].ID
-				svSpecs[1] = selectedVersions[0].ID
 			}
+
+			if selection.Mode == "pinned" {
+				_ = cmd.Set("diff_mode", "pinned")
+			}
+
+			svSpecs = differ.SpecsForSelection(selection)
 		} else {
 			svSpecs[0] = diffArgs[0]
 		}
-	case 2:
+	default:
 		svSpecs = diffArgs
 	}
 
-	states, _ := be.States(svSpecs[0], svSpecs[1])
+	states, _ := be.States(svSpecs...)
 
 	return states, nil
 }
@@ -105,18 +132,9 @@ func (be *BackendLocal) State() ([]byte, error) {
 func (be *BackendLocal) StateVersions(augmenter ...func(context.Context, *cli.Command, *tfe.StateVersionListOptions) error) ([]*tfe.StateVersion, error) {
 	var versions []*tfe.StateVersion
 
-	// If there's a .terraform/environment file, we need to use that to
-	// determine the workspace directory.
-	if be.EnvOverride == "" {
-		envFile := filepath.Join(be.RootDir, ".terraform/environment")
-		if envFileData, err := os.ReadFile(envFile); err == nil {
-			be.EnvOverride = string(bytes.TrimSpace(envFileData))
-		}
-	}
-
 	envPath := ""
-	if be.EnvOverride != "" {
-		envPath = filepath.Join("terraform.tfstate.d", be.EnvOverride)
+	if ws := be.CurrentWorkspace(); ws != defaultWorkspace {
+		envPath = filepath.Join("terraform.tfstate.d", ws)
 	}
 
 	files, err := filepath.Glob(filepath.Join(be.RootDir, envPath, "terraform.tfstate*"))
@@ -214,3 +232,80 @@ func (be *BackendLocal) String() string {
 func (be *BackendLocal) Type() (string, error) {
 	return be.Backend.Type, nil
 }
+
+// CurrentWorkspace returns the effective workspace name, preferring an
+// explicit WithWorkspace/--workspace selection, then EnvOverride, then the
+// .terraform/environment file written by `terraform workspace select`, and
+// finally falling back to "default".
+func (be *BackendLocal) CurrentWorkspace() string {
+	if be.Workspace != "" {
+		return be.Workspace
+	}
+	if be.EnvOverride != "" {
+		return be.EnvOverride
+	}
+
+	envFile := filepath.Join(be.RootDir, ".terraform/environment")
+	if data, err := os.ReadFile(envFile); err == nil {
+		if ws := strings.TrimSpace(string(data)); ws != "" {
+			return ws
+		}
+	}
+
+	return defaultWorkspace
+}
+
+// Workspaces enumerates the workspaces available beneath RootDir: "default"
+// plus every subdirectory of terraform.tfstate.d that contains a
+// terraform.tfstate file.
+func (be *BackendLocal) Workspaces() ([]string, error) {
+	workspaces := []string{defaultWorkspace}
+
+	entries, err := os.ReadDir(filepath.Join(be.RootDir, "terraform.tfstate.d"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return workspaces, nil
+		}
+		return nil, fmt.Errorf("failed to list workspaces: %w", err)
+	}
+
+	var named []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		p := filepath.Join(be.RootDir, "terraform.tfstate.d", entry.Name(), "terraform.tfstate")
+		if _, err := os.Stat(p); err == nil {
+			named = append(named, entry.Name())
+		}
+	}
+	sort.Strings(named)
+
+	return append(workspaces, named...), nil
+}
+
+// StatePath resolves the on-disk terraform.tfstate path for workspace. An
+// empty workspace resolves to CurrentWorkspace(). It returns ErrWorkspaceNotFound
+// if the workspace has no state file yet.
+func (be *BackendLocal) StatePath(workspace string) (string, error) {
+	if workspace == "" {
+		workspace = be.CurrentWorkspace()
+	}
+
+	p := filepath.Join(be.RootDir, "terraform.tfstate.d", workspace, "terraform.tfstate")
+	if workspace == defaultWorkspace {
+		p = filepath.Join(be.RootDir, "terraform.tfstate")
+		if be.Backend.Config.Path != "" {
+			p = filepath.Join(be.RootDir, be.Backend.Config.Path)
+		}
+	}
+
+	if _, err := os.Stat(p); err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("%w: %s", ErrWorkspaceNotFound, workspace)
+		}
+		return "", fmt.Errorf("failed to stat state file for workspace %s: %w", workspace, err)
+	}
+
+	return p, nil
+}
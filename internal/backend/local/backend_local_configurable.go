@@ -0,0 +1,86 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package local
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/tfctl/tfctl/internal/backend/configschema"
+	"github.com/tfctl/tfctl/internal/backend/statemgr"
+)
+
+// localStateManager is the StateManager returned by BackendLocal.StateMgr. It
+// reads the terraform.tfstate file for a single resolved workspace.
+type localStateManager struct {
+	path string
+}
+
+func (m *localStateManager) State() ([]byte, error) {
+	return os.ReadFile(m.path)
+}
+
+// ConfigSchema describes the options accepted under a local backend's
+// "config" block. https://developer.hashicorp.com/terraform/language/backend/local
+func (be *BackendLocal) ConfigSchema() *configschema.Block {
+	return &configschema.Block{
+		Attributes: map[string]*configschema.Attribute{
+			"path": {
+				Type:        configschema.TypeString,
+				Description: "path to the state file, relative to the root module",
+				Optional:    true,
+			},
+			"workspace_dir": {
+				Type:        configschema.TypeString,
+				Description: "directory holding the state files for non-default workspaces",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+// Configure applies decoded "config" block values to the backend, mirroring
+// the shape unmarshaled from .terraform/terraform.tfstate in load().
+func (be *BackendLocal) Configure(config map[string]any) error {
+	if path, ok := config["path"].(string); ok {
+		be.Backend.Config.Path = path
+	}
+	if workspaceDir, ok := config["workspace_dir"].(string); ok {
+		be.Backend.Config.WorkspaceDir = workspaceDir
+	}
+	return nil
+}
+
+// DeleteWorkspace removes the state directory for a non-default workspace.
+// Deleting the default workspace is refused, matching Terraform's own
+// behavior (it cannot be deleted, only emptied).
+func (be *BackendLocal) DeleteWorkspace(name string) error {
+	if name == "" || name == defaultWorkspace {
+		return fmt.Errorf("cannot delete the %q workspace", defaultWorkspace)
+	}
+
+	dir := filepath.Join(be.RootDir, "terraform.tfstate.d", name)
+	if _, err := os.Stat(dir); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("%w: %s", ErrWorkspaceNotFound, name)
+		}
+		return fmt.Errorf("failed to stat workspace %s: %w", name, err)
+	}
+
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("failed to delete workspace %s: %w", name, err)
+	}
+	return nil
+}
+
+// StateMgr returns a statemgr.Reader reading the resolved state file for
+// workspace. An empty workspace resolves to CurrentWorkspace().
+func (be *BackendLocal) StateMgr(workspace string) (statemgr.Reader, error) {
+	path, err := be.StatePath(workspace)
+	if err != nil {
+		return nil, err
+	}
+	return &localStateManager{path: path}, nil
+}
@@ -74,6 +74,47 @@ func WithEnvOverride(env string) BackendLocalOption {
 	}
 }
 
+// WithSource resolves source via go-getter and points RootDir at the
+// cache-keyed directory it was materialized into, so the rest of BackendLocal
+// (StateVersions/States/Workspaces) transparently operates on the fetched
+// tree. If source is empty this is a no-op, preserving the default
+// local-filesystem-only behavior. Per SourceCacheDir/fetchSource, a repeated
+// call with the same source within the freshness window reuses the
+// previously materialized files instead of re-fetching.
+func WithSource(source string) BackendLocalOption {
+	return func(ctx context.Context, cmd *cli.Command, be *BackendLocal) error {
+		if source == "" {
+			return nil
+		}
+		be.Source = source
+
+		dest, ok := SourceCacheDir(source)
+		if !ok {
+			cwd, _ := os.Getwd()
+			dest = filepath.Join(cwd, ".tfctl-source-cache")
+		}
+
+		if err := fetchSource(ctx, source, dest); err != nil {
+			return err
+		}
+
+		be.RootDir = dest
+		return be.load(ctx, cmd)
+	}
+}
+
+// WithWorkspace sets an explicit workspace selection (typically the
+// --workspace/-w flag value), which CurrentWorkspace and StatePath prefer
+// over EnvOverride and the .terraform/environment file.
+func WithWorkspace(name string) BackendLocalOption {
+	return func(ctx context.Context, cmd *cli.Command, be *BackendLocal) error {
+		if name != "" {
+			be.Workspace = name
+		}
+		return nil
+	}
+}
+
 func WithNoBackend(rootDir string) BackendLocalOption {
 	return func(ctx context.Context, cmd *cli.Command, be *BackendLocal) error {
 		// Is rootDir a relative or absolute path?
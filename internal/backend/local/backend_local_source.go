@@ -0,0 +1,109 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package local
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	getter "github.com/hashicorp/go-getter"
+
+	"github.com/tfctl/tfctl/internal/config"
+	"github.com/tfctl/tfctl/internal/log"
+)
+
+// fetchedMarker records the last successful fetch time for a materialized
+// Source directory, so repeated runs within the freshness window reuse the
+// cached tree instead of re-fetching.
+const fetchedMarker = ".tfctl-source-fetched"
+
+// fetchSource materializes source into dest via go-getter, skipping the
+// fetch entirely if dest was already populated within the freshness window
+// (cache.source-ttl-minutes, default 5). A "?ref=<tag-or-branch>" fragment on
+// a git source is honored natively by go-getter's git detector/getter; S3 and
+// GCS prefixes are enumerated by their respective directory-mode getters, so
+// multiple terraform.tfstate*/terraform.tfstate.backup files land directly in
+// dest for StateVersions to discover just as it would a local RootDir.
+func fetchSource(ctx context.Context, source, dest string) error {
+	ttlMinutes, _ := config.GetInt("cache.source-ttl-minutes", 5)
+	if ttlMinutes > 0 {
+		if info, err := os.Stat(filepath.Join(dest, fetchedMarker)); err == nil {
+			if time.Since(info.ModTime()) < time.Duration(ttlMinutes)*time.Minute {
+				log.Debugf("source cache fresh, skipping fetch: source=%s dest=%s", source, dest)
+				return nil
+			}
+		}
+	}
+
+	if err := os.MkdirAll(dest, 0o755); err != nil { //nolint:mnd
+		return fmt.Errorf("failed to create source cache dir: %w", err)
+	}
+
+	client := &getter.Client{
+		Ctx:  ctx,
+		Src:  source,
+		Dst:  dest,
+		Pwd:  dest,
+		Mode: getter.ClientModeAny,
+	}
+	if err := client.Get(); err != nil {
+		return fmt.Errorf("failed to fetch source %s: %w", source, err)
+	}
+
+	propagateHTTPTimestamp(source, dest)
+
+	marker := filepath.Join(dest, fetchedMarker)
+	if err := os.WriteFile(marker, []byte(time.Now().UTC().Format(time.RFC3339)), 0o644); err != nil { //nolint:mnd
+		log.WithError(err).Debugf("failed to write source fetch marker: dest=%s", dest)
+	}
+
+	return nil
+}
+
+// propagateHTTPTimestamp best-effort applies a plain HTTP(S) source's
+// Last-Modified response header to the materialized file's mtime, since
+// go-getter's http getter does not preserve it and StateVersions sorts by
+// mtime. This only applies to single-file http(s) sources; git checkouts and
+// S3/GCS listings already carry meaningful filesystem/ETag-derived
+// timestamps through their respective getters.
+func propagateHTTPTimestamp(source, dest string) {
+	raw := strings.TrimPrefix(source, "http::")
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return
+	}
+
+	resp, err := http.Head(u.String())
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+
+	lastModified := resp.Header.Get("Last-Modified")
+	if lastModified == "" {
+		return
+	}
+	t, err := http.ParseTime(lastModified)
+	if err != nil {
+		return
+	}
+
+	base := filepath.Base(u.Path)
+	if base == "" || base == "/" || base == "." {
+		base = "terraform.tfstate"
+	}
+	target := filepath.Join(dest, base)
+	if _, err := os.Stat(target); err != nil {
+		return
+	}
+	if err := os.Chtimes(target, t, t); err != nil {
+		log.WithError(err).Debugf("failed to propagate http mtime: target=%s", target)
+	}
+}
@@ -0,0 +1,26 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package local
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"path/filepath"
+
+	"github.com/tfctl/tfctl/internal/cacheutil"
+)
+
+// SourceCacheDir returns the cache-keyed directory a given Source URL should
+// be materialized into. Unlike cacheutil.Read/Write, which frame a single
+// blob, a Source resolves to a tree of files (a state directory, possibly a
+// git checkout), so we key off cacheutil's base directory but lay out our own
+// per-source subdirectory rather than a single framed entry.
+func SourceCacheDir(source string) (string, bool) {
+	base, ok := cacheutil.Dir()
+	if !ok {
+		return "", false
+	}
+	h := sha256.Sum256([]byte(source))
+	return filepath.Join(base, "local-source", hex.EncodeToString(h[:])), true
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package backend
+
+import (
+	"context"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/tfctl/tfctl/internal/backend/configschema"
+	"github.com/tfctl/tfctl/internal/backend/statemgr"
+	"github.com/tfctl/tfctl/internal/meta"
+)
+
+// Configurable is implemented by backends that can declare and accept their
+// own configuration, and manage workspaces beyond read-only querying. It is
+// an additive extension of Backend, not a replacement: existing callers that
+// only need Runs/State/States/StateVersions are unaffected, while callers
+// that want to e.g. describe or validate backend options, or resolve a
+// StateManager for a specific workspace, can type-assert for it.
+type Configurable interface {
+	Backend
+
+	// ConfigSchema describes the backend's accepted configuration options.
+	ConfigSchema() *configschema.Block
+	// Configure applies raw config values (as decoded from the backend's
+	// "config" block in .terraform/terraform.tfstate) to the backend.
+	Configure(config map[string]any) error
+	// Workspaces lists the workspaces known to this backend.
+	Workspaces() ([]string, error)
+	// DeleteWorkspace removes a workspace's state. It is an error to delete
+	// the backend's default workspace.
+	DeleteWorkspace(name string) error
+	// StateMgr returns a statemgr.Reader for the named workspace. An empty
+	// workspace resolves to the backend's current workspace.
+	StateMgr(workspace string) (statemgr.Reader, error)
+}
+
+// Factory constructs a Backend for the given root directory and environment
+// override, mirroring the option pattern each backend package already
+// exposes (FromRootDir, WithEnvOverride, ...).
+type Factory func(ctx context.Context, cmd *cli.Command, meta meta.Meta) (Backend, error)
+
+// registry maps a backend's Backend.Type string (as read from
+// .terraform/terraform.tfstate) to the Factory that constructs it.
+// NewBackend dispatches through this registry once it has peeked the type;
+// the no-backend-file special case is still handled inline since it has no
+// type to look up.
+var registry = map[string]Factory{}
+
+// Register adds a Factory for the given backend type. Intended to be called
+// from init() in this package as each backend gains a Configurable
+// implementation.
+func Register(typ string, f Factory) {
+	registry[typ] = f
+}
+
+// Lookup returns the Factory registered for typ, if any.
+func Lookup(typ string) (Factory, bool) {
+	f, ok := registry[typ]
+	return f, ok
+}
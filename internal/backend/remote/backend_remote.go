@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -18,19 +19,55 @@ import (
 	"github.com/hashicorp/go-tfe"
 	"github.com/urfave/cli/v3"
 
+	"github.com/tfctl/tfctl/internal/audit"
 	"github.com/tfctl/tfctl/internal/config"
+	"github.com/tfctl/tfctl/internal/credhelper"
 	"github.com/tfctl/tfctl/internal/differ"
+	"github.com/tfctl/tfctl/internal/disco"
 	"github.com/tfctl/tfctl/internal/svutil"
 )
 
+// tfeServiceIDs are tried in order against a host's discovery document to
+// find its TFE API root. Listed most-specific first so a server advertising
+// only a minor-version-specific service ID still resolves.
+var tfeServiceIDs = []string{"tfe.v2.2", "tfe.v2.1", "tfe.v2"}
+
 type BackendRemote struct {
-	Ctx              context.Context
-	Cmd              *cli.Command
-	RootDir          string `json:"-" validate:"dir"`
-	EnvOverride      string
-	SvOverride       string
-	RunList          []*tfe.Run
-	StateVersionList []*tfe.StateVersion
+	Ctx         context.Context
+	Cmd         *cli.Command
+	RootDir     string `json:"-" validate:"dir"`
+	EnvOverride string
+	SvOverride  string
+	// WorkspaceOverride, like EnvOverride/SvOverride, is an in-memory-only
+	// override consulted by WorkspaceName() ahead of the --workspace flag.
+	// Fan-out callers (Workspaces()'s prefix-expansion branch, and whatever
+	// command drives it) set this per-iteration to point Runs()/
+	// StateVersions() at one specific workspace without re-parsing cmd
+	// flags: neither method's signature has room for an extra selector
+	// argument (StateVersions already has a trailing variadic augmenter,
+	// and Go only allows one per function), so the selector travels as a
+	// field instead, the same way Env/Sv already do.
+	WorkspaceOverride string
+	RunList           []*tfe.Run
+	StateVersionList  []*tfe.StateVersion
+	// RetryPolicy configures Hitter's backoff for 429/5xx responses. The
+	// zero value is replaced with DefaultRetryPolicy by NewBackendRemote.
+	RetryPolicy RetryPolicy
+	// HTTPClient is the client Hitter uses to execute requests. Injectable
+	// for tests; the zero value is replaced with http.DefaultClient by
+	// NewBackendRemote.
+	HTTPClient *http.Client
+	// Audit, if set, wraps every request Client() issues with a record of
+	// what was fetched, for cost/rate-limit forensics and compliance. Nil
+	// disables auditing.
+	Audit *audit.Handle
+	// Services is the discovered .well-known/terraform.json service map for
+	// Backend.Config.Hostname, populated by Client() after a successful
+	// discovery fetch. Nil if discovery hasn't run yet or the host has no
+	// discovery document, so future commands that need another service ID
+	// (e.g. "modules.v1") should treat a missing entry as "use the host's
+	// own hard-coded default" rather than an error.
+	Services         disco.Document
 	Version          int    `json:"version" validate:"gte=4"`
 	TerraformVersion string `json:"terraform_version" validate:"semver"`
 	Backend          struct {
@@ -41,8 +78,10 @@ type BackendRemote struct {
 			Organization string `json:"organization" validate:"required"`
 			Token        any    `json:"token"`
 			Workspaces   struct {
-				Name   string `json:"name" validate:"required_without=Prefix"`
-				Prefix string `json:"prefix" validate:"required_without=Name"`
+				Name    string   `json:"name" validate:"required_without_all=Prefix Tags"`
+				Prefix  string   `json:"prefix" validate:"required_without=Name"`
+				Project string   `json:"project"`
+				Tags    []string `json:"tags"`
 			} `json:"workspaces"`
 		} `json:"config"`
 	} `json:"backend"`
@@ -57,10 +96,21 @@ var (
 	ErrNoCurrentStateVersion         = errors.New("no current state version")
 	ErrURLNotSupported               = errors.New("URL not supported")
 	ErrWorkspaceNameAndPrefixBothSet = errors.New("both workspace name and prefix are set")
+	ErrNoWorkspaceSelector           = errors.New("no workspace name, prefix, or tags configured")
+	ErrCredentialHelperFailed        = errors.New("credentials helper failed")
 )
 
 // Client optionally validates and returns a TFE client to the host specified
-// in the remote backend.
+// in the remote backend. The API root is resolved via Terraform's service
+// discovery protocol (.well-known/terraform.json) when the host publishes
+// one, falling back to the historical "https://<host>" default when
+// discovery finds nothing (a 404) or the host is unreachable -- a host that
+// otherwise works today shouldn't start failing because it doesn't publish
+// a discovery document.
+//
+// The discovery protocol has no client-version-constraint field to check
+// against (that's a different, registry-protocol concept), so there's no
+// "too old a client" check here.
 func (be *BackendRemote) Client(validate ...bool) (*tfe.Client, error) {
 	beCfg := be.Backend.Config
 
@@ -70,10 +120,34 @@ func (be *BackendRemote) Client(validate ...bool) (*tfe.Client, error) {
 		return nil, fmt.Errorf("failed to resolve token: %w", err)
 	}
 
-	client, err := tfe.NewClient(&tfe.Config{
-		Address: "https://" + beCfg.Hostname,
+	address := "https://" + beCfg.Hostname
+
+	ctx := be.Ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	httpClient := be.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	if doc, found, err := disco.Discover(ctx, httpClient, beCfg.Hostname); err == nil && found {
+		be.Services = doc
+		if u, ok := doc.Service(tfeServiceIDs...); ok {
+			address = u
+		}
+	}
+
+	conf := &tfe.Config{
+		Address: address,
 		Token:   token,
-	})
+	}
+	if be.Audit != nil {
+		conf.HTTPClient = &http.Client{
+			Transport: be.Audit.WrapTransport(http.DefaultTransport, beCfg.Hostname),
+		}
+	}
+
+	client, err := tfe.NewClient(conf)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create TFE client: %w", err)
 	}
@@ -109,24 +183,27 @@ func (be *BackendRemote) DiffStates(ctx context.Context, cmd *cli.Command) ([][]
 				return nil, err
 			}
 
-			selectedVersions := differ.SelectStateVersions(be.StateVersionList)
+			selection := differ.SelectStateVersions(be.StateVersionList)
 
-			log.Debugf("selectedVersions: %d", len(selectedVersions))
+			log.Debugf("selection: %d", len(selection.Versions))
 
-			if len(selectedVersions) == 0 {
+			if len(selection.Versions) < 2 {
 				return nil, nil
-			} else if len(selectedVersions) == 2 {
-				svSpecs[0] = selectedVersions[1].ID
-				svSpecs[1] = selectedVersions[0].ID
 			}
+
+			if selection.Mode == "pinned" {
+				_ = cmd.Set("diff_mode", "pinned")
+			}
+
+			svSpecs = differ.SpecsForSelection(selection)
 		} else {
 			svSpecs[0] = diffArgs[0]
 		}
-	case 2:
+	default:
 		svSpecs = diffArgs
 	}
 
-	states, err := be.States(svSpecs[0], svSpecs[1])
+	states, err := be.States(svSpecs...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get states: %w", err)
 	}
@@ -144,8 +221,10 @@ func (be *BackendRemote) Host() string {
 
 	var host string
 
-	// Precedence 1: --host flag
-	if be.Cmd.IsSet("host") {
+	// Precedence 1: --host flag. Only consulted when Cmd is set: library
+	// callers constructed via New have no *cli.Command at all, and rely on
+	// Config.Host having already been applied via WithHost instead.
+	if be.Cmd != nil && be.Cmd.IsSet("host") {
 		host = be.Cmd.String("host")
 		if host != "" {
 			return host
@@ -177,8 +256,9 @@ func (be *BackendRemote) Organization() (string, error) {
 
 	var org string
 
-	// Precedence 1: --org flag
-	if be.Cmd.IsSet("org") {
+	// Precedence 1: --org flag. Only consulted when Cmd is set; see Host's
+	// equivalent comment.
+	if be.Cmd != nil && be.Cmd.IsSet("org") {
 		org = be.Cmd.String("org")
 		if org != "" {
 			return org, nil
@@ -267,7 +347,14 @@ func (be *BackendRemote) Runs() ([]*tfe.Run, error) {
 }
 
 func (be *BackendRemote) State() ([]byte, error) {
-	sv := be.Cmd.String("sv")
+	// Cmd's --sv flag wins when present (the CLI path); library callers
+	// with no Cmd fall back to SvOverride, set directly via Config.SvOverride.
+	sv := be.SvOverride
+	if be.Cmd != nil {
+		if v := be.Cmd.String("sv"); v != "" {
+			sv = v
+		}
+	}
 	states, err := be.States(sv)
 	if err != nil {
 		return nil, err
@@ -504,8 +591,13 @@ func (be *BackendRemote) String() string {
 	return fmt.Sprintf("ConfigRemote: %+v", beCopy)
 }
 
-// Token retrieves the token from the environment variable, config file, or
-// the credentials file, in that order.
+// Token retrieves the token from the environment variable, a .terraformrc
+// credentials_helper, the config file, or the credentials file, in that
+// order. This already covers the standard Terraform token sources end to
+// end, resolved lazily wherever a token is actually needed (Client,
+// BuckNaked, load), so there's no separate BackendRemoteOption for it -- an
+// eager WithTerraformCredentials() option would just race Token()'s own
+// lookup rather than replace it.
 func (be *BackendRemote) Token() (string, error) {
 	var token string
 
@@ -513,10 +605,10 @@ func (be *BackendRemote) Token() (string, error) {
 	// The precedence is:
 	// 1. TF_TOKEN_app_terraform_io
 	// 2. TF_TOKEN
-	// 3. Token in the config file
-	// 4. Token in the TF credentials file.
-	hostname := strings.ReplaceAll(be.Backend.Config.Hostname, ".", "_")
-	if token = os.Getenv("TF_TOKEN_" + hostname); token == "" {
+	// 3. .terraformrc credentials_helper
+	// 4. Token in the config file
+	// 5. Token in the TF credentials file.
+	if token = os.Getenv("TF_TOKEN_" + tokenEnvHostname(be.Backend.Config.Hostname)); token == "" {
 		token = os.Getenv("TF_TOKEN")
 	}
 
@@ -526,41 +618,85 @@ func (be *BackendRemote) Token() (string, error) {
 		return token, nil
 	}
 
+	// A credentials_helper, if configured, takes precedence over a static
+	// config-file token or credentials.tfrc.json -- it's how TFE orgs mint
+	// short-lived tokens instead of storing a long-lived one on disk.
+	if be.Cmd == nil || !be.Cmd.Bool("no-credentials-helper") {
+		if cfg, ok, err := credhelper.Load(); err == nil && ok {
+			ctx := be.Ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			t, err := credhelper.Get(ctx, cfg, be.Backend.Config.Hostname)
+			if err != nil {
+				return "", fmt.Errorf("%v: %w", err, ErrCredentialHelperFailed)
+			}
+			return t, nil
+		}
+	}
+
 	token, _ = be.Backend.Config.Token.(string)
 
 	// Once we're here, token may have existed already in the config file or it
 	// may have been overridden by an environment variable.  If it's still empty,
 	// we need to try to get it from the credentials file.
 	if token == "" {
-		home, err := os.UserHomeDir()
+		credsFile, err := credentialsFilePath()
 		if err != nil {
-			return "", fmt.Errorf("failed to get user home directory: %w", err)
+			return "", err
 		}
 
-		credsFile := home + "/.terraform.d/credentials.tfrc.json"
-		data, err := os.ReadFile(credsFile)
-		if err != nil {
-			return "", fmt.Errorf("failed to read credentials file: %w", err)
-		}
-
-		var creds struct {
-			Credentials map[string]struct {
-				Token string `json:"token"`
-			} `json:"credentials"`
-		}
+		if data, err := os.ReadFile(credsFile); err == nil {
+			var creds struct {
+				Credentials map[string]struct {
+					Token string `json:"token"`
+				} `json:"credentials"`
+			}
 
-		if err := json.Unmarshal(data, &creds); err != nil {
-			return "", fmt.Errorf("failed to unmarshal credentials file: %w", err)
-		}
+			if err := json.Unmarshal(data, &creds); err != nil {
+				return "", fmt.Errorf("failed to unmarshal credentials file: %w", err)
+			}
 
-		if cred, ok := creds.Credentials[be.Backend.Config.Hostname]; ok {
-			return cred.Token, nil
+			if cred, ok := creds.Credentials[be.Backend.Config.Hostname]; ok {
+				return cred.Token, nil
+			}
 		}
 	}
 
 	return token, nil
 }
 
+// tokenEnvHostname converts host into the form Terraform 1.2+ uses to build
+// a per-host TF_TOKEN_<host> environment variable name: dots become a
+// single underscore and dashes become a double underscore, so the result is
+// unambiguous to reverse (e.g. "app.terraform.io" -> "app_terraform_io",
+// "my-tfe-host.example.com" -> "my__tfe__host_example_com").
+func tokenEnvHostname(host string) string {
+	host = strings.ReplaceAll(host, "-", "__")
+	host = strings.ReplaceAll(host, ".", "_")
+	return host
+}
+
+// credentialsFilePath returns the location of the Terraform CLI credentials
+// file, honoring TF_CLI_CONFIG_FILE and the older TERRAFORM_CONFIG: both
+// point at the CLI config file (.terraformrc/terraform.rc), and
+// credentials.tfrc.json is always its sibling in the same directory. With
+// neither set, that directory defaults to ~/.terraform.d.
+func credentialsFilePath() (string, error) {
+	if p := os.Getenv("TF_CLI_CONFIG_FILE"); p != "" {
+		return filepath.Join(filepath.Dir(p), "credentials.tfrc.json"), nil
+	}
+	if p := os.Getenv("TERRAFORM_CONFIG"); p != "" {
+		return filepath.Join(filepath.Dir(p), "credentials.tfrc.json"), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user home directory: %w", err)
+	}
+	return filepath.Join(home, ".terraform.d", "credentials.tfrc.json"), nil
+}
+
 func (be *BackendRemote) Type() (string, error) {
 	return be.Backend.Type, nil
 }
@@ -601,7 +737,14 @@ func (be *BackendRemote) Workspace() (*tfe.Workspace, error) {
 }
 
 func (be *BackendRemote) WorkspaceName() (string, error) {
-	ws := be.Cmd.String("workspace")
+	if be.WorkspaceOverride != "" {
+		return be.WorkspaceOverride, nil
+	}
+
+	var ws string
+	if be.Cmd != nil {
+		ws = be.Cmd.String("workspace")
+	}
 	if ws != "" {
 		return ws, nil
 	}
@@ -635,3 +778,166 @@ func (be *BackendRemote) WorkspaceName() (string, error) {
 	log.Debugf("workspace prefixed name = %s", name)
 	return name, nil
 }
+
+// ResolveWorkspaces returns the concrete workspace(s) this backend targets.
+// If a workspace name is available (--workspace flag, workspaces.name, or a
+// prefixed/env name), it resolves to that single workspace. Otherwise, if
+// workspaces.tags is set, it lists every workspace in the organization tagged
+// with the intersection of those tags (optionally narrowed to
+// workspaces.project), via the TFE list-workspaces API. Callers that need to
+// operate over a tag-selected multi-workspace set (e.g. iterating states)
+// should use this instead of Workspace()/WorkspaceName(), which only ever
+// resolve a single name.
+func (be *BackendRemote) ResolveWorkspaces(ctx context.Context) ([]*tfe.Workspace, error) {
+	if name, err := be.WorkspaceName(); err == nil && name != "" {
+		workspace, err := be.Workspace()
+		if err != nil {
+			return nil, err
+		}
+		return []*tfe.Workspace{workspace}, nil
+	}
+
+	tags := be.Backend.Config.Workspaces.Tags
+	if len(tags) == 0 {
+		return nil, ErrNoWorkspaceSelector
+	}
+
+	be.Backend.Config.Hostname = be.Host()
+
+	client, err := be.Client()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get TFE client: %w", err)
+	}
+
+	organization, err := be.Organization()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+
+	options := &tfe.WorkspaceListOptions{
+		ListOptions: tfe.ListOptions{PageNumber: 1, PageSize: 100},
+		Tags:        strings.Join(tags, ","),
+	}
+
+	if project := be.Backend.Config.Workspaces.Project; project != "" {
+		projectID, err := resolveProjectID(ctx, client, organization, project)
+		if err != nil {
+			return nil, err
+		}
+		options.ProjectID = projectID
+	}
+
+	var results []*tfe.Workspace
+	for {
+		page, err := client.Workspaces.List(ctx, organization, options)
+		if err != nil {
+			ctxErr := ErrorContext{
+				Host:      be.Backend.Config.Hostname,
+				Org:       organization,
+				Operation: "list workspaces",
+				Resource:  "workspace",
+			}
+			return nil, FriendlyTFE(err, ctxErr)
+		}
+
+		results = append(results, page.Items...)
+
+		if page.Pagination.NextPage == 0 {
+			break
+		}
+		options.ListOptions.PageNumber++
+	}
+
+	return results, nil
+}
+
+// Workspaces returns the workspace(s) that fan-out callers (e.g. a query
+// command iterating "every prod-* workspace") should run against. It's
+// ResolveWorkspaces plus the one case ResolveWorkspaces can't cover: a bare
+// workspaces.prefix with no environment selected (no --workspace flag, no
+// WorkspaceOverride/EnvOverride, no .terraform/environment file).
+// WorkspaceName() always turns that case into a single non-empty name
+// (Prefix + "" == Prefix), so ResolveWorkspaces's "is a name already
+// resolved?" check never falls through to a multi-workspace listing. This
+// method checks for that case first and, when it applies, lists every
+// workspace matching "<prefix>*" directly instead. maxWorkspaces bounds how
+// many are returned (0 means unbounded), matching the --limit convention
+// used elsewhere for paginated listings.
+func (be *BackendRemote) Workspaces(ctx context.Context, maxWorkspaces int) ([]*tfe.Workspace, error) {
+	workspaces := be.Backend.Config.Workspaces
+	if workspaces.Prefix != "" && workspaces.Name == "" && be.WorkspaceOverride == "" {
+		var flagWS string
+		if be.Cmd != nil {
+			flagWS = be.Cmd.String("workspace")
+		}
+
+		env := be.EnvOverride
+		if env == "" {
+			envFile := filepath.Join(be.RootDir, ".terraform/environment")
+			if envFileData, err := os.ReadFile(envFile); err == nil {
+				env = string(bytes.TrimSpace(envFileData))
+			}
+		}
+
+		if flagWS == "" && env == "" {
+			return be.listWorkspacesByPrefix(ctx, workspaces.Prefix, maxWorkspaces)
+		}
+	}
+
+	return be.ResolveWorkspaces(ctx)
+}
+
+// listWorkspacesByPrefix lists every workspace in the organization whose
+// name starts with prefix, via TFE's wildcard-name search, paginated and
+// bounded by maxWorkspaces (0 = unbounded).
+func (be *BackendRemote) listWorkspacesByPrefix(ctx context.Context, prefix string, maxWorkspaces int) ([]*tfe.Workspace, error) {
+	be.Backend.Config.Hostname = be.Host()
+
+	client, err := be.Client()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get TFE client: %w", err)
+	}
+
+	organization, err := be.Organization()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+
+	pageSize := 100
+	if maxWorkspaces > 0 && maxWorkspaces < pageSize {
+		pageSize = maxWorkspaces
+	}
+
+	options := &tfe.WorkspaceListOptions{
+		ListOptions:  tfe.ListOptions{PageNumber: 1, PageSize: pageSize},
+		WildcardName: prefix + "*",
+	}
+
+	var results []*tfe.Workspace
+	for {
+		page, err := client.Workspaces.List(ctx, organization, options)
+		if err != nil {
+			ctxErr := ErrorContext{
+				Host:      be.Backend.Config.Hostname,
+				Org:       organization,
+				Operation: "list workspaces",
+				Resource:  "workspace",
+			}
+			return nil, FriendlyTFE(err, ctxErr)
+		}
+
+		results = append(results, page.Items...)
+
+		if maxWorkspaces > 0 && len(results) >= maxWorkspaces {
+			results = results[:maxWorkspaces]
+			break
+		}
+
+		if page.Pagination.NextPage == 0 {
+			break
+		}
+		options.ListOptions.PageNumber++
+	}
+
+	return results, nil
+}
@@ -0,0 +1,221 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package remote
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-tfe"
+
+	"github.com/tfctl/tfctl/internal/backend/configschema"
+	"github.com/tfctl/tfctl/internal/backend/statemgr"
+)
+
+// remoteStateManager is the statemgr.Reader returned by
+// BackendRemote.StateMgr. It pulls the named workspace's current state
+// version's raw JSON, reusing the same State/StateVersion machinery as
+// State().
+type remoteStateManager struct {
+	be        *BackendRemote
+	workspace string
+}
+
+func (m *remoteStateManager) State() ([]byte, error) {
+	states, err := m.be.States("CSV~0")
+	if err != nil {
+		return nil, err
+	}
+	return states[0], nil
+}
+
+// ConfigSchema describes the options accepted under a remote backend's
+// "config" block. https://developer.hashicorp.com/terraform/language/backend/remote
+func (be *BackendRemote) ConfigSchema() *configschema.Block {
+	return &configschema.Block{
+		Attributes: map[string]*configschema.Attribute{
+			"hostname": {
+				Type:        configschema.TypeString,
+				Description: "the Terraform Cloud/Enterprise hostname",
+				Optional:    true,
+			},
+			"organization": {
+				Type:        configschema.TypeString,
+				Description: "the name of the organization containing the target workspace(s)",
+				Required:    true,
+			},
+			"token": {
+				Type:        configschema.TypeString,
+				Description: "the API token used to authenticate with the host",
+				Optional:    true,
+				Sensitive:   true,
+			},
+		},
+	}
+}
+
+// Configure applies decoded "config" block values to the backend, mirroring
+// the shape unmarshaled from .terraform/terraform.tfstate in load().
+func (be *BackendRemote) Configure(config map[string]any) error {
+	if hostname, ok := config["hostname"].(string); ok {
+		be.Backend.Config.Hostname = hostname
+	}
+	if organization, ok := config["organization"].(string); ok {
+		be.Backend.Config.Organization = organization
+	}
+	if token, ok := config["token"]; ok {
+		be.Backend.Config.Token = token
+	}
+	if workspaces, ok := config["workspaces"].(map[string]any); ok {
+		if name, ok := workspaces["name"].(string); ok {
+			be.Backend.Config.Workspaces.Name = name
+		}
+		if prefix, ok := workspaces["prefix"].(string); ok {
+			be.Backend.Config.Workspaces.Prefix = prefix
+		}
+		if project, ok := workspaces["project"].(string); ok {
+			be.Backend.Config.Workspaces.Project = project
+		}
+		if tags, ok := workspaces["tags"].([]any); ok {
+			for _, t := range tags {
+				if tag, ok := t.(string); ok {
+					be.Backend.Config.Workspaces.Tags = append(be.Backend.Config.Workspaces.Tags, tag)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Workspaces lists the organization's workspace names via the TFE API,
+// honoring the backend's configured workspaces.prefix and workspaces.tags
+// (narrowed by workspaces.project) if set, so a prefixed or tag-selected
+// remote backend only sees the workspaces it could actually select.
+func (be *BackendRemote) Workspaces() ([]string, error) {
+	be.Backend.Config.Hostname = be.Host()
+
+	client, err := be.Client()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get TFE client: %w", err)
+	}
+
+	organization, err := be.Organization()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+
+	ctx := context.Background()
+
+	options := &tfe.WorkspaceListOptions{
+		ListOptions: tfe.ListOptions{PageNumber: 1, PageSize: 100},
+	}
+	if prefix := be.Backend.Config.Workspaces.Prefix; prefix != "" {
+		options.Search = prefix
+	}
+	if tags := be.Backend.Config.Workspaces.Tags; len(tags) > 0 {
+		options.Tags = strings.Join(tags, ",")
+	}
+	if project := be.Backend.Config.Workspaces.Project; project != "" {
+		projectID, err := resolveProjectID(ctx, client, organization, project)
+		if err != nil {
+			return nil, err
+		}
+		options.ProjectID = projectID
+	}
+
+	var names []string
+	for {
+		page, err := client.Workspaces.List(ctx, organization, options)
+		if err != nil {
+			ctxErr := ErrorContext{
+				Host:      be.Backend.Config.Hostname,
+				Org:       organization,
+				Operation: "list workspaces",
+				Resource:  "workspace",
+			}
+			return nil, FriendlyTFE(err, ctxErr)
+		}
+
+		for _, ws := range page.Items {
+			names = append(names, ws.Name)
+		}
+
+		if page.Pagination.NextPage == 0 {
+			break
+		}
+		options.ListOptions.PageNumber++
+	}
+
+	return names, nil
+}
+
+// DeleteWorkspace deletes a workspace from the organization via the TFE API.
+func (be *BackendRemote) DeleteWorkspace(name string) error {
+	be.Backend.Config.Hostname = be.Host()
+
+	client, err := be.Client()
+	if err != nil {
+		return fmt.Errorf("failed to get TFE client: %w", err)
+	}
+
+	organization, err := be.Organization()
+	if err != nil {
+		return fmt.Errorf("failed to get organization: %w", err)
+	}
+
+	ctx := context.Background()
+	if err := client.Workspaces.Delete(ctx, organization, name); err != nil {
+		ctxErr := ErrorContext{
+			Host:      be.Backend.Config.Hostname,
+			Org:       organization,
+			Workspace: name,
+			Operation: "delete workspace",
+			Resource:  "workspace",
+		}
+		return FriendlyTFE(err, ctxErr)
+	}
+	return nil
+}
+
+// StateMgr returns a statemgr.Reader for the named workspace's current state
+// version. An empty workspace resolves to WorkspaceName()'s own precedence
+// (--workspace flag, then workspaces.name/prefix).
+func (be *BackendRemote) StateMgr(workspace string) (statemgr.Reader, error) {
+	if workspace != "" {
+		be.Backend.Config.Workspaces.Name = workspace
+		be.Backend.Config.Workspaces.Prefix = ""
+	}
+	return &remoteStateManager{be: be, workspace: workspace}, nil
+}
+
+// resolveProjectID looks up a project's ID by name, since the TFE
+// workspace-list API filters by project ID rather than name and
+// workspaces.project (as read from .terraform/terraform.tfstate) only gives
+// us the name.
+func resolveProjectID(ctx context.Context, client *tfe.Client, organization, name string) (string, error) {
+	options := &tfe.ProjectListOptions{
+		ListOptions: tfe.ListOptions{PageNumber: 1, PageSize: 100},
+		Query:       name,
+	}
+	for {
+		page, err := client.Projects.List(ctx, organization, options)
+		if err != nil {
+			return "", fmt.Errorf("failed to list projects: %w", err)
+		}
+
+		for _, p := range page.Items {
+			if p.Name == name {
+				return p.ID, nil
+			}
+		}
+
+		if page.Pagination.NextPage == 0 {
+			break
+		}
+		options.ListOptions.PageNumber = page.Pagination.NextPage
+	}
+
+	return "", fmt.Errorf("project %q not found in organization %s", name, organization)
+}
@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
 
@@ -90,11 +91,34 @@ func WithDefaults() BackendRemoteOption {
 		be.Version = 4
 		be.TerraformVersion = "0.0.0"
 		be.Backend.Type = "remote"
+		be.RetryPolicy = DefaultRetryPolicy
+		be.HTTPClient = http.DefaultClient
 
 		return nil
 	}
 }
 
+// WithRetryPolicy overrides Hitter's 429/5xx backoff policy, e.g. for tests
+// that want a tighter loop than DefaultRetryPolicy.
+func WithRetryPolicy(policy RetryPolicy) BackendRemoteOption {
+	return func(ctx context.Context, cmd *cli.Command, be *BackendRemote) error {
+		be.RetryPolicy = policy
+		return nil
+	}
+}
+
+// WithHTTPClient overrides the *http.Client Hitter uses to execute requests,
+// primarily so tests can point it at an httptest.Server without touching the
+// network.
+func WithHTTPClient(client *http.Client) BackendRemoteOption {
+	return func(ctx context.Context, cmd *cli.Command, be *BackendRemote) error {
+		if client != nil {
+			be.HTTPClient = client
+		}
+		return nil
+	}
+}
+
 func WithEnvOverride(env string) BackendRemoteOption {
 	return func(ctx context.Context, cmd *cli.Command, be *BackendRemote) error {
 		if env != "" {
@@ -114,6 +138,75 @@ func WithSvOverride() BackendRemoteOption {
 	}
 }
 
+// WithSv sets SvOverride directly, bypassing WithSvOverride's dependency on
+// a *cli.Command. Used by New to apply Config.SvOverride.
+func WithSv(sv string) BackendRemoteOption {
+	return func(ctx context.Context, cmd *cli.Command, be *BackendRemote) error {
+		if sv != "" {
+			be.SvOverride = sv
+		}
+		return nil
+	}
+}
+
+// WithHost sets the TFE/HCP hostname directly, bypassing Host()'s
+// flag/config/default precedence. Useful for programmatic construction
+// (e.g. tests, or callers that already know the target host).
+func WithHost(host string) BackendRemoteOption {
+	return func(ctx context.Context, cmd *cli.Command, be *BackendRemote) error {
+		if host != "" {
+			be.Backend.Config.Hostname = host
+		}
+		return nil
+	}
+}
+
+// WithOrg sets the organization directly, bypassing Organization()'s
+// flag/config precedence.
+func WithOrg(org string) BackendRemoteOption {
+	return func(ctx context.Context, cmd *cli.Command, be *BackendRemote) error {
+		if org != "" {
+			be.Backend.Config.Organization = org
+		}
+		return nil
+	}
+}
+
+// WithToken sets the backend config's API token, which Hitter sends as is.
+// Token() (and so Client()) still prefers TF_TOKEN_<host>, TF_TOKEN and a
+// .terraformrc credentials_helper over it; it replaces only the config-file
+// token and the credentials-file lookup.
+func WithToken(token string) BackendRemoteOption {
+	return func(ctx context.Context, cmd *cli.Command, be *BackendRemote) error {
+		if token != "" {
+			be.Backend.Config.Token = token
+		}
+		return nil
+	}
+}
+
+// WithWorkspaceName configures a single-workspace (workspaces.name) style
+// remote backend.
+func WithWorkspaceName(name string) BackendRemoteOption {
+	return func(ctx context.Context, cmd *cli.Command, be *BackendRemote) error {
+		if name != "" {
+			be.Backend.Config.Workspaces.Name = name
+		}
+		return nil
+	}
+}
+
+// WithWorkspacePrefix configures a prefixed-workspace (workspaces.prefix)
+// style remote backend, as used for multi-workspace configurations.
+func WithWorkspacePrefix(prefix string) BackendRemoteOption {
+	return func(ctx context.Context, cmd *cli.Command, be *BackendRemote) error {
+		if prefix != "" {
+			be.Backend.Config.Workspaces.Prefix = prefix
+		}
+		return nil
+	}
+}
+
 // load reads the terraform config file and unmarshals it into the BackendRemote
 // struct. It is simply a convenience method to make NewBackendRemote more
 // readable.
@@ -5,7 +5,11 @@ package remote
 
 import (
 	"os"
+	"sync"
 
+	"github.com/apex/log"
+
+	"github.com/tfctl/tfctl/internal/cache"
 	"github.com/tfctl/tfctl/internal/cacheutil"
 	"github.com/tfctl/tfctl/internal/config"
 )
@@ -13,10 +17,31 @@ import (
 // CacheEntry is provided by cacheutil.Entry; local alias removed to avoid
 // duplication.
 
+var (
+	cacheStoreOnce sync.Once
+	cacheStore     cache.Store
+)
+
+// store returns the configured cache.Store (config key "cache.backend"),
+// falling back to the filesystem store tfctl has always used if the
+// config is invalid.
+func store() cache.Store {
+	cacheStoreOnce.Do(func() {
+		s, err := cache.NewStore()
+		if err != nil {
+			log.WithError(err).Warn("invalid cache.backend config, falling back to filesystem cache")
+			s = nil
+		}
+		cacheStore = s
+	})
+	return cacheStore
+}
+
 // CacheEntryPath returns the path to the cache entry for the given key, if it
 // exists. The cache is organized first by the backend hostname
 // (app.terraform.io) and then by the organization name. The key is hashed and
-// used as the filename.
+// used as the filename. This only resolves for the filesystem backend; other
+// backends have no on-disk path.
 func CacheEntryPath(be *BackendRemote, key string) (string, bool) {
 	hostname, organization := getOverrides(be)
 	p, exists := cacheutil.EntryPath([]string{hostname, organization}, key)
@@ -31,17 +56,48 @@ func CacheEntryPath(be *BackendRemote, key string) (string, bool) {
 // be false.
 func CacheReader(be *BackendRemote, key string) (*cacheutil.Entry, bool) {
 	hostname, organization := getOverrides(be)
-	return cacheutil.Read([]string{hostname, organization}, key)
+
+	s := store()
+	if s == nil {
+		return cacheutil.Read([]string{hostname, organization}, key)
+	}
+
+	entry, ok, err := s.Get([]string{hostname, organization}, key)
+	if err != nil {
+		log.WithError(err).Warn("cache read failed")
+		return nil, false
+	}
+	if !ok {
+		return nil, false
+	}
+	return &cacheutil.Entry{Key: entry.Key, Data: entry.Data, ExpiresAt: entry.ExpiresAt}, true
 }
 
 func CacheWriter(be *BackendRemote, key string, data []byte) error {
 	hostname, organization := getOverrides(be)
-	return cacheutil.Write([]string{hostname, organization}, key, data)
+
+	s := store()
+	if s == nil {
+		return cacheutil.Write([]string{hostname, organization}, key, data)
+	}
+	return s.Put([]string{hostname, organization}, key, data, cache.PutOptions{})
 }
 
+// PurgeCache runs the active backend's maintenance sweep. It's called from
+// hitter.go's hot read path on every cached request, so the sweep itself is
+// debounced via cacheutil.ShouldSweep rather than running on every call;
+// "tfctl cache prune" runs it unconditionally.
 func PurgeCache() error {
-	cleanHours, _ := config.GetInt("cache.clean")
-	return cacheutil.Purge(cleanHours)
+	if !cacheutil.ShouldSweep() {
+		return nil
+	}
+
+	s := store()
+	if s == nil {
+		cleanHours, _ := config.GetInt("cache.clean")
+		return cacheutil.Purge(cleanHours)
+	}
+	return s.Purge(nil)
 }
 
 func getOverrides(be *BackendRemote) (hostname, organization string) {
@@ -0,0 +1,67 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package remote
+
+import "context"
+
+// Config is a plain-value description of a remote/TFC backend, for callers
+// constructing a BackendRemote programmatically instead of driving it off a
+// *cli.Command -- e.g. pkg/tfctl. Every field is optional; an empty Config
+// behaves like NewBackendRemote(ctx, nil, WithDefaults()) with nothing else
+// applied.
+type Config struct {
+	// Host is the TFE/HCP hostname, e.g. "app.terraform.io".
+	Host string
+	// Token authenticates API requests. If empty, Token() still falls back
+	// to TF_TOKEN_<host>/TF_TOKEN, the credentials file, and a
+	// .terraformrc credentials_helper, same as the CLI path.
+	Token string
+	// Organization is the TFC/TFE organization name.
+	Organization string
+	// Workspace is a single workspace name. For a prefixed or tag-selected
+	// multi-workspace configuration, leave this empty and set RootDir to a
+	// directory with a .terraform/terraform.tfstate backend config instead.
+	Workspace string
+	// RootDir, if set, loads backend config the same way FromRootDir does:
+	// from RootDir/.terraform/terraform.tfstate.
+	RootDir string
+	// EnvOverride pins the Terraform workspace (".terraform/environment")
+	// used to resolve a prefixed workspace name.
+	EnvOverride string
+	// SvOverride pins a state version spec (e.g. "CSV~1") State() resolves
+	// against, in place of the current state version.
+	SvOverride string
+}
+
+// New constructs a BackendRemote directly from cfg, with no *cli.Command
+// involved -- the library entry point pkg/tfctl builds on. RootDir, if set,
+// is loaded first so an explicit Host/Organization/Workspace/Token in cfg
+// can still override whatever the backend config on disk says.
+func New(ctx context.Context, cfg Config) (*BackendRemote, error) {
+	var opts []BackendRemoteOption
+
+	if cfg.RootDir != "" {
+		opts = append(opts, FromRootDir(cfg.RootDir))
+	}
+	if cfg.Host != "" {
+		opts = append(opts, WithHost(cfg.Host))
+	}
+	if cfg.Organization != "" {
+		opts = append(opts, WithOrg(cfg.Organization))
+	}
+	if cfg.Token != "" {
+		opts = append(opts, WithToken(cfg.Token))
+	}
+	if cfg.Workspace != "" {
+		opts = append(opts, WithWorkspaceName(cfg.Workspace))
+	}
+	if cfg.EnvOverride != "" {
+		opts = append(opts, WithEnvOverride(cfg.EnvOverride))
+	}
+	if cfg.SvOverride != "" {
+		opts = append(opts, WithSv(cfg.SvOverride))
+	}
+
+	return NewBackendRemote(ctx, nil, opts...)
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package remote
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// CredentialsFilePath is credentialsFilePath, exported for callers like
+// `tfctl login`/`tfctl logout` that need to write the credentials file, not
+// just have Token() read it.
+func CredentialsFilePath() (string, error) {
+	return credentialsFilePath()
+}
+
+// credentialsFileDoc is the on-disk shape of credentials.tfrc.json.
+type credentialsFileDoc struct {
+	Credentials map[string]struct {
+		Token string `json:"token"`
+	} `json:"credentials"`
+}
+
+// SaveCredentials merges host's token into the credentials file via
+// read-modify-write, creating the file (and its parent directory) with
+// 0600/0700 permissions if it doesn't exist yet. An existing entry for host
+// is overwritten; every other host's entry is left untouched.
+func SaveCredentials(host, token string) error {
+	path, err := credentialsFilePath()
+	if err != nil {
+		return err
+	}
+
+	doc, err := readCredentialsFile(path)
+	if err != nil {
+		return err
+	}
+
+	if doc.Credentials == nil {
+		doc.Credentials = map[string]struct {
+			Token string `json:"token"`
+		}{}
+	}
+	doc.Credentials[host] = struct {
+		Token string `json:"token"`
+	}{Token: token}
+
+	return writeCredentialsFile(path, doc)
+}
+
+// RemoveCredentials deletes host's entry from the credentials file. It's a
+// no-op (no error) if the file, or host's entry in it, doesn't exist.
+func RemoveCredentials(host string) error {
+	path, err := credentialsFilePath()
+	if err != nil {
+		return err
+	}
+
+	doc, err := readCredentialsFile(path)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := doc.Credentials[host]; !ok {
+		return nil
+	}
+	delete(doc.Credentials, host)
+
+	return writeCredentialsFile(path, doc)
+}
+
+func readCredentialsFile(path string) (credentialsFileDoc, error) {
+	var doc credentialsFileDoc
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return doc, nil
+		}
+		return doc, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	if len(data) == 0 {
+		return doc, nil
+	}
+
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return doc, fmt.Errorf("failed to unmarshal %s: %w", path, err)
+	}
+
+	return doc, nil
+}
+
+// writeCredentialsFile writes doc to path atomically: it's built in a temp
+// file in the same directory (so the final rename is same-filesystem) and
+// renamed into place, so a crash mid-write can't leave a truncated
+// credentials file behind.
+func writeCredentialsFile(path string, doc credentialsFileDoc) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal credentials file: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".credentials.tfrc.json.*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp credentials file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath) //nolint:errcheck
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close() //nolint:errcheck
+		return fmt.Errorf("failed to write temp credentials file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close temp credentials file: %w", err)
+	}
+	if err := os.Chmod(tmpPath, 0o600); err != nil {
+		return fmt.Errorf("failed to set permissions on temp credentials file: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", path, err)
+	}
+
+	return nil
+}
@@ -6,50 +6,315 @@ package remote
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math/rand"
 	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/apex/log"
+
+	"github.com/tfctl/tfctl/internal/cacheutil"
 )
 
-// TODO Doesn't belong in this package.
-// THINK Needs to take a CacheEntry.
-func Hitter(be *BackendRemote, url string) (bytes.Buffer, error) {
+// RetryPolicy configures Hitter's backoff for 429/5xx responses.
+type RetryPolicy struct {
+	MaxRetries int
+	BaseDelay  time.Duration
+	MaxDelay   time.Duration
+}
 
-	if err := PurgeCache(); err != nil {
-		log.WithError(err).Warn("failed to purge cache")
+// DefaultRetryPolicy is used whenever a BackendRemote's RetryPolicy is left
+// at its zero value.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries: 5,
+	BaseDelay:  500 * time.Millisecond, //nolint:mnd
+	MaxDelay:   30 * time.Second,       //nolint:mnd
+}
+
+// RateLimitedError is returned when Hitter exhausts its retry policy against
+// repeated 429/5xx responses. Callers can distinguish this from AuthError or
+// other failures via errors.As.
+type RateLimitedError struct {
+	StatusCode int
+	Attempts   int
+}
+
+func (e *RateLimitedError) Error() string {
+	return fmt.Sprintf("rate limited after %d attempts (last status %d)", e.Attempts, e.StatusCode)
+}
+
+// AuthError is returned when the TFE API rejects the request's credentials
+// (401/403), as opposed to a transient server-side failure.
+type AuthError struct {
+	StatusCode int
+}
+
+func (e *AuthError) Error() string {
+	return fmt.Sprintf("authentication failed: status %d", e.StatusCode)
+}
+
+// cacheMeta is the small sidecar persisted alongside a Hitter response body,
+// stored under the same cache partition with "#meta" appended to the key so
+// it doesn't collide with the body entry.
+type cacheMeta struct {
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"lastModified,omitempty"`
+	CacheControl string    `json:"cacheControl,omitempty"`
+	MaxAge       int       `json:"maxAge,omitempty"`
+	FetchedAt    time.Time `json:"fetchedAt"`
+}
+
+func metaKey(url string) string {
+	return url + "#meta"
+}
+
+func readCacheMeta(be *BackendRemote, url string) (cacheMeta, bool) {
+	entry, ok := CacheReader(be, metaKey(url))
+	if !ok {
+		return cacheMeta{}, false
+	}
+	var m cacheMeta
+	if err := json.Unmarshal(entry.Data, &m); err != nil {
+		return cacheMeta{}, false
 	}
+	return m, true
+}
 
-	if entry, ok := CacheReader(be, url); ok {
-		log.Debugf("cache hit: %s", entry.Path)
-		return *bytes.NewBuffer(entry.Data), nil
+func writeCacheMeta(be *BackendRemote, url string, m cacheMeta) {
+	data, err := json.Marshal(m)
+	if err != nil {
+		log.WithError(err).Warn("failed to marshal cache metadata")
+		return
 	}
+	if err := CacheWriter(be, metaKey(url), data); err != nil {
+		log.WithError(err).Warn("failed to write cache metadata")
+	}
+}
 
-	ctx := context.Background()
+// parseMaxAge extracts "max-age=N" from a Cache-Control header value.
+func parseMaxAge(cacheControl string) int {
+	for _, directive := range strings.Split(cacheControl, ",") {
+		directive = strings.TrimSpace(directive)
+		if name, value, ok := strings.Cut(directive, "="); ok && strings.EqualFold(strings.TrimSpace(name), "max-age") {
+			if n, err := strconv.Atoi(strings.TrimSpace(value)); err == nil {
+				return n
+			}
+		}
+	}
+	return 0
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return bytes.Buffer{}, fmt.Errorf("failed to create request: %w", err)
+// fallbackMaxAge returns TFCTL_CACHE_TTL (seconds) when set, for responses
+// that send no Cache-Control max-age directive of their own. It lets a
+// caller trust cheap, infrequently-changing listings for N seconds without a
+// validation round-trip, on a window they choose rather than the server's.
+func fallbackMaxAge() int {
+	v, ok := os.LookupEnv("TFCTL_CACHE_TTL")
+	if !ok {
+		return 0
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		return 0
 	}
+	return n
+}
 
-	//nolint:forcetypeassert
-	req.Header.Set("Authorization", "Bearer "+be.Backend.Config.Token.(string))
+// httpClient returns be.HTTPClient, falling back to http.DefaultClient for
+// backends constructed without going through NewBackendRemote/WithDefaults.
+func httpClient(be *BackendRemote) *http.Client {
+	if be.HTTPClient != nil {
+		return be.HTTPClient
+	}
+	return http.DefaultClient
+}
 
-	http := &http.Client{}
-	resp, err := http.Do(req)
-	if err != nil {
-		return bytes.Buffer{}, fmt.Errorf("failed to execute request: %w", err)
+// retryPolicy returns be.RetryPolicy, falling back to DefaultRetryPolicy.
+func retryPolicy(be *BackendRemote) RetryPolicy {
+	if be.RetryPolicy.MaxRetries == 0 && be.RetryPolicy.BaseDelay == 0 && be.RetryPolicy.MaxDelay == 0 {
+		return DefaultRetryPolicy
+	}
+	return be.RetryPolicy
+}
+
+// backoffWithJitter returns a full-jitter delay for the given 0-indexed
+// retry attempt, capped at policy.MaxDelay.
+func backoffWithJitter(policy RetryPolicy, attempt int) time.Duration {
+	maxDelay := policy.BaseDelay << attempt //nolint:gosec
+	if maxDelay <= 0 || maxDelay > policy.MaxDelay {
+		maxDelay = policy.MaxDelay
+	}
+	if maxDelay <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(maxDelay))) //nolint:gosec
+}
+
+// preemptiveRateLimitSleep honors Terraform Cloud's X-RateLimit-Remaining/
+// X-RateLimit-Reset headers, sleeping until the window resets if we've
+// already exhausted our quota rather than firing a request doomed to 429.
+func preemptiveRateLimitSleep(resp *http.Response) {
+	remaining := resp.Header.Get("X-RateLimit-Remaining")
+	if remaining == "" {
+		return
+	}
+	n, err := strconv.ParseFloat(remaining, 64)
+	if err != nil || n > 0 {
+		return
+	}
+
+	resetSecs := resp.Header.Get("X-RateLimit-Reset")
+	if resetSecs == "" {
+		return
+	}
+	reset, err := strconv.ParseFloat(resetSecs, 64)
+	if err != nil || reset <= 0 {
+		return
 	}
-	defer resp.Body.Close()
 
-	var doc bytes.Buffer
-	if _, err := doc.ReadFrom(resp.Body); err != nil {
-		return bytes.Buffer{}, fmt.Errorf("failed to read response: %w", err)
+	d := time.Duration(reset * float64(time.Second))
+	log.Debugf("rate limit exhausted, sleeping %s before next request", d)
+	time.Sleep(d)
+}
+
+// retryAfterDelay parses a Retry-After header, which per RFC 9110 is either
+// a number of seconds or an HTTP-date. Returns (0, false) if absent/invalid.
+func retryAfterDelay(resp *http.Response) (time.Duration, bool) {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(secs) * time.Second, true
 	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// Hitter fetches url (a TFE state version download link), serving cached
+// bytes when possible. It sends a conditional GET (If-None-Match/
+// If-Modified-Since) when cache metadata from a prior fetch is available; a
+// 304 response refreshes the cached entry's expiry without re-downloading
+// the body. 429/5xx responses are retried with exponential backoff and
+// jitter per be.RetryPolicy, honoring any Retry-After header, and Terraform
+// Cloud's X-RateLimit-Remaining/X-RateLimit-Reset headers are consulted
+// after every 429/5xx response and every response whose body is read, to
+// preemptively sleep before the next request; 304 and 401/403 responses
+// return without consulting them.
+func Hitter(be *BackendRemote, url string) (bytes.Buffer, error) {
+	if err := PurgeCache(); err != nil {
+		log.WithError(err).Warn("failed to purge cache")
+	}
+
+	meta, hasMeta := readCacheMeta(be, url)
+	entry, hasEntry := CacheReader(be, url)
+
+	if hasEntry && hasMeta && meta.MaxAge > 0 && time.Since(meta.FetchedAt) < time.Duration(meta.MaxAge)*time.Second {
+		log.Debugf("cache fresh within max-age: %s", entry.Path)
+		be.Audit.EmitCacheHit(be.Backend.Config.Hostname, be.Backend.Config.Organization, url)
+		return *bytes.NewBuffer(entry.Data), nil
+	}
+
+	policy := retryPolicy(be)
+	client := httpClient(be)
+
+	var lastStatus int
+	for attempt := 0; attempt <= policy.MaxRetries; attempt++ {
+		ctx := context.Background()
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return bytes.Buffer{}, fmt.Errorf("failed to create request: %w", err)
+		}
+
+		//nolint:forcetypeassert
+		req.Header.Set("Authorization", "Bearer "+be.Backend.Config.Token.(string))
+		if hasEntry && hasMeta {
+			if meta.ETag != "" {
+				req.Header.Set("If-None-Match", meta.ETag)
+			}
+			if meta.LastModified != "" {
+				req.Header.Set("If-Modified-Since", meta.LastModified)
+			}
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return bytes.Buffer{}, fmt.Errorf("failed to execute request: %w", err)
+		}
+
+		switch {
+		case resp.StatusCode == http.StatusNotModified:
+			resp.Body.Close()
+			if !hasEntry {
+				return bytes.Buffer{}, errors.New("received 304 Not Modified with no cached entry")
+			}
+			meta.FetchedAt = time.Now()
+			writeCacheMeta(be, url, meta)
+			log.Debugf("cache revalidated (304): %s", entry.Path)
+			cacheutil.RecordRevalidation()
+			be.Audit.EmitCacheHit(be.Backend.Config.Hostname, be.Backend.Config.Organization, url)
+			return *bytes.NewBuffer(entry.Data), nil
+
+		case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+			resp.Body.Close()
+			return bytes.Buffer{}, &AuthError{StatusCode: resp.StatusCode}
+
+		case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError:
+			lastStatus = resp.StatusCode
+			preemptiveRateLimitSleep(resp)
+			resp.Body.Close()
+			if attempt == policy.MaxRetries {
+				return bytes.Buffer{}, &RateLimitedError{StatusCode: lastStatus, Attempts: attempt + 1}
+			}
+			delay := backoffWithJitter(policy, attempt)
+			if ra, ok := retryAfterDelay(resp); ok && ra > delay {
+				delay = ra
+			}
+			log.Debugf("retrying after status %d: attempt=%d delay=%s", lastStatus, attempt+1, delay)
+			time.Sleep(delay)
+			continue
+
+		default:
+			var doc bytes.Buffer
+			if _, err := doc.ReadFrom(resp.Body); err != nil {
+				resp.Body.Close()
+				return bytes.Buffer{}, fmt.Errorf("failed to read response: %w", err)
+			}
+			resp.Body.Close()
+
+			if err := CacheWriter(be, url, doc.Bytes()); err != nil {
+				log.WithError(err).Warn("failed to write state to cache")
+			}
+
+			cacheControl := resp.Header.Get("Cache-Control")
+			maxAge := parseMaxAge(cacheControl)
+			if maxAge == 0 {
+				maxAge = fallbackMaxAge()
+			}
+			writeCacheMeta(be, url, cacheMeta{
+				ETag:         resp.Header.Get("ETag"),
+				LastModified: resp.Header.Get("Last-Modified"),
+				CacheControl: cacheControl,
+				MaxAge:       maxAge,
+				FetchedAt:    time.Now(),
+			})
+
+			preemptiveRateLimitSleep(resp)
 
-	if err := CacheWriter(be, url, doc.Bytes()); err != nil {
-		log.WithError(err).Warn("failed to write state to cache")
+			return doc, nil
+		}
 	}
 
-	return doc, nil
+	return bytes.Buffer{}, &RateLimitedError{StatusCode: lastStatus, Attempts: policy.MaxRetries + 1}
 }
@@ -53,10 +53,8 @@ func hostEnvKey(host string) string {
 	if host == "" {
 		return ""
 	}
-	// mirrors Token() env var construction logic: dots to underscores
-	// e.g., app.terraform.io -> app_terraform_io
-	key := "TF_TOKEN_" + replaceDots(host)
-	return key
+	// mirrors Token()'s tokenEnvHostname env var construction logic.
+	return "TF_TOKEN_" + tokenEnvHostname(host)
 }
 
 func nonEmpty(s, fallback string) string {
@@ -65,15 +63,3 @@ func nonEmpty(s, fallback string) string {
 	}
 	return s
 }
-
-func replaceDots(s string) string {
-	b := make([]byte, len(s))
-	for i := 0; i < len(s); i++ {
-		if s[i] == '.' {
-			b[i] = '_'
-		} else {
-			b[i] = s[i]
-		}
-	}
-	return string(b)
-}
@@ -5,27 +5,55 @@ package s3
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"regexp"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/apex/log"
 	awsv2 "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	ddbtypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	s3v2 "github.com/aws/aws-sdk-go-v2/service/s3"
-	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/hashicorp/go-tfe"
 	"github.com/urfave/cli/v3"
 
 	awsx "github.com/tfctl/tfctl/internal/aws"
+	"github.com/tfctl/tfctl/internal/config"
 	"github.com/tfctl/tfctl/internal/differ"
 	"github.com/tfctl/tfctl/internal/svutil"
 )
 
+// stateVersionsHeadBytes is how much of a state document StateVersions reads
+// via a Range GetObject when it has to go to S3 for a version's serial: just
+// enough to cover the "version"/"terraform_version"/"serial"/"lineage"
+// fields Terraform always writes ahead of the (often large) "resources"
+// array.
+const stateVersionsHeadBytes = 4096
+
+// serialFieldPattern extracts a state document's top-level "serial" field
+// from a byte prefix that may be too short to be valid JSON on its own (a
+// Range GetObject response), so it's scanned for directly instead of being
+// unmarshaled.
+var serialFieldPattern = regexp.MustCompile(`"serial"\s*:\s*(\d+)`)
+
+// parseHeadSerial returns the "serial" value found in data, or 0 if the
+// field isn't present in the scanned prefix.
+func parseHeadSerial(data []byte) int64 {
+	m := serialFieldPattern.FindSubmatch(data)
+	if m == nil {
+		return 0
+	}
+	n, _ := strconv.ParseInt(string(m[1]), 10, 64)
+	return n
+}
+
 type BackendS3 struct {
 	Ctx              context.Context
 	Cmd              *cli.Command
@@ -37,17 +65,154 @@ type BackendS3 struct {
 	Backend          struct {
 		Type   string `json:"type" validate:"eq=local"`
 		Config struct {
-			Bucket   string `json:"bucket"`
-			Key      string `json:"key"`
-			Prefix   string `json:"workspace_key_prefix"`
-			Region   string `json:"region"`
-			Encrypt  bool   `json:"encrypt"`
-			KmsKeyID string `json:"kms_key_id"`
+			Bucket         string `json:"bucket"`
+			Key            string `json:"key"`
+			Prefix         string `json:"workspace_key_prefix"`
+			Region         string `json:"region"`
+			Profile        string `json:"profile"`
+			Encrypt        bool   `json:"encrypt"`
+			KmsKeyID       string `json:"kms_key_id"`
+			SSECustomerKey string `json:"sse_customer_key"`
+			DynamoDBTable  string `json:"dynamodb_table"`
+			RoleArn        string `json:"role_arn"`
+			UsePathStyle   bool   `json:"use_path_style"`
+			Endpoints      struct {
+				S3 string `json:"s3"`
+			} `json:"endpoints"`
 		} `json:"config"`
 		Hash int `json:"hash"`
 	} `json:"backend"`
 }
 
+// awsConfigOptions builds the region/profile/assume-role/proxy options
+// shared by client() and checkLock(). The backend config's own (legacy,
+// flat) role_arn takes precedence, matching Terraform's own s3 backend; if
+// it's unset, tfctl's aws.assume_role.* config keys are consulted instead,
+// letting an operator assume a role for *reading* state (e.g. a
+// cross-account audit profile) without editing the state-owning Terraform
+// configuration itself. aws.proxy_url/aws.ca_bundle_file, if set, route AWS
+// SDK traffic through a dedicated egress proxy without exporting
+// HTTPS_PROXY process-wide, which would also redirect any TFE API traffic
+// tfctl makes in the same invocation through the same proxy.
+func (be *BackendS3) awsConfigOptions() []awsx.Option {
+	var cfgOpts []awsx.Option
+	if be.Backend.Config.Region != "" {
+		cfgOpts = append(cfgOpts, awsx.WithRegion(be.Backend.Config.Region))
+	}
+	if be.Backend.Config.Profile != "" {
+		cfgOpts = append(cfgOpts, awsx.WithProfile(be.Backend.Config.Profile))
+	}
+
+	roleARN := be.Backend.Config.RoleArn
+	if roleARN == "" {
+		roleARN, _ = config.GetString("aws.assume_role.role-arn")
+	}
+	if roleARN != "" {
+		ac := awsx.AssumeRoleConfig{RoleARN: roleARN}
+		ac.SessionName, _ = config.GetString("aws.assume_role.session-name")
+		ac.ExternalID, _ = config.GetString("aws.assume_role.external-id")
+		ac.SourceProfile, _ = config.GetString("aws.assume_role.source-profile")
+		if d, _ := config.GetString("aws.assume_role.duration"); d != "" {
+			if parsed, err := time.ParseDuration(d); err == nil {
+				ac.Duration = parsed
+			} else {
+				log.Debugf("ignoring invalid aws.assume_role.duration %q: %v", d, err)
+			}
+		}
+		cfgOpts = append(cfgOpts, awsx.WithAssumeRole(ac))
+	} else if webRoleARN, _ := config.GetString("aws.web_identity.role-arn"); webRoleARN != "" {
+		tokenFile, _ := config.GetString("aws.web_identity.token-file")
+		cfgOpts = append(cfgOpts, awsx.WithWebIdentityRoleARN(webRoleARN, tokenFile))
+	} else if cmdName, _ := config.GetString("aws.credential_source.command"); cmdName != "" {
+		args, _ := config.GetStringSlice("aws.credential_source.args")
+		cfgOpts = append(cfgOpts, awsx.WithCredentialSource(awsx.ExecCredentialSource{Command: cmdName, Args: args}))
+	}
+
+	if proxyURL, _ := config.GetString("aws.proxy_url"); proxyURL != "" {
+		cfgOpts = append(cfgOpts, awsx.WithHTTPProxy(proxyURL))
+	}
+	if caBundleFile, _ := config.GetString("aws.ca_bundle_file"); caBundleFile != "" {
+		if pemBytes, err := os.ReadFile(caBundleFile); err == nil {
+			cfgOpts = append(cfgOpts, awsx.WithCABundle(pemBytes))
+		} else {
+			log.Debugf("failed to read aws.ca_bundle_file %q: %v", caBundleFile, err)
+		}
+	}
+
+	return cfgOpts
+}
+
+// client builds an S3 client from the backend's region/profile/endpoint
+// config, reusing the shared awsx config loader so behavior (profile
+// resolution, default region chain, etc.) stays consistent with other
+// backends that talk to AWS.
+func (be *BackendS3) client() (*s3v2.Client, error) {
+	cfg, err := awsx.LoadAWSConfig(be.Ctx, be.awsConfigOptions()...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+
+	var svcOpts []func(*s3v2.Options)
+	if endpoint := be.Backend.Config.Endpoints.S3; endpoint != "" {
+		svcOpts = append(svcOpts, func(o *s3v2.Options) {
+			o.BaseEndpoint = awsv2.String(endpoint)
+		})
+	}
+	if be.Backend.Config.UsePathStyle {
+		svcOpts = append(svcOpts, func(o *s3v2.Options) {
+			o.UsePathStyle = true
+		})
+	}
+
+	return awsx.NewS3(cfg, svcOpts...), nil
+}
+
+// concurrency bounds how many GetObject calls StateVersions fans out at
+// once. The --concurrency flag, when set, wins; otherwise the s3.concurrency
+// config key is consulted, defaulting to 16.
+func (be *BackendS3) concurrency() int {
+	if n := be.Cmd.Int("concurrency"); n > 0 {
+		return int(n)
+	}
+	n, _ := config.GetInt("s3.concurrency", 16)
+	if n <= 0 {
+		return 16
+	}
+	return n
+}
+
+// checkLock looks for a Terraform-style DynamoDB lock item for key and warns
+// if one is held. It is advisory only: tfctl is a read-only inspection tool,
+// so a concurrent apply holding the write lock is not a reason to fail a read.
+func (be *BackendS3) checkLock(key string) error {
+	table := be.Backend.Config.DynamoDBTable
+	if table == "" {
+		return nil
+	}
+
+	cfg, err := awsx.LoadAWSConfig(be.Ctx, be.awsConfigOptions()...)
+	if err != nil {
+		return err
+	}
+
+	lockID := fmt.Sprintf("%s/%s", be.Backend.Config.Bucket, key)
+	ddb := dynamodb.NewFromConfig(cfg)
+	out, err := ddb.GetItem(be.Ctx, &dynamodb.GetItemInput{
+		TableName: awsv2.String(table),
+		Key: map[string]ddbtypes.AttributeValue{
+			"LockID": &ddbtypes.AttributeValueMemberS{Value: lockID},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to check lock table: %w", err)
+	}
+	if len(out.Item) > 0 {
+		return fmt.Errorf("lock held for %s", lockID)
+	}
+
+	return nil
+}
+
 func (be *BackendS3) DiffStates(ctx context.Context, cmd *cli.Command) ([][]byte, error) {
 	// Fixup diffArgs
 	svSpecs := []string{"CSV~1", "CSV~0"}
@@ -69,24 +234,27 @@ func (be *BackendS3) DiffStates(ctx context.Context, cmd *cli.Command) ([][]byte
 				return nil, fmt.Errorf("failed to get state version list: %v", err)
 			}
 
-			selectedVersions := differ.SelectStateVersions(stateVersionList)
+			selection := differ.SelectStateVersions(stateVersionList)
 
-			log.Debugf("selectedVersions: %d", len(selectedVersions))
+			log.Debugf("selection: %d", len(selection.Versions))
 
-			if len(selectedVersions) == 0 {
+			if len(selection.Versions) < 2 {
 				return nil, nil
-			} else if len(selectedVersions) == 2 {
-				svSpecs[0] = selectedVersions[1].ID
-				svSpecs[1] = selectedVersions[0].ID
 			}
+
+			if selection.Mode == "pinned" {
+				_ = cmd.Set("diff_mode", "pinned")
+			}
+
+			svSpecs = differ.SpecsForSelection(selection)
 		} else {
 			svSpecs[0] = diffArgs[0]
 		}
-	case 2:
+	default:
 		svSpecs = diffArgs
 	}
 
-	states, _ := be.States(svSpecs[0], svSpecs[1])
+	states, _ := be.States(svSpecs...)
 
 	return states, nil
 }
@@ -126,39 +294,134 @@ func (be *BackendS3) StateBody(svID string) ([]byte, error) {
 	}
 	key := filepath.Join(be.Backend.Config.Prefix, env, be.Backend.Config.Key)
 
-	// Build AWS config (inherit env; override region if provided)
-	var cfgOpts []awsx.Option
-	if be.Backend.Config.Region != "" {
-		cfgOpts = append(cfgOpts, awsx.WithRegion(be.Backend.Config.Region))
+	if err := be.checkLock(key); err != nil {
+		log.WithError(err).Warnf("state may be locked: %s", key)
 	}
-	cfg, err := awsx.LoadAWSConfig(be.Ctx, cfgOpts...)
+
+	svc, err := be.client()
 	if err != nil {
-		return nil, fmt.Errorf("failed to load AWS config: %w", err)
-	}
-	svc := awsx.NewS3(cfg)
-	input := &s3v2.GetObjectInput{
-		Bucket:    awsv2.String(be.Backend.Config.Bucket),
-		Key:       awsv2.String(key),
-		VersionId: awsv2.String(svID),
+		return nil, err
 	}
 
-	result, err := svc.GetObject(be.Ctx, input)
+	body, err := awsx.GetObjectVersion(be.Ctx, svc, be.Backend.Config.Bucket, key, svID, be.Backend.Config.SSECustomerKey)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get S3 object: %w", err)
 	}
-	defer result.Body.Close()
+	defer body.Close()
 
-	data, err := io.ReadAll(result.Body)
+	data, err := io.ReadAll(body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read S3 object body: %w", err)
 	}
 
+	if err := CacheWriter(be, svID, data); err != nil {
+		log.WithError(err).Error("error writing to cache")
+	}
+
 	return data, nil
 }
 
-// StateVersions implements backend.Backend. It scans be.RootDir for state and
-// backup files, parses them, and creates minimal tfe.StateVersion with ID as
-// filename, CreatedAt from file timestamp, and Serial from the document.
+// headStateVersion resolves one object version's serial, preferring an
+// already-cached full body (written by a prior StateBody call) over going
+// back to S3. When it does have to go to S3, it reads only
+// stateVersionsHeadBytes via a Range GetObject rather than the full state
+// body: StateVersions only needs the serial to sort and limit by, and the
+// full body is fetched (and cached) later, if at all, by StateBody.
+func (be *BackendS3) headStateVersion(svc awsx.S3ObjectGetter, prefix string, v awsx.ObjectVersion) (*tfe.StateVersion, error) {
+	var serial int64
+	if entry, ok := CacheReader(be, v.VersionID); ok {
+		serial = parseHeadSerial(entry.Data)
+	} else {
+		head, err := awsx.GetObjectVersionRange(be.Ctx, svc, be.Backend.Config.Bucket, prefix, v.VersionID, be.Backend.Config.SSECustomerKey, stateVersionsHeadBytes)
+		if err != nil {
+			return nil, err
+		}
+		serial = parseHeadSerial(head)
+	}
+
+	return &tfe.StateVersion{
+		ID:        v.VersionID,
+		CreatedAt: v.LastModified,
+		Serial:    serial,
+	}, nil
+}
+
+// objectEpoch is one contiguous run of a state key's history bounded by
+// delete markers. Epoch 0 is always the current, live epoch; epoch 1 is the
+// run before the most recent destroy/re-apply, and so on, oldest last.
+type objectEpoch struct {
+	versions []awsx.ObjectVersion
+}
+
+// segmentEpochs splits every version of prefix out of allVersions into
+// delete-marker-bounded epochs. S3 retains every version across a destroy
+// and re-apply, so a workspace that's been torn down and recreated has more
+// than one disjoint run of state history sharing the same key; treating
+// them as one run (the previous behavior) either silently hid the earlier
+// history or blended two unrelated histories together. A workspace that's
+// never been deleted has exactly one epoch.
+func segmentEpochs(allVersions []awsx.ObjectVersion, prefix string) []objectEpoch {
+	var deleteTimes []time.Time
+	var versions []awsx.ObjectVersion
+	for _, v := range allVersions {
+		// This filters out tflock files. The prefix is literally a prefix so
+		// both the actual state file versions and any lock files they might
+		// have are returned by the AWS API.
+		if v.Key != prefix {
+			log.Debugf("Throwing away %s", v.Key)
+			continue
+		}
+		if v.IsDeleteMarker {
+			deleteTimes = append(deleteTimes, v.LastModified)
+			continue
+		}
+		versions = append(versions, v)
+	}
+
+	epochs := make([]objectEpoch, len(deleteTimes)+1)
+	for _, v := range versions {
+		epoch := 0
+		for _, dt := range deleteTimes {
+			if v.LastModified.Before(dt) {
+				epoch++
+			}
+		}
+		epochs[epoch].versions = append(epochs[epoch].versions, v)
+	}
+
+	out := epochs[:0]
+	for _, e := range epochs {
+		if len(e.versions) > 0 {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// parseAtSpec parses the --at flag's value: either an RFC3339 timestamp, or
+// a duration (e.g. "24h") interpreted as "that long ago".
+func parseAtSpec(raw string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t, nil
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("not an RFC3339 timestamp or a duration: %w", err)
+	}
+	return time.Now().Add(-d.Abs()), nil
+}
+
+// StateVersions implements backend.Backend. It lists every S3 object version
+// for the workspace's state key, groups them into delete-marker-bounded
+// epochs (see segmentEpochs), and resolves each candidate version's serial
+// through a bounded worker pool. By default only the current epoch is
+// returned, truncated at the first version whose head has no serial (a
+// version whose fetch fails is skipped, not truncated at) and capped at
+// --limit, matching tfctl's historical behavior. --all-epochs
+// returns every epoch's versions, newest-first, so existing CSV~N specs can
+// address across epoch boundaries (e.g. "CSV~0" vs "CSV~12" to diff across a
+// destroy/re-apply). --epoch N scopes to one epoch. --at returns the single
+// version live at a given timestamp, searching every epoch.
 func (be *BackendS3) StateVersions(augmenter ...func(context.Context, *cli.Command, *tfe.StateVersionListOptions) error) ([]*tfe.StateVersion, error) {
 	var env string
 	if be.EnvOverride != "" {
@@ -171,138 +434,124 @@ func (be *BackendS3) StateVersions(augmenter ...func(context.Context, *cli.Comma
 	}
 	prefix := filepath.Join(be.Backend.Config.Prefix, env, be.Backend.Config.Key)
 
-	var cfgOpts []awsx.Option
-	if be.Backend.Config.Region != "" {
-		cfgOpts = append(cfgOpts, awsx.WithRegion(be.Backend.Config.Region))
-	}
-	cfg, err := awsx.LoadAWSConfig(be.Ctx, cfgOpts...)
+	svc, err := be.client()
 	if err != nil {
-		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+		return nil, err
 	}
 
-	svc := awsx.NewS3(cfg)
-	paginator := s3v2.NewListObjectVersionsPaginator(svc, &s3v2.ListObjectVersionsInput{
-		Bucket: awsv2.String(be.Backend.Config.Bucket),
-		Prefix: awsv2.String(prefix),
-	})
-	combinedVersions := []*tfe.StateVersion{}
+	allObjectVersions, err := awsx.ListObjectVersions(be.Ctx, svc, be.Backend.Config.Bucket, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list object versions: %w", err)
+	}
+	epochs := segmentEpochs(allObjectVersions, prefix)
 
-	var allDeleteMarkers []types.DeleteMarkerEntry
-	var allVersions []types.ObjectVersion
-	for paginator.HasMorePages() {
-		page, err := paginator.NextPage(be.Ctx)
+	var atTime time.Time
+	var atRequested bool
+	if raw := be.Cmd.String("at"); raw != "" {
+		atTime, err = parseAtSpec(raw)
 		if err != nil {
-			return nil, fmt.Errorf("failed to list object versions: %w", err)
-		}
-		allDeleteMarkers = append(allDeleteMarkers, page.DeleteMarkers...)
-		allVersions = append(allVersions, page.Versions...)
-	}
-	var mostRecentDelete time.Time
-	for _, d := range allDeleteMarkers {
-		// This filters out tflock files. The prefix is literally a prefix so both
-		// the actual state file versions and any lock files they might have, are
-		// returned by the AWS API.
-		if d.Key == nil || *d.Key != prefix {
-			if d.Key != nil {
-				log.Debugf("Throwing away delete marker %s", *d.Key)
-			}
-			continue
+			return nil, fmt.Errorf("invalid --at value %q: %w", raw, err)
 		}
-		if d.LastModified != nil && d.LastModified.After(mostRecentDelete) {
-			mostRecentDelete = *d.LastModified
+		atRequested = true
+	}
+
+	var selectedEpochs []objectEpoch
+	switch {
+	case be.Cmd.IsSet("epoch"):
+		wantEpoch := int(be.Cmd.Int("epoch"))
+		if wantEpoch < 0 || wantEpoch >= len(epochs) {
+			return nil, fmt.Errorf("epoch %d not found; have epochs 0..%d", wantEpoch, len(epochs)-1)
 		}
+		selectedEpochs = epochs[wantEpoch : wantEpoch+1]
+	case be.Cmd.Bool("all-epochs") || atRequested:
+		selectedEpochs = epochs
+	case len(epochs) > 0:
+		selectedEpochs = epochs[:1]
 	}
 
-	for _, v := range allVersions {
-		if v.Key == nil || *v.Key != prefix {
-			if v.Key != nil {
-				log.Debugf("Throwing away %s", *v.Key)
-			}
-			continue
+	type candidateRef struct {
+		version awsx.ObjectVersion
+		epoch   int // index into selectedEpochs
+	}
+	var candidates []candidateRef
+	for ei, e := range selectedEpochs {
+		for _, v := range e.versions {
+			candidates = append(candidates, candidateRef{version: v, epoch: ei})
 		}
+	}
 
-		if v.LastModified != nil && v.LastModified.Before(mostRecentDelete) {
+	// Resolve each candidate's serial through a bounded worker pool instead
+	// of one GetObject per version in series: for workspaces with hundreds
+	// of historical versions, the round-trip latency otherwise dominates.
+	// Results are collected into an index-aligned slice so the final order
+	// doesn't depend on which goroutine finishes first.
+	resolved := make([]*tfe.StateVersion, len(candidates))
+	sem := make(chan struct{}, be.concurrency())
+	var wg sync.WaitGroup
+	for i, c := range candidates {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, v awsx.ObjectVersion) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			sv, err := be.headStateVersion(svc, prefix, v)
+			if err != nil {
+				log.WithError(err).Error("s3 head object failed")
+				return
+			}
+			resolved[i] = sv
+		}(i, c.version)
+	}
+	wg.Wait()
+
+	// Group resolved versions back by epoch so each epoch is sorted and
+	// truncated (at the first zero serial) independently, then concatenated
+	// newest-epoch-first. Versions whose fetch failed were left nil and are
+	// dropped here.
+	byEpoch := make([][]*tfe.StateVersion, len(selectedEpochs))
+	for i, sv := range resolved {
+		if sv == nil {
 			continue
 		}
+		byEpoch[candidates[i].epoch] = append(byEpoch[candidates[i].epoch], sv)
+	}
 
-		obj, err := svc.GetObject(be.Ctx, &s3v2.GetObjectInput{
-			Bucket:    awsv2.String(be.Backend.Config.Bucket),
-			Key:       awsv2.String(prefix),
-			VersionId: v.VersionId,
+	var combinedVersions []*tfe.StateVersion
+	for _, group := range byEpoch {
+		sort.Slice(group, func(i, j int) bool {
+			return group[i].CreatedAt.After(group[j].CreatedAt)
 		})
-		if err != nil {
-			log.WithError(err).Error("s3 get object failed")
-			continue
+		for _, v := range group {
+			if v.Serial == 0 {
+				break
+			}
+			combinedVersions = append(combinedVersions, v)
 		}
+	}
 
-		var body []byte
-		if v.VersionId == nil {
-			// Shouldn't happen, but skip if no version id
-			_ = obj.Body.Close()
-			continue
-		}
-		entry, ok := CacheReader(be, *v.VersionId)
-		if !ok {
-			body, err = io.ReadAll(obj.Body)
-			obj.Body.Close()
-			if err != nil {
+	if atRequested {
+		var best *tfe.StateVersion
+		for _, v := range combinedVersions {
+			if v.CreatedAt.After(atTime) {
 				continue
 			}
-
-			if err := CacheWriter(be, *v.VersionId, body); err != nil {
-				log.WithError(err).Error("error writing to cache")
+			if best == nil || v.CreatedAt.After(best.CreatedAt) {
+				best = v
 			}
-		} else {
-			body = entry.Data
-		}
-
-		var doc map[string]interface{}
-		_ = json.Unmarshal(body, &doc)
-		serial := doc["serial"]
-
-		var serialInt int64
-		switch s := serial.(type) {
-		case float64:
-			serialInt = int64(s)
-		case int64:
-			serialInt = s
-		case int:
-			serialInt = int64(s)
-		default:
-			serialInt = 0
-		}
-
-		// Guard against nil pointers
-		if v.VersionId != nil && v.LastModified != nil {
-			combinedVersions = append(combinedVersions, &tfe.StateVersion{
-				ID:        *v.VersionId,
-				CreatedAt: *v.LastModified,
-				Serial:    serialInt,
-			})
 		}
-
-	}
-
-	sort.Slice(combinedVersions, func(i, j int) bool {
-		return combinedVersions[i].CreatedAt.After(combinedVersions[j].CreatedAt)
-	})
-
-	currentVersions := []*tfe.StateVersion{}
-
-	for _, v := range combinedVersions {
-		if v.Serial == 0 {
-			break
+		if best == nil {
+			return nil, fmt.Errorf("no state version found live at %s", atTime.Format(time.RFC3339))
 		}
-
-		currentVersions = append(currentVersions, v)
+		return []*tfe.StateVersion{best}, nil
 	}
 
 	limit := be.Cmd.Int("limit")
-	if len(currentVersions) > limit {
-		currentVersions = currentVersions[:limit]
+	if len(combinedVersions) > limit {
+		combinedVersions = combinedVersions[:limit]
 	}
 
-	return currentVersions, nil
+	return combinedVersions, nil
 }
 
 func (be *BackendS3) States(specs ...string) ([][]byte, error) {
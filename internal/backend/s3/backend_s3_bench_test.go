@@ -0,0 +1,79 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package s3
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	s3v2 "github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/hashicorp/go-tfe"
+
+	awsx "github.com/tfctl/tfctl/internal/aws"
+)
+
+// fakeS3Getter stands in for a real S3 client, sleeping for latency on every
+// GetObject call (to approximate an AWS round-trip) before returning a
+// small, fixed state document. It implements awsx.S3ObjectGetter.
+type fakeS3Getter struct {
+	latency time.Duration
+}
+
+func (f *fakeS3Getter) GetObject(_ context.Context, _ *s3v2.GetObjectInput, _ ...func(*s3v2.Options)) (*s3v2.GetObjectOutput, error) {
+	time.Sleep(f.latency)
+	return &s3v2.GetObjectOutput{
+		Body: io.NopCloser(strings.NewReader(`{"version":4,"terraform_version":"1.6.0","serial":7,"lineage":"x"}`)),
+	}, nil
+}
+
+// benchmarkHeadStateVersionFanOut resolves n candidates' serials through the
+// same bounded worker-pool shape StateVersions uses, at the given pool size,
+// against a fake S3 client with a fixed per-call latency. Comparing
+// workers=1 (the old, effectively-serial behavior) against workers=16 (the
+// new default) shows the wall-clock win from fanning GetObject calls out.
+func benchmarkHeadStateVersionFanOut(b *testing.B, n, workers int) {
+	be := &BackendS3{Ctx: context.Background()}
+	getter := &fakeS3Getter{latency: 2 * time.Millisecond}
+
+	candidates := make([]awsx.ObjectVersion, n)
+	for i := range candidates {
+		candidates[i] = awsx.ObjectVersion{VersionID: fmt.Sprintf("v%d", i), LastModified: time.Now()}
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		resolved := make([]*tfe.StateVersion, len(candidates))
+		sem := make(chan struct{}, workers)
+		var wg sync.WaitGroup
+		for idx, v := range candidates {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(idx int, v awsx.ObjectVersion) {
+				defer wg.Done()
+				defer func() { <-sem }()
+				sv, err := be.headStateVersion(getter, "prefix", v)
+				if err == nil {
+					resolved[idx] = sv
+				}
+			}(idx, v)
+		}
+		wg.Wait()
+	}
+}
+
+// BenchmarkHeadStateVersionFanOutSerial approximates StateVersions before
+// this change: one GetObject in flight at a time.
+func BenchmarkHeadStateVersionFanOutSerial(b *testing.B) {
+	benchmarkHeadStateVersionFanOut(b, 64, 1)
+}
+
+// BenchmarkHeadStateVersionFanOutPooled uses the new default pool size.
+func BenchmarkHeadStateVersionFanOutPooled(b *testing.B) {
+	benchmarkHeadStateVersionFanOut(b, 64, 16)
+}
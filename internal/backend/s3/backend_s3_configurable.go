@@ -0,0 +1,237 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package s3
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	awsv2 "github.com/aws/aws-sdk-go-v2/aws"
+	s3v2 "github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"github.com/tfctl/tfctl/internal/backend/configschema"
+	"github.com/tfctl/tfctl/internal/backend/statemgr"
+)
+
+// defaultWorkspace is the workspace name used when no explicit workspace is
+// selected, matching Terraform's own default workspace convention.
+const defaultWorkspace = "default"
+
+// s3StateManager is the statemgr.Reader returned by BackendS3.StateMgr. It
+// reads the current (no VersionId) object for a single resolved workspace key.
+type s3StateManager struct {
+	be  *BackendS3
+	key string
+}
+
+func (m *s3StateManager) State() ([]byte, error) {
+	svc, err := m.be.client()
+	if err != nil {
+		return nil, err
+	}
+
+	input := &s3v2.GetObjectInput{
+		Bucket: awsv2.String(m.be.Backend.Config.Bucket),
+		Key:    awsv2.String(m.key),
+	}
+	if m.be.Backend.Config.SSECustomerKey != "" {
+		input.SSECustomerAlgorithm = awsv2.String("AES256")
+		input.SSECustomerKey = awsv2.String(m.be.Backend.Config.SSECustomerKey)
+	}
+
+	result, err := svc.GetObject(m.be.Ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get S3 object: %w", err)
+	}
+	defer result.Body.Close()
+
+	data, err := io.ReadAll(result.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read S3 object body: %w", err)
+	}
+	return data, nil
+}
+
+// ConfigSchema describes the options accepted under an s3 backend's "config"
+// block. https://developer.hashicorp.com/terraform/language/backend/s3
+func (be *BackendS3) ConfigSchema() *configschema.Block {
+	return &configschema.Block{
+		Attributes: map[string]*configschema.Attribute{
+			"bucket": {
+				Type:        configschema.TypeString,
+				Description: "the name of the S3 bucket holding state",
+				Required:    true,
+			},
+			"key": {
+				Type:        configschema.TypeString,
+				Description: "the path to the state file inside the bucket",
+				Required:    true,
+			},
+			"region": {
+				Type:        configschema.TypeString,
+				Description: "the AWS region of the S3 bucket",
+				Optional:    true,
+			},
+			"profile": {
+				Type:        configschema.TypeString,
+				Description: "the AWS shared config profile to use",
+				Optional:    true,
+			},
+			"workspace_key_prefix": {
+				Type:        configschema.TypeString,
+				Description: "prefix applied to the state path for non-default workspaces",
+				Optional:    true,
+			},
+			"kms_key_id": {
+				Type:        configschema.TypeString,
+				Description: "the ARN of a KMS key used to encrypt the state",
+				Optional:    true,
+			},
+			"sse_customer_key": {
+				Type:        configschema.TypeString,
+				Description: "a customer-supplied encryption key for SSE-C",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"dynamodb_table": {
+				Type:        configschema.TypeString,
+				Description: "the DynamoDB table used for state locking",
+				Optional:    true,
+			},
+			"role_arn": {
+				Type:        configschema.TypeString,
+				Description: "an IAM role ARN to assume before accessing S3",
+				Optional:    true,
+			},
+			"use_path_style": {
+				Type:        configschema.TypeBool,
+				Description: "use path-style S3 URLs instead of virtual-hosted-style",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+// Configure applies decoded "config" block values to the backend, mirroring
+// the shape unmarshaled from .terraform/terraform.tfstate in load().
+func (be *BackendS3) Configure(config map[string]any) error {
+	if bucket, ok := config["bucket"].(string); ok {
+		be.Backend.Config.Bucket = bucket
+	}
+	if key, ok := config["key"].(string); ok {
+		be.Backend.Config.Key = key
+	}
+	if region, ok := config["region"].(string); ok {
+		be.Backend.Config.Region = region
+	}
+	if profile, ok := config["profile"].(string); ok {
+		be.Backend.Config.Profile = profile
+	}
+	if prefix, ok := config["workspace_key_prefix"].(string); ok {
+		be.Backend.Config.Prefix = prefix
+	}
+	if kmsKeyID, ok := config["kms_key_id"].(string); ok {
+		be.Backend.Config.KmsKeyID = kmsKeyID
+	}
+	if sseCustomerKey, ok := config["sse_customer_key"].(string); ok {
+		be.Backend.Config.SSECustomerKey = sseCustomerKey
+	}
+	if dynamoDBTable, ok := config["dynamodb_table"].(string); ok {
+		be.Backend.Config.DynamoDBTable = dynamoDBTable
+	}
+	if roleArn, ok := config["role_arn"].(string); ok {
+		be.Backend.Config.RoleArn = roleArn
+	}
+	if usePathStyle, ok := config["use_path_style"].(bool); ok {
+		be.Backend.Config.UsePathStyle = usePathStyle
+	}
+	if endpoint, ok := config["endpoint"].(string); ok {
+		be.Backend.Config.Endpoints.S3 = endpoint
+	}
+	if endpoints, ok := config["endpoints"].(map[string]any); ok {
+		if s3Endpoint, ok := endpoints["s3"].(string); ok {
+			be.Backend.Config.Endpoints.S3 = s3Endpoint
+		}
+	}
+	return nil
+}
+
+// workspacePrefix returns the configured workspace_key_prefix, defaulting to
+// "env:" to match Terraform's own S3 backend default.
+func (be *BackendS3) workspacePrefix() string {
+	if be.Backend.Config.Prefix != "" {
+		return be.Backend.Config.Prefix
+	}
+	return "env:"
+}
+
+// Workspaces lists non-default workspace names by listing objects under the
+// workspace key prefix, always including "default" first.
+func (be *BackendS3) Workspaces() ([]string, error) {
+	svc, err := be.client()
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{defaultWorkspace}
+
+	prefix := be.workspacePrefix() + "/"
+	paginator := s3v2.NewListObjectsV2Paginator(svc, &s3v2.ListObjectsV2Input{
+		Bucket:    awsv2.String(be.Backend.Config.Bucket),
+		Prefix:    awsv2.String(prefix),
+		Delimiter: awsv2.String("/"),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(be.Ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list workspaces: %w", err)
+		}
+		for _, p := range page.CommonPrefixes {
+			if p.Prefix == nil {
+				continue
+			}
+			name := strings.TrimSuffix(strings.TrimPrefix(*p.Prefix, prefix), "/")
+			if name != "" {
+				names = append(names, name)
+			}
+		}
+	}
+
+	return names, nil
+}
+
+// DeleteWorkspace deletes the state object for a non-default workspace.
+// Deleting the default workspace is refused, matching Terraform's own
+// behavior (it cannot be deleted, only emptied).
+func (be *BackendS3) DeleteWorkspace(name string) error {
+	if name == "" || name == defaultWorkspace {
+		return fmt.Errorf("cannot delete the %q workspace", defaultWorkspace)
+	}
+
+	svc, err := be.client()
+	if err != nil {
+		return err
+	}
+
+	key := filepath.Join(be.workspacePrefix(), name, be.Backend.Config.Key)
+	if _, err := svc.DeleteObject(be.Ctx, &s3v2.DeleteObjectInput{
+		Bucket: awsv2.String(be.Backend.Config.Bucket),
+		Key:    awsv2.String(key),
+	}); err != nil {
+		return fmt.Errorf("failed to delete workspace %s: %w", name, err)
+	}
+	return nil
+}
+
+// StateMgr returns a statemgr.Reader for the named workspace's current
+// object. An empty workspace resolves to the default (unprefixed) key.
+func (be *BackendS3) StateMgr(workspace string) (statemgr.Reader, error) {
+	key := be.Backend.Config.Key
+	if workspace != "" && workspace != defaultWorkspace {
+		key = filepath.Join(be.workspacePrefix(), workspace, be.Backend.Config.Key)
+	}
+	return &s3StateManager{be: be, key: key}, nil
+}
@@ -4,16 +4,42 @@
 package s3
 
 import (
+	"sync"
+
+	"github.com/apex/log"
+
+	"github.com/tfctl/tfctl/internal/cache"
 	"github.com/tfctl/tfctl/internal/cacheutil"
 	"github.com/tfctl/tfctl/internal/config"
 )
 
 // CacheEntry is provided by cacheutil.Entry; local alias removed to avoid duplication.
 
+var (
+	cacheStoreOnce sync.Once
+	cacheStore     cache.Store
+)
+
+// store returns the configured cache.Store (config key "cache.backend"),
+// falling back to the filesystem store tfctl has always used if the
+// config is invalid.
+func store() cache.Store {
+	cacheStoreOnce.Do(func() {
+		s, err := cache.NewStore()
+		if err != nil {
+			log.WithError(err).Warn("invalid cache.backend config, falling back to filesystem cache")
+			s = nil
+		}
+		cacheStore = s
+	})
+	return cacheStore
+}
+
 // CacheEntryPath returns the path to the cache entry for the given key, if it
 // exists. The cache is organized first by the backend hostname
 // (app.terraform.io) and then by the organization name. The key is hashed and
-// used as the filename.
+// used as the filename. This only resolves for the filesystem backend; other
+// backends have no on-disk path.
 func CacheEntryPath(be *BackendS3, key string) (string, bool) {
 	sub := []string{be.Backend.Config.Bucket, be.Backend.Config.Prefix, be.Backend.Config.Key}
 	p, exists := cacheutil.EntryPath(sub, key)
@@ -28,15 +54,65 @@ func CacheEntryPath(be *BackendS3, key string) (string, bool) {
 // be false.
 func CacheReader(be *BackendS3, key string) (*cacheutil.Entry, bool) {
 	sub := []string{be.Backend.Config.Bucket, be.Backend.Config.Prefix, be.Backend.Config.Key}
-	return cacheutil.Read(sub, key)
+
+	s := store()
+	if s == nil {
+		return cacheutil.Read(sub, key)
+	}
+
+	entry, ok, err := s.Get(sub, key)
+	if err != nil {
+		log.WithError(err).Warn("cache read failed")
+		return nil, false
+	}
+	if !ok {
+		return nil, false
+	}
+	return &cacheutil.Entry{Key: entry.Key, Data: entry.Data, ExpiresAt: entry.ExpiresAt}, true
 }
 
+// CacheWriter stores a state body for the given key. State blobs can be
+// large, so the entry is stored with Compressed set, which the filesystem
+// store honors via cacheutil.WriteCompressed.
 func CacheWriter(be *BackendS3, key string, data []byte) error {
 	sub := []string{be.Backend.Config.Bucket, be.Backend.Config.Prefix, be.Backend.Config.Key}
-	return cacheutil.Write(sub, key, data)
+
+	s := store()
+	if s == nil {
+		return cacheutil.WriteCompressed(sub, key, "application/json", data)
+	}
+	return s.Put(sub, key, data, cache.PutOptions{ContentType: "application/json", Compressed: true})
 }
 
+// PurgeCache runs the active backend's maintenance sweep: for the default
+// filesystem store, that's the TTL pass (entries older than cache.clean
+// hours), the corruption pass (entries whose framed header fails to parse,
+// regardless of age) and the LRU pass (cache.max-entries /
+// cache.max-bytes, or the legacy cache.max-size-mb).
+// It's called from StateBody's hot path on every state read, so the full
+// sweep is debounced via cacheutil.ShouldSweep rather than re-walking the
+// whole cache tree every time; "tfctl cache prune" runs it unconditionally.
 func PurgeCache() error {
-	cleanHours, _ := config.GetInt("cache.clean")
-	return cacheutil.Purge(cleanHours)
+	if !cacheutil.ShouldSweep() {
+		return nil
+	}
+
+	s := store()
+	if s == nil {
+		cleanHours, _ := config.GetInt("cache.clean")
+		if err := cacheutil.Purge(cleanHours); err != nil {
+			return err
+		}
+		if err := cacheutil.PurgeCorrupt(); err != nil {
+			return err
+		}
+
+		maxEntries, _ := config.GetInt("cache.max-entries")
+		if maxBytes, err := config.GetByteSize("cache.max-bytes"); err == nil && maxBytes > 0 {
+			return cacheutil.PurgeLRUBytes(maxEntries, maxBytes)
+		}
+		maxSizeMB, _ := config.GetInt("cache.max-size-mb")
+		return cacheutil.PurgeLRU(maxEntries, maxSizeMB)
+	}
+	return s.Purge(nil)
 }
@@ -0,0 +1,15 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package statemgr holds the minimal state-handle interface shared between
+// backend.Configurable and each backend's own StateMgr implementation. It is
+// a leaf package (no dependency on backend or any backend/* package) so that
+// both sides can reference the same type without an import cycle.
+package statemgr
+
+// Reader is a minimal analog of Terraform's statemgr.Reader: a handle on a
+// single workspace's state that can be read. tfctl is read-only, so it omits
+// Terraform's RefreshState/WriteState/PersistState/Lock.
+type Reader interface {
+	State() ([]byte, error)
+}
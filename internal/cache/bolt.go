@@ -0,0 +1,185 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"go.etcd.io/bbolt"
+)
+
+// boltBucket is the single bucket every namespace/key lives in; namespacing
+// is done by key prefix (see namespaceKey), not by bucket.
+var boltBucket = []byte("cache")
+
+// boltStore is a single-file cache backed by a local BoltDB database,
+// useful where fsStore's many small framed files are awkward - a networked
+// or home-dir filesystem with slow MkdirAll/stat, or a CI runner that wants
+// one cache artifact to upload/download as a unit rather than a directory
+// tree.
+type boltStore struct {
+	db *bbolt.DB
+}
+
+// newBoltStore opens (creating if necessary) the BoltDB file at path.
+func newBoltStore(path string) (*boltStore, error) {
+	db, err := bbolt.Open(path, 0o600, &bbolt.Options{Timeout: 5 * time.Second}) //nolint:mnd
+	if err != nil {
+		return nil, fmt.Errorf("cache: bolt open %s: %w", path, err)
+	}
+	if err := db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(boltBucket)
+		return err
+	}); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("cache: bolt init %s: %w", path, err)
+	}
+	return &boltStore{db: db}, nil
+}
+
+func (s *boltStore) Get(ns []string, key string) (Entry, bool, error) {
+	nk := []byte(namespaceKey(ns, key))
+
+	var entry Entry
+	var found bool
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(boltBucket)
+		raw := b.Get(nk)
+		if raw == nil {
+			return nil
+		}
+
+		e, err := decodeBoltEntry(key, raw)
+		if err != nil {
+			return b.Delete(nk)
+		}
+		if !e.ExpiresAt.IsZero() && time.Now().After(e.ExpiresAt) {
+			return b.Delete(nk)
+		}
+
+		entry, found = e, true
+		return nil
+	})
+	if err != nil {
+		return Entry{}, false, fmt.Errorf("cache: bolt get %s: %w", nk, err)
+	}
+	return entry, found, nil
+}
+
+func (s *boltStore) Put(ns []string, key string, data []byte, opts PutOptions) error {
+	entry := Entry{Key: key, Data: data, CreatedAt: time.Now()}
+	if opts.TTL > 0 {
+		entry.ExpiresAt = entry.CreatedAt.Add(opts.TTL)
+	}
+
+	nk := []byte(namespaceKey(ns, key))
+	raw := encodeBoltEntry(entry)
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(boltBucket).Put(nk, raw)
+	})
+}
+
+func (s *boltStore) Delete(ns []string, key string) error {
+	nk := []byte(namespaceKey(ns, key))
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(boltBucket).Delete(nk)
+	})
+}
+
+// Purge removes every key under ns, if given. With no namespace it instead
+// sweeps the whole store for TTL-expired or unreadable entries - bbolt has
+// no separate background janitor, so this stands in for the
+// cacheutil.Purge/PurgeCorrupt passes of the filesystem store. It does not
+// read cache.clean: an entry put without a TTL is never removed by age.
+func (s *boltStore) Purge(ns []string) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(boltBucket)
+		c := b.Cursor()
+
+		if len(ns) > 0 {
+			prefix := []byte(namespaceKey(ns, ""))
+			var stale [][]byte
+			for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+				stale = append(stale, append([]byte{}, k...))
+			}
+			return deleteKeys(b, stale)
+		}
+
+		var stale [][]byte
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			e, err := decodeBoltEntry("", v)
+			if err != nil || (!e.ExpiresAt.IsZero() && time.Now().After(e.ExpiresAt)) {
+				stale = append(stale, append([]byte{}, k...))
+			}
+		}
+		return deleteKeys(b, stale)
+	})
+}
+
+func deleteKeys(b *bbolt.Bucket, keys [][]byte) error {
+	for _, k := range keys {
+		if err := b.Delete(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *boltStore) Stat(ns []string) (Stats, error) {
+	prefix := []byte(namespaceKey(ns, ""))
+
+	var stats Stats
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		c := tx.Bucket(boltBucket).Cursor()
+		for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			e, err := decodeBoltEntry("", v)
+			if err != nil {
+				continue
+			}
+			stats.Entries++
+			stats.Bytes += int64(len(v))
+			if stats.Oldest.IsZero() || e.CreatedAt.Before(stats.Oldest) {
+				stats.Oldest = e.CreatedAt
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return Stats{}, fmt.Errorf("cache: bolt stat: %w", err)
+	}
+	return stats, nil
+}
+
+// encodeBoltEntry packs createdAt and expiresAt ahead of the payload as two
+// fixed 8-byte big-endian unix timestamps (expiresAt 0 meaning no TTL), the
+// same length-prefixed-fields spirit as cacheutil's frame format, sized down
+// for a value that's already inside a transactionally-checksummed B+tree.
+func encodeBoltEntry(e Entry) []byte {
+	buf := make([]byte, 16+len(e.Data)) //nolint:mnd
+	binary.BigEndian.PutUint64(buf[0:8], uint64(e.CreatedAt.Unix()))
+	var expires int64
+	if !e.ExpiresAt.IsZero() {
+		expires = e.ExpiresAt.Unix()
+	}
+	binary.BigEndian.PutUint64(buf[8:16], uint64(expires))
+	copy(buf[16:], e.Data)
+	return buf
+}
+
+func decodeBoltEntry(key string, raw []byte) (Entry, error) {
+	if len(raw) < 16 { //nolint:mnd
+		return Entry{}, fmt.Errorf("cache: bolt: truncated entry")
+	}
+	createdAt := time.Unix(int64(binary.BigEndian.Uint64(raw[0:8])), 0)
+	expiresUnix := int64(binary.BigEndian.Uint64(raw[8:16]))
+	var expiresAt time.Time
+	if expiresUnix > 0 {
+		expiresAt = time.Unix(expiresUnix, 0)
+	}
+	data := append([]byte{}, raw[16:]...)
+	return Entry{Key: key, Data: data, CreatedAt: createdAt, ExpiresAt: expiresAt}, nil
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cache defines a pluggable Store interface for caching remote
+// query results (paginated TFE API responses, state version bodies, and
+// the like), with filesystem, in-memory, and network-backed
+// implementations selectable via config.Type's "cache.backend" key. This
+// sits above internal/cacheutil, which remains the on-disk framing/LRU
+// implementation the filesystem Store delegates to.
+package cache
+
+import (
+	"errors"
+	"time"
+)
+
+// Entry is a cached value returned by Store.Get.
+type Entry struct {
+	Key       string
+	Data      []byte
+	CreatedAt time.Time
+	// ExpiresAt is the zero time if the entry has no TTL.
+	ExpiresAt time.Time
+}
+
+// Stats reports usage for one namespace, as shown by `tfctl cache stats`.
+type Stats struct {
+	Entries int
+	Bytes   int64
+	Oldest  time.Time
+}
+
+// PutOptions controls how Store.Put writes an entry.
+type PutOptions struct {
+	// ContentType is stored with the entry for informational purposes (and,
+	// for the filesystem store, picked up by WriteCompressed's framing).
+	ContentType string
+	// Compressed requests payload compression, when the backend supports it
+	// (the filesystem store zstd-compresses; others may ignore this).
+	Compressed bool
+	// TTL is the entry's time-to-live. <= 0 means no TTL: the entry is only
+	// evicted by Purge's age-based or LRU sweep, matching tfctl's
+	// longstanding cache behavior.
+	TTL time.Duration
+}
+
+// Store is a pluggable cache backend, keyed by a namespace (e.g. a TFE
+// hostname+organization pair, or an S3 bucket/prefix/key triple) and a
+// clear-text key within it.
+type Store interface {
+	// Get returns the entry for key within ns. The second return value is
+	// false on a miss, including an entry that has outlived its TTL.
+	Get(ns []string, key string) (Entry, bool, error)
+	// Put stores data for key within ns, per opts.
+	Put(ns []string, key string, data []byte, opts PutOptions) error
+	// Delete removes a single entry, if present. A missing entry is not an
+	// error.
+	Delete(ns []string, key string) error
+	// Purge evicts entries from ns: if ns is empty, it runs the backend's
+	// whole-store maintenance sweep (the filesystem store drops expired,
+	// corrupt, and over-budget entries; the memory store expired ones; the
+	// bolt store expired and unreadable ones; the redis and s3 stores return
+	// an error instead); if ns is given, every entry under that namespace is
+	// removed outright, e.g. to evict one stale TFE host/organization.
+	Purge(ns []string) error
+	// Stat reports usage for ns.
+	Stat(ns []string) (Stats, error)
+}
+
+// ErrUnsupported is returned by operations a Store implementation doesn't
+// support (e.g. Stat on a backend with no listing API).
+var ErrUnsupported = errors.New("cache: operation not supported by this backend")
@@ -0,0 +1,94 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"strings"
+
+	"github.com/tfctl/tfctl/internal/cacheutil"
+	"github.com/tfctl/tfctl/internal/config"
+)
+
+// fsStore is the default Store, delegating to cacheutil's framed,
+// checksummed, atomically-written on-disk entries. It's the same cache
+// tfctl has always used; the other Store implementations are newer,
+// pluggable alternatives.
+type fsStore struct{}
+
+func newFSStore() *fsStore {
+	return &fsStore{}
+}
+
+func (fsStore) Get(ns []string, key string) (Entry, bool, error) {
+	entry, ok := cacheutil.Read(ns, key)
+	if !ok {
+		return Entry{}, false, nil
+	}
+	return Entry{
+		Key:       entry.Key,
+		Data:      entry.Data,
+		ExpiresAt: entry.ExpiresAt,
+	}, true, nil
+}
+
+func (fsStore) Put(ns []string, key string, data []byte, opts PutOptions) error {
+	contentType := opts.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	switch {
+	case opts.Compressed && opts.TTL > 0:
+		return cacheutil.WriteCompressedTTL(ns, key, contentType, opts.TTL, data)
+	case opts.Compressed:
+		return cacheutil.WriteCompressed(ns, key, contentType, data)
+	case opts.TTL > 0:
+		return cacheutil.WriteTTL(ns, key, opts.TTL, data)
+	default:
+		return cacheutil.Write(ns, key, data)
+	}
+}
+
+func (fsStore) Delete(ns []string, key string) error {
+	return cacheutil.Delete(ns, key)
+}
+
+// Purge with ns empty runs the same TTL, corruption, and LRU passes `tfctl
+// cache` has always run over the whole cache. With ns given it removes
+// every entry in that partition, regardless of age.
+func (fsStore) Purge(ns []string) error {
+	if len(ns) > 0 {
+		return cacheutil.PurgeNamespace(ns)
+	}
+
+	cleanHours, _ := config.GetInt("cache.clean")
+	if err := cacheutil.Purge(cleanHours); err != nil {
+		return err
+	}
+	if err := cacheutil.PurgeCorrupt(); err != nil {
+		return err
+	}
+
+	maxEntries, _ := config.GetInt("cache.max-entries")
+	if maxBytes, err := config.GetByteSize("cache.max-bytes"); err == nil && maxBytes > 0 {
+		return cacheutil.PurgeLRUBytes(maxEntries, maxBytes)
+	}
+	maxSizeMB, _ := config.GetInt("cache.max-size-mb")
+	return cacheutil.PurgeLRU(maxEntries, maxSizeMB)
+}
+
+func (fsStore) Stat(ns []string) (Stats, error) {
+	entries, size, oldest, err := cacheutil.Stats(ns)
+	if err != nil {
+		return Stats{}, err
+	}
+	return Stats{Entries: entries, Bytes: size, Oldest: oldest}, nil
+}
+
+// namespaceKey joins a namespace slice into the single string some
+// non-filesystem backends use as a key prefix.
+func namespaceKey(ns []string, key string) string {
+	parts := append(append([]string{}, ns...), key)
+	return strings.Join(parts, "/")
+}
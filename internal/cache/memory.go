@@ -0,0 +1,168 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// memoryStore is an in-process LRU cache: entries are evicted, oldest
+// first, once maxBytes is exceeded. It does not persist across process
+// restarts, so it suits short-lived, single-invocation commands that want
+// to avoid re-fetching within one run rather than sharing results across
+// runs or users.
+type memoryStore struct {
+	mu       sync.Mutex
+	maxBytes int64
+	curBytes int64
+	order    *list.List // front = most recently used
+	index    map[string]*list.Element
+}
+
+type memoryItem struct {
+	namespaceKey string
+	entry        Entry
+	size         int64
+}
+
+// newMemoryStore returns a memoryStore evicting down to maxBytes whenever a
+// Put would exceed it. maxBytes <= 0 means unbounded.
+func newMemoryStore(maxBytes int64) *memoryStore {
+	return &memoryStore{
+		maxBytes: maxBytes,
+		order:    list.New(),
+		index:    make(map[string]*list.Element),
+	}
+}
+
+func (s *memoryStore) Get(ns []string, key string) (Entry, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	nk := namespaceKey(ns, key)
+	el, ok := s.index[nk]
+	if !ok {
+		return Entry{}, false, nil
+	}
+
+	item := el.Value.(*memoryItem)
+	if !item.entry.ExpiresAt.IsZero() && time.Now().After(item.entry.ExpiresAt) {
+		s.removeElement(el)
+		return Entry{}, false, nil
+	}
+
+	s.order.MoveToFront(el)
+	return item.entry, true, nil
+}
+
+func (s *memoryStore) Put(ns []string, key string, data []byte, opts PutOptions) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	nk := namespaceKey(ns, key)
+	if el, ok := s.index[nk]; ok {
+		s.removeElement(el)
+	}
+
+	entry := Entry{
+		Key:       key,
+		Data:      data,
+		CreatedAt: time.Now(),
+	}
+	if opts.TTL > 0 {
+		entry.ExpiresAt = entry.CreatedAt.Add(opts.TTL)
+	}
+
+	item := &memoryItem{namespaceKey: nk, entry: entry, size: int64(len(data))}
+	el := s.order.PushFront(item)
+	s.index[nk] = el
+	s.curBytes += item.size
+
+	s.evictToBudget()
+	return nil
+}
+
+func (s *memoryStore) Delete(ns []string, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if el, ok := s.index[namespaceKey(ns, key)]; ok {
+		s.removeElement(el)
+	}
+	return nil
+}
+
+// Purge drops every entry under ns. With ns empty it is the maintenance
+// sweep: it drops only entries that have outlived their TTL, since the byte
+// budget is already enforced on every Put and there are no corrupt entries
+// to find in memory.
+func (s *memoryStore) Purge(ns []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(ns) == 0 {
+		now := time.Now()
+		for _, el := range s.index {
+			item := el.Value.(*memoryItem)
+			if !item.entry.ExpiresAt.IsZero() && now.After(item.entry.ExpiresAt) {
+				s.removeElement(el)
+			}
+		}
+		return nil
+	}
+
+	prefix := namespaceKey(ns, "")
+	for nk, el := range s.index {
+		if len(nk) >= len(prefix) && nk[:len(prefix)] == prefix {
+			s.removeElement(el)
+		}
+	}
+	return nil
+}
+
+func (s *memoryStore) Stat(ns []string) (Stats, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prefix := namespaceKey(ns, "")
+	var stats Stats
+	for el := s.order.Front(); el != nil; el = el.Next() {
+		item := el.Value.(*memoryItem)
+		if len(ns) > 0 && (len(item.namespaceKey) < len(prefix) || item.namespaceKey[:len(prefix)] != prefix) {
+			continue
+		}
+		stats.Entries++
+		stats.Bytes += item.size
+		if stats.Oldest.IsZero() || item.entry.CreatedAt.Before(stats.Oldest) {
+			stats.Oldest = item.entry.CreatedAt
+		}
+	}
+	return stats, nil
+}
+
+// removeElement drops el from both the LRU list and the index, adjusting
+// curBytes. Callers must hold s.mu.
+func (s *memoryStore) removeElement(el *list.Element) {
+	item := el.Value.(*memoryItem)
+	s.order.Remove(el)
+	delete(s.index, item.namespaceKey)
+	s.curBytes -= item.size
+}
+
+// evictToBudget removes least-recently-used entries until curBytes fits
+// maxBytes. Callers must hold s.mu.
+func (s *memoryStore) evictToBudget() {
+	if s.maxBytes <= 0 {
+		return
+	}
+	for s.curBytes > s.maxBytes {
+		oldest := s.order.Back()
+		if oldest == nil {
+			break
+		}
+		s.removeElement(oldest)
+	}
+}
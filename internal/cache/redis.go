@@ -0,0 +1,226 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// redisStore is a shared network cache backed by a Redis (or Redis-protocol
+// compatible) server, letting teammates sharing an org hit warm
+// workspace/state-version listings without each re-paginating TFE
+// themselves. It speaks RESP directly over net.Dial rather than pulling in
+// a client library, since all it needs is SET/GET/DEL/KEYS.
+type redisStore struct {
+	addr     string
+	password string
+	timeout  time.Duration
+}
+
+// newRedisStore returns a redisStore dialing addr (host:port) for every
+// operation. password may be empty.
+func newRedisStore(addr, password string) *redisStore {
+	return &redisStore{addr: addr, password: password, timeout: 5 * time.Second}
+}
+
+func (s *redisStore) Get(ns []string, key string) (Entry, bool, error) {
+	conn, err := s.dial()
+	if err != nil {
+		return Entry{}, false, err
+	}
+	defer conn.Close()
+
+	reply, err := conn.do("GET", namespaceKey(ns, key))
+	if err != nil {
+		return Entry{}, false, err
+	}
+	if reply.isNil {
+		return Entry{}, false, nil
+	}
+
+	return Entry{Key: key, Data: []byte(reply.bulk)}, true, nil
+}
+
+func (s *redisStore) Put(ns []string, key string, data []byte, opts PutOptions) error {
+	conn, err := s.dial()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	nk := namespaceKey(ns, key)
+	if opts.TTL > 0 {
+		_, err = conn.do("SET", nk, string(data), "PX", strconv.FormatInt(opts.TTL.Milliseconds(), 10))
+	} else {
+		_, err = conn.do("SET", nk, string(data))
+	}
+	return err
+}
+
+func (s *redisStore) Delete(ns []string, key string) error {
+	conn, err := s.dial()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = conn.do("DEL", namespaceKey(ns, key))
+	return err
+}
+
+// Purge deletes every key under ns (or, if ns is empty, refuses: FLUSHDB
+// would affect every other tfctl user sharing this Redis, which is never
+// what a single invocation's cleanup should do).
+func (s *redisStore) Purge(ns []string) error {
+	if len(ns) == 0 {
+		return fmt.Errorf("cache: redis backend requires a namespace to purge, refusing to flush the whole server")
+	}
+
+	conn, err := s.dial()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	reply, err := conn.do("KEYS", namespaceKey(ns, "")+"*")
+	if err != nil {
+		return err
+	}
+	for _, k := range reply.array {
+		if _, err := conn.do("DEL", k.bulk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Stat reports the entry count under ns via KEYS; byte size and oldest
+// entry aren't tracked by this minimal client, so those fields are left
+// zero.
+func (s *redisStore) Stat(ns []string) (Stats, error) {
+	conn, err := s.dial()
+	if err != nil {
+		return Stats{}, err
+	}
+	defer conn.Close()
+
+	reply, err := conn.do("KEYS", namespaceKey(ns, "")+"*")
+	if err != nil {
+		return Stats{}, err
+	}
+	return Stats{Entries: len(reply.array)}, nil
+}
+
+// redisConn is a single short-lived RESP connection.
+type redisConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (s *redisStore) dial() (*redisConn, error) {
+	c, err := net.DialTimeout("tcp", s.addr, s.timeout)
+	if err != nil {
+		return nil, fmt.Errorf("cache: redis dial %s: %w", s.addr, err)
+	}
+	conn := &redisConn{Conn: c, r: bufio.NewReader(c)}
+	_ = conn.SetDeadline(time.Now().Add(s.timeout))
+
+	if s.password != "" {
+		if _, err := conn.do("AUTH", s.password); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	return conn, nil
+}
+
+// redisReply is the subset of RESP reply shapes this client needs.
+type redisReply struct {
+	bulk  string
+	array []redisReply
+	isNil bool
+}
+
+// do sends a RESP array command and parses the reply.
+func (c *redisConn) do(args ...string) (redisReply, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%d\r\n", len(args))
+	for _, a := range args {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(a), a)
+	}
+	if _, err := c.Write([]byte(b.String())); err != nil {
+		return redisReply{}, fmt.Errorf("cache: redis write: %w", err)
+	}
+	return c.readReply()
+}
+
+// readReply parses one RESP value from the connection.
+func (c *redisConn) readReply() (redisReply, error) {
+	line, err := c.r.ReadString('\n')
+	if err != nil {
+		return redisReply{}, fmt.Errorf("cache: redis read: %w", err)
+	}
+	line = strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+	if line == "" {
+		return redisReply{}, fmt.Errorf("cache: redis: empty reply line")
+	}
+
+	switch line[0] {
+	case '+': // simple string
+		return redisReply{bulk: line[1:]}, nil
+	case '-': // error
+		return redisReply{}, fmt.Errorf("cache: redis error: %s", line[1:])
+	case ':': // integer
+		return redisReply{bulk: line[1:]}, nil
+	case '$': // bulk string
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return redisReply{}, fmt.Errorf("cache: redis: bad bulk length %q: %w", line[1:], err)
+		}
+		if n < 0 {
+			return redisReply{isNil: true}, nil
+		}
+		buf := make([]byte, n+2) // payload + trailing CRLF
+		if _, err := readFull(c.r, buf); err != nil {
+			return redisReply{}, fmt.Errorf("cache: redis: bad bulk payload: %w", err)
+		}
+		return redisReply{bulk: string(buf[:n])}, nil
+	case '*': // array
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return redisReply{}, fmt.Errorf("cache: redis: bad array length %q: %w", line[1:], err)
+		}
+		if n < 0 {
+			return redisReply{isNil: true}, nil
+		}
+		arr := make([]redisReply, n)
+		for i := range arr {
+			el, err := c.readReply()
+			if err != nil {
+				return redisReply{}, err
+			}
+			arr[i] = el
+		}
+		return redisReply{array: arr}, nil
+	default:
+		return redisReply{}, fmt.Errorf("cache: redis: unexpected reply type %q", line[0])
+	}
+}
+
+func readFull(r *bufio.Reader, buf []byte) (int, error) {
+	total := 0
+	for total < len(buf) {
+		n, err := r.Read(buf[total:])
+		total += n
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
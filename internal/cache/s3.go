@@ -0,0 +1,151 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// httpStore is a shared network cache backed by a plain HTTP PUT/GET/DELETE
+// gateway (e.g. a small object-store proxy sitting in front of an actual S3
+// bucket). It is not an AWS SigV4 client: it assumes baseURL already points
+// somewhere that accepts unsigned (or HTTP Basic Auth'd) requests, so teams
+// that want real S3 should put one of those gateways, or something
+// compatible, in front of it.
+type httpStore struct {
+	baseURL  string
+	user     string
+	password string
+	client   *http.Client
+}
+
+// newHTTPStore returns an httpStore issuing requests against baseURL
+// (no trailing slash expected). user/password are sent as HTTP Basic Auth
+// when user is non-empty.
+func newHTTPStore(baseURL, user, password string) *httpStore {
+	return &httpStore{
+		baseURL:  baseURL,
+		user:     user,
+		password: password,
+		client:   &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (s *httpStore) url(ns []string, key string) string {
+	return s.baseURL + "/" + namespaceKey(ns, key)
+}
+
+func (s *httpStore) newRequest(method, url string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return nil, fmt.Errorf("cache: s3 request: %w", err)
+	}
+	if s.user != "" {
+		req.SetBasicAuth(s.user, s.password)
+	}
+	return req, nil
+}
+
+func (s *httpStore) Get(ns []string, key string) (Entry, bool, error) {
+	req, err := s.newRequest(http.MethodGet, s.url(ns, key), nil)
+	if err != nil {
+		return Entry{}, false, err
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return Entry{}, false, fmt.Errorf("cache: s3 get %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return Entry{}, false, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return Entry{}, false, fmt.Errorf("cache: s3 get %s: unexpected status %s", key, resp.Status)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return Entry{}, false, fmt.Errorf("cache: s3 get %s: %w", key, err)
+	}
+	return Entry{Key: key, Data: data}, true, nil
+}
+
+func (s *httpStore) Put(ns []string, key string, data []byte, opts PutOptions) error {
+	req, err := s.newRequest(http.MethodPut, s.url(ns, key), bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	if opts.ContentType != "" {
+		req.Header.Set("Content-Type", opts.ContentType)
+	}
+	if opts.TTL > 0 {
+		req.Header.Set("X-Cache-TTL-Seconds", fmt.Sprintf("%d", int64(opts.TTL.Seconds())))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("cache: s3 put %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("cache: s3 put %s: unexpected status %s", key, resp.Status)
+	}
+	return nil
+}
+
+func (s *httpStore) Delete(ns []string, key string) error {
+	req, err := s.newRequest(http.MethodDelete, s.url(ns, key), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("cache: s3 delete %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("cache: s3 delete %s: unexpected status %s", key, resp.Status)
+	}
+	return nil
+}
+
+// Purge deletes every object under ns by requesting a DELETE on the
+// namespace "directory" itself; it relies on the gateway recursing, since a
+// plain PUT/GET/DELETE surface has no listing verb to walk ourselves.
+func (s *httpStore) Purge(ns []string) error {
+	if len(ns) == 0 {
+		return ErrUnsupported
+	}
+
+	req, err := s.newRequest(http.MethodDelete, s.baseURL+"/"+namespaceKey(ns, ""), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("cache: s3 purge %s: %w", namespaceKey(ns, ""), err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("cache: s3 purge %s: unexpected status %s", namespaceKey(ns, ""), resp.Status)
+	}
+	return nil
+}
+
+// Stat is unsupported: a plain PUT/GET/DELETE gateway has no listing API to
+// aggregate usage from.
+func (s *httpStore) Stat(ns []string) (Stats, error) {
+	return Stats{}, ErrUnsupported
+}
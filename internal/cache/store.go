@@ -0,0 +1,48 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"fmt"
+
+	"github.com/tfctl/tfctl/internal/config"
+)
+
+// NewStore builds the Store selected by the "cache.backend" config key
+// ("fs", the default; "memory"; "redis"; "s3"; or "bolt"), reading whatever
+// backend-specific keys that choice needs.
+func NewStore() (Store, error) {
+	backend, _ := config.GetString("cache.backend", "fs")
+
+	switch backend {
+	case "", "fs":
+		return newFSStore(), nil
+	case "memory":
+		maxSizeMB, _ := config.GetInt("cache.max-size-mb")
+		return newMemoryStore(int64(maxSizeMB) * 1024 * 1024), nil
+	case "bolt":
+		path, _ := config.GetString("cache.url")
+		if path == "" {
+			return nil, fmt.Errorf("cache: cache.backend is %q but cache.url is not set", backend)
+		}
+		return newBoltStore(path)
+	case "redis":
+		addr, _ := config.GetString("cache.url")
+		if addr == "" {
+			return nil, fmt.Errorf("cache: cache.backend is %q but cache.url is not set", backend)
+		}
+		password, _ := config.GetString("cache.password")
+		return newRedisStore(addr, password), nil
+	case "s3":
+		baseURL, _ := config.GetString("cache.url")
+		if baseURL == "" {
+			return nil, fmt.Errorf("cache: cache.backend is %q but cache.url is not set", backend)
+		}
+		user, _ := config.GetString("cache.user")
+		password, _ := config.GetString("cache.password")
+		return newHTTPStore(baseURL, user, password), nil
+	default:
+		return nil, fmt.Errorf("cache: unknown cache.backend %q", backend)
+	}
+}
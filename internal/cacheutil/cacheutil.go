@@ -10,18 +10,24 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/tfctl/tfctl/internal/log"
 )
 
 // Entry represents a cached artifact on disk.
-// Key is the clear-text key; EncodedKey is the hashed filename.
+// Key is the clear-text key; EncodedKey is the hashed filename. ExpiresAt is
+// the zero time if the entry was written without a TTL (Write/WriteCompressed),
+// meaning it only expires via Purge's age-based sweep.
 type Entry struct {
 	Key        string
 	EncodedKey string
 	Path       string
 	Data       []byte
+	ExpiresAt  time.Time
 }
 
 // Dir resolves the base cache directory.
@@ -82,7 +88,108 @@ func EntryPath(subdirs []string, clearKey string) (string, bool) {
 	return p, false
 }
 
-// Purge removes files older than the provided number of hours.
+// Delete removes a single cache entry, if present. A missing entry is not an
+// error.
+func Delete(subdirs []string, clearKey string) error {
+	p, exists := EntryPath(subdirs, clearKey)
+	if !exists {
+		return nil
+	}
+	if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete cache entry: %w", err)
+	}
+	if err := os.Remove(lockPath(p)); err != nil && !os.IsNotExist(err) {
+		log.WithError(err).Debugf("failed to remove cache lock file %s", lockPath(p))
+	}
+	return nil
+}
+
+// ListedEntry is one on-disk cache entry as reported by `tfctl cache ls`.
+// EncodedKey is the hashed filename: cleartext keys aren't recoverable from
+// disk once written, so entries are addressed by it (see RemoveEntry).
+type ListedEntry struct {
+	Partition  string
+	EncodedKey string
+	Size       int64
+	ModTime    time.Time
+}
+
+// List returns every on-disk entry beneath subdirs (or the whole cache, if
+// subdirs is empty), for `tfctl cache ls`.
+func List(subdirs []string) ([]ListedEntry, error) {
+	base, ok := Dir()
+	if !ok {
+		return nil, nil
+	}
+	dir := filepath.Join(append([]string{base}, subdirs...)...)
+
+	var out []ListedEntry
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, walkErr error) error {
+		if walkErr != nil {
+			if os.IsNotExist(walkErr) {
+				return nil
+			}
+			return walkErr
+		}
+		if info == nil || info.IsDir() || isLockFile(path) {
+			return nil
+		}
+
+		rel, err := filepath.Rel(base, path)
+		if err != nil {
+			return err
+		}
+		out = append(out, ListedEntry{
+			Partition:  filepath.Dir(rel),
+			EncodedKey: filepath.Base(rel),
+			Size:       info.Size(),
+			ModTime:    info.ModTime(),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cache entries: %w", err)
+	}
+	return out, nil
+}
+
+// RemoveEntry deletes one on-disk entry by its encoded (EntryPath/List)
+// filename within subdirs, for `tfctl cache rm`. A missing entry is not an
+// error.
+func RemoveEntry(subdirs []string, encodedKey string) error {
+	base, ok := Dir()
+	if !ok {
+		return nil
+	}
+	p := filepath.Join(append([]string{base}, append(subdirs, encodedKey)...)...)
+	if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove cache entry: %w", err)
+	}
+	if err := os.Remove(lockPath(p)); err != nil && !os.IsNotExist(err) {
+		log.WithError(err).Debugf("failed to remove cache lock file %s", lockPath(p))
+	}
+	return nil
+}
+
+// PurgeNamespace removes every entry beneath subdirs, regardless of age,
+// e.g. to fully evict one TFE host/organization or S3 bucket/prefix/key
+// partition.
+func PurgeNamespace(subdirs []string) error {
+	base, ok := Dir()
+	if !ok {
+		return nil
+	}
+	dir := filepath.Join(append([]string{base}, subdirs...)...)
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("failed to purge cache partition %s: %w", dir, err)
+	}
+	return nil
+}
+
+// Purge removes files older than the provided number of hours. A file whose
+// advisory lock (see withExclusiveLock) is currently held by another process
+// is left alone rather than contended for - it's simply picked up by a later
+// sweep once that write finishes.
 // If hours <= 0 or the cache dir cannot be resolved, it is a no-op.
 func Purge(hours int) error {
 	if hours <= 0 {
@@ -107,16 +214,12 @@ func Purge(hours int) error {
 			return walkErr
 		}
 
-		if info == nil {
+		if info == nil || info.IsDir() || isLockFile(path) {
 			return nil
 		}
 
-		if !info.IsDir() && time.Since(info.ModTime()) > maxAge {
-			if err := os.Remove(path); err == nil {
-				log.Debugf("removed cache file %s", path)
-			} else {
-				log.WithError(err).Warnf("failed to remove cache file %s", path)
-			}
+		if time.Since(info.ModTime()) > maxAge {
+			removeIfUnlocked(path, "purge")
 		}
 		return nil
 	}); err != nil {
@@ -125,32 +228,367 @@ func Purge(hours int) error {
 	return nil
 }
 
-// Read attempts to read a cached entry.
+// counterHits/counterMisses/counterRevalidations/counterBytes track this
+// process's cache activity since startup, for CounterSnapshot and the
+// top-level --cache-stats flag. Unlike Stats, which reports a partition's
+// persisted on-disk footprint, these describe what this one invocation did
+// with the cache and are never written to disk.
+var (
+	counterHits          atomic.Int64
+	counterMisses        atomic.Int64
+	counterRevalidations atomic.Int64
+	counterBytes         atomic.Int64
+)
+
+// CounterStats is a snapshot of this process's cache activity, as reported
+// by CounterSnapshot.
+type CounterStats struct {
+	Hits          int64
+	Misses        int64
+	Revalidations int64
+	Bytes         int64
+}
+
+// CounterSnapshot returns this process's cache hit/miss/revalidation counts
+// and cumulative bytes served from cache so far.
+func CounterSnapshot() CounterStats {
+	return CounterStats{
+		Hits:          counterHits.Load(),
+		Misses:        counterMisses.Load(),
+		Revalidations: counterRevalidations.Load(),
+		Bytes:         counterBytes.Load(),
+	}
+}
+
+// RecordRevalidation records a cache entry that was validated rather than
+// re-downloaded, e.g. remote.Hitter's conditional GET receiving a 304 Not
+// Modified. That path never calls Read (it already knows its entry is
+// fresh), so it reports the revalidation here instead.
+func RecordRevalidation() {
+	counterRevalidations.Add(1)
+}
+
+// Stats reports the number of entries, their total size in bytes, and the
+// oldest entry's mtime for the partition identified by subdirs. Used by
+// `tfctl cache stats` to show per-bucket/prefix/key usage.
+func Stats(subdirs []string) (entries int, size int64, oldest time.Time, err error) {
+	base, ok := Dir()
+	if !ok {
+		return 0, 0, time.Time{}, nil
+	}
+
+	dir := filepath.Join(append([]string{base}, subdirs...)...)
+	err = filepath.Walk(dir, func(path string, info os.FileInfo, walkErr error) error {
+		if walkErr != nil {
+			if os.IsNotExist(walkErr) {
+				return nil
+			}
+			return walkErr
+		}
+		if info == nil || info.IsDir() || isLockFile(path) {
+			return nil
+		}
+
+		entries++
+		size += info.Size()
+		if oldest.IsZero() || info.ModTime().Before(oldest) {
+			oldest = info.ModTime()
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, 0, time.Time{}, fmt.Errorf("failed to stat cache partition: %w", err)
+	}
+	return entries, size, oldest, nil
+}
+
+// PurgeLRU evicts least-recently-accessed entries from each `sub` partition
+// (the directory one level below the cache base, e.g. the S3 bucket name or
+// TFE host) until it satisfies maxEntries and maxSizeMB. Either budget <= 0
+// disables that check. mtime is the recency signal: readLocked bumps it on
+// every hit, so it reflects last access, not just last write. See
+// PurgeLRUBytes for a byte-precise size budget.
+func PurgeLRU(maxEntries int, maxSizeMB int) error {
+	return PurgeLRUBytes(maxEntries, int64(maxSizeMB)*1024*1024) //nolint:mnd
+}
+
+// PurgeLRUBytes is PurgeLRU with a byte-precise size budget rather than
+// megabyte granularity, for callers resolving cache.max-bytes (a
+// human-readable size like "1GiB" via config.GetByteSize) instead of the
+// legacy cache.max-size-mb.
+func PurgeLRUBytes(maxEntries int, maxBytes int64) error {
+	if maxEntries <= 0 && maxBytes <= 0 {
+		log.Debug("cache LRU eviction disabled")
+		return nil
+	}
+
+	base, ok := Dir()
+	if !ok {
+		return nil
+	}
+
+	partitions, err := os.ReadDir(base)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to list cache partitions: %w", err)
+	}
+
+	for _, partition := range partitions {
+		if !partition.IsDir() {
+			continue
+		}
+		if err := purgePartitionLRU(filepath.Join(base, partition.Name()), maxEntries, maxBytes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// purgePartitionLRU walks a single partition, collects every file with its
+// mtime, and removes the oldest until both budgets are satisfied.
+func purgePartitionLRU(dir string, maxEntries int, maxSize int64) error {
+	type fileInfo struct {
+		path    string
+		size    int64
+		modTime time.Time
+	}
+	var files []fileInfo
+	var total int64
+
+	if err := filepath.Walk(dir, func(path string, info os.FileInfo, walkErr error) error {
+		if walkErr != nil {
+			if os.IsNotExist(walkErr) {
+				return nil
+			}
+			return walkErr
+		}
+		if info == nil || info.IsDir() || isLockFile(path) {
+			return nil
+		}
+		files = append(files, fileInfo{path: path, size: info.Size(), modTime: info.ModTime()})
+		total += info.Size()
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to walk cache partition %s: %w", dir, err)
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].modTime.Before(files[j].modTime)
+	})
+
+	remaining := len(files)
+	for _, f := range files {
+		overEntries := maxEntries > 0 && remaining > maxEntries
+		overSize := maxSize > 0 && total > maxSize
+		if !overEntries && !overSize {
+			break
+		}
+
+		// A file currently being written holds its lock, so skip it rather
+		// than contend with the writer - it's picked up by a later sweep
+		// once that write finishes.
+		fl, acquired, err := tryExclusiveLock(f.path)
+		if err != nil {
+			log.WithError(err).Debugf("failed to check cache entry lock: path=%s", f.path)
+			continue
+		}
+		if !acquired {
+			log.Debugf("skipping lru eviction of locked cache file %s", f.path)
+			continue
+		}
+
+		if err := os.Remove(f.path); err != nil {
+			fl.Unlock() //nolint:errcheck
+			log.WithError(err).Warnf("failed to evict cache file %s", f.path)
+			continue
+		}
+		fl.Unlock() //nolint:errcheck
+		if err := os.Remove(lockPath(f.path)); err != nil && !os.IsNotExist(err) {
+			log.WithError(err).Debugf("failed to remove cache lock file %s", lockPath(f.path))
+		}
+		log.Debugf("evicted lru cache file %s", f.path)
+		total -= f.size
+		remaining--
+	}
+	return nil
+}
+
+// sweepInterval is the minimum time between full Purge/PurgeCorrupt/PurgeLRU
+// sweeps triggered opportunistically via ShouldSweep.
+const sweepInterval = 5 * time.Minute
+
+var (
+	lastSweepMu sync.Mutex
+	lastSweep   time.Time
+)
+
+// ShouldSweep reports whether enough time has passed since the last sweep to
+// run the full Purge/PurgeCorrupt/PurgeLRU pass again, and if so, records now
+// as the new last-swept time. Backends' PurgeCache methods call this before
+// running that pass from a hot read path (e.g. every StateBody call), so a
+// single busy invocation doesn't re-walk the whole cache tree per state
+// fetch; an explicit "tfctl cache prune" should call Purge/PurgeCorrupt/
+// PurgeLRU directly instead, bypassing the debounce.
+func ShouldSweep() bool {
+	lastSweepMu.Lock()
+	defer lastSweepMu.Unlock()
+
+	if time.Since(lastSweep) < sweepInterval {
+		return false
+	}
+	lastSweep = time.Now()
+	return true
+}
+
+// Read attempts to read a cached entry, holding a shared advisory lock (see
+// withSharedLock) for the duration so it never observes a write that's still
+// in flight. Entries are framed (see Write); if the frame's checksum does
+// not match its payload the entry is treated as a cache miss (and a debug
+// line is logged) rather than returned corrupted.
 func Read(subdirs []string, clearKey string) (*Entry, bool) {
 	if !Enabled() {
 		return nil, false
 	}
 	p, ok := EntryPath(subdirs, clearKey)
 	if !ok {
+		counterMisses.Add(1)
+		return nil, false
+	}
+
+	var entry *Entry
+	var hit bool
+	if err := withSharedLock(p, func() error {
+		entry, hit = readLocked(p, clearKey)
+		return nil
+	}); err != nil {
+		log.WithError(err).Debugf("failed to acquire cache read lock: key=%s", clearKey)
+		counterMisses.Add(1)
+		return nil, false
+	}
+	return entry, hit
+}
+
+// readLocked is Read's body once the caller holds an appropriate lock on p.
+func readLocked(p, clearKey string) (*Entry, bool) {
+	raw, err := os.ReadFile(p)
+	if err != nil {
+		counterMisses.Add(1)
 		return nil, false
 	}
-	b, err := os.ReadFile(p)
+
+	hdr, payload, err := decodeFrame(raw)
 	if err != nil {
+		log.Debugf("cache entry unparseable: key=%s err=%v", clearKey, err)
+		counterMisses.Add(1)
+		return nil, false
+	}
+
+	if !verifyChecksum(hdr.Checksum, payload) {
+		log.Debugf("cache checksum mismatch: key=%s", clearKey)
+		counterMisses.Add(1)
+		return nil, false
+	}
+
+	if hdr.Expired(time.Now()) {
+		log.Debugf("cache entry expired: key=%s", clearKey)
+		counterMisses.Add(1)
+		return nil, false
+	}
+
+	if stale, ok := hdr.StaleInput(); ok {
+		log.Debugf("cache entry invalidated by changed input: key=%s kind=%s name=%s", clearKey, stale.Kind, stale.Name)
+		counterMisses.Add(1)
+		if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+			log.WithError(err).Debugf("failed to remove invalidated cache entry: key=%s", clearKey)
+		}
 		return nil, false
 	}
-	b = bytes.TrimSpace(b)
+
+	counterHits.Add(1)
+	counterBytes.Add(int64(len(payload)))
+
+	// Bump mtime on every hit so PurgeLRU's recency signal reflects last
+	// access, not just last write.
+	now := time.Now()
+	if err := os.Chtimes(p, now, now); err != nil {
+		log.WithError(err).Debugf("failed to bump cache entry mtime: key=%s", clearKey)
+	}
+
+	var expiresAt time.Time
+	if hdr.TTL > 0 {
+		expiresAt = hdr.CreatedAt.Add(hdr.TTL)
+	}
+
 	encoded := encodeKey(clearKey)
 	log.Debugf("cache hit: key=%s", clearKey)
 	return &Entry{
 		Key:        clearKey,
 		EncodedKey: encoded,
 		Path:       p,
-		Data:       b,
+		Data:       payload,
+		ExpiresAt:  expiresAt,
 	}, true
 }
 
-// Write stores data for the given key beneath subdirs. Creates directories as needed.
+// Write stores data for the given key beneath subdirs, framed with a small
+// header (magic, version, content-type, created-at, SHA-256 of payload).
+// Creates directories as needed and writes atomically via os.CreateTemp
+// followed by os.Rename. The entry never expires on its own; see WriteTTL
+// for a per-key expiry.
 func Write(subdirs []string, clearKey string, data []byte) error {
+	return writeFramed(subdirs, clearKey, "application/octet-stream", false, 0, nil, data)
+}
+
+// WriteTTL is Write with a per-key time-to-live: Read treats the entry as a
+// miss once ttl has elapsed since it was written, independent of Purge's
+// global age-based sweep. ttl <= 0 means no expiry, matching Write.
+func WriteTTL(subdirs []string, clearKey string, ttl time.Duration, data []byte) error {
+	return writeFramed(subdirs, clearKey, "application/octet-stream", false, ttl, nil, data)
+}
+
+// WriteCompressed is a sibling of Write for callers with large payloads
+// (e.g. state files) that want zstd compression applied before the framed
+// entry is written to disk.
+func WriteCompressed(subdirs []string, clearKey string, contentType string, data []byte) error {
+	return writeFramed(subdirs, clearKey, contentType, true, 0, nil, data)
+}
+
+// WriteCompressedTTL combines WriteCompressed and WriteTTL.
+func WriteCompressedTTL(subdirs []string, clearKey string, contentType string, ttl time.Duration, data []byte) error {
+	return writeFramed(subdirs, clearKey, contentType, true, ttl, nil, data)
+}
+
+// Options bundles a cache entry's expiry and content-validation settings for
+// WriteWithOptions/WriteCompressedWithOptions.
+type Options struct {
+	// TTL is as WriteTTL's ttl: <= 0 means no expiry.
+	TTL time.Duration
+	// Inputs pins this entry's validity to external facts (env vars, files)
+	// beyond its own payload and TTL - see Input. A mismatch at Read time
+	// invalidates the entry immediately, regardless of TTL.
+	Inputs []Input
+}
+
+// WriteWithOptions is Write extended with Options: a TTL and/or a set of
+// Inputs (env vars, files) this entry's validity should be pinned to, so a
+// token rotation or a referenced config file changing invalidates it without
+// waiting for Purge's age-based sweep or a manual cache clear.
+func WriteWithOptions(subdirs []string, clearKey string, opts Options, data []byte) error {
+	return writeFramed(subdirs, clearKey, "application/octet-stream", false, opts.TTL, opts.Inputs, data)
+}
+
+// WriteCompressedWithOptions combines WriteCompressed and WriteWithOptions.
+func WriteCompressedWithOptions(subdirs []string, clearKey, contentType string, opts Options, data []byte) error {
+	return writeFramed(subdirs, clearKey, contentType, true, opts.TTL, opts.Inputs, data)
+}
+
+// writeFramed encodes data into a frame and writes it atomically, holding an
+// exclusive advisory lock (see withExclusiveLock) on the destination path for
+// the duration so two concurrent tfctl processes writing the same key can't
+// tear each other's contents.
+func writeFramed(subdirs []string, clearKey, contentType string, compressed bool, ttl time.Duration, inputs []Input, data []byte) error {
 	if !Enabled() {
 		return nil // treat as disabled.
 	}
@@ -163,12 +601,49 @@ func Write(subdirs []string, clearKey string, data []byte) error {
 	if err := os.MkdirAll(dir, 0o755); err != nil { //nolint:mnd
 		return fmt.Errorf("failed to create cache directory: %w", err)
 	}
+
 	p := filepath.Join(dir, encoded)
-	if err := os.WriteFile(p, data, os.FileMode(0o600)); err != nil { //nolint:mnd
-		return fmt.Errorf("failed to write to cache: %w", err)
-	}
-	log.Debugf("cache write: key=%s", clearKey)
-	return nil
+
+	return withExclusiveLock(p, func() error {
+		var buf bytes.Buffer
+		if err := encodeFrame(&buf, contentType, compressed, ttl, inputs, data); err != nil {
+			return fmt.Errorf("failed to frame cache entry: %w", err)
+		}
+
+		tmp, err := os.CreateTemp(dir, encoded+".tmp-*")
+		if err != nil {
+			return fmt.Errorf("failed to create temp cache file: %w", err)
+		}
+		tmpPath := tmp.Name()
+
+		if _, err := tmp.Write(buf.Bytes()); err != nil {
+			tmp.Close()
+			os.Remove(tmpPath)
+			return fmt.Errorf("failed to write to cache: %w", err)
+		}
+		if err := tmp.Sync(); err != nil {
+			tmp.Close()
+			os.Remove(tmpPath)
+			return fmt.Errorf("failed to sync cache file: %w", err)
+		}
+		if err := tmp.Chmod(0o600); err != nil { //nolint:mnd
+			tmp.Close()
+			os.Remove(tmpPath)
+			return fmt.Errorf("failed to chmod cache file: %w", err)
+		}
+		if err := tmp.Close(); err != nil {
+			os.Remove(tmpPath)
+			return fmt.Errorf("failed to close cache file: %w", err)
+		}
+
+		if err := os.Rename(tmpPath, p); err != nil {
+			os.Remove(tmpPath)
+			return fmt.Errorf("failed to finalize cache file: %w", err)
+		}
+
+		log.Debugf("cache write: key=%s compressed=%v", clearKey, compressed)
+		return nil
+	})
 }
 
 // sha256 returns a 32-byte digest.
@@ -6,6 +6,7 @@ package cacheutil
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -239,19 +240,15 @@ func TestRead_SuccessfulRead(t *testing.T) {
 	t.Setenv("TFCTL_CACHE_DIR", tmpDir)
 	t.Setenv("TFCTL_CACHE", "1")
 
-	// Create cache file
-	subdir := filepath.Join(tmpDir, "data")
-	err := os.MkdirAll(subdir, 0o755)
-	require.NoError(t, err)
-
 	testData := []byte("cached data content")
 	testKey := "cache-key-123"
-	encodedKey := encodeKey(testKey)
-	filePath := filepath.Join(subdir, encodedKey)
 
-	err = os.WriteFile(filePath, testData, 0o600)
+	err := Write([]string{"data"}, testKey, testData)
 	require.NoError(t, err)
 
+	encodedKey := encodeKey(testKey)
+	filePath := filepath.Join(tmpDir, "data", encodedKey)
+
 	entry, found := Read([]string{"data"}, testKey)
 
 	assert.True(t, found)
@@ -262,30 +259,53 @@ func TestRead_SuccessfulRead(t *testing.T) {
 	assert.Equal(t, testData, entry.Data)
 }
 
-// TestRead_TrimsWhitespace verifies Read trims leading/trailing whitespace
-// from file content.
-func TestRead_TrimsWhitespace(t *testing.T) {
+// TestRead_BadFrame verifies Read treats an unframed (or corrupt) file as a
+// cache miss rather than returning its raw bytes.
+func TestRead_BadFrame(t *testing.T) {
 	tmpDir := t.TempDir()
 	t.Setenv("TFCTL_CACHE_DIR", tmpDir)
 	t.Setenv("TFCTL_CACHE", "1")
 
-	// Create cache file with whitespace
 	subdir := filepath.Join(tmpDir, "data")
 	err := os.MkdirAll(subdir, 0o755)
 	require.NoError(t, err)
 
-	testData := []byte("  \n  cached content  \n  ")
-	testKey := "key-with-whitespace"
+	testKey := "legacy-key"
 	encodedKey := encodeKey(testKey)
 	filePath := filepath.Join(subdir, encodedKey)
 
-	err = os.WriteFile(filePath, testData, 0o600)
+	err = os.WriteFile(filePath, []byte("unframed legacy content"), 0o600)
 	require.NoError(t, err)
 
 	entry, found := Read([]string{"data"}, testKey)
 
-	assert.True(t, found)
-	assert.Equal(t, []byte("cached content"), entry.Data)
+	assert.False(t, found)
+	assert.Nil(t, entry)
+}
+
+// TestRead_ChecksumMismatch verifies Read rejects an entry whose payload no
+// longer matches its framed checksum.
+func TestRead_ChecksumMismatch(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("TFCTL_CACHE_DIR", tmpDir)
+	t.Setenv("TFCTL_CACHE", "1")
+
+	testKey := "tampered-key"
+	err := Write([]string{"data"}, testKey, []byte("original content"))
+	require.NoError(t, err)
+
+	encodedKey := encodeKey(testKey)
+	filePath := filepath.Join(tmpDir, "data", encodedKey)
+
+	raw, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	raw[len(raw)-1] ^= 0xFF // flip the last payload byte
+	require.NoError(t, os.WriteFile(filePath, raw, 0o600))
+
+	entry, found := Read([]string{"data"}, testKey)
+
+	assert.False(t, found)
+	assert.Nil(t, entry)
 }
 
 // TestWrite_CachingDisabled verifies Write is no-op when caching is
@@ -328,15 +348,15 @@ func TestWrite_SuccessfulWrite(t *testing.T) {
 
 	assert.NoError(t, err)
 
-	// Verify file exists with correct content
+	// Verify file exists with correct content, unwrapping its frame.
 	expectedDir := filepath.Join(tmpDir, "cache", "data")
 	encoded := encodeKey(testKey)
 	expectedPath := filepath.Join(expectedDir, encoded)
 	assert.FileExists(t, expectedPath)
 
-	content, err := os.ReadFile(expectedPath)
-	assert.NoError(t, err)
-	assert.Equal(t, testData, content)
+	entry, found := Read(subdirs, testKey)
+	assert.True(t, found)
+	assert.Equal(t, testData, entry.Data)
 }
 
 // TestWrite_FilePermissions verifies Write creates files with 0600
@@ -379,20 +399,18 @@ func TestWrite_OverwritesExisting(t *testing.T) {
 	require.NoError(t, err)
 
 	// Verify old data
-	encoded := encodeKey(testKey)
-	expectedPath := filepath.Join(tmpDir, encoded)
-	content, err := os.ReadFile(expectedPath)
-	require.NoError(t, err)
-	assert.Equal(t, oldData, content)
+	entry, found := Read([]string{}, testKey)
+	require.True(t, found)
+	assert.Equal(t, oldData, entry.Data)
 
 	// Overwrite with new data
 	err = Write([]string{}, testKey, newData)
 	assert.NoError(t, err)
 
 	// Verify new data
-	content, err = os.ReadFile(expectedPath)
-	assert.NoError(t, err)
-	assert.Equal(t, newData, content)
+	entry, found = Read([]string{}, testKey)
+	require.True(t, found)
+	assert.Equal(t, newData, entry.Data)
 }
 
 // TestWrite_EmptyData verifies Write handles empty data correctly.
@@ -408,12 +426,15 @@ func TestWrite_EmptyData(t *testing.T) {
 
 	assert.NoError(t, err)
 
-	// Verify empty file exists
+	// The frame header is present even for an empty payload, but the
+	// round-tripped entry's data should still be empty.
 	encoded := encodeKey(testKey)
 	expectedPath := filepath.Join(tmpDir, encoded)
-	info, err := os.Stat(expectedPath)
-	assert.NoError(t, err)
-	assert.Equal(t, int64(0), info.Size())
+	assert.FileExists(t, expectedPath)
+
+	entry, found := Read([]string{}, testKey)
+	assert.True(t, found)
+	assert.Empty(t, entry.Data)
 }
 
 // TestPurge_DisabledWithZeroHours verifies Purge is no-op when hours <= 0.
@@ -528,6 +549,42 @@ func TestPurge_NestedDirectories(t *testing.T) {
 	assert.NoFileExists(t, oldPath)
 }
 
+// TestPurgeCorrupt_RemovesUnframedFiles verifies PurgeCorrupt removes files
+// that don't parse as a valid frame, regardless of age.
+func TestPurgeCorrupt_RemovesUnframedFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("TFCTL_CACHE_DIR", tmpDir)
+	t.Setenv("TFCTL_CACHE", "1")
+
+	require.NoError(t, Write([]string{}, "good-key", []byte("good data")))
+
+	badPath := filepath.Join(tmpDir, "not-a-frame")
+	require.NoError(t, os.WriteFile(badPath, []byte("not framed"), 0o600))
+
+	require.NoError(t, PurgeCorrupt())
+
+	assert.NoFileExists(t, badPath)
+	entry, found := Read([]string{}, "good-key")
+	assert.True(t, found)
+	assert.Equal(t, []byte("good data"), entry.Data)
+}
+
+// TestWriteCompressed_RoundTrip verifies WriteCompressed entries decode back
+// to their original payload.
+func TestWriteCompressed_RoundTrip(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("TFCTL_CACHE_DIR", tmpDir)
+	t.Setenv("TFCTL_CACHE", "1")
+
+	testData := []byte(strings.Repeat("state file contents ", 100))
+
+	require.NoError(t, WriteCompressed([]string{}, "state-key", "application/json", testData))
+
+	entry, found := Read([]string{}, "state-key")
+	assert.True(t, found)
+	assert.Equal(t, testData, entry.Data)
+}
+
 // TestEncodeKey_Consistency verifies encodeKey produces consistent output.
 func TestEncodeKey_Consistency(t *testing.T) {
 	testKey := "consistent-key"
@@ -629,3 +686,145 @@ func TestIntegration_FullWorkflow(t *testing.T) {
 	assert.True(t, exists1)
 	assert.NotEmpty(t, path1)
 }
+
+// TestCounterSnapshot_TracksHitsAndMisses verifies Read updates the counters
+// CounterSnapshot reports: a miss for a key that was never written, a hit
+// (plus its payload size in bytes) once it's written and read back. Counters
+// are process-global, so this asserts deltas rather than absolute values.
+func TestCounterSnapshot_TracksHitsAndMisses(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("TFCTL_CACHE_DIR", tmpDir)
+	t.Setenv("TFCTL_CACHE", "1")
+
+	before := CounterSnapshot()
+
+	_, found := Read([]string{"data"}, "counter-miss-key")
+	assert.False(t, found)
+
+	testData := []byte("counter test data")
+	err := Write([]string{"data"}, "counter-hit-key", testData)
+	require.NoError(t, err)
+
+	_, found = Read([]string{"data"}, "counter-hit-key")
+	assert.True(t, found)
+
+	after := CounterSnapshot()
+
+	assert.Equal(t, before.Misses+1, after.Misses)
+	assert.Equal(t, before.Hits+1, after.Hits)
+	assert.Equal(t, before.Bytes+int64(len(testData)), after.Bytes)
+}
+
+// TestRecordRevalidation_IncrementsCounter verifies RecordRevalidation bumps
+// the revalidation counter without touching hits/misses/bytes.
+func TestRecordRevalidation_IncrementsCounter(t *testing.T) {
+	before := CounterSnapshot()
+
+	RecordRevalidation()
+
+	after := CounterSnapshot()
+
+	assert.Equal(t, before.Revalidations+1, after.Revalidations)
+	assert.Equal(t, before.Hits, after.Hits)
+	assert.Equal(t, before.Misses, after.Misses)
+	assert.Equal(t, before.Bytes, after.Bytes)
+}
+
+// TestList_ReturnsEntriesWithPartitionAndEncodedKey verifies List reports
+// every on-disk file beneath subdirs, split into its partition and encoded
+// filename.
+func TestList_ReturnsEntriesWithPartitionAndEncodedKey(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("TFCTL_CACHE_DIR", tmpDir)
+
+	sub := []string{"app.terraform.io", "my-org"}
+	err := Write(sub, "state-key", []byte("data"))
+	require.NoError(t, err)
+
+	entries, err := List(nil)
+
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, filepath.Join(sub...), entries[0].Partition)
+	assert.Equal(t, encodeKey("state-key"), entries[0].EncodedKey)
+	assert.NotZero(t, entries[0].Size)
+}
+
+// TestList_ScopedToSubdir verifies List only reports entries beneath the
+// requested partition when one is given.
+func TestList_ScopedToSubdir(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("TFCTL_CACHE_DIR", tmpDir)
+
+	require.NoError(t, Write([]string{"org-a"}, "key1", []byte("data")))
+	require.NoError(t, Write([]string{"org-b"}, "key2", []byte("data")))
+
+	entries, err := List([]string{"org-a"})
+
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "org-a", entries[0].Partition)
+}
+
+// TestList_MissingCacheDirReturnsEmpty verifies List tolerates a cache
+// directory that doesn't exist yet.
+func TestList_MissingCacheDirReturnsEmpty(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("TFCTL_CACHE_DIR", filepath.Join(tmpDir, "does-not-exist"))
+
+	entries, err := List(nil)
+
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+// TestRemoveEntry_RemovesSingleFile verifies RemoveEntry deletes exactly the
+// entry named by its encoded key, leaving sibling entries alone.
+func TestRemoveEntry_RemovesSingleFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("TFCTL_CACHE_DIR", tmpDir)
+
+	sub := []string{"app.terraform.io", "my-org"}
+	require.NoError(t, Write(sub, "key1", []byte("data")))
+	require.NoError(t, Write(sub, "key2", []byte("data")))
+
+	err := RemoveEntry(sub, encodeKey("key1"))
+
+	require.NoError(t, err)
+	_, exists := EntryPath(sub, "key1")
+	assert.False(t, exists)
+	_, exists = EntryPath(sub, "key2")
+	assert.True(t, exists)
+}
+
+// TestRemoveEntry_MissingEntryIsNotAnError verifies RemoveEntry is a no-op,
+// not an error, when the entry isn't present.
+func TestRemoveEntry_MissingEntryIsNotAnError(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("TFCTL_CACHE_DIR", tmpDir)
+
+	err := RemoveEntry([]string{"app.terraform.io", "my-org"}, "does-not-exist")
+
+	assert.NoError(t, err)
+}
+
+// TestShouldSweep_DebouncesWithinInterval verifies ShouldSweep returns true
+// once and then false for subsequent calls within sweepInterval.
+func TestShouldSweep_DebouncesWithinInterval(t *testing.T) {
+	lastSweepMu.Lock()
+	lastSweep = time.Time{}
+	lastSweepMu.Unlock()
+
+	assert.True(t, ShouldSweep())
+	assert.False(t, ShouldSweep())
+}
+
+// TestShouldSweep_AllowsAfterIntervalElapses verifies ShouldSweep returns
+// true again once sweepInterval has passed since the last sweep.
+func TestShouldSweep_AllowsAfterIntervalElapses(t *testing.T) {
+	lastSweepMu.Lock()
+	lastSweep = time.Now().Add(-sweepInterval - time.Second)
+	lastSweepMu.Unlock()
+
+	assert.True(t, ShouldSweep())
+}
@@ -0,0 +1,364 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package cacheutil
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// frameMagic identifies a framed cache entry; frameVersion allows the layout
+// to evolve without breaking older readers.
+var frameMagic = [4]byte{'T', 'F', 'C', 'C'}
+
+const frameVersion = 3
+
+// InputKind identifies what an Input fingerprints: an environment variable's
+// value or a file's contents.
+type InputKind string
+
+const (
+	InputEnv  InputKind = "env"
+	InputFile InputKind = "file"
+)
+
+// Input identifies one external fact - an environment variable or a file -
+// that a cache entry's validity depends on, alongside its own TTL. Write
+// records each Input's current fingerprint in the frame; Read invalidates
+// the entry the moment any recorded Input's current fingerprint no longer
+// matches, e.g. because TFE_TOKEN was rotated or a referenced config file
+// changed.
+type Input struct {
+	Kind InputKind
+	Name string
+}
+
+// inputFingerprint is one Input's recorded hash, as written into the frame
+// at Write time and compared against the Input's current hash at Read time.
+type inputFingerprint struct {
+	Kind InputKind
+	Name string
+	Hash [32]byte
+}
+
+// hashInput computes in's current fingerprint: the SHA-256 of an env var's
+// value, or of a file's contents. An unreadable file hashes to the same
+// value as an empty one, so a file that's been deleted since Write reads as
+// changed rather than silently skipped.
+func hashInput(in Input) [32]byte {
+	switch in.Kind {
+	case InputFile:
+		data, _ := os.ReadFile(in.Name)
+		return sha256.Sum256(data)
+	case InputEnv:
+		fallthrough
+	default:
+		return sha256.Sum256([]byte(os.Getenv(in.Name)))
+	}
+}
+
+// frameHeader is the small fixed-plus-variable header written ahead of every
+// cache entry's payload so corrupt, partial, or stale entries can be
+// detected before the payload is trusted.
+type frameHeader struct {
+	Version     uint8
+	Compressed  bool
+	CreatedAt   time.Time
+	ContentType string
+	// TTL is the entry's per-key time-to-live, or 0 if the entry never
+	// expires on its own (the longstanding behavior, left to Purge's
+	// age-based sweep instead). Added in frameVersion 2.
+	TTL time.Duration
+	// Inputs is the set of env vars/files this entry's validity was pinned
+	// to at write time, checked by readLocked alongside Expired. Added in
+	// frameVersion 3; a v1/v2 frame has none and is never invalidated this
+	// way.
+	Inputs   []inputFingerprint
+	Checksum [32]byte
+}
+
+// Expired reports whether hdr's entry has outlived its TTL as of now. An
+// entry with TTL <= 0 never expires this way.
+func (hdr frameHeader) Expired(now time.Time) bool {
+	return hdr.TTL > 0 && now.Sub(hdr.CreatedAt) > hdr.TTL
+}
+
+// StaleInput returns the first recorded Input whose current fingerprint no
+// longer matches what was recorded at Write time, or (Input{}, false) if
+// every one still matches.
+func (hdr frameHeader) StaleInput() (Input, bool) {
+	for _, fp := range hdr.Inputs {
+		in := Input{Kind: fp.Kind, Name: fp.Name}
+		if hashInput(in) != fp.Hash {
+			return in, true
+		}
+	}
+	return Input{}, false
+}
+
+// ErrBadFrame is returned (wrapped) when a cache entry's header cannot be
+// parsed, e.g. because it predates framing or was truncated.
+var ErrBadFrame = errors.New("cacheutil: malformed cache frame")
+
+// encodeFrame writes magic, header, and payload (optionally zstd-compressed)
+// to w. The checksum is always computed over the uncompressed payload, so a
+// Read checks it only after decompressing, which also catches a payload that
+// decompressed to the wrong bytes. ttl <= 0 means the entry
+// never expires on its own. Each of inputs' current fingerprint is computed
+// and recorded in the frame, for a later Read to compare against.
+func encodeFrame(w io.Writer, contentType string, compressed bool, ttl time.Duration, inputs []Input, payload []byte) error {
+	sum := sha256.Sum256(payload)
+
+	body := payload
+	if compressed {
+		enc, err := zstd.NewWriter(nil)
+		if err != nil {
+			return fmt.Errorf("failed to create zstd encoder: %w", err)
+		}
+		body = enc.EncodeAll(payload, nil)
+		enc.Close()
+	}
+
+	if _, err := w.Write(frameMagic[:]); err != nil {
+		return err
+	}
+
+	hdr := struct {
+		Version    uint8
+		Compressed uint8
+		CreatedAt  int64
+		TTL        int64
+	}{
+		Version:   frameVersion,
+		CreatedAt: time.Now().Unix(),
+		TTL:       int64(ttl / time.Second),
+	}
+	if compressed {
+		hdr.Compressed = 1
+	}
+
+	if err := binary.Write(w, binary.BigEndian, hdr.Version); err != nil {
+		return err
+	}
+	if err := binary.Write(w, binary.BigEndian, hdr.Compressed); err != nil {
+		return err
+	}
+	if err := binary.Write(w, binary.BigEndian, hdr.CreatedAt); err != nil {
+		return err
+	}
+	if err := binary.Write(w, binary.BigEndian, hdr.TTL); err != nil {
+		return err
+	}
+
+	ctBytes := []byte(contentType)
+	if err := binary.Write(w, binary.BigEndian, uint16(len(ctBytes))); err != nil {
+		return err
+	}
+	if _, err := w.Write(ctBytes); err != nil {
+		return err
+	}
+
+	if err := binary.Write(w, binary.BigEndian, uint16(len(inputs))); err != nil {
+		return err
+	}
+	for _, in := range inputs {
+		if err := writeLengthPrefixed(w, []byte(in.Kind)); err != nil {
+			return err
+		}
+		if err := writeLengthPrefixed(w, []byte(in.Name)); err != nil {
+			return err
+		}
+		hash := hashInput(in)
+		if _, err := w.Write(hash[:]); err != nil {
+			return err
+		}
+	}
+
+	if _, err := w.Write(sum[:]); err != nil {
+		return err
+	}
+
+	_, err := w.Write(body)
+	return err
+}
+
+// writeLengthPrefixed writes a uint16 byte-length prefix followed by b,
+// the same shape already used for contentType.
+func writeLengthPrefixed(w io.Writer, b []byte) error {
+	if err := binary.Write(w, binary.BigEndian, uint16(len(b))); err != nil {
+		return err
+	}
+	_, err := w.Write(b)
+	return err
+}
+
+// readLengthPrefixed reads a uint16 byte-length prefix followed by that many
+// bytes, the inverse of writeLengthPrefixed.
+func readLengthPrefixed(r io.Reader) ([]byte, error) {
+	var n uint16
+	if err := binary.Read(r, binary.BigEndian, &n); err != nil {
+		return nil, err
+	}
+	b := make([]byte, n)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// decodeFrame parses a framed cache entry, returning its header and the
+// decompressed payload. It returns ErrBadFrame if raw does not begin with a
+// valid frame.
+func decodeFrame(raw []byte) (frameHeader, []byte, error) {
+	r := bytes.NewReader(raw)
+
+	var magic [4]byte
+	if _, err := io.ReadFull(r, magic[:]); err != nil || magic != frameMagic {
+		return frameHeader{}, nil, fmt.Errorf("%w: bad magic", ErrBadFrame)
+	}
+
+	var version, compressed uint8
+	var createdAtUnix, ttlSeconds int64
+	if err := binary.Read(r, binary.BigEndian, &version); err != nil {
+		return frameHeader{}, nil, fmt.Errorf("%w: %v", ErrBadFrame, err)
+	}
+	if err := binary.Read(r, binary.BigEndian, &compressed); err != nil {
+		return frameHeader{}, nil, fmt.Errorf("%w: %v", ErrBadFrame, err)
+	}
+	if err := binary.Read(r, binary.BigEndian, &createdAtUnix); err != nil {
+		return frameHeader{}, nil, fmt.Errorf("%w: %v", ErrBadFrame, err)
+	}
+	// TTL was added in frameVersion 2; a v1 frame has no TTL field and
+	// reads as never-expiring.
+	if version >= 2 {
+		if err := binary.Read(r, binary.BigEndian, &ttlSeconds); err != nil {
+			return frameHeader{}, nil, fmt.Errorf("%w: %v", ErrBadFrame, err)
+		}
+	}
+
+	var ctLen uint16
+	if err := binary.Read(r, binary.BigEndian, &ctLen); err != nil {
+		return frameHeader{}, nil, fmt.Errorf("%w: %v", ErrBadFrame, err)
+	}
+	ctBytes := make([]byte, ctLen)
+	if _, err := io.ReadFull(r, ctBytes); err != nil {
+		return frameHeader{}, nil, fmt.Errorf("%w: %v", ErrBadFrame, err)
+	}
+
+	// Inputs were added in frameVersion 3; a v1/v2 frame has none.
+	var inputs []inputFingerprint
+	if version >= 3 {
+		var count uint16
+		if err := binary.Read(r, binary.BigEndian, &count); err != nil {
+			return frameHeader{}, nil, fmt.Errorf("%w: %v", ErrBadFrame, err)
+		}
+		for i := uint16(0); i < count; i++ {
+			kindBytes, err := readLengthPrefixed(r)
+			if err != nil {
+				return frameHeader{}, nil, fmt.Errorf("%w: %v", ErrBadFrame, err)
+			}
+			nameBytes, err := readLengthPrefixed(r)
+			if err != nil {
+				return frameHeader{}, nil, fmt.Errorf("%w: %v", ErrBadFrame, err)
+			}
+			var hash [32]byte
+			if _, err := io.ReadFull(r, hash[:]); err != nil {
+				return frameHeader{}, nil, fmt.Errorf("%w: %v", ErrBadFrame, err)
+			}
+			inputs = append(inputs, inputFingerprint{
+				Kind: InputKind(kindBytes),
+				Name: string(nameBytes),
+				Hash: hash,
+			})
+		}
+	}
+
+	var checksum [32]byte
+	if _, err := io.ReadFull(r, checksum[:]); err != nil {
+		return frameHeader{}, nil, fmt.Errorf("%w: %v", ErrBadFrame, err)
+	}
+
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return frameHeader{}, nil, fmt.Errorf("%w: %v", ErrBadFrame, err)
+	}
+
+	payload := body
+	if compressed == 1 {
+		dec, err := zstd.NewReader(nil)
+		if err != nil {
+			return frameHeader{}, nil, fmt.Errorf("failed to create zstd decoder: %w", err)
+		}
+		payload, err = dec.DecodeAll(body, nil)
+		dec.Close()
+		if err != nil {
+			return frameHeader{}, nil, fmt.Errorf("%w: failed to decompress: %v", ErrBadFrame, err)
+		}
+	}
+
+	hdr := frameHeader{
+		Version:     version,
+		Compressed:  compressed == 1,
+		CreatedAt:   time.Unix(createdAtUnix, 0),
+		ContentType: string(ctBytes),
+		TTL:         time.Duration(ttlSeconds) * time.Second,
+		Inputs:      inputs,
+		Checksum:    checksum,
+	}
+
+	return hdr, payload, nil
+}
+
+// verifyChecksum reports whether payload's SHA-256 digest matches checksum.
+func verifyChecksum(checksum [32]byte, payload []byte) bool {
+	return checksum == sha256.Sum256(payload)
+}
+
+// hasBadFrame reports whether the file at path exists but cannot be parsed
+// as a valid cache frame, so PurgeCorrupt can evict corrupt/partial entries
+// independent of age.
+func hasBadFrame(path string) bool {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	_, _, err = decodeFrame(raw)
+	return err != nil
+}
+
+// PurgeCorrupt walks the cache dir and removes every entry whose header
+// fails to parse as a valid frame, regardless of age. It complements Purge's
+// age-based eviction so callers (e.g. s3.PurgeCache) can run both passes.
+func PurgeCorrupt() error {
+	base, ok := Dir()
+	if !ok {
+		return nil
+	}
+
+	return filepath.Walk(base, func(path string, info os.FileInfo, walkErr error) error {
+		if walkErr != nil {
+			if os.IsNotExist(walkErr) {
+				return nil
+			}
+			return walkErr
+		}
+		if info == nil || info.IsDir() || isLockFile(path) {
+			return nil
+		}
+
+		if hasBadFrame(path) {
+			removeIfUnlocked(path, "corrupt-purge")
+		}
+		return nil
+	})
+}
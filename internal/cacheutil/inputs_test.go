@@ -0,0 +1,90 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package cacheutil
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestWriteWithOptions_ValidWhenInputsUnchanged verifies an entry written
+// with Inputs is still a hit as long as none of them have changed.
+func TestWriteWithOptions_ValidWhenInputsUnchanged(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("TFCTL_CACHE_DIR", tmpDir)
+	t.Setenv("TFCTL_CACHE", "1")
+	t.Setenv("TEST_CACHE_TOKEN", "secret-v1")
+
+	opts := Options{Inputs: []Input{{Kind: InputEnv, Name: "TEST_CACHE_TOKEN"}}}
+	require.NoError(t, WriteWithOptions([]string{}, "org-key", opts, []byte("org data")))
+
+	entry, found := Read([]string{}, "org-key")
+	assert.True(t, found)
+	assert.Equal(t, []byte("org data"), entry.Data)
+}
+
+// TestWriteWithOptions_InvalidatedByChangedEnv verifies an entry is treated
+// as a miss (and removed from disk) once a recorded env Input's value
+// changes after it was written.
+func TestWriteWithOptions_InvalidatedByChangedEnv(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("TFCTL_CACHE_DIR", tmpDir)
+	t.Setenv("TFCTL_CACHE", "1")
+	t.Setenv("TEST_CACHE_TOKEN", "secret-v1")
+
+	opts := Options{Inputs: []Input{{Kind: InputEnv, Name: "TEST_CACHE_TOKEN"}}}
+	require.NoError(t, WriteWithOptions([]string{}, "org-key", opts, []byte("org data")))
+
+	t.Setenv("TEST_CACHE_TOKEN", "secret-v2")
+
+	entry, found := Read([]string{}, "org-key")
+	assert.False(t, found)
+	assert.Nil(t, entry)
+
+	p, exists := EntryPath([]string{}, "org-key")
+	assert.False(t, exists)
+	assert.NoFileExists(t, p)
+}
+
+// TestWriteWithOptions_InvalidatedByChangedFile verifies an entry is
+// invalidated once a recorded file Input's contents change.
+func TestWriteWithOptions_InvalidatedByChangedFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("TFCTL_CACHE_DIR", tmpDir)
+	t.Setenv("TFCTL_CACHE", "1")
+
+	cfgPath := filepath.Join(tmpDir, "host.hcl")
+	require.NoError(t, os.WriteFile(cfgPath, []byte("hostname = \"a.example.com\""), 0o600))
+
+	opts := Options{Inputs: []Input{{Kind: InputFile, Name: cfgPath}}}
+	require.NoError(t, WriteWithOptions([]string{}, "host-key", opts, []byte("host data")))
+
+	_, found := Read([]string{}, "host-key")
+	assert.True(t, found)
+
+	require.NoError(t, os.WriteFile(cfgPath, []byte("hostname = \"b.example.com\""), 0o600))
+
+	_, found = Read([]string{}, "host-key")
+	assert.False(t, found)
+}
+
+// TestWriteWithOptions_CombinesWithTTL verifies an entry written with both
+// an Options.TTL (here 0, meaning no expiry) and Inputs is a hit while its
+// Inputs are unchanged.
+func TestWriteWithOptions_CombinesWithTTL(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("TFCTL_CACHE_DIR", tmpDir)
+	t.Setenv("TFCTL_CACHE", "1")
+
+	opts := Options{TTL: 0, Inputs: []Input{{Kind: InputEnv, Name: "TEST_CACHE_UNSET_VAR"}}}
+	require.NoError(t, WriteWithOptions([]string{}, "ttl-key", opts, []byte("data")))
+
+	entry, found := Read([]string{}, "ttl-key")
+	assert.True(t, found)
+	assert.Equal(t, []byte("data"), entry.Data)
+}
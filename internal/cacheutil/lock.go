@@ -0,0 +1,100 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package cacheutil
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gofrs/flock"
+
+	"github.com/tfctl/tfctl/internal/log"
+)
+
+// lockExt is the suffix used for a cache entry's companion advisory-lock
+// file. Walk-based scans (List, Stats, Purge, PurgeCorrupt, PurgeLRU) skip
+// files with this suffix so they're never mistaken for cache entries.
+const lockExt = ".lock"
+
+// lockPath returns the advisory-lock file path accompanying the cache entry
+// at p.
+func lockPath(p string) string {
+	return p + lockExt
+}
+
+// isLockFile reports whether path is an advisory-lock file rather than a
+// cache entry.
+func isLockFile(path string) bool {
+	return strings.HasSuffix(path, lockExt)
+}
+
+// withExclusiveLock acquires an exclusive advisory lock on p's companion
+// .lock file for the duration of fn, so two concurrent tfctl processes
+// writing the same key can't tear file contents or race on MkdirAll.
+func withExclusiveLock(p string, fn func() error) error {
+	fl := flock.New(lockPath(p))
+	if err := fl.Lock(); err != nil {
+		return fmt.Errorf("failed to acquire cache write lock: %w", err)
+	}
+	defer func() {
+		if err := fl.Unlock(); err != nil {
+			log.WithError(err).Debugf("failed to release cache write lock: path=%s", p)
+		}
+	}()
+	return fn()
+}
+
+// withSharedLock acquires a shared advisory lock on p's companion .lock file
+// for the duration of fn, so a reader never observes a write mid-flight.
+func withSharedLock(p string, fn func() error) error {
+	fl := flock.New(lockPath(p))
+	if err := fl.RLock(); err != nil {
+		return fmt.Errorf("failed to acquire cache read lock: %w", err)
+	}
+	defer func() {
+		if err := fl.Unlock(); err != nil {
+			log.WithError(err).Debugf("failed to release cache read lock: path=%s", p)
+		}
+	}()
+	return fn()
+}
+
+// tryExclusiveLock attempts a non-blocking exclusive lock on p's companion
+// .lock file. ok is false (with a nil error) if another process currently
+// holds it - Purge/PurgeLRU/PurgeCorrupt's signal to leave that entry alone
+// rather than contend with an in-flight write. The caller must Unlock fl
+// once acquired is true.
+func tryExclusiveLock(p string) (fl *flock.Flock, acquired bool, err error) {
+	fl = flock.New(lockPath(p))
+	acquired, err = fl.TryLock()
+	return fl, acquired, err
+}
+
+// removeIfUnlocked removes path unless another process currently holds its
+// advisory lock, in which case it's left alone for a later sweep to pick up
+// rather than fought over.
+func removeIfUnlocked(path, verb string) {
+	fl, acquired, err := tryExclusiveLock(path)
+	if err != nil {
+		log.WithError(err).Debugf("failed to check cache entry lock: path=%s", path)
+		return
+	}
+	if !acquired {
+		log.Debugf("skipping %s of locked cache file %s", verb, path)
+		return
+	}
+	defer fl.Unlock() //nolint:errcheck
+
+	if err := os.Remove(path); err == nil {
+		log.Debugf("removed cache file %s", path)
+	} else {
+		log.WithError(err).Warnf("failed to remove cache file %s", path)
+		return
+	}
+
+	if err := os.Remove(lockPath(path)); err != nil && !os.IsNotExist(err) {
+		log.WithError(err).Debugf("failed to remove cache lock file %s", lockPath(path))
+	}
+}
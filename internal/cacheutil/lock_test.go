@@ -0,0 +1,135 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package cacheutil
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIsLockFile verifies isLockFile recognizes only the ".lock" suffix.
+func TestIsLockFile(t *testing.T) {
+	assert.True(t, isLockFile("/tmp/cache/abc123.lock"))
+	assert.False(t, isLockFile("/tmp/cache/abc123"))
+}
+
+// TestList_SkipsLockFiles verifies List never reports a companion .lock file
+// as a cache entry.
+func TestList_SkipsLockFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("TFCTL_CACHE_DIR", tmpDir)
+	t.Setenv("TFCTL_CACHE", "1")
+
+	require.NoError(t, Write([]string{}, "key", []byte("data")))
+
+	entries, err := List(nil)
+	require.NoError(t, err)
+	for _, e := range entries {
+		assert.False(t, isLockFile(e.EncodedKey))
+	}
+}
+
+// TestPurge_SkipsLockedFile verifies Purge leaves a file alone while its
+// advisory lock is held by another writer, rather than deleting out from
+// under it.
+func TestPurge_SkipsLockedFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("TFCTL_CACHE_DIR", tmpDir)
+	t.Setenv("TFCTL_CACHE", "1")
+
+	require.NoError(t, Write([]string{}, "held-key", []byte("data")))
+
+	p, exists := EntryPath(nil, "held-key")
+	require.True(t, exists)
+
+	pastTime := time.Now().Add(-3 * time.Hour)
+	require.NoError(t, os.Chtimes(p, pastTime, pastTime))
+
+	fl, acquired, err := tryExclusiveLock(p)
+	require.NoError(t, err)
+	require.True(t, acquired)
+	defer fl.Unlock() //nolint:errcheck
+
+	require.NoError(t, Purge(1))
+
+	assert.FileExists(t, p)
+}
+
+// TestPurge_RemovesLockFile verifies Purge removes a stale entry's companion
+// .lock file along with the entry itself, rather than leaving it behind.
+func TestPurge_RemovesLockFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("TFCTL_CACHE_DIR", tmpDir)
+	t.Setenv("TFCTL_CACHE", "1")
+
+	require.NoError(t, Write([]string{}, "stale-key", []byte("data")))
+
+	p, exists := EntryPath(nil, "stale-key")
+	require.True(t, exists)
+	require.FileExists(t, lockPath(p))
+
+	pastTime := time.Now().Add(-3 * time.Hour)
+	require.NoError(t, os.Chtimes(p, pastTime, pastTime))
+
+	require.NoError(t, Purge(1))
+
+	assert.NoFileExists(t, p)
+	assert.NoFileExists(t, lockPath(p))
+}
+
+// TestDelete_RemovesLockFile verifies Delete removes a cache entry's
+// companion .lock file along with the entry itself.
+func TestDelete_RemovesLockFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("TFCTL_CACHE_DIR", tmpDir)
+	t.Setenv("TFCTL_CACHE", "1")
+
+	require.NoError(t, Write([]string{}, "deleted-key", []byte("data")))
+
+	p, exists := EntryPath(nil, "deleted-key")
+	require.True(t, exists)
+	require.FileExists(t, lockPath(p))
+
+	require.NoError(t, Delete(nil, "deleted-key"))
+
+	assert.NoFileExists(t, p)
+	assert.NoFileExists(t, lockPath(p))
+}
+
+// TestWrite_ConcurrentWritesDoNotCorrupt writes the same key from many
+// goroutines at once and verifies every write lands as a cleanly framed,
+// checksum-valid entry rather than a torn file.
+func TestWrite_ConcurrentWritesDoNotCorrupt(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("TFCTL_CACHE_DIR", tmpDir)
+	t.Setenv("TFCTL_CACHE", "1")
+
+	const writers = 16
+	var wg sync.WaitGroup
+	errs := make([]error, writers)
+
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			data := []byte(fmt.Sprintf("payload-%d", i))
+			errs[i] = Write([]string{}, "shared-key", data)
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+
+	entry, found := Read([]string{}, "shared-key")
+	require.True(t, found)
+	assert.Contains(t, string(entry.Data), "payload-")
+}
@@ -10,8 +10,10 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/apex/log"
 	"github.com/urfave/cli/v3"
 
+	"github.com/tfctl/tfctl/internal/audit"
 	"github.com/tfctl/tfctl/internal/config"
 	"github.com/tfctl/tfctl/internal/meta"
 	"github.com/tfctl/tfctl/internal/util"
@@ -38,21 +40,30 @@ func InitApp(ctx context.Context, args []string) (*cli.Command, error) {
 
 	// allow short if-style local cfg; no actual outer cfg
 	cfg2, _ := config.Load(ns) //nolint
+	auditHandle, err := audit.NewHandle()
+	if err != nil {
+		log.WithError(err).Warn("failed to initialize audit sink, auditing disabled")
+	}
 	meta := meta.Meta{
 		Args:        args,
 		Config:      cfg2,
 		Context:     ctx,
 		StartingDir: sd,
+		Audit:       auditHandle,
 	}
 
 	// See if the arg immediately following the command might be a directory.
 	// This is determined by whether or not it begins with - or --.  If it does,
 	// it's a flag and the CWD directory is the starting directory.  If it's not,
 	// we assume we have a directory spec of some sort and need to parse it more.
-	// Special-case the 'completion' and 'ps' commands which take a plain
-	// positional argument (e.g., 'bash' or 'zsh' for completion, plan file
-	// for ps).
-	if (ns != "completion" && ns != "ps") && len(args) > 2 && !strings.HasPrefix(args[2], "-") {
+	// Special-case the 'completion', 'ps', 'diff', 'state', 'config',
+	// 'login' and 'logout' commands which take plain positional arguments
+	// (e.g., 'bash' or 'zsh' for completion, plan file for ps, from/to
+	// state specs for diff, the history/diff subcommand name for state,
+	// the edit/encrypt-value/decrypt-value subcommand name (and an
+	// optional value) for config, a hostname for login/logout) rather
+	// than a RootDir.
+	if (ns != "completion" && ns != "__complete" && ns != "ps" && ns != "diff" && ns != "state" && ns != "config" && ns != "login" && ns != "logout") && len(args) > 2 && !strings.HasPrefix(args[2], "-") {
 		if wd, env, err := util.ParseRootDir(args[2]); err == nil {
 			meta.RootDir = wd
 			meta.Env = env
@@ -73,10 +84,27 @@ func InitApp(ctx context.Context, args []string) (*cli.Command, error) {
 				Usage:       "tfctl version info",
 				HideDefault: true,
 			},
+			&cli.BoolFlag{
+				Name:        "cache-stats",
+				Usage:       "print cache hit/miss/revalidation counts and bytes served after the command completes",
+				HideDefault: true,
+			},
+			&cli.DurationFlag{
+				Name:        "timeout",
+				Usage:       "abort the command (TFCTL_TIMEOUT) after this long, e.g. 30s, 5m",
+				HideDefault: true,
+			},
 		},
 	}
 
 	app.Commands = append(app.Commands,
+		cacheCommandBuilder(meta),
+		configCommandBuilder(meta),
+		diffCommandBuilder(meta),
+		driftCommandBuilder(meta),
+		lintCommandBuilder(meta),
+		loginCommandBuilder(meta),
+		logoutCommandBuilder(meta),
 		mqCommandBuilder(meta),
 		oqCommandBuilder(meta),
 		pqCommandBuilder(meta),
@@ -84,9 +112,11 @@ func InitApp(ctx context.Context, args []string) (*cli.Command, error) {
 		rqCommandBuilder(meta),
 		siCommandBuilder(meta),
 		sqCommandBuilder(meta),
+		stateCommandBuilder(meta),
 		svqCommandBuilder(meta),
 		wqCommandBuilder(meta),
 		completionCommandBuilder(meta),
+		completeCommandBuilder(meta),
 	)
 
 	// Make sure flags are sorted for the --help text.
@@ -5,6 +5,7 @@ package command
 
 import (
 	"context"
+	"strings"
 
 	"github.com/urfave/cli/v3"
 )
@@ -18,3 +19,11 @@ type Augmenter[T any] func(
 	*cli.Command,
 	*T,
 ) error
+
+// splitFilterKey splits a dot-separated filter key (e.g. "tag.env") into its
+// prefix and remainder, for use by server-side augmenters registered under a
+// "prefix.*" wildcard.
+func splitFilterKey(key string) (prefix string, remainder string, ok bool) {
+	prefix, remainder, ok = strings.Cut(key, ".")
+	return prefix, remainder, ok
+}
@@ -0,0 +1,199 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/tfctl/tfctl/internal/cacheutil"
+	"github.com/tfctl/tfctl/internal/config"
+	"github.com/tfctl/tfctl/internal/meta"
+)
+
+// cacheStatsCommandAction prints per-partition usage (entry count, total
+// bytes, oldest entry) for every leaf directory under the cache base, e.g.
+// one row per TFE host/organization or S3 bucket/prefix/key. This reports
+// the cache's persisted on-disk footprint; for this one invocation's
+// hit/miss/revalidation activity, see the top-level --cache-stats flag.
+func cacheStatsCommandAction(ctx context.Context, cmd *cli.Command) error {
+	base, ok := cacheutil.Dir()
+	if !ok {
+		fmt.Fprintln(os.Stdout, "cache disabled")
+		return nil
+	}
+
+	partitions, err := leafDirs(base)
+	if err != nil {
+		return fmt.Errorf("failed to list cache partitions: %w", err)
+	}
+	sort.Strings(partitions)
+
+	for _, sub := range partitions {
+		entries, size, oldest, err := cacheutil.Stats(strings.Split(sub, string(filepath.Separator)))
+		if err != nil {
+			return fmt.Errorf("cache stats %s: %w", sub, err)
+		}
+		if entries == 0 {
+			continue
+		}
+		fmt.Fprintf(os.Stdout, "%-60s entries=%-6d bytes=%-12d oldest=%s\n",
+			sub, entries, size, oldest.Format("2006-01-02T15:04:05"))
+	}
+	return nil
+}
+
+// leafDirs returns every directory beneath base that contains at least one
+// regular file, expressed relative to base with the OS path separator
+// preserved (the caller splits it back into components on
+// filepath.Separator).
+func leafDirs(base string) ([]string, error) {
+	seen := map[string]bool{}
+	var out []string
+
+	err := filepath.Walk(base, func(path string, info os.FileInfo, walkErr error) error {
+		if walkErr != nil {
+			if os.IsNotExist(walkErr) {
+				return nil
+			}
+			return walkErr
+		}
+		if info == nil || info.IsDir() {
+			return nil
+		}
+
+		dir := filepath.Dir(path)
+		if seen[dir] {
+			return nil
+		}
+		seen[dir] = true
+
+		rel, err := filepath.Rel(base, dir)
+		if err != nil {
+			return err
+		}
+		out = append(out, rel)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// cacheLsCommandAction lists on-disk cache entries, one per line, optionally
+// scoped to the partition given as the command's argument (e.g.
+// "app.terraform.io/my-org"). Entries are addressed by their encoded
+// (SHA-256) filename, since cleartext keys aren't recoverable from disk.
+func cacheLsCommandAction(ctx context.Context, cmd *cli.Command) error {
+	var sub []string
+	if p := cmd.Args().First(); p != "" {
+		sub = strings.Split(p, string(filepath.Separator))
+	}
+
+	entries, err := cacheutil.List(sub)
+	if err != nil {
+		return fmt.Errorf("failed to list cache entries: %w", err)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Partition != entries[j].Partition {
+			return entries[i].Partition < entries[j].Partition
+		}
+		return entries[i].EncodedKey < entries[j].EncodedKey
+	})
+
+	for _, e := range entries {
+		fmt.Fprintf(os.Stdout, "%-60s %-66s bytes=%-12d modified=%s\n",
+			e.Partition, e.EncodedKey, e.Size, e.ModTime.Format("2006-01-02T15:04:05"))
+	}
+	return nil
+}
+
+// cacheRmCommandAction removes cache entries. Given just a partition
+// (e.g. "app.terraform.io/my-org"), the whole partition is removed; given a
+// partition and an encoded key (as shown by `tfctl cache ls`), only that
+// entry is removed.
+func cacheRmCommandAction(ctx context.Context, cmd *cli.Command) error {
+	args := cmd.Args().Slice()
+	if len(args) == 0 {
+		return fmt.Errorf("usage: tfctl cache rm <partition> [encoded-key]")
+	}
+	sub := strings.Split(args[0], string(filepath.Separator))
+
+	if len(args) > 1 {
+		return cacheutil.RemoveEntry(sub, args[1])
+	}
+	return cacheutil.PurgeNamespace(sub)
+}
+
+// cachePruneCommandAction runs the cache's full maintenance sweep
+// unconditionally: the TTL pass (cache.clean hours), the corruption pass,
+// and the LRU pass (cache.max-entries / cache.max-bytes, or the legacy
+// cache.max-size-mb). The same sweep also runs opportunistically from
+// backends' hot read paths, but debounced (cacheutil.ShouldSweep) so it
+// doesn't re-walk the whole cache on every state fetch; this command
+// bypasses that debounce for an on-demand clean.
+func cachePruneCommandAction(ctx context.Context, cmd *cli.Command) error {
+	cleanHours, _ := config.GetInt("cache.clean")
+	if err := cacheutil.Purge(cleanHours); err != nil {
+		return fmt.Errorf("failed to purge expired cache entries: %w", err)
+	}
+	if err := cacheutil.PurgeCorrupt(); err != nil {
+		return fmt.Errorf("failed to purge corrupt cache entries: %w", err)
+	}
+
+	maxEntries, _ := config.GetInt("cache.max-entries")
+	if maxBytes, err := config.GetByteSize("cache.max-bytes"); err == nil && maxBytes > 0 {
+		if err := cacheutil.PurgeLRUBytes(maxEntries, maxBytes); err != nil {
+			return fmt.Errorf("failed to purge over-budget cache entries: %w", err)
+		}
+		return nil
+	}
+
+	maxSizeMB, _ := config.GetInt("cache.max-size-mb")
+	if err := cacheutil.PurgeLRU(maxEntries, maxSizeMB); err != nil {
+		return fmt.Errorf("failed to purge over-budget cache entries: %w", err)
+	}
+	return nil
+}
+
+func cacheCommandBuilder(meta meta.Meta) *cli.Command {
+	return &cli.Command{
+		Name:  "cache",
+		Usage: "inspect and manage the on-disk query cache",
+		Metadata: map[string]any{
+			"meta": meta,
+		},
+		Commands: []*cli.Command{
+			{
+				Name:   "stats",
+				Usage:  "show per-partition cache usage (entries, size, oldest entry)",
+				Action: cacheStatsCommandAction,
+			},
+			{
+				Name:      "ls",
+				Usage:     "list on-disk cache entries",
+				UsageText: "tfctl cache ls [partition]",
+				Action:    cacheLsCommandAction,
+			},
+			{
+				Name:      "rm",
+				Usage:     "remove a cache partition, or a single entry within one",
+				UsageText: "tfctl cache rm <partition> [encoded-key]",
+				Action:    cacheRmCommandAction,
+			},
+			{
+				Name:   "prune",
+				Usage:  "run the TTL/corruption/LRU maintenance sweep now, unconditionally",
+				Action: cachePruneCommandAction,
+			},
+		},
+	}
+}
@@ -6,6 +6,7 @@ package command
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
@@ -13,10 +14,12 @@ import (
 
 	"github.com/hashicorp/go-tfe"
 	"github.com/hashicorp/jsonapi"
+	"github.com/tidwall/gjson"
 	"github.com/urfave/cli/v3"
 
 	"github.com/tfctl/tfctl/internal/attrs"
 	"github.com/tfctl/tfctl/internal/backend/remote"
+	"github.com/tfctl/tfctl/internal/filters"
 	"github.com/tfctl/tfctl/internal/meta"
 	"github.com/tfctl/tfctl/internal/output"
 )
@@ -61,7 +64,77 @@ func EmitJSONAPISlice(results any, al attrs.AttrList, cmd *cli.Command) error {
 	if err := jsonapi.MarshalPayload(&raw, results); err != nil {
 		return fmt.Errorf("failed to marshal payload: %w", err)
 	}
-	output.SliceDiceSpit(raw, al, cmd, "data", os.Stdout, nil)
+	output.SliceDiceSpit(raw, al, cmd, "data", os.Stdout, nil, nil)
+	return nil
+}
+
+// projectRows runs results (e.g. a QueryActionRunner fetch's typed slice)
+// through the jsonapi-marshal + attrs-filter stage EmitJSONAPISlice and
+// writeStreamPage both use, and returns the matching rows. Unlike those two,
+// it doesn't apply each attr's TransformSpec: --recursive, its one caller,
+// aggregates rows from several roots before a single terminal
+// EmitJSONAPIRows/SliceDiceSpit call, and that's where transforms get
+// applied -- doing it here too would apply them twice.
+func projectRows[T any](results []T, al attrs.AttrList, cmd *cli.Command) ([]map[string]interface{}, error) {
+	if len(results) == 0 {
+		return nil, nil
+	}
+
+	var raw bytes.Buffer
+	if err := jsonapi.MarshalPayload(&raw, results); err != nil {
+		return nil, fmt.Errorf("failed to marshal results: %w", err)
+	}
+
+	candidates := gjson.Parse(raw.String()).Get("data")
+	return filters.FilterDataset(candidates, al, cmd.String("filter")), nil
+}
+
+// EmitJSONAPIRows is EmitJSONAPISlice's row-based counterpart: it wraps
+// already-projected rows (e.g. --recursive's aggregate across multiple
+// roots) in the same {"data": [...]} shape jsonapi.MarshalPayload produces,
+// so they flow through the same output.SliceDiceSpit pipeline -- sorting,
+// postProcess, and attr transforms included.
+func EmitJSONAPIRows(rows []map[string]interface{}, al attrs.AttrList, cmd *cli.Command, postProcess func([]map[string]interface{}) error) error {
+	payload, err := json.Marshal(map[string]interface{}{"data": rows})
+	if err != nil {
+		return fmt.Errorf("failed to marshal rows: %w", err)
+	}
+
+	output.SliceDiceSpit(*bytes.NewBuffer(payload), al, cmd, "data", os.Stdout, postProcess, nil)
+	return nil
+}
+
+// writeStreamPage projects page through the same jsonapi-marshal + attrs +
+// filter pipeline EmitJSONAPISlice uses for a whole result set, then writes
+// each resulting row to enc immediately. It's QueryActionRunner's --stream
+// counterpart to EmitJSONAPISlice: the same projection, but applied page by
+// page instead of once to a single buffered dataset. Sorting isn't part of
+// that pipeline here: SortDataset needs the complete dataset, which is
+// exactly what --stream is avoiding holding onto, so --sort is ignored under
+// --stream.
+func writeStreamPage[T any](enc output.StreamEncoder, page []T, al attrs.AttrList, cmd *cli.Command) error {
+	if len(page) == 0 {
+		return nil
+	}
+
+	var raw bytes.Buffer
+	if err := jsonapi.MarshalPayload(&raw, page); err != nil {
+		return fmt.Errorf("failed to marshal page: %w", err)
+	}
+
+	candidates := gjson.Parse(raw.String()).Get("data")
+	rows := filters.FilterDataset(candidates, al, cmd.String("filter"))
+
+	for _, row := range rows {
+		for _, attr := range al {
+			if attr.TransformSpec != "" {
+				row[attr.OutputKey] = attr.Transform(row[attr.OutputKey])
+			}
+		}
+		if err := enc.WriteRow(row, al); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -77,12 +150,35 @@ func GetMeta(cmd *cli.Command) meta.Meta {
 	return meta.Meta{}
 }
 
+// pageSinkKey is the context key QueryActionRunner's --stream path uses to
+// hand a per-page callback down to a fetcher's pagination loop, without
+// widening every fetcher's return-a-full-slice signature. PaginateWithOptions
+// (and any hand-rolled pagination loop that wants to support --stream, e.g.
+// svq's fetchStateVersionsPushdown) checks for one via pageSinkFromContext
+// and, if present, invokes it with each page's items as soon as they're
+// fetched, ahead of the final accumulated return.
+type pageSinkKey struct{}
+
+// withPageSink returns a context carrying sink, retrievable via
+// pageSinkFromContext[T].
+func withPageSink[T any](ctx context.Context, sink func([]T) error) context.Context {
+	return context.WithValue(ctx, pageSinkKey{}, sink)
+}
+
+// pageSinkFromContext retrieves the callback set by withPageSink[T], if any.
+func pageSinkFromContext[T any](ctx context.Context) (func([]T) error, bool) {
+	sink, ok := ctx.Value(pageSinkKey{}).(func([]T) error)
+	return sink, ok
+}
+
 // PaginateWithOptions[T, O] is a generic paginator that drives paginated API
 // calls with mutable options. It handles pagination logic and returns all
 // collected results. The augmenter callback (if provided) is called before
 // each API invocation, allowing options customization (e.g., setting filters
 // or tags). The fetcher callback encapsulates the actual API call and must
-// return results, pagination info, and any error.
+// return results, pagination info, and any error. If ctx carries a page sink
+// (see withPageSink), each page's items are also handed to it as soon as
+// they're fetched, ahead of the final accumulated return.
 func PaginateWithOptions[T, O any](
 	ctx context.Context,
 	cmd *cli.Command,
@@ -92,6 +188,8 @@ func PaginateWithOptions[T, O any](
 ) ([]T, error) {
 	var results []T
 
+	sink, streaming := pageSinkFromContext[T](ctx)
+
 	// Paginate through pages
 	for {
 		// Invoke augmenter before each page (to allow options mutation)
@@ -104,9 +202,24 @@ func PaginateWithOptions[T, O any](
 		// Fetch current page
 		items, pagination, err := fetcher(ctx, options)
 		if err != nil {
+			// A --timeout/Ctrl-C cancellation mid-pagination still leaves
+			// results holding whatever pages came back before it fired;
+			// return them alongside the error so a caller (see
+			// DeadlineMiddleware/QueryActionRunner.Run) can flush partial
+			// output instead of discarding it. Any other fetch error keeps
+			// discarding results, same as before - a real API failure isn't
+			// "partial", it's suspect.
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return results, err
+			}
 			return nil, err
 		}
 
+		if streaming && len(items) > 0 {
+			if err := sink(items); err != nil {
+				return nil, err
+			}
+		}
 		results = append(results, items...)
 
 		// Check if there are more pages
@@ -64,10 +64,10 @@ _tfctl()
       local opts="$common --schema --host -h --org --limit -l --workspace -w"
             ;;
         si)
-            local opts="$common --passphrase -p --sv"
+            local opts="$common --passphrase -p --sv --no-init"
             ;;
         sq)
-      local opts="$common --chop --concrete -k --diff --diff_filter --host -h --org --passphrase --short --sv --limit --workspace -w"
+      local opts="$common --chop --concrete -k --diff --format --host -h --org --no-init --passphrase --short --sv --limit --workspace -w"
             ;;
         svq)
       local opts="$common --schema --host -h --org --limit -l --workspace -w"
@@ -76,7 +76,7 @@ _tfctl()
       local opts="$common --schema --host -h --org --limit -l"
             ;;
         completion)
-            local opts="bash zsh"
+            local opts="bash zsh fish powershell"
             COMPREPLY=( $(compgen -W "$opts" -- "$cur") )
             return 0
             ;;
@@ -104,6 +104,71 @@ _tfctl()
 complete -F _tfctl tfctl
 `
 
+const fishCompletionScript = `# fish completion for tfctl
+function __tfctl_complete
+    tfctl __complete $argv 2>/dev/null | string match -r '\t' | while read -l line
+        set -l parts (string split -m 1 \t -- $line)
+        printf '%s\t%s\n' $parts[1] $parts[2]
+    end
+end
+
+set -l tfctl_cmds mq oq pq rq si sq svq wq completion
+
+complete -c tfctl -f
+complete -c tfctl -n "not __fish_seen_subcommand_from $tfctl_cmds" -a "$tfctl_cmds" -d "tfctl subcommand"
+
+complete -c tfctl -n "__fish_seen_subcommand_from mq pq rq svq wq" -l org -d "organization" -a "(__tfctl_complete org)"
+complete -c tfctl -n "__fish_seen_subcommand_from rq svq wq" -l workspace -s w -d "workspace" -a "(__tfctl_complete workspace)"
+complete -c tfctl -n "__fish_seen_subcommand_from sq" -l sv -d "state version" -a "(__tfctl_complete sv)"
+complete -c tfctl -n "__fish_seen_subcommand_from sq" -l ignore-attr -d "attribute to ignore" -a "(__tfctl_complete ignore-attr)"
+
+complete -c tfctl -l attrs -s a -d "attributes to include"
+complete -c tfctl -l filter -s f -d "filters to apply"
+complete -c tfctl -l output -s o -d "output format" -a "text json raw yaml"
+complete -c tfctl -l sort -s s -d "sort attributes"
+complete -c tfctl -l tldr -d "show tldr page"
+
+complete -c tfctl -n "__fish_seen_subcommand_from completion" -a "bash zsh fish powershell"
+`
+
+const powershellCompletionScript = `# PowerShell completion for tfctl
+$tfctlCommonOpts = '--attrs', '-a', '--color', '-c', '--filter', '-f', '--output', '-o', '--sort', '-s', '--titles', '-t', '--tldr'
+$tfctlCmds = 'mq', 'oq', 'pq', 'rq', 'si', 'sq', 'svq', 'wq', 'completion'
+
+function TfctlComplete([string]$kind, [string]$wordToComplete) {
+    tfctl __complete $kind 2>$null | ForEach-Object {
+        $value, $desc = $_ -split "\t", 2
+        if ($value -like "$wordToComplete*") {
+            [System.Management.Automation.CompletionResult]::new($value, $value, 'ParameterValue', $desc)
+        }
+    }
+}
+
+Register-ArgumentCompleter -Native -CommandName tfctl -ScriptBlock {
+    param($wordToComplete, $commandAst, $cursorPosition)
+
+    $tokens = $commandAst.CommandElements | ForEach-Object { $_.ToString() }
+    $cmd = if ($tokens.Count -gt 1) { $tokens[1] } else { '' }
+
+    if ($tokens.Count -le 1) {
+        $tfctlCmds | Where-Object { $_ -like "$wordToComplete*" } |
+            ForEach-Object { [System.Management.Automation.CompletionResult]::new($_, $_, 'Command', $_) }
+        return
+    }
+
+    $prev = $tokens[-2]
+    switch ($prev) {
+        '--org' { TfctlComplete 'org' $wordToComplete; return }
+        { $_ -in '--workspace', '-w' } { TfctlComplete 'workspace' $wordToComplete; return }
+        '--sv' { TfctlComplete 'sv' $wordToComplete; return }
+        '--ignore-attr' { TfctlComplete 'ignore-attr' $wordToComplete; return }
+    }
+
+    $tfctlCommonOpts | Where-Object { $_ -like "$wordToComplete*" } |
+        ForEach-Object { [System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterName', $_) }
+}
+`
+
 const zshCompletionScript = `#compdef tfctl
 
 _tfctl() {
@@ -174,6 +239,7 @@ _tfctl() {
       _arguments -C \
         '(-p --passphrase)'{-p,--passphrase}'[state passphrase]' \
         '--sv[state version]' \
+        '--no-init[discover the backend from *.tf files]' \
         '::RootDir:_directories'
       ;;
     sq)
@@ -182,9 +248,10 @@ _tfctl() {
         '--chop[chop common resource prefix from names]' \
         '--concrete[only include concrete resources]' \
         '--diff[find difference between state versions]' \
-        '--diff_filter[filter for diff results]' \
+        '--format[diff output format]' \
         '--host[host to use for queries]' \
         '--limit[limit state versions returned]' \
+        '--no-init[discover the backend from *.tf files]' \
         '(-p --passphrase)'{-p,--passphrase}'[encrypted state passphrase]' \
         '--short[include full resource name paths]' \
         '--sv[state version to query]' \
@@ -211,7 +278,7 @@ _tfctl() {
         '::RootDir:_directories'
       ;;
     completion)
-      _arguments '1: :((bash zsh))'
+      _arguments '1: :((bash zsh fish powershell))'
       ;;
     *)
       _arguments -C $common '*:directory:_directories'
@@ -237,16 +304,27 @@ func completionCommandAction(ctx context.Context, cmd *cli.Command) error {
 		fmt.Fprint(os.Stdout, bashCompletionScript)
 	case "zsh":
 		fmt.Fprint(os.Stdout, zshCompletionScript)
+	case "fish":
+		fmt.Fprint(os.Stdout, fishCompletionScript)
+	case "powershell", "pwsh":
+		fmt.Fprint(os.Stdout, powershellCompletionScript)
 	default:
-		// Try to detect from SHELL or print help
+		// Try to detect from SHELL (bash/zsh/fish) or, for PowerShell - which
+		// doesn't set SHELL - from PSModulePath, which it always sets.
 		sh := os.Getenv("SHELL")
 		switch {
 		case strings.HasSuffix(sh, "zsh"):
 			fmt.Fprint(os.Stdout, zshCompletionScript)
 		case strings.HasSuffix(sh, "bash"):
 			fmt.Fprint(os.Stdout, bashCompletionScript)
+		case strings.HasSuffix(sh, "fish") || strings.HasSuffix(sh, "fish.exe"):
+			fmt.Fprint(os.Stdout, fishCompletionScript)
+		case strings.HasSuffix(sh, "pwsh") || strings.HasSuffix(sh, "powershell"):
+			fmt.Fprint(os.Stdout, powershellCompletionScript)
+		case os.Getenv("PSModulePath") != "":
+			fmt.Fprint(os.Stdout, powershellCompletionScript)
 		default:
-			fmt.Fprintln(os.Stderr, "usage: tfctl completion [bash|zsh]")
+			fmt.Fprintln(os.Stderr, "usage: tfctl completion [bash|zsh|fish|powershell]")
 			return nil
 		}
 	}
@@ -257,7 +335,7 @@ func completionCommandBuilder(meta meta.Meta) *cli.Command {
 	return &cli.Command{
 		Name:      "completion",
 		Usage:     "generate shell completion script",
-		UsageText: "tfctl completion [bash|zsh]",
+		UsageText: "tfctl completion [bash|zsh|fish|powershell]",
 		Metadata: map[string]any{
 			"meta": meta,
 		},
@@ -0,0 +1,203 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/apex/log"
+	"github.com/urfave/cli/v3"
+
+	"github.com/tfctl/tfctl/internal/config"
+	"github.com/tfctl/tfctl/internal/meta"
+)
+
+// configEditTarget picks the file `tfctl config edit` opens: Config.Source,
+// if a prior Load found one, otherwise $TFCTL_CFG_FILE if set, otherwise a
+// fresh tfctl.yaml under $XDG_CONFIG_HOME (falling back to
+// os.UserConfigDir), created if it doesn't exist yet -- so "config edit" on
+// a brand-new host has somewhere to write to rather than erroring.
+func configEditTarget() (string, error) {
+	if config.Config.Source != "" {
+		return config.Config.Source, nil
+	}
+
+	if cfgPath := os.Getenv("TFCTL_CFG_FILE"); cfgPath != "" {
+		return cfgPath, nil
+	}
+
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		var err error
+		dir, err = os.UserConfigDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve a config directory: %w", err)
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create %q: %w", dir, err)
+	}
+
+	path := filepath.Join(dir, "tfctl.yaml")
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		if err := os.WriteFile(path, nil, 0o600); err != nil {
+			return "", fmt.Errorf("failed to create %q: %w", path, err)
+		}
+	}
+
+	return path, nil
+}
+
+// configEditCommandAction is the action handler for `tfctl config edit`. It
+// opens the active config file (see configEditTarget) in $EDITOR, falling
+// back to "vi", then reloads the global Config so the rest of the process
+// sees any changes immediately.
+func configEditCommandAction(ctx context.Context, cmd *cli.Command) error {
+	path, err := configEditTarget()
+	if err != nil {
+		return err
+	}
+
+	editor := os.Getenv("EDITOR")
+	if editor == "" {
+		editor = "vi"
+	}
+
+	editCmd := exec.CommandContext(ctx, editor, path)
+	editCmd.Stdin = os.Stdin
+	editCmd.Stdout = os.Stdout
+	editCmd.Stderr = os.Stderr
+	if err := editCmd.Run(); err != nil {
+		return fmt.Errorf("failed to run %s: %w", editor, err)
+	}
+
+	if _, err := config.Load(); err != nil {
+		log.Debugf("config reload after edit failed: %v", err)
+	}
+
+	return nil
+}
+
+// configEncryptValueCommandAction is the action handler for `tfctl config
+// encrypt-value`. It reads a plaintext value (the first positional
+// argument, or stdin if none is given), encrypts it under a passphrase
+// (--passphrase, prompted for if unset), and writes the resulting
+// "!enc:<base64>" string tfctl.yaml can store verbatim to stdout.
+func configEncryptValueCommandAction(ctx context.Context, cmd *cli.Command) error {
+	plaintext, err := configReadValueArg(cmd)
+	if err != nil {
+		return err
+	}
+
+	passphrase, err := stateResolvePassphrase(cmd, "passphrase")
+	if err != nil {
+		return fmt.Errorf("failed to resolve passphrase: %w", err)
+	}
+
+	encrypted, err := config.EncryptValue(string(plaintext), passphrase)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt value: %w", err)
+	}
+
+	fmt.Fprintln(os.Stdout, encrypted)
+	return nil
+}
+
+// configDecryptValueCommandAction is the action handler for `tfctl config
+// decrypt-value`. It reads a "!enc:<base64>" value (the first positional
+// argument, or stdin if none is given), decrypts it under a passphrase
+// (--passphrase, prompted for if unset), and writes the plaintext to
+// stdout.
+func configDecryptValueCommandAction(ctx context.Context, cmd *cli.Command) error {
+	encoded, err := configReadValueArg(cmd)
+	if err != nil {
+		return err
+	}
+
+	passphrase, err := stateResolvePassphrase(cmd, "passphrase")
+	if err != nil {
+		return fmt.Errorf("failed to resolve passphrase: %w", err)
+	}
+
+	plaintext, err := config.DecryptValue(string(encoded), passphrase)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt value: %w", err)
+	}
+
+	fmt.Fprintln(os.Stdout, plaintext)
+	return nil
+}
+
+// configReadValueArg returns cmd's first positional argument, or, if none
+// was given, stdin read to EOF and trimmed of its trailing newline --
+// letting encrypt-value/decrypt-value be used either as `tfctl config
+// encrypt-value secret` or piped, e.g. `echo -n secret | tfctl config
+// encrypt-value`.
+func configReadValueArg(cmd *cli.Command) ([]byte, error) {
+	if arg := cmd.Args().First(); arg != "" {
+		return []byte(arg), nil
+	}
+
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read stdin: %w", err)
+	}
+
+	for len(data) > 0 && (data[len(data)-1] == '\n' || data[len(data)-1] == '\r') {
+		data = data[:len(data)-1]
+	}
+	return data, nil
+}
+
+// configCommandBuilder constructs the "config" subcommand, for editing and
+// managing tfctl.yaml and the "!enc:"-encrypted values it can contain (see
+// internal/config's multi-source Load).
+func configCommandBuilder(meta meta.Meta) *cli.Command {
+	return &cli.Command{
+		Name:  "config",
+		Usage: "edit and manage tfctl configuration",
+		Metadata: map[string]any{
+			"meta": meta,
+		},
+		Commands: []*cli.Command{
+			{
+				Name:      "edit",
+				Usage:     "open the active config file in $EDITOR",
+				UsageText: "tfctl config edit",
+				Flags:     NewGlobalFlags("config"),
+				Action:    configEditCommandAction,
+			},
+			{
+				Name:      "encrypt-value",
+				Usage:     "encrypt a value for storage in tfctl.yaml as !enc:<base64>",
+				UsageText: "tfctl config encrypt-value [VALUE] [--passphrase PASSPHRASE]",
+				Flags: append([]cli.Flag{
+					&cli.StringFlag{
+						Name:  "passphrase",
+						Usage: "passphrase to encrypt with; prompted for if unset",
+					},
+				}, NewGlobalFlags("config")...),
+				Action: configEncryptValueCommandAction,
+			},
+			{
+				Name:      "decrypt-value",
+				Usage:     "decrypt a !enc:<base64> value from tfctl.yaml",
+				UsageText: "tfctl config decrypt-value [VALUE] [--passphrase PASSPHRASE]",
+				Flags: append([]cli.Flag{
+					&cli.StringFlag{
+						Name:  "passphrase",
+						Usage: "passphrase used to encrypt the value; prompted for if unset",
+					},
+				}, NewGlobalFlags("config")...),
+				Action: configDecryptValueCommandAction,
+			},
+		},
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/apex/log"
+	"github.com/urfave/cli/v3"
+
+	"github.com/tfctl/tfctl/internal/attrs"
+	"github.com/tfctl/tfctl/internal/backend"
+	"github.com/tfctl/tfctl/internal/config"
+	"github.com/tfctl/tfctl/internal/diff"
+	"github.com/tfctl/tfctl/internal/meta"
+	"github.com/tfctl/tfctl/internal/output"
+)
+
+// diffRow is one flattened row of a diff.Result: either a whole added/
+// removed resource, or a single changed attribute within a changed
+// resource. Flattening to rows, rather than rendering diff.Result directly,
+// is what lets diffCommandAction hand the result to output.SliceDiceSpit
+// and get --filter/--sort/--color/--output for free, the same as every
+// other query command.
+type diffRow struct {
+	Resource  string `json:"resource"`
+	Action    string `json:"action"`
+	Attribute string `json:"attribute,omitempty"`
+	Old       string `json:"old,omitempty"`
+	New       string `json:"new,omitempty"`
+}
+
+// diffDefaultAttrs specifies the default attributes displayed for diff rows.
+var diffDefaultAttrs = []string{".resource", ".action", ".attribute", ".old", ".new"}
+
+// diffRows flattens result into diffRow entries: one row for each added or
+// removed resource, one row per attribute change for each changed resource.
+func diffRows(result *diff.Result) []diffRow {
+	var rows []diffRow
+	for _, r := range result.Resources {
+		if r.Action != diff.Changed {
+			rows = append(rows, diffRow{Resource: r.Address, Action: string(r.Action)})
+			continue
+		}
+		for _, c := range r.Changes {
+			oldVal, newVal := diff.FormatAttributeChange(c)
+			rows = append(rows, diffRow{
+				Resource:  r.Address,
+				Action:    string(c.Action),
+				Attribute: c.Attribute,
+				Old:       oldVal,
+				New:       newVal,
+			})
+		}
+	}
+	return rows
+}
+
+// diffCommandAction is the action handler for the "diff" subcommand. It
+// resolves two state specs against the active backend, computes their
+// structured diff via the internal/diff engine also used by `sq --diff`,
+// and emits the result through the common output pipeline.
+func diffCommandAction(ctx context.Context, cmd *cli.Command) error {
+	m := GetMeta(cmd)
+	log.Debugf("Executing action for %v", m.Args[1:])
+
+	if ShortCircuitTLDR(ctx, cmd, "diff") {
+		return nil
+	}
+
+	config.Config.Namespace = "diff"
+
+	be, err := backend.NewBackend(ctx, *cmd)
+	if err != nil {
+		return err
+	}
+
+	// Default to the two most recent state versions, the same default
+	// backend.SelfDiffer.DiffStates uses, when the caller doesn't pin one or
+	// both sides explicitly.
+	specs := []string{"CSV~1", "CSV~0"}
+	if len(m.Args) > 2 && m.Args[2] != "" {
+		specs[0] = m.Args[2]
+	}
+	if len(m.Args) > 3 && m.Args[3] != "" {
+		specs[1] = m.Args[3]
+	}
+
+	states, err := be.States(specs...)
+	if err != nil {
+		return fmt.Errorf("failed to get states: %w", err)
+	}
+	if len(states) != 2 {
+		return fmt.Errorf("expected 2 states to diff, got %d", len(states))
+	}
+
+	result, err := diff.Compute(states[0], states[1], diff.Options{
+		IgnoreAttrs: splitCSV(cmd.String("ignore-attr")),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to compute diff: %w", err)
+	}
+
+	jsonData, err := json.Marshal(diffRows(result))
+	if err != nil {
+		return fmt.Errorf("failed to marshal dataset: %w", err)
+	}
+
+	attrList := attrs.AttrList{}
+	for _, a := range diffDefaultAttrs {
+		_ = attrList.Set(a)
+	}
+	if userAttrs := cmd.String("attrs"); userAttrs != "" {
+		_ = attrList.Set(userAttrs)
+	}
+
+	var raw bytes.Buffer
+	raw.Write(jsonData)
+
+	output.SliceDiceSpit(raw, attrList, cmd, "", os.Stdout, nil, classifyDiffAction)
+
+	return nil
+}
+
+// classifyDiffAction maps a diffRow's Action -- diff.Added/diff.Removed/
+// diff.Changed, reused verbatim whether the row is a whole resource or a
+// single changed attribute -- onto the same create/update/delete bucket
+// names ps's classifyPlanAction uses, so both commands share one
+// colors.actions.* config section.
+func classifyDiffAction(row map[string]interface{}) string {
+	switch row["action"] {
+	case string(diff.Added):
+		return "create"
+	case string(diff.Removed):
+		return "delete"
+	case string(diff.Changed):
+		return "update"
+	default:
+		return ""
+	}
+}
+
+// diffCommandBuilder constructs the "diff" subcommand.
+func diffCommandBuilder(meta meta.Meta) *cli.Command {
+	return &cli.Command{
+		Name:      "diff",
+		Usage:     "diff two state versions",
+		UsageText: "tfctl diff [from] [to] [options]",
+		Metadata: map[string]any{
+			"meta": meta,
+		},
+		Flags: append([]cli.Flag{
+			&cli.StringFlag{
+				Name:  "ignore-attr",
+				Usage: "comma-separated list of attribute names to exclude from the diff",
+			},
+			NewHostFlag("diff"),
+			NewOrgFlag("diff"),
+			tldrFlag,
+			workspaceFlag,
+			noInitFlag,
+			concurrencyFlag,
+			allEpochsFlag,
+			epochFlag,
+			atFlag,
+		}, NewGlobalFlags("diff")...),
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			return ctx, GlobalFlagsValidator(ctx, cmd)
+		},
+		Action: diffCommandAction,
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/apex/log"
+	"github.com/urfave/cli/v3"
+
+	// Blank-imported so AWS resource scanners register themselves via
+	// init(), the same pattern internal/backend/backend.go would use for a
+	// blank-import-driven registry (here, direct Register calls instead of
+	// per-type factories in this package, since drift has exactly one
+	// provider today).
+	_ "github.com/tfctl/tfctl/internal/drift/aws"
+
+	"github.com/tfctl/tfctl/internal/drift"
+	"github.com/tfctl/tfctl/internal/meta"
+	"github.com/tfctl/tfctl/internal/state"
+)
+
+// driftCommandAction is the action handler for the "drift" subcommand. It
+// loads Terraform state for the target root directory, scans it against
+// live cloud resources via the registered drift.Scanners, and renders the
+// resulting drift.Report.
+func driftCommandAction(ctx context.Context, cmd *cli.Command) error {
+	m := GetMeta(cmd)
+	log.Debugf("Executing action for %v", m.Args[1:])
+
+	if ShortCircuitTLDR(ctx, cmd, "drift") {
+		return nil
+	}
+
+	stateData, err := state.LoadStateData(ctx, cmd, m.RootDir)
+	if err != nil {
+		return err
+	}
+
+	opts := drift.ScanOptions{
+		Only:   splitCSV(cmd.String("only")),
+		Ignore: splitCSV(cmd.String("ignore")),
+	}
+
+	report, err := drift.Scan(ctx, stateData, opts)
+	if err != nil {
+		return err
+	}
+
+	format := "unified"
+	if cmd.Bool("json") {
+		format = "json"
+	}
+
+	return drift.Render(os.Stdout, format, report)
+}
+
+// splitCSV splits a comma-separated flag value into its trimmed parts,
+// returning nil for an empty value.
+func splitCSV(value string) []string {
+	if value == "" {
+		return nil
+	}
+
+	var out []string
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+
+	return out
+}
+
+// driftCommandBuilder constructs the cli.Command for "drift", wiring
+// metadata, flags, and the action handler.
+func driftCommandBuilder(meta meta.Meta) *cli.Command {
+	return &cli.Command{
+		Name:      "drift",
+		Usage:     "compare state to live cloud resources",
+		UsageText: "tfctl drift [RootDir] [options]",
+		Metadata: map[string]any{
+			"meta": meta,
+		},
+		Flags: append([]cli.Flag{
+			&cli.StringFlag{
+				Name:  "only",
+				Usage: "comma-separated list of resource types to scan; default is every type with a registered scanner",
+			},
+			&cli.StringFlag{
+				Name:  "ignore",
+				Usage: "comma-separated list of resource types to exclude from scanning",
+			},
+			&cli.BoolFlag{
+				Name:        "json",
+				Usage:       "emit a machine-readable JSON report, suitable for CI gating",
+				HideDefault: true,
+			},
+			&cli.StringFlag{
+				Name:  "passphrase",
+				Usage: "encrypted state passphrase",
+			},
+			noKeyCacheFlag,
+			tldrFlag,
+			noInitFlag,
+		}, NewGlobalFlags("drift")...),
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			return ctx, GlobalFlagsValidator(ctx, cmd)
+		},
+		Action: driftCommandAction,
+	}
+}
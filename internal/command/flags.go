@@ -4,7 +4,9 @@
 package command
 
 import (
+	"math"
 	"os/exec"
+	"runtime"
 
 	altsrc "github.com/urfave/cli-altsrc/v3"
 	yaml "github.com/urfave/cli-altsrc/v3/yaml"
@@ -34,6 +36,75 @@ var (
 		),
 		Value: "",
 	}
+
+	maxWorkspacesFlag *cli.IntFlag = &cli.IntFlag{
+		Name:      "max-workspaces",
+		Usage:     "maximum workspaces to fan out across when backend.workspaces.prefix selects more than one (0 = unbounded)",
+		Value:     0,
+		Validator: IntRangeValidator(0, 99999),
+	}
+
+	concurrencyFlag *cli.IntFlag = &cli.IntFlag{
+		Name:      "concurrency",
+		Hidden:    true,
+		Usage:     "S3 backend: bounded worker pool size for fetching state version metadata (0 = s3.concurrency config, or 16)",
+		Value:     0,
+		Validator: IntRangeValidator(0, 256),
+	}
+
+	allEpochsFlag *cli.BoolFlag = &cli.BoolFlag{
+		Name:        "all-epochs",
+		Usage:       "S3 backend: include state history from before a destroy/re-apply cycle, not just the current epoch",
+		HideDefault: true,
+	}
+
+	epochFlag *cli.IntFlag = &cli.IntFlag{
+		Name:        "epoch",
+		Usage:       "S3 backend: select one delete-marker-bounded history segment (0 = current, 1 = the segment before the most recent destroy/re-apply, and so on)",
+		HideDefault: true,
+	}
+
+	atFlag *cli.StringFlag = &cli.StringFlag{
+		Name:  "at",
+		Usage: "S3 backend: select the state version live at this RFC3339 timestamp, or duration ago (e.g. \"24h\"); searches all epochs",
+	}
+
+	noKeyCacheFlag *cli.BoolFlag = &cli.BoolFlag{
+		Name:        "no-key-cache",
+		Usage:       "don't read or write the derived state encryption key from/to the OS keyring",
+		HideDefault: true,
+	}
+
+	noInitFlag *cli.BoolFlag = &cli.BoolFlag{
+		Name:        "no-init",
+		Usage:       "discover the backend from *.tf files instead of .terraform/terraform.tfstate, as if terraform init had never been run",
+		HideDefault: true,
+	}
+
+	streamFlag *cli.StringFlag = &cli.StringFlag{
+		Name:  "stream",
+		Usage: "write results to stdout as pages arrive instead of buffering the full result set, one of: ndjson, csv, tsv, yaml",
+		Validator: EnvAwareValidator("stream", nil, func(value string) error {
+			if value == "" {
+				return nil
+			}
+			return FlagValidators(value, EnumValidator("ndjson", "csv", "tsv", "yaml"))
+		}),
+	}
+
+	recursiveFlag *cli.BoolFlag = &cli.BoolFlag{
+		Name:        "recursive",
+		Aliases:     []string{"R"},
+		Usage:       "treat RootDir as a parent of one or more Terraform roots (detected via .terraform, terragrunt.hcl, or backend.tf) and aggregate results across all of them",
+		HideDefault: true,
+	}
+
+	maxWorkersFlag *cli.IntFlag = &cli.IntFlag{
+		Name:      "max-workers",
+		Usage:     "with --recursive, how many roots to query concurrently",
+		Value:     int64(runtime.NumCPU()),
+		Validator: IntRangeValidator(1, math.MaxInt32),
+	}
 )
 
 func NewGlobalFlags(params ...string) (flags []cli.Flag) {
@@ -65,9 +136,9 @@ func NewGlobalFlags(params ...string) (flags []cli.Flag) {
 			Aliases: []string{"o"},
 			Usage:   "output format",
 			Value:   "text",
-			Validator: func(value string) error {
+			Validator: EnvAwareValidator("output", nil, func(value string) error {
 				return FlagValidators(value, OutputValidator)
-			},
+			}),
 		},
 		&cli.StringFlag{
 			Name:    "sort",
@@ -80,6 +151,26 @@ func NewGlobalFlags(params ...string) (flags []cli.Flag) {
 			Usage:   "show titles with text output",
 			Value:   false,
 		},
+		&cli.BoolFlag{
+			Name:        "no-credentials-helper",
+			Usage:       "don't consult a .terraformrc credentials_helper when resolving a token",
+			HideDefault: true,
+		},
+		&cli.IntFlag{
+			Name:        "width",
+			Usage:       "fit table output to this many columns; 0 disables fitting. Defaults to the detected terminal width or $COLUMNS",
+			HideDefault: true,
+		},
+		&cli.BoolFlag{
+			Name:  "wrap",
+			Usage: "soft-wrap over-width table cells instead of truncating them",
+			Value: false,
+		},
+		&cli.StringFlag{
+			Name:  "ellipsis",
+			Usage: "marker appended to truncated table cells",
+			Value: "…",
+		},
 	}
 
 	return
@@ -99,6 +190,8 @@ func NewHostFlag(params ...string) (flag *cli.StringFlag) {
 			cli.EnvVar("TF_CLOUD_HOSTNAME"),
 		),
 		Value: "app.terraform.io",
+		Validator: EnvAwareValidator("host", []string{"TFCTL_HOST", "TF_CLOUD_HOSTNAME"},
+			RegexValidator(`^[a-zA-Z0-9.-]+$`)),
 	}
 
 	if len(params) == 2 {
@@ -120,6 +213,13 @@ func NewOrgFlag(params ...string) (flag *cli.StringFlag) {
 			cli.EnvVar("TFCTL_ORG"),
 			cli.EnvVar("TF_CLOUD_ORGANIZATION"),
 		),
+		Validator: EnvAwareValidator("org", []string{"TFCTL_ORG", "TF_CLOUD_ORGANIZATION"}, func(value string) error {
+			if value == "" {
+				// Unset is valid; org is then derived from the backend.
+				return nil
+			}
+			return RegexValidator(`^[a-zA-Z0-9_-]+$`)(value)
+		}),
 	}
 
 	// params[0] is the TFCTL config file. We only want to refer to it in non-
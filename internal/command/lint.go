@@ -0,0 +1,471 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/urfave/cli/v3"
+
+	"github.com/tfctl/tfctl/internal/backend/remote"
+	"github.com/tfctl/tfctl/internal/config"
+	"github.com/tfctl/tfctl/internal/filters"
+	"github.com/tfctl/tfctl/internal/meta"
+)
+
+// Severity is a Diagnostic's urgency, "error" or "warning". Only "error"
+// causes lintCommandAction to exit non-zero; "warning" is reported but
+// doesn't fail the run.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Diagnostic is one finding reported by a Check.
+type Diagnostic struct {
+	Severity Severity `json:"severity"`
+	// Path identifies where the finding applies: a RootDir-relative file
+	// (MixedBackendsCheck), a config set key (FilterSyntaxCheck), or a
+	// logical name (a host, an org) for checks with no file to point at.
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// Check is one pluggable lint rule. Run receives the full *cli.Command
+// rather than just a meta.Meta, because several checks (InvalidHostCheck,
+// UnknownOrgCheck, StaleStateCheck) need to build a remote/local backend,
+// which is resolved from flags (--host, --org, --workspace) as well as
+// RootDir; meta.Meta alone, as the request described, isn't enough to do
+// that the way every other backend-touching command in this package
+// already does it.
+type Check interface {
+	Name() string
+	Run(ctx context.Context, cmd *cli.Command) []Diagnostic
+}
+
+// lintChecks is the registry lintCommandAction runs, in the order each
+// check's findings should be reported.
+var lintChecks = []Check{
+	invalidHostCheck{},
+	mixedBackendsCheck{},
+	unknownOrgCheck{},
+	staleStateCheck{},
+	filterSyntaxCheck{},
+}
+
+// lintCheckResult pairs one Check's name with the Diagnostics it produced,
+// the shape reported in --format json and walked to build --format sarif.
+type lintCheckResult struct {
+	Check       string       `json:"check"`
+	Diagnostics []Diagnostic `json:"diagnostics"`
+}
+
+// lintCommandAction is the action handler for the "lint" subcommand. It
+// runs every registered Check and reports the combined diagnostics in the
+// requested --format, returning an error (so the process exits non-zero,
+// per the existing main.go convention) if any diagnostic is error-severity.
+func lintCommandAction(ctx context.Context, cmd *cli.Command) error {
+	if ShortCircuitTLDR(ctx, cmd, "lint") {
+		return nil
+	}
+
+	var (
+		results  []lintCheckResult
+		errCount int
+	)
+	for _, c := range lintChecks {
+		diags := c.Run(ctx, cmd)
+		for _, d := range diags {
+			if d.Severity == SeverityError {
+				errCount++
+			}
+		}
+		results = append(results, lintCheckResult{Check: c.Name(), Diagnostics: diags})
+	}
+
+	switch cmd.String("format") {
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(results); err != nil {
+			return fmt.Errorf("failed to render lint results as json: %w", err)
+		}
+	case "sarif":
+		if err := renderLintSARIF(os.Stdout, results); err != nil {
+			return err
+		}
+	default:
+		renderLintText(os.Stdout, results)
+	}
+
+	if errCount > 0 {
+		return fmt.Errorf("lint: %d error diagnostic(s)", errCount)
+	}
+	return nil
+}
+
+// renderLintText writes one line per diagnostic, grouped by check, the
+// human-scannable default.
+func renderLintText(w *os.File, results []lintCheckResult) {
+	for _, r := range results {
+		if len(r.Diagnostics) == 0 {
+			fmt.Fprintf(w, "%s: ok\n", r.Check)
+			continue
+		}
+		for _, d := range r.Diagnostics {
+			path := d.Path
+			if path == "" {
+				path = "-"
+			}
+			fmt.Fprintf(w, "%s: %s: %s: %s\n", r.Check, d.Severity, path, d.Message)
+		}
+	}
+}
+
+// sarifLog, sarifRun, and friends are the minimal subset of the SARIF 2.1.0
+// schema (https://sarifweb.azurewebsites.net/) GitHub code scanning actually
+// reads: one run, one tool-driver name, one result per Diagnostic.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name string `json:"name"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// renderLintSARIF writes results as a SARIF 2.1.0 log, so they can be
+// uploaded to GitHub code scanning (or any other SARIF consumer) via
+// `github/codeql-action/upload-sarif` or equivalent.
+func renderLintSARIF(w *os.File, results []lintCheckResult) error {
+	run := sarifRun{Tool: sarifTool{Driver: sarifDriver{Name: "tfctl lint"}}}
+
+	for _, r := range results {
+		for _, d := range r.Diagnostics {
+			level := "warning"
+			if d.Severity == SeverityError {
+				level = "error"
+			}
+
+			result := sarifResult{
+				RuleID:  r.Check,
+				Level:   level,
+				Message: sarifMessage{Text: d.Message},
+			}
+			if d.Path != "" {
+				result.Locations = []sarifLocation{{
+					PhysicalLocation: sarifPhysicalLocation{
+						ArtifactLocation: sarifArtifactLocation{URI: d.Path},
+					},
+				}}
+			}
+			run.Results = append(run.Results, result)
+		}
+	}
+
+	sl := sarifLog{
+		Schema:  "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(sl); err != nil {
+		return fmt.Errorf("failed to render lint results as sarif: %w", err)
+	}
+	return nil
+}
+
+// invalidHostCheck verifies the configured host is reachable and that a TFE
+// client was able to negotiate against it (tfe.NewClient resolves the
+// service discovery document and pings the API as part of construction;
+// Client(true) additionally requires the result identify as Cloud or
+// Enterprise, the same validation NewBackendRemote itself applies).
+type invalidHostCheck struct{}
+
+func (invalidHostCheck) Name() string { return "InvalidHostCheck" }
+
+func (invalidHostCheck) Run(ctx context.Context, cmd *cli.Command) []Diagnostic {
+	be, err := remote.NewBackendRemote(ctx, cmd, remote.BuckNaked())
+	if err != nil {
+		return []Diagnostic{{Severity: SeverityError, Message: fmt.Sprintf("failed to resolve host: %v", err)}}
+	}
+
+	if _, err := be.Client(true); err != nil {
+		return []Diagnostic{{
+			Severity: SeverityError,
+			Path:     be.Backend.Config.Hostname,
+			Message:  fmt.Sprintf("host unreachable or not a valid TFC/TFE API: %v", err),
+		}}
+	}
+	return nil
+}
+
+// mixedBackendsCheck looks for more than one distinct backend type declared
+// across the *.tf files directly under RootDir (root module only, same
+// scope as backend/cloud.FromHCL) - e.g. one file with
+// `backend "remote" {}` and another with `backend "s3" {}` - which
+// Terraform itself would refuse to initialize.
+type mixedBackendsCheck struct{}
+
+func (mixedBackendsCheck) Name() string { return "MixedBackendsCheck" }
+
+var lintTerraformBlockSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{{Type: "terraform"}},
+}
+
+var lintBackendBlockSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "cloud"},
+		{Type: "backend", LabelNames: []string{"type"}},
+	},
+}
+
+func (mixedBackendsCheck) Run(ctx context.Context, cmd *cli.Command) []Diagnostic {
+	m := GetMeta(cmd)
+
+	files, err := filepath.Glob(filepath.Join(m.RootDir, "*.tf"))
+	if err != nil || len(files) == 0 {
+		return nil
+	}
+
+	parser := hclparse.NewParser()
+	seen := map[string][]string{} // backend type -> files declaring it
+
+	for _, path := range files {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		f, diags := parser.ParseHCL(data, path)
+		if diags.HasErrors() {
+			continue
+		}
+
+		content, _, _ := f.Body.PartialContent(lintTerraformBlockSchema)
+		for _, tfBlock := range content.Blocks {
+			inner, _, _ := tfBlock.Body.PartialContent(lintBackendBlockSchema)
+			for _, b := range inner.Blocks {
+				kind := "cloud"
+				if b.Type == "backend" {
+					kind = b.Labels[0]
+				}
+				seen[kind] = append(seen[kind], filepath.Base(path))
+			}
+		}
+	}
+
+	if len(seen) <= 1 {
+		return nil
+	}
+
+	kinds := make([]string, 0, len(seen))
+	for kind := range seen {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+
+	var msg string
+	for i, kind := range kinds {
+		if i > 0 {
+			msg += "; "
+		}
+		msg += fmt.Sprintf("%q in %v", kind, seen[kind])
+	}
+
+	return []Diagnostic{{
+		Severity: SeverityError,
+		Path:     m.RootDir,
+		Message:  "conflicting backend configurations: " + msg,
+	}}
+}
+
+// unknownOrgCheck resolves the organization the same way every org-scoped
+// query command does (InitRemoteOrgQuery: --org flag, config, or backend)
+// and confirms the token can actually see it.
+type unknownOrgCheck struct{}
+
+func (unknownOrgCheck) Name() string { return "UnknownOrgCheck" }
+
+func (unknownOrgCheck) Run(ctx context.Context, cmd *cli.Command) []Diagnostic {
+	_, org, client, err := InitRemoteOrgQuery(ctx, cmd)
+	if err != nil {
+		return []Diagnostic{{Severity: SeverityWarning, Message: fmt.Sprintf("could not resolve an organization to check: %v", err)}}
+	}
+
+	if _, err := client.Organizations.Read(ctx, org); err != nil {
+		return []Diagnostic{{
+			Severity: SeverityError,
+			Path:     org,
+			Message:  fmt.Sprintf("organization %q not visible to the configured token: %v", org, err),
+		}}
+	}
+	return nil
+}
+
+// staleStateCheck warns when the workspace's newest state version is older
+// than --stale-after (default 720h/30d).
+type staleStateCheck struct{}
+
+func (staleStateCheck) Name() string { return "StaleStateCheck" }
+
+func (staleStateCheck) Run(ctx context.Context, cmd *cli.Command) []Diagnostic {
+	be, err := InitLocalBackendQuery(ctx, cmd)
+	if err != nil {
+		return []Diagnostic{{Severity: SeverityWarning, Message: fmt.Sprintf("could not resolve a backend to check: %v", err)}}
+	}
+
+	versions, err := be.StateVersions()
+	if err != nil || len(versions) == 0 {
+		return nil
+	}
+
+	newest := versions[0].CreatedAt
+	for _, v := range versions[1:] {
+		if v.CreatedAt.After(newest) {
+			newest = v.CreatedAt
+		}
+	}
+
+	threshold := cmd.Duration("stale-after")
+	if age := time.Since(newest); age > threshold {
+		return []Diagnostic{{
+			Severity: SeverityWarning,
+			Path:     be.String(),
+			Message:  fmt.Sprintf("newest state version is %s old (older than --stale-after %s)", age.Round(time.Hour), threshold),
+		}}
+	}
+	return nil
+}
+
+// filterSyntaxCheck parses every "--filter"/"-f" value found in the config
+// sets a command would actually have injected (<cmd>.defaults, nostate,
+// defaults - see injectConfigSet/resolveSet in main.go) via
+// filters.ParseExpr, the same parser BuildFilters uses, so a typo'd filter
+// expression is caught before it silently matches nothing at run time.
+//
+// The request as filed described this walking a dedicated "filters:" config
+// section; no such section exists here; config-supplied filters only ever
+// appear as "--filter"/"-f" tokens inside a set's flag list, so that's what
+// this actually scans. It reads each set's entries directly via
+// config.GetStringSlice rather than calling resolveSet (package main,
+// unreachable from here) - which means a "@name" set-composition reference
+// is reported as-is rather than followed into the set it names. Most sets
+// don't compose, and this is a lint, not the actual flag-injection path, so
+// that's an acceptable gap rather than a reason to duplicate resolveSet's
+// cycle/depth-tracking composition logic in this package.
+type filterSyntaxCheck struct{}
+
+func (filterSyntaxCheck) Name() string { return "FilterSyntaxCheck" }
+
+var lintFilterScanCommands = []string{"mq", "oq", "pq", "rq", "si", "sq", "svq", "wq"}
+
+func (filterSyntaxCheck) Run(ctx context.Context, cmd *cli.Command) []Diagnostic {
+	var diags []Diagnostic
+
+	keys := []string{"defaults", "nostate"}
+	for _, c := range lintFilterScanCommands {
+		keys = append(keys, c+".defaults")
+	}
+
+	for _, key := range keys {
+		entries, _ := config.GetStringSlice(key)
+		var flags []string
+		for _, entry := range entries {
+			flags = append(flags, strings.Fields(entry)...)
+		}
+
+		for i, f := range flags {
+			if (f != "--filter" && f != "-f") || i+1 >= len(flags) {
+				continue
+			}
+			spec := flags[i+1]
+			if _, err := filters.ParseExpr(spec); err != nil {
+				diags = append(diags, Diagnostic{
+					Severity: SeverityError,
+					Path:     key,
+					Message:  fmt.Sprintf("invalid filter %q: %v", spec, err),
+				})
+			}
+		}
+	}
+
+	return diags
+}
+
+// lintCommandBuilder constructs the cli.Command for "lint", wiring metadata,
+// flags, and the action handler.
+func lintCommandBuilder(meta meta.Meta) *cli.Command {
+	return &cli.Command{
+		Name:      "lint",
+		Usage:     "check config and RootDir for common misconfigurations",
+		UsageText: "tfctl lint [RootDir] [options]",
+		Metadata: map[string]any{
+			"meta": meta,
+		},
+		Flags: []cli.Flag{
+			NewHostFlag("lint", meta.Config.Source),
+			NewOrgFlag("lint", meta.Config.Source),
+			workspaceFlag,
+			tldrFlag,
+			&cli.StringFlag{
+				Name:      "format",
+				Usage:     "lint output format",
+				Value:     "text",
+				Validator: EnvAwareValidator("format", nil, EnumValidator("text", "json", "sarif")),
+			},
+			&cli.DurationFlag{
+				Name:  "stale-after",
+				Usage: "age at which StaleStateCheck flags the newest state version",
+				Value: 30 * 24 * time.Hour,
+			},
+		},
+		Action: lintCommandAction,
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/apex/log"
+	"github.com/urfave/cli/v3"
+
+	"github.com/tfctl/tfctl/internal/backend/remote"
+	"github.com/tfctl/tfctl/internal/disco"
+	"github.com/tfctl/tfctl/internal/meta"
+	"github.com/tfctl/tfctl/internal/oauthlogin"
+)
+
+// defaultLoginHost is the host `tfctl login`/`tfctl logout` target when no
+// hostname is given, matching `terraform login`'s own default.
+const defaultLoginHost = "app.terraform.io"
+
+// loginCommandAction is the action handler for `tfctl login [hostname]`. It
+// runs the browser-based OAuth2 PKCE flow login.v1 describes and saves the
+// resulting token to credentials.tfrc.json, the same file Token() already
+// reads -- no other code needs to change once it's populated.
+func loginCommandAction(ctx context.Context, cmd *cli.Command) error {
+	host := cmd.Args().First()
+	if host == "" {
+		host = defaultLoginHost
+	}
+
+	if _, found, err := disco.Discover(ctx, http.DefaultClient, host); err != nil {
+		return fmt.Errorf("failed to discover services for %s: %w", host, err)
+	} else if !found {
+		return fmt.Errorf("%s does not publish a service discovery document", host)
+	}
+
+	login, ok := disco.Login(host)
+	if !ok {
+		return fmt.Errorf("%s does not support OAuth login (no login.v1 discovery entry)", host)
+	}
+
+	pkce, err := oauthlogin.NewPKCE()
+	if err != nil {
+		return fmt.Errorf("failed to prepare login request: %w", err)
+	}
+
+	listener, err := oauthlogin.Listen(login.Ports)
+	if err != nil {
+		return err
+	}
+
+	authzURL, err := oauthlogin.AuthzURL(login, listener.RedirectURI(), pkce.State, pkce.Challenge)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "Opening the browser to:\n  %s\n\nIf it doesn't open automatically, visit that URL to finish logging in.\n", authzURL)
+	if err := oauthlogin.OpenBrowser(authzURL); err != nil {
+		log.Debugf("failed to open browser automatically: %v", err)
+	}
+
+	code, err := listener.Await(ctx, pkce.State)
+	if err != nil {
+		return fmt.Errorf("login failed: %w", err)
+	}
+
+	token, err := oauthlogin.ExchangeCode(ctx, login, code, pkce.Verifier, listener.RedirectURI())
+	if err != nil {
+		return fmt.Errorf("failed to exchange authorization code: %w", err)
+	}
+
+	if err := remote.SaveCredentials(host, token); err != nil {
+		return fmt.Errorf("failed to save credentials: %w", err)
+	}
+
+	path, _ := remote.CredentialsFilePath()
+	fmt.Fprintf(os.Stdout, "Logged in to %s. Credentials saved to %s.\n", host, path)
+
+	return nil
+}
+
+// logoutCommandAction is the action handler for `tfctl logout <host>`. It
+// only removes the local credentials.tfrc.json entry: login.v1 (see the
+// fields decoded into disco.LoginV1) has no revoke endpoint to call, unlike
+// the credentials_helper exec protocol, which does support a "forget"
+// subcommand of its own.
+func logoutCommandAction(ctx context.Context, cmd *cli.Command) error {
+	host := cmd.Args().First()
+	if host == "" {
+		return fmt.Errorf("usage: tfctl logout <host>")
+	}
+
+	if err := remote.RemoveCredentials(host); err != nil {
+		return fmt.Errorf("failed to remove credentials for %s: %w", host, err)
+	}
+
+	fmt.Fprintf(os.Stdout, "Removed local credentials for %s.\n", host)
+
+	return nil
+}
+
+// loginCommandBuilder constructs the cli.Command for "login".
+func loginCommandBuilder(meta meta.Meta) *cli.Command {
+	return &cli.Command{
+		Name:      "login",
+		Usage:     "authenticate to a Terraform Cloud/Enterprise host via OAuth2",
+		UsageText: "tfctl login [hostname]",
+		Metadata: map[string]any{
+			"meta": meta,
+		},
+		Action: loginCommandAction,
+	}
+}
+
+// logoutCommandBuilder constructs the cli.Command for "logout".
+func logoutCommandBuilder(meta meta.Meta) *cli.Command {
+	return &cli.Command{
+		Name:      "logout",
+		Usage:     "remove locally stored credentials for a host",
+		UsageText: "tfctl logout <host>",
+		Metadata: map[string]any{
+			"meta": meta,
+		},
+		Action: logoutCommandAction,
+	}
+}
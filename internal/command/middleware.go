@@ -0,0 +1,119 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/tfctl/tfctl/internal/backend/remote"
+	"github.com/tfctl/tfctl/internal/log"
+)
+
+// ActionFunc is the shape of QueryActionRunner's fetch step: given a
+// context and command, it returns the fetched results or an error.
+type ActionFunc[T any] func(context.Context, *cli.Command) ([]T, error)
+
+// Middleware wraps an ActionFunc with cross-cutting behavior (panic
+// recovery, timing, error enrichment), borrowing the interceptor-chain
+// pattern from gRPC middleware.
+type Middleware[T any] func(next ActionFunc[T]) ActionFunc[T]
+
+// Chain wraps fn with mws, applied in the order given so that mws[0] is
+// the outermost (sees the call first, the return value last).
+func Chain[T any](fn ActionFunc[T], mws ...Middleware[T]) ActionFunc[T] {
+	for i := len(mws) - 1; i >= 0; i-- {
+		fn = mws[i](fn)
+	}
+	return fn
+}
+
+// PanicError is a typed error produced by RecoveryMiddleware when a fetch
+// step panics (e.g. a nil *tfe.Client, a malformed list-options field),
+// letting the CLI exit cleanly with a message instead of a raw stack.
+type PanicError struct {
+	Value any
+	// Stack is only populated when TFCTL_LOG=debug or trace; it's rarely
+	// useful to an end user and can be long.
+	Stack string
+}
+
+func (e *PanicError) Error() string {
+	if e.Stack != "" {
+		return fmt.Sprintf("internal error: %v\n%s", e.Value, e.Stack)
+	}
+	return fmt.Sprintf("internal error: %v", e.Value)
+}
+
+// RecoveryMiddleware converts a panic in the wrapped ActionFunc into a
+// *PanicError instead of crashing the process.
+func RecoveryMiddleware[T any]() Middleware[T] {
+	return func(next ActionFunc[T]) ActionFunc[T] {
+		return func(ctx context.Context, cmd *cli.Command) (results []T, err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					pe := &PanicError{Value: r}
+					if log.DebugEnabled() {
+						pe.Stack = string(debug.Stack())
+					}
+					err = pe
+				}
+			}()
+			return next(ctx, cmd)
+		}
+	}
+}
+
+// TimingMiddleware logs how long the wrapped fetch step took, tagged with
+// name (typically the command name).
+func TimingMiddleware[T any](name string) Middleware[T] {
+	return func(next ActionFunc[T]) ActionFunc[T] {
+		return func(ctx context.Context, cmd *cli.Command) ([]T, error) {
+			start := time.Now()
+			results, err := next(ctx, cmd)
+			log.Debugf("%s: fetch took %s", name, time.Since(start))
+			return results, err
+		}
+	}
+}
+
+// DeadlineMiddleware tags a context.DeadlineExceeded/context.Canceled error
+// coming out of the wrapped fetch step with name (the command it happened
+// in), so a --timeout or Ctrl-C cancellation is reported as e.g. "wq:
+// context deadline exceeded" instead of the bare stdlib message. Results are
+// passed through unchanged - any rows already accumulated before the
+// cancellation (see PaginateWithOptions) still reach Run's caller, which
+// decides whether to flush them.
+func DeadlineMiddleware[T any](name string) Middleware[T] {
+	return func(next ActionFunc[T]) ActionFunc[T] {
+		return func(ctx context.Context, cmd *cli.Command) ([]T, error) {
+			results, err := next(ctx, cmd)
+			if err != nil && (errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled)) {
+				err = fmt.Errorf("%s: %w", name, err)
+			}
+			return results, err
+		}
+	}
+}
+
+// ErrorContextMiddleware enriches any error returned by the wrapped fetch
+// step via remote.FriendlyTFE, building the ErrorContext from ctxFn so
+// callers don't have to construct it by hand at every fetch call site.
+// ctxFn is called lazily, only when there's an error to enrich.
+func ErrorContextMiddleware[T any](ctxFn func() remote.ErrorContext) Middleware[T] {
+	return func(next ActionFunc[T]) ActionFunc[T] {
+		return func(ctx context.Context, cmd *cli.Command) ([]T, error) {
+			results, err := next(ctx, cmd)
+			if err != nil {
+				return results, remote.FriendlyTFE(err, ctxFn())
+			}
+			return results, nil
+		}
+	}
+}
@@ -5,10 +5,10 @@ package command
 
 import (
 	"context"
+	"fmt"
 	"reflect"
-	"strings"
+	"strconv"
 
-	"github.com/apex/log"
 	"github.com/hashicorp/go-tfe"
 	"github.com/urfave/cli/v3"
 
@@ -21,6 +21,60 @@ import (
 // modules in the "mq" command output.
 var mqDefaultAttrs = []string{".id", "name"}
 
+// mqValidRegistryModuleIncludes are the values tfe.RegistryModuleIncludeOpt
+// accepts; mirrored here as plain strings so an unknown --filter
+// "include=..." value can be rejected with a clear error before it ever
+// reaches the API.
+var mqValidRegistryModuleIncludes = map[string]bool{
+	"no-code-modules": true,
+	"latest-version":  true,
+}
+
+// init registers mq's server-side filter adapters with the shared
+// filters.ServerSideAugmenter registry.
+func init() {
+	filters.RegisterAugmenter("mq", "provider", func(f filters.Filter, opts *tfe.RegistryModuleListOptions) error {
+		opts.Provider = f.Value
+		return nil
+	})
+	filters.RegisterAugmenter("mq", "registry", func(f filters.Filter, opts *tfe.RegistryModuleListOptions) error {
+		switch f.Value {
+		case "public":
+			opts.RegistryName = tfe.PublicRegistry
+		case "private":
+			opts.RegistryName = tfe.PrivateRegistry
+		default:
+			return fmt.Errorf("registry must be \"public\" or \"private\", got %q", f.Value)
+		}
+		return nil
+	})
+	filters.RegisterAugmenter("mq", "name", func(f filters.Filter, opts *tfe.RegistryModuleListOptions) error {
+		opts.WildcardName = f.Value
+		return nil
+	})
+	filters.RegisterAugmenter("mq", "include", func(f filters.Filter, opts *tfe.RegistryModuleListOptions) error {
+		values := f.Values
+		if len(values) == 0 {
+			values = []string{f.Value}
+		}
+		for _, v := range values {
+			if !mqValidRegistryModuleIncludes[v] {
+				return fmt.Errorf("unknown include value %q", v)
+			}
+			opts.Include = append(opts.Include, tfe.RegistryModuleIncludeOpt(v))
+		}
+		return nil
+	})
+	filters.RegisterAugmenter("mq", "page-size", func(f filters.Filter, opts *tfe.RegistryModuleListOptions) error {
+		n, err := strconv.Atoi(f.Value)
+		if err != nil {
+			return fmt.Errorf("page-size must be numeric, got %q", f.Value)
+		}
+		opts.PageSize = n
+		return nil
+	})
+}
+
 // mqCommandAction is the action handler for the "mq" subcommand. It lists
 // registry modules for the selected organization, supports --tldr/--schema
 // shortcuts, and emits results per common flags.
@@ -62,42 +116,15 @@ func mqCommandAction(ctx context.Context, cmd *cli.Command) error {
 	).Run(ctx, cmd)
 }
 
-// mqServerSideFilterAugmenter augments the registry module list options with
-// server-side filters before each API call.
+// mqServerSideFilterAugmenter augments the RegistryModuleListOptions with
+// server-side filters extracted from the --filter flag, dispatching through
+// the shared filters.ServerSideAugmenter registry.
 func mqServerSideFilterAugmenter(
 	_ context.Context,
 	cmd *cli.Command,
 	opts *tfe.RegistryModuleListOptions,
 ) error {
-	spec := cmd.String("filter")
-	filterList := filters.BuildFilters(spec)
-
-	for _, f := range filterList {
-		// We only care about server-side filters.
-		if f.ServerSide {
-			parts := strings.Split(f.Key, ".")
-			switch parts[0] {
-			case "provider":
-				opts.Provider = f.Value
-			case "registry":
-				switch f.Value {
-				case "public":
-					opts.RegistryName = tfe.PublicRegistry
-				case "private":
-					opts.RegistryName = tfe.PrivateRegistry
-				}
-			}
-
-		}
-	}
-
-	// THINK Other server-sides to include?
-	// opts.WildcardName = "*dev*"
-	// opts.Include = append(opts.Include, tfe.WSOrganization)
-
-	log.Debugf("opts after augmentation: %+v", opts)
-
-	return nil
+	return filters.ApplyServerSide("mq", cmd, opts)
 }
 
 // mqCommandBuilder constructs the cli.Command for "mq", wiring metadata,
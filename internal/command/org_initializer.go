@@ -37,6 +37,9 @@ func InitLocalBackendQuery(ctx context.Context, cmd *cli.Command) (
 	if err != nil {
 		return nil, err
 	}
+	if rbe, ok := be.(*remote.BackendRemote); ok {
+		rbe.Audit = GetMeta(cmd).Audit
+	}
 	log.Debugf("be: %v", be)
 	return be, nil
 }
@@ -52,6 +55,7 @@ func InitRemoteOrgQuery(
 	if err != nil {
 		return nil, "", nil, err
 	}
+	be.Audit = GetMeta(cmd).Audit
 	log.Debugf("be: %v", be)
 
 	client, err := be.Client()
@@ -6,9 +6,7 @@ package command
 import (
 	"context"
 	"reflect"
-	"strings"
 
-	"github.com/apex/log"
 	"github.com/hashicorp/go-tfe"
 	"github.com/urfave/cli/v3"
 
@@ -19,6 +17,23 @@ import (
 
 var pqDefaultAttrs = []string{".id", "name"}
 
+// init registers pq's server-side filter adapters with the shared
+// filters.ServerSideAugmenter registry.
+func init() {
+	filters.RegisterAugmenter("pq", "name", func(f filters.Filter, opts *tfe.ProjectListOptions) error {
+		opts.Query = f.Value
+		return nil
+	})
+	filters.RegisterAugmenter("pq", "tag.*", func(f filters.Filter, opts *tfe.ProjectListOptions) error {
+		_, tag, _ := splitFilterKey(f.Key)
+		opts.TagBindings = append(opts.TagBindings, &tfe.TagBinding{
+			Key:   tag,
+			Value: f.Value,
+		})
+		return nil
+	})
+}
+
 // pqCommandAction is the action handler for the "pq" subcommand. It lists
 // projects for the selected organization, supports --tldr/--schema
 // short-circuit behavior, and emits output per common flags.
@@ -43,15 +58,7 @@ func pqCommandAction(ctx context.Context, cmd *cli.Command) error {
 			) {
 				page, err := client.Projects.List(ctx, org, opts)
 				if err != nil {
-					ctxErr := OrgQueryErrorContext(
-						be,
-						org,
-						"list projects",
-					)
-					return nil, nil, remote.FriendlyTFE(
-						err,
-						ctxErr,
-					)
+					return nil, nil, err
 				}
 				return page.Items, page.Pagination, nil
 			},
@@ -64,47 +71,24 @@ func pqCommandAction(ctx context.Context, cmd *cli.Command) error {
 		reflect.TypeOf((*tfe.Project)(nil)).Elem(),
 		pqDefaultAttrs,
 		fn,
-	).Run(ctx, cmd)
+	).WithErrorContext(func() remote.ErrorContext {
+		return OrgQueryErrorContext(be, org, "list projects")
+	}).Run(ctx, cmd)
 }
 
 // pqServerSideFilterAugmenter augments the ProjectListOptions with
-// server-side filters extracted from the --filter flag.
+// server-side filters extracted from the --filter flag, dispatching through
+// the shared filters.ServerSideAugmenter registry.
 func pqServerSideFilterAugmenter(
 	_ context.Context,
 	cmd *cli.Command,
 	opts *tfe.ProjectListOptions,
 ) error {
-
 	// THINK Should we do this?
 	// Include tag info.
 	opts.Include = append(opts.Include, tfe.ProjectEffectiveTagBindings)
 
-	spec := cmd.String("filter")
-	filterList := filters.BuildFilters(spec)
-
-	for _, f := range filterList {
-		// We only care about server-side filters.
-		if !f.ServerSide {
-			continue
-		}
-
-		parts := strings.Split(f.Key, ".")
-
-		if len(parts) > 1 && parts[0] == "tag" {
-			opts.TagBindings = append(opts.TagBindings, &tfe.TagBinding{
-				Key:   parts[1],
-				Value: f.Value,
-			})
-			continue
-		}
-
-		if f.Key == "name" {
-			opts.Query = f.Value
-		}
-	}
-
-	log.Debugf("opts after augmentation: %+v", opts)
-	return nil
+	return filters.ApplyServerSide("pq", cmd, opts)
 }
 
 // pqCommandBuilder constructs the cli.Command for "pq", wiring metadata,
@@ -28,9 +28,18 @@ import (
 var ansiColorRegex = regexp.MustCompile(`\x1b\[[0-9;]*m`)
 
 // PlanResource represents a parsed resource action from the plan output.
+// Provider, Module, Reason and Drift are only ever populated by
+// parseJSONPlan -- parsePlanOutput's text scrape has no equivalent
+// information to offer. They are carried in each row, but ps has no
+// --attrs flag (psCommandBuilder drops it), so its output shows only the
+// psDefaultAttrs columns, .resource and .action.
 type PlanResource struct {
 	Resource string `json:"resource"`
 	Action   string `json:"action"`
+	Provider string `json:"provider,omitempty"`
+	Module   string `json:"module,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+	Drift    string `json:"drift,omitempty"`
 }
 
 // psDefaultAttrs specifies the default attributes displayed for plan resources.
@@ -79,8 +88,26 @@ func psCommandAction(ctx context.Context, cmd *cli.Command) error {
 		defer input.Close()
 	}
 
-	// Parse the plan output and get resource actions
-	resources, err := parsePlanOutput(input, cmd.Bool("concrete"))
+	// Parse the plan output and get resource actions. --plan-format=json
+	// forces the structured path; otherwise we sniff the input, since
+	// `terraform show -json <planfile>` output works here just as well as
+	// the human-readable text parsePlanOutput expects, and shouldn't
+	// require the caller to know which one they're piping in.
+	reader := bufio.NewReader(input)
+	isJSON := cmd.String("plan-format") == "json"
+	if !isJSON {
+		isJSON, err = sniffJSONPlan(reader)
+		if err != nil {
+			return err
+		}
+	}
+
+	var resources []PlanResource
+	if isJSON {
+		resources, err = parseJSONPlan(reader, cmd.Bool("concrete"))
+	} else {
+		resources, err = parsePlanOutput(reader, cmd.Bool("concrete"))
+	}
 	if err != nil {
 		return err
 	}
@@ -106,11 +133,40 @@ func psCommandAction(ctx context.Context, cmd *cli.Command) error {
 	var raw bytes.Buffer
 	raw.Write(jsonData)
 
-	output.SliceDiceSpit(raw, attrList, cmd, "", os.Stdout, nil)
+	output.SliceDiceSpit(raw, attrList, cmd, "", os.Stdout, nil, classifyPlanAction)
 
 	return nil
 }
 
+// classifyPlanAction maps a PlanResource row's Action text down to the
+// create/update/delete/replace/read/no-op vocabulary TableWriter's row
+// coloring understands. parsePlanOutput and jsonChangeAction each produce
+// their own richer phrasing ("created", "updated in-place", "destroyed",
+// "replaced", "moved", "imported"...), so this matches by substring rather
+// than requiring an exact vocabulary. Actions with no sensible bucket --
+// "moved" and "imported" -- return "", leaving the row's normal even/odd
+// striping in place.
+func classifyPlanAction(row map[string]interface{}) string {
+	action, _ := row["action"].(string)
+
+	switch {
+	case strings.Contains(action, "replac"):
+		return "replace"
+	case strings.Contains(action, "creat"):
+		return "create"
+	case strings.Contains(action, "destroy"), strings.Contains(action, "delet"):
+		return "delete"
+	case strings.Contains(action, "updat"):
+		return "update"
+	case strings.Contains(action, "read"):
+		return "read"
+	case action == "no-op":
+		return "no-op"
+	default:
+		return ""
+	}
+}
+
 // parsePlanOutput reads the plan input and extracts resource action lines.
 // Format: # <resource-path> will be <action>
 // Example: # module.myapp[0].aws_s3_bucket.s3_loggingbucket will be created
@@ -169,6 +225,137 @@ func parsePlanOutput(input io.Reader, concrete bool) ([]PlanResource, error) {
 	return resources, nil
 }
 
+// sniffJSONPlan reports whether r's next non-whitespace byte is '{', the
+// first byte of any `terraform show -json` plan document, without
+// consuming r -- so the caller can still hand the full reader to whichever
+// of parseJSONPlan/parsePlanOutput it picks.
+func sniffJSONPlan(r *bufio.Reader) (bool, error) {
+	for n := 1; ; n++ {
+		b, err := r.Peek(n)
+		if err != nil {
+			if err == io.EOF {
+				return false, nil
+			}
+			return false, fmt.Errorf("error reading plan input: %w", err)
+		}
+		switch c := b[n-1]; c {
+		case ' ', '\t', '\n', '\r':
+			continue
+		default:
+			return c == '{', nil
+		}
+	}
+}
+
+// jsonPlan mirrors the subset of `terraform show -json <planfile>` (or the
+// final "planned_values"-bearing event of `terraform plan -json`) that ps
+// needs: the list of resource changes, plus resource_drift for the --drift
+// column.
+type jsonPlan struct {
+	ResourceChanges []jsonResourceChange `json:"resource_changes"`
+	ResourceDrift   []jsonResourceChange `json:"resource_drift"`
+}
+
+type jsonResourceChange struct {
+	Address         string `json:"address"`
+	PreviousAddress string `json:"previous_address"`
+	ModuleAddress   string `json:"module_address"`
+	ProviderName    string `json:"provider_name"`
+	ActionReason    string `json:"action_reason"`
+	Change          struct {
+		Actions   []string `json:"actions"`
+		Importing *struct {
+			ID string `json:"id"`
+		} `json:"importing"`
+	} `json:"change"`
+}
+
+// providerShortName trims a provider_name address like
+// "registry.terraform.io/hashicorp/aws" down to its local name ("aws"),
+// matching what `terraform plan`'s own text output shows.
+func providerShortName(providerName string) string {
+	if i := strings.LastIndex(providerName, "/"); i >= 0 {
+		return providerName[i+1:]
+	}
+	return providerName
+}
+
+// parseJSONPlan reads the machine-readable plan format produced by
+// `terraform show -json <planfile>` and extracts resource action lines.
+// Unlike parsePlanOutput, it decodes change.actions directly instead of
+// scraping human-readable text, so it isn't affected by wording changes in
+// Terraform's plan summary.
+func parseJSONPlan(input io.Reader, concrete bool) ([]PlanResource, error) {
+	var plan jsonPlan
+	if err := json.NewDecoder(input).Decode(&plan); err != nil {
+		return nil, fmt.Errorf("error reading plan input: %w", err)
+	}
+
+	drift := make(map[string]string, len(plan.ResourceDrift))
+	for _, rc := range plan.ResourceDrift {
+		if action, ok := jsonChangeAction(rc); ok && action != "no-op" {
+			drift[rc.Address] = action
+		}
+	}
+
+	var resources []PlanResource
+	for _, rc := range plan.ResourceChanges {
+		action, ok := jsonChangeAction(rc)
+		if !ok {
+			continue
+		}
+		if action == "no-op" {
+			continue
+		}
+		if concrete && action == "read" {
+			continue
+		}
+
+		resources = append(resources, PlanResource{
+			Resource: rc.Address,
+			Action:   action,
+			Provider: providerShortName(rc.ProviderName),
+			Module:   rc.ModuleAddress,
+			Reason:   rc.ActionReason,
+			Drift:    drift[rc.Address],
+		})
+	}
+
+	return resources, nil
+}
+
+// jsonChangeAction maps a resource change's actions (and its importing/
+// previous_address fields) to the same action vocabulary parsePlanOutput
+// produces from human-readable text.
+func jsonChangeAction(rc jsonResourceChange) (string, bool) {
+	if rc.PreviousAddress != "" && rc.PreviousAddress != rc.Address {
+		return "moved", true
+	}
+	if rc.Change.Importing != nil {
+		return "imported", true
+	}
+
+	actions := rc.Change.Actions
+	switch {
+	case len(actions) == 1 && actions[0] == "create":
+		return "created", true
+	case len(actions) == 1 && actions[0] == "update":
+		return "updated in-place", true
+	case len(actions) == 1 && actions[0] == "delete":
+		return "destroyed", true
+	case len(actions) == 1 && actions[0] == "read":
+		return "read", true
+	case len(actions) == 1 && actions[0] == "no-op":
+		return "no-op", true
+	case len(actions) == 2 && actions[0] == "delete" && actions[1] == "create":
+		return "replaced", true
+	case len(actions) == 2 && actions[0] == "create" && actions[1] == "delete":
+		return "replaced", true
+	default:
+		return "", false
+	}
+}
+
 // psCommandBuilder constructs the "ps" subcommand.
 func psCommandBuilder(meta meta.Meta) *cli.Command {
 	flags := NewGlobalFlags("ps")
@@ -193,6 +380,14 @@ func psCommandBuilder(meta meta.Meta) *cli.Command {
 				Usage:   "only include concrete resources",
 				Value:   false,
 			},
+			&cli.StringFlag{
+				Name:  "plan-format",
+				Usage: "plan input format: text|json",
+				Value: "text",
+				Validator: func(value string) error {
+					return FlagValidators(value, PlanFormatValidator)
+				},
+			},
 		}...),
 		Action: psCommandAction,
 	}
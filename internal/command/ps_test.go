@@ -1,6 +1,7 @@
 package command
 
 import (
+	"bufio"
 	"strings"
 	"testing"
 
@@ -63,3 +64,153 @@ func TestParsePlanOutputConcrete(t *testing.T) {
 
 	assert.Equal(t, expected, resources)
 }
+
+// testJSONPlan is the shared example `terraform show -json` plan output used
+// across all parseJSONPlan tests.
+var testJSONPlan = `{
+  "resource_changes": [
+    {
+      "address": "module.myapp.aws_s3_bucket.bucket",
+      "change": {"actions": ["create"]}
+    },
+    {
+      "address": "aws_instance.web",
+      "change": {"actions": ["update"]}
+    },
+    {
+      "address": "aws_instance.old",
+      "change": {"actions": ["delete", "create"]}
+    },
+    {
+      "address": "aws_instance.gone",
+      "change": {"actions": ["delete"]}
+    },
+    {
+      "address": "data.aws_caller_identity.validator",
+      "change": {"actions": ["read"]}
+    },
+    {
+      "address": "aws_instance.unchanged",
+      "change": {"actions": ["no-op"]}
+    },
+    {
+      "address": "aws_instance.renamed",
+      "previous_address": "aws_instance.old_name",
+      "change": {"actions": ["update"]}
+    },
+    {
+      "address": "aws_instance.imported",
+      "change": {"actions": ["create"], "importing": {"id": "i-1234"}}
+    }
+  ]
+}`
+
+func TestParseJSONPlan(t *testing.T) {
+	reader := strings.NewReader(testJSONPlan)
+	resources, err := parseJSONPlan(reader, false)
+	assert.NoError(t, err)
+
+	expected := []PlanResource{
+		{Resource: "module.myapp.aws_s3_bucket.bucket", Action: "created"},
+		{Resource: "aws_instance.web", Action: "updated in-place"},
+		{Resource: "aws_instance.old", Action: "replaced"},
+		{Resource: "aws_instance.gone", Action: "destroyed"},
+		{Resource: "data.aws_caller_identity.validator", Action: "read"},
+		{Resource: "aws_instance.renamed", Action: "moved"},
+		{Resource: "aws_instance.imported", Action: "imported"},
+	}
+
+	assert.Equal(t, expected, resources)
+}
+
+// testJSONPlanColumns exercises provider_name, module_address, action_reason
+// and resource_drift, none of which testJSONPlan's entries set.
+var testJSONPlanColumns = `{
+  "resource_changes": [
+    {
+      "address": "module.myapp.aws_s3_bucket.bucket",
+      "module_address": "module.myapp",
+      "provider_name": "registry.terraform.io/hashicorp/aws",
+      "action_reason": "replace_because_cannot_update_value",
+      "change": {"actions": ["delete", "create"]}
+    },
+    {
+      "address": "aws_instance.web",
+      "provider_name": "registry.terraform.io/hashicorp/aws",
+      "change": {"actions": ["update"]}
+    }
+  ],
+  "resource_drift": [
+    {
+      "address": "aws_instance.web",
+      "change": {"actions": ["update"]}
+    },
+    {
+      "address": "aws_instance.unchanged",
+      "change": {"actions": ["no-op"]}
+    }
+  ]
+}`
+
+func TestParseJSONPlanColumns(t *testing.T) {
+	reader := strings.NewReader(testJSONPlanColumns)
+	resources, err := parseJSONPlan(reader, false)
+	assert.NoError(t, err)
+
+	expected := []PlanResource{
+		{
+			Resource: "module.myapp.aws_s3_bucket.bucket",
+			Action:   "replaced",
+			Provider: "aws",
+			Module:   "module.myapp",
+			Reason:   "replace_because_cannot_update_value",
+		},
+		{
+			Resource: "aws_instance.web",
+			Action:   "updated in-place",
+			Provider: "aws",
+			Drift:    "updated in-place",
+		},
+	}
+
+	assert.Equal(t, expected, resources)
+}
+
+func TestSniffJSONPlan(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  bool
+	}{
+		{"json", testJSONPlan, true},
+		{"json with leading whitespace", "  \n\t{}", true},
+		{"text", testPlanOutput, false},
+		{"empty", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sniffJSONPlan(bufio.NewReader(strings.NewReader(tt.input)))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseJSONPlanConcrete(t *testing.T) {
+	reader := strings.NewReader(testJSONPlan)
+	resources, err := parseJSONPlan(reader, true)
+	assert.NoError(t, err)
+
+	// With concrete=true, the data source read is excluded.
+	expected := []PlanResource{
+		{Resource: "module.myapp.aws_s3_bucket.bucket", Action: "created"},
+		{Resource: "aws_instance.web", Action: "updated in-place"},
+		{Resource: "aws_instance.old", Action: "replaced"},
+		{Resource: "aws_instance.gone", Action: "destroyed"},
+		{Resource: "aws_instance.renamed", Action: "moved"},
+		{Resource: "aws_instance.imported", Action: "imported"},
+	}
+
+	assert.Equal(t, expected, resources)
+}
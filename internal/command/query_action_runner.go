@@ -5,10 +5,16 @@ package command
 
 import (
 	"context"
+	"errors"
+	"os"
 	"reflect"
 
 	"github.com/apex/log"
 	"github.com/urfave/cli/v3"
+
+	"github.com/tfctl/tfctl/internal/attrs"
+	"github.com/tfctl/tfctl/internal/backend/remote"
+	"github.com/tfctl/tfctl/internal/output"
 )
 
 // QueryActionRunner[T] encapsulates the common query action pattern for all
@@ -20,6 +26,19 @@ type QueryActionRunner[T any] struct {
 	SchemaType   reflect.Type
 	DefaultAttrs []string
 	FetchFn      func(context.Context, *cli.Command) ([]T, error)
+
+	// Middlewares wraps FetchFn, innermost last, on top of the built-in
+	// panic recovery and timing middlewares every runner gets for free.
+	// Use WithErrorContext to add TFE error enrichment.
+	Middlewares []Middleware[T]
+}
+
+// WithErrorContext appends an ErrorContextMiddleware built from ctxFn,
+// sparing callers from constructing a remote.ErrorContext by hand at every
+// fetch call site.
+func (qar *QueryActionRunner[T]) WithErrorContext(ctxFn func() remote.ErrorContext) *QueryActionRunner[T] {
+	qar.Middlewares = append(qar.Middlewares, ErrorContextMiddleware[T](ctxFn))
+	return qar
 }
 
 // Run executes the query action with the provided context and command.
@@ -43,9 +62,32 @@ func (qar *QueryActionRunner[T]) Run(
 	attrs := BuildAttrs(cmd, qar.DefaultAttrs...)
 	log.Debugf("attrs: %v", attrs)
 
-	// Step 4: Fetch data.
-	results, err := qar.FetchFn(ctx, cmd)
+	// Step 4: Fetch data, through the panic-recovery/timing/deadline/
+	// error-context middleware chain.
+	mws := append([]Middleware[T]{RecoveryMiddleware[T](), TimingMiddleware[T](qar.CommandName), DeadlineMiddleware[T](qar.CommandName)}, qar.Middlewares...)
+	fetch := Chain(ActionFunc[T](qar.FetchFn), mws...)
+
+	if cmd.Bool("recursive") {
+		return qar.runRecursive(ctx, cmd, attrs, fetch)
+	}
+
+	if format := cmd.String("stream"); format != "" {
+		return qar.runStreaming(ctx, cmd, attrs, format, fetch)
+	}
+
+	results, err := fetch(ctx, cmd)
 	if err != nil {
+		// A --timeout/Ctrl-C cancellation may still have left us holding
+		// whatever pages PaginateWithOptions had already collected; flush
+		// those before returning the (now command-tagged) error, so a CI job
+		// with a hard wall-clock budget gets partial output instead of
+		// nothing.
+		if len(results) > 0 && (errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled)) {
+			log.Warnf("%s: emitting %d partial result(s) collected before cancellation", qar.CommandName, len(results))
+			if emitErr := EmitJSONAPISlice(results, attrs, cmd); emitErr != nil {
+				return emitErr
+			}
+		}
 		return err
 	}
 
@@ -56,6 +98,78 @@ func (qar *QueryActionRunner[T]) Run(
 	return nil
 }
 
+// runStreaming is Run's --stream path. It hands fetch a page sink via
+// context (see withPageSink), so any fetcher built on PaginateWithOptions -
+// or a hand-rolled pagination loop that checks pageSinkFromContext, like
+// svq's fetchStateVersionsPushdown - writes each page straight to stdout as
+// soon as it arrives, rather than waiting for the whole result set to
+// accumulate. A fetcher that doesn't paginate in pages at all (e.g. a
+// local/S3 backend's single-shot read) never invokes the sink, so
+// runStreaming falls back to streaming the one batch it gets back from
+// fetch once it returns.
+func (qar *QueryActionRunner[T]) runStreaming(
+	ctx context.Context,
+	cmd *cli.Command,
+	al attrs.AttrList,
+	format string,
+	fetch ActionFunc[T],
+) error {
+	enc, err := output.NewStreamEncoder(format, os.Stdout)
+	if err != nil {
+		return err
+	}
+
+	if cmd.Bool("local") {
+		for i := range al {
+			al[i].TransformSpec += "t"
+		}
+	}
+
+	streamedAnyPage := false
+	sink := func(page []T) error {
+		streamedAnyPage = true
+		return writeStreamPage(enc, page, al, cmd)
+	}
+
+	results, err := fetch(withPageSink(ctx, sink), cmd)
+	if err != nil {
+		return err
+	}
+
+	if !streamedAnyPage {
+		if err := writeStreamPage(enc, results, al, cmd); err != nil {
+			return err
+		}
+	}
+
+	return enc.Close()
+}
+
+// runRecursive is Run's --recursive path. It discovers every Terraform root
+// under GetMeta(cmd).RootDir (see DiscoverRoots) and calls fetch once per
+// root, each against its own root-scoped *cli.Command, aggregating the
+// attrs-projected rows into a single sorted emit instead of one per root.
+// See RunRecursiveRows for the fan-out/error-collection behavior.
+func (qar *QueryActionRunner[T]) runRecursive(
+	ctx context.Context,
+	cmd *cli.Command,
+	al attrs.AttrList,
+	fetch ActionFunc[T],
+) error {
+	rows, err := RunRecursiveRows(ctx, cmd, &al, func(ctx context.Context, scoped *cli.Command) ([]map[string]interface{}, error) {
+		results, err := fetch(ctx, scoped)
+		if err != nil {
+			return nil, err
+		}
+		return projectRows(results, al, scoped)
+	})
+	if err != nil {
+		return err
+	}
+
+	return EmitJSONAPIRows(rows, al, cmd, nil)
+}
+
 // NewQueryActionRunner creates a QueryActionRunner with the provided
 // configuration. It's a convenience factory that reduces boilerplate in
 // individual command files.
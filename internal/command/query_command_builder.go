@@ -37,6 +37,7 @@ func (qcb *QueryCommandBuilder) Build() *cli.Command {
 		Flags: append(qcb.Flags, append([]cli.Flag{
 			tldrFlag,
 			schemaFlag,
+			streamFlag,
 		}, NewGlobalFlags(qcb.Name)...)...),
 		Before: func(ctx context.Context, c *cli.Command) (context.Context, error) {
 			return ctx, GlobalFlagsValidator(ctx, c)
@@ -0,0 +1,163 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+
+	"github.com/apex/log"
+	"github.com/urfave/cli/v3"
+
+	"github.com/tfctl/tfctl/internal/attrs"
+	"github.com/tfctl/tfctl/internal/meta"
+)
+
+// recursiveRootMarkers are the files/directories whose presence identifies
+// a directory as a Terraform root for --recursive discovery.
+var recursiveRootMarkers = []string{".terraform", "terragrunt.hcl", "backend.tf"}
+
+// DiscoverRoots walks parent and returns every descendant directory (parent
+// itself excluded) that looks like a Terraform root, i.e. contains one of
+// recursiveRootMarkers. Once a directory matches, its own subdirectories
+// aren't descended into, so a module cache's nested .terraform directories
+// inside an already-matched root don't themselves show up as additional
+// roots. Roots are returned in the order filepath.WalkDir visits them
+// (lexical, depth-first).
+func DiscoverRoots(parent string) ([]string, error) {
+	var roots []string
+
+	err := filepath.WalkDir(parent, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() || path == parent {
+			return nil
+		}
+
+		for _, marker := range recursiveRootMarkers {
+			if _, statErr := os.Stat(filepath.Join(path, marker)); statErr == nil {
+				roots = append(roots, path)
+				return filepath.SkipDir
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("--recursive: failed to walk %s: %w", parent, err)
+	}
+
+	return roots, nil
+}
+
+// rootScopedCommand returns a shallow copy of cmd whose Metadata["meta"] is
+// rebound to root, so anything downstream that reads RootDir off
+// cmd.Metadata (backend.NewBackend, chiefly) resolves against root instead
+// of the RootDir InitApp originally parsed. Each recursive worker gets its
+// own copy, via its own Metadata map, so concurrent workers never race on
+// the same map.
+func rootScopedCommand(cmd *cli.Command, root string) *cli.Command {
+	m := GetMeta(cmd)
+	m.RootDirSpec = meta.RootDirSpec{RootDir: root, Env: m.Env}
+
+	scoped := *cmd
+	scoped.Metadata = make(map[string]any, len(cmd.Metadata))
+	for k, v := range cmd.Metadata {
+		scoped.Metadata[k] = v
+	}
+	scoped.Metadata["meta"] = m
+
+	return &scoped
+}
+
+// RecursiveRowSource returns the attrs-filtered (not yet transformed or
+// sorted) result rows for a single Terraform root, given a cmd already
+// scoped to that root (see rootScopedCommand).
+type RecursiveRowSource func(ctx context.Context, cmd *cli.Command) ([]map[string]interface{}, error)
+
+// RunRecursiveRows is --recursive's shared implementation: it discovers
+// every Terraform root under GetMeta(cmd).RootDir, appends "rootDir" and
+// "env" to *al so they survive the attrs projection, then calls source
+// once per root, fanning out across at most --max-workers roots at a time
+// through a bounded worker pool (the same sem-channel + sync.WaitGroup
+// shape internal/backend/s3 already uses for its own bounded fan-out)
+// rather than pulling in a new dependency for it. Every returned row is
+// stamped with the root it came from. A root's failure is logged and
+// excluded from the aggregate rather than aborting the rest of the run;
+// RunRecursiveRows only returns an error if every root failed.
+func RunRecursiveRows(ctx context.Context, cmd *cli.Command, al *attrs.AttrList, source RecursiveRowSource) ([]map[string]interface{}, error) {
+	m := GetMeta(cmd)
+
+	roots, err := DiscoverRoots(m.RootDir)
+	if err != nil {
+		return nil, err
+	}
+	if len(roots) == 0 {
+		return nil, fmt.Errorf("--recursive: no Terraform root found under %s", m.RootDir)
+	}
+
+	//nolint:errcheck
+	al.Set("rootDir")
+	//nolint:errcheck
+	al.Set("env")
+
+	maxWorkers := int(cmd.Int("max-workers"))
+	if maxWorkers <= 0 {
+		maxWorkers = runtime.NumCPU()
+	}
+
+	rowsByRoot := make([][]map[string]interface{}, len(roots))
+	errsByRoot := make([]error, len(roots))
+
+	sem := make(chan struct{}, maxWorkers)
+	var wg sync.WaitGroup
+	for i, root := range roots {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, root string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			scoped := rootScopedCommand(cmd, root)
+			rows, err := source(ctx, scoped)
+			if err != nil {
+				errsByRoot[i] = err
+				return
+			}
+
+			env := GetMeta(scoped).Env
+			for _, row := range rows {
+				row["rootDir"] = root
+				row["env"] = env
+			}
+			rowsByRoot[i] = rows
+		}(i, root)
+	}
+	wg.Wait()
+
+	var rows []map[string]interface{}
+	failures := 0
+	for i, root := range roots {
+		if errsByRoot[i] != nil {
+			failures++
+			log.Errorf("--recursive: %s: %v", root, errsByRoot[i])
+			continue
+		}
+		rows = append(rows, rowsByRoot[i]...)
+	}
+
+	if failures == len(roots) {
+		return nil, fmt.Errorf("--recursive: all %d roots failed", len(roots))
+	}
+	if failures > 0 {
+		log.Warnf("--recursive: %d of %d roots failed, results are partial", failures, len(roots))
+	}
+
+	return rows, nil
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/go-tfe"
 	"github.com/urfave/cli/v3"
 
+	"github.com/tfctl/tfctl/internal/backend/remote"
 	"github.com/tfctl/tfctl/internal/meta"
 )
 
@@ -21,34 +22,67 @@ var rqDefaultAttrs = []string{".id", "created-at", "status"}
 // runs via the active backend, supports --tldr/--schema shortcuts, and
 // emits results per common flags.
 func rqCommandAction(ctx context.Context, cmd *cli.Command) error {
-	be, err := InitLocalBackendQuery(ctx, cmd)
-	if err != nil {
-		return err
-	}
-
-	// Create a fetcher that delegates to the backend
-	fetcher := func(
-		ctx context.Context,
-		org string,
-		opts *tfe.RunListOptions,
-	) ([]*tfe.Run, *tfe.Pagination, error) {
-		runs, err := be.Runs()
+	// The backend is resolved inside fn, not once up front, so that
+	// --recursive (which calls fn once per root, each against its own
+	// root-scoped *cli.Command) re-resolves against the right root every
+	// time instead of reusing the first root's backend for all of them.
+	fn := func(ctx context.Context, cmd *cli.Command) ([]*tfe.Run, error) {
+		be, err := InitLocalBackendQuery(ctx, cmd)
 		if err != nil {
-			return nil, nil, err
+			return nil, err
 		}
-		// Local backend doesn't support pagination, return all results
-		return runs, &tfe.Pagination{NextPage: 0}, nil
-	}
 
-	// Use RemoteQueryFetcherFactory to handle augmentation
-	// (though local backend doesn't support it)
-	fn := RemoteQueryFetcherFactory(
-		nil, // no backend for error context (local backend)
-		"",  // no org needed
-		fetcher,
-		rqServerSideFilterAugmenter,
-		"list runs",
-	)
+		// Create a fetcher that delegates to the backend. For a remote backend
+		// whose backend.workspaces.prefix selects more than one workspace (e.g.
+		// "prod-" with no specific environment chosen), fan out across every
+		// matching workspace via Workspaces() and concatenate their runs,
+		// bounded by --max-workspaces; every other backend/selector shape is
+		// unaffected and still makes exactly one Runs() call.
+		fetcher := func(
+			ctx context.Context,
+			org string,
+			opts *tfe.RunListOptions,
+		) ([]*tfe.Run, *tfe.Pagination, error) {
+			rbe, ok := be.(*remote.BackendRemote)
+			if !ok {
+				runs, err := be.Runs()
+				if err != nil {
+					return nil, nil, err
+				}
+				// Local backend doesn't support pagination, return all results
+				return runs, &tfe.Pagination{NextPage: 0}, nil
+			}
+
+			workspaces, err := rbe.Workspaces(ctx, int(cmd.Int("max-workspaces")))
+			if err != nil {
+				return nil, nil, err
+			}
+
+			var runs []*tfe.Run
+			for _, ws := range workspaces {
+				rbe.WorkspaceOverride = ws.Name
+				wsRuns, err := rbe.Runs()
+				if err != nil {
+					rbe.WorkspaceOverride = ""
+					return nil, nil, err
+				}
+				runs = append(runs, wsRuns...)
+			}
+			rbe.WorkspaceOverride = ""
+
+			return runs, &tfe.Pagination{NextPage: 0}, nil
+		}
+
+		// Use RemoteQueryFetcherFactory to handle augmentation
+		// (though local backend doesn't support it)
+		return RemoteQueryFetcherFactory(
+			nil, // no backend for error context (local backend)
+			"",  // no org needed
+			fetcher,
+			rqServerSideFilterAugmenter,
+			"list runs",
+		)(ctx, cmd)
+	}
 
 	return NewQueryActionRunner(
 		"rq",
@@ -77,14 +111,18 @@ func rqCommandBuilder(meta meta.Meta) *cli.Command {
 		UsageText: "tfctl rq [RootDir] [options]",
 		Flags: []cli.Flag{
 			&cli.IntFlag{
-				Name:    "limit",
-				Aliases: []string{"l"},
-				Usage:   "limit runs returned",
-				Value:   99999,
+				Name:      "limit",
+				Aliases:   []string{"l"},
+				Usage:     "limit runs returned",
+				Value:     99999,
+				Validator: IntRangeValidator(1, 99999),
 			},
 			NewHostFlag("rq"),
 			NewOrgFlag("rq"),
 			workspaceFlag,
+			maxWorkspacesFlag,
+			recursiveFlag,
+			maxWorkersFlag,
 		},
 		Action: rqCommandAction,
 		Meta:   meta,
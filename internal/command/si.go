@@ -9,7 +9,9 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/cursor"
 	"github.com/charmbracelet/bubbles/textinput"
@@ -21,6 +23,7 @@ import (
 
 	"github.com/tfctl/tfctl/internal/command/si"
 	"github.com/tfctl/tfctl/internal/config"
+	"github.com/tfctl/tfctl/internal/drift"
 	"github.com/tfctl/tfctl/internal/meta"
 	"github.com/tfctl/tfctl/internal/state"
 )
@@ -34,6 +37,9 @@ func siCommandAction(ctx context.Context, cmd *cli.Command) error {
 
 	config.Config.Namespace = "si"
 
+	si.FSConfig.Allowed = cmd.Bool("allow-fs")
+	si.FSConfig.BaseDir = meta.RootDir
+
 	// Use the same backend detection and state loading as sq
 	stateData, err := state.LoadStateData(ctx, cmd, meta.RootDir)
 	if err != nil {
@@ -41,20 +47,37 @@ func siCommandAction(ctx context.Context, cmd *cli.Command) error {
 	}
 
 	// Run interactive console
-	return runSiInteractiveConsole(stateData)
+	return runSiInteractiveConsole(ctx, stateData, cmd.String("output"))
+}
+
+// siHistoryEntry is one entry in ~/.tfctl_si_history: the command and the
+// time it was entered. The time is saved to and loaded from the file, but
+// ctrl+r search does not read it: search matches by position, newest first.
+type siHistoryEntry struct {
+	Time    time.Time
+	Command string
 }
 
 // siModel represents the Bubble Tea model for si command
 type siModel struct {
+	ctx            context.Context
 	input          textinput.Model
-	history        []string // Full history for navigation (includes file history)
-	sessionHistory []string // Only commands from this session (matches with outputs)
+	history        []siHistoryEntry // Full history for navigation (includes file history)
+	sessionHistory []string         // Only commands from this session (matches with outputs)
 	histIndex      int
 	output         []string
 	stateData      map[string]interface{}
+	format         string // --output format passed to si.ProcessQueryFormatted
+
+	// searchMode, when true, puts Update into ctrl+r reverse-incremental
+	// search: keystrokes extend/shrink searchPattern instead of editing
+	// input, and searchIndex tracks the current match into history.
+	searchMode    bool
+	searchPattern string
+	searchIndex   int
 }
 
-func initialSiModel(stateData map[string]interface{}) siModel {
+func initialSiModel(ctx context.Context, stateData map[string]interface{}, format string) siModel {
 	ti := textinput.New()
 	ti.Placeholder = ""
 	ti.Focus()
@@ -75,12 +98,15 @@ func initialSiModel(stateData map[string]interface{}) siModel {
 	output = append(output, "Type 'help' for syntax, 'exit' or Ctrl+C to quit.")
 
 	return siModel{
+		ctx:            ctx,
 		input:          ti,
 		history:        history,
 		sessionHistory: []string{}, // Empty for new session
 		histIndex:      -1,
 		output:         output,
 		stateData:      stateData,
+		format:         format,
+		searchIndex:    -1,
 	}
 }
 
@@ -89,7 +115,25 @@ func (m siModel) Init() tea.Cmd {
 }
 
 func (m siModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if m.searchMode {
+		return m.updateSearch(msg)
+	}
+
 	if key, ok := msg.(tea.KeyMsg); ok {
+		// Bracketed paste: bubbletea reports embedded newlines in Runes with
+		// Paste set rather than delivering them as separate "enter" key
+		// presses. Collapse them to spaces so a multi-line JSON path pasted
+		// from a browser lands in the input as a single query instead of
+		// being submitted line-by-line.
+		if key.Paste && key.Type == tea.KeyRunes {
+			text := strings.NewReplacer("\r\n", " ", "\n", " ").Replace(string(key.Runes))
+			val := m.input.Value()
+			pos := m.input.Position()
+			m.input.SetValue(val[:pos] + text + val[pos:])
+			m.input.SetCursor(pos + len(text))
+			return m, nil
+		}
+
 		switch key.String() {
 		case "enter":
 			entry := m.input.Value()
@@ -99,7 +143,7 @@ func (m siModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					return m, tea.Quit
 				}
 				if entry == "help" {
-					m.history = append(m.history, entry)
+					m.history = append(m.history, siHistoryEntry{Time: time.Now(), Command: entry})
 					m.sessionHistory = append(m.sessionHistory, entry)
 					m.histIndex = -1
 					m.output = append(m.output, getSiHelp())
@@ -109,9 +153,9 @@ func (m siModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 
 				// Process query and get output
-				result := processSiQuery(m.stateData, entry)
+				result := processSiQuery(m.ctx, m.stateData, entry, m.format)
 
-				m.history = append(m.history, entry)
+				m.history = append(m.history, siHistoryEntry{Time: time.Now(), Command: entry})
 				m.sessionHistory = append(m.sessionHistory, entry)
 				m.histIndex = -1
 				m.output = append(m.output, result)
@@ -129,7 +173,7 @@ func (m siModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			} else if m.histIndex > 0 {
 				m.histIndex--
 			}
-			m.input.SetValue(m.history[m.histIndex])
+			m.input.SetValue(m.history[m.histIndex].Command)
 			m.input.CursorEnd()
 			return m, nil
 
@@ -139,7 +183,7 @@ func (m siModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			if m.histIndex >= 0 && m.histIndex < len(m.history)-1 {
 				m.histIndex++
-				m.input.SetValue(m.history[m.histIndex])
+				m.input.SetValue(m.history[m.histIndex].Command)
 				m.input.CursorEnd()
 			} else {
 				m.histIndex = -1
@@ -147,6 +191,36 @@ func (m siModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 
+		case "ctrl+r":
+			m.searchMode = true
+			m.searchPattern = ""
+			m.searchIndex = -1
+			return m, nil
+
+		case "ctrl+a":
+			m.input.CursorStart()
+			return m, nil
+
+		case "ctrl+e":
+			m.input.CursorEnd()
+			return m, nil
+
+		case "ctrl+u":
+			val := m.input.Value()
+			pos := m.input.Position()
+			m.input.SetValue(val[pos:])
+			m.input.SetCursor(0)
+			return m, nil
+
+		case "ctrl+w":
+			val := m.input.Value()
+			pos := m.input.Position()
+			before := strings.TrimRight(val[:pos], " ")
+			cut := strings.LastIndex(before, " ") + 1
+			m.input.SetValue(val[:cut] + val[pos:])
+			m.input.SetCursor(cut)
+			return m, nil
+
 		case "ctrl+c", "esc":
 			return m, tea.Quit
 		}
@@ -157,6 +231,71 @@ func (m siModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// updateSearch handles keystrokes while in ctrl+r reverse-incremental search
+// mode: printable characters extend searchPattern and re-search from the most
+// recent history entry; a repeated ctrl+r cycles to the next older match.
+func (m siModel) updateSearch(msg tea.Msg) (tea.Model, tea.Cmd) {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch key.String() {
+	case "ctrl+r":
+		if m.searchIndex > 0 {
+			m.searchIndex = searchHistoryBackward(m.history, m.searchPattern, m.searchIndex-1)
+		} else {
+			m.searchIndex = -1
+		}
+		return m, nil
+
+	case "enter", "esc":
+		if m.searchIndex >= 0 {
+			m.input.SetValue(m.history[m.searchIndex].Command)
+			m.input.CursorEnd()
+		}
+		m.searchMode = false
+		m.searchPattern = ""
+		m.searchIndex = -1
+		return m, nil
+
+	case "ctrl+c":
+		m.searchMode = false
+		m.searchPattern = ""
+		m.searchIndex = -1
+		return m, nil
+
+	case "backspace":
+		if len(m.searchPattern) > 0 {
+			runes := []rune(m.searchPattern)
+			m.searchPattern = string(runes[:len(runes)-1])
+			m.searchIndex = searchHistoryBackward(m.history, m.searchPattern, len(m.history)-1)
+		}
+		return m, nil
+	}
+
+	if key.Type == tea.KeyRunes {
+		m.searchPattern += string(key.Runes)
+		m.searchIndex = searchHistoryBackward(m.history, m.searchPattern, len(m.history)-1)
+	}
+
+	return m, nil
+}
+
+// searchHistoryBackward returns the index of the most recent entry at or
+// before start whose command contains pattern, or -1 if none matches.
+func searchHistoryBackward(history []siHistoryEntry, pattern string, start int) int {
+	if pattern == "" {
+		return -1
+	}
+	for i := start; i >= 0; i-- {
+		if strings.Contains(history[i].Command, pattern) {
+			return i
+		}
+	}
+	return -1
+}
+
 func (m siModel) View() string {
 	// Terraform purple style for the prompt
 	promptStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#623CE4"))
@@ -180,8 +319,16 @@ func (m siModel) View() string {
 		}
 	}
 
-	// Add current prompt and input
-	lines = append(lines, promptStyle.Render("> ")+m.input.View())
+	// Add current prompt and input, or the reverse-i-search prompt
+	if m.searchMode {
+		match := ""
+		if m.searchIndex >= 0 {
+			match = m.history[m.searchIndex].Command
+		}
+		lines = append(lines, fmt.Sprintf("(reverse-i-search)`%s': %s", m.searchPattern, match))
+	} else {
+		lines = append(lines, promptStyle.Render("> ")+m.input.View())
+	}
 
 	return strings.Join(lines, "\n")
 }
@@ -215,9 +362,14 @@ func getSiHelp() string {
      terraform_version                - Get Terraform version
      version                          - Get state file version
      outputs.name                     - Get output value
+     /drift                           - Scan all resources for drift
+     /drift aws_instance.web          - Scan one resource's address for drift
 
   Navigation:
      ↑/↓ arrows                       - Navigate command history
+     Ctrl+R                           - Reverse-incremental search history
+     Ctrl+A / Ctrl+E                  - Jump to start/end of line
+     Ctrl+U / Ctrl+W                  - Delete to start of line / word before cursor
      Ctrl+C                           - Exit
 
   Examples:
@@ -234,8 +386,11 @@ func getSiHistoryFile() string {
 	return filepath.Join(homeDir, ".tfctl_si_history")
 }
 
-func loadSiHistory(filename string) []string {
-	var history []string
+// loadSiHistory reads ~/.tfctl_si_history. Each line is "<unix-timestamp>\t
+// <command>"; plain command-only lines from older versions of tfctl are
+// tolerated and given a zero Time.
+func loadSiHistory(filename string) []siHistoryEntry {
+	var history []siHistoryEntry
 
 	file, err := os.Open(filename)
 	if err != nil {
@@ -246,15 +401,29 @@ func loadSiHistory(filename string) []string {
 	scanner := bufio.NewScanner(file)
 	for scanner.Scan() {
 		line := strings.TrimSpace(scanner.Text())
-		if line != "" {
-			history = append(history, line)
+		if line == "" {
+			continue
+		}
+
+		if ts, command, ok := strings.Cut(line, "\t"); ok {
+			entry := siHistoryEntry{Command: command}
+			if unix, err := strconv.ParseInt(ts, 10, 64); err == nil {
+				entry.Time = time.Unix(unix, 0)
+			}
+			history = append(history, entry)
+		} else {
+			history = append(history, siHistoryEntry{Command: line})
 		}
 	}
 
 	return history
 }
 
-func processSiQuery(stateData map[string]interface{}, query string) string {
+func processSiQuery(ctx context.Context, stateData map[string]interface{}, query string, format string) string {
+	if rest, ok := strings.CutPrefix(query, "/drift"); ok {
+		return processDriftQuery(ctx, stateData, strings.TrimSpace(rest))
+	}
+
 	var result strings.Builder
 
 	// Capture fmt.Print output by temporarily redirecting
@@ -263,7 +432,7 @@ func processSiQuery(stateData map[string]interface{}, query string) string {
 	os.Stdout = w
 
 	// Process the query (this will write to our pipe instead of stdout)
-	si.ProcessQuery(stateData, query)
+	si.ProcessQueryFormatted(stateData, query, 0, "", format)
 
 	// Restore stdout and read what was written
 	w.Close()
@@ -289,13 +458,30 @@ func processSiQuery(stateData map[string]interface{}, query string) string {
 	return strings.TrimSuffix(output, "\n")
 }
 
-func runSiInteractiveConsole(stateData map[string]interface{}) error {
-	p := tea.NewProgram(initialSiModel(stateData))
+// processDriftQuery handles "/drift [address]": a scan scoped to address (or
+// the whole state, if address is empty), rendered the same unified +/-/~
+// format as "tfctl drift".
+func processDriftQuery(ctx context.Context, stateData map[string]interface{}, address string) string {
+	report, err := drift.Scan(ctx, stateData, drift.ScanOptions{Address: address})
+	if err != nil {
+		return fmt.Sprintf("Error: %v", err)
+	}
+
+	var buf strings.Builder
+	if err := drift.Render(&buf, "unified", report); err != nil {
+		return fmt.Sprintf("Error: %v", err)
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+func runSiInteractiveConsole(ctx context.Context, stateData map[string]interface{}, format string) error {
+	p := tea.NewProgram(initialSiModel(ctx, stateData, format))
 	_, err := p.Run()
 	return err
 }
 
-func saveSiHistory(filename string, history []string) {
+func saveSiHistory(filename string, history []siHistoryEntry) {
 	// Keep only the last 1000 commands
 	maxHistory := 1000
 	start := 0
@@ -311,7 +497,11 @@ func saveSiHistory(filename string, history []string) {
 
 	writer := bufio.NewWriter(file)
 	for i := start; i < len(history); i++ {
-		fmt.Fprintln(writer, history[i])
+		entry := history[i]
+		if entry.Time.IsZero() {
+			entry.Time = time.Now()
+		}
+		fmt.Fprintf(writer, "%d\t%s\n", entry.Time.Unix(), entry.Command)
 	}
 	writer.Flush()
 }
@@ -334,12 +524,19 @@ func siCommandBuilder(meta meta.Meta) *cli.Command {
 				Usage:   "passphrase for encrypted state files",
 				Value:   "",
 			},
+			noKeyCacheFlag,
 			&cli.StringFlag{
 				Name:        "sv",
 				Usage:       "state version to query",
 				Value:       "0",
 				HideDefault: true,
 			},
+			&cli.BoolFlag{
+				Name:  "allow-fs",
+				Usage: "allow file/fileexists/filebase64/templatefile to read from RootDir",
+				Value: false,
+			},
+			noInitFlag,
 		}, NewGlobalFlags("si")...),
 		Action: siCommandAction,
 	}
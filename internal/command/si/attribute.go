@@ -0,0 +1,41 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package si
+
+// AttributeStep is one step of a ParsedQuery's AttributePath: either a map
+// key (into a resource's attributes, or a nested map) or a list index
+// (into a nested list, e.g. "network_interface[0]").
+type AttributeStep struct {
+	Key     string // map key; empty when IsIndex is true
+	Index   int    // list index; meaningful only when IsIndex is true
+	IsIndex bool
+}
+
+// walkAttributePath walks path into value step by step, descending through
+// nested maps and slices the way jq's ".a.b[0].c" would. It returns nil as
+// soon as a step doesn't apply (wrong shape, missing key, or out-of-range
+// index) instead of panicking.
+func walkAttributePath(value interface{}, path []AttributeStep) interface{} {
+	for _, step := range path {
+		if step.IsIndex {
+			slice, ok := value.([]interface{})
+			if !ok || step.Index < 0 || step.Index >= len(slice) {
+				return nil
+			}
+			value = slice[step.Index]
+			continue
+		}
+
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		v, exists := m[step.Key]
+		if !exists {
+			return nil
+		}
+		value = v
+	}
+	return value
+}
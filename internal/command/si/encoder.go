@@ -0,0 +1,316 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package si
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Encoder renders ProcessQuery's results in a selectable output format. Each
+// matched resource is fed through EncodeMatch one at a time; a single
+// extracted attribute value instead goes through EncodeAttribute. Flush
+// finishes any output that couldn't be written until every match was seen
+// (a CSV header and its column union, a YAML document, and so on).
+type Encoder interface {
+	EncodeMatch(match map[string]interface{}) error
+	EncodeAttribute(value interface{}) error
+	Flush() error
+}
+
+// NewEncoder returns the Encoder registered for format, writing to w.
+// Unknown formats fall back to the "text" encoder, ProcessQuery's
+// longstanding default.
+func NewEncoder(format string, w io.Writer) Encoder {
+	switch format {
+	case "json":
+		return jsonEncoder{w: w}
+	case "ndjson":
+		return &ndjsonEncoder{enc: json.NewEncoder(w)}
+	case "yaml":
+		return &yamlEncoder{w: w}
+	case "csv":
+		return &csvEncoder{w: w}
+	case "hcl":
+		return hclEncoder{w: w}
+	default:
+		return &textEncoder{w: w, seen: make(map[string]bool)}
+	}
+}
+
+// textEncoder reproduces ProcessQuery's original behavior: one resource
+// address per matched instance (skipping addresses already printed), or one
+// formatted attribute value per line.
+type textEncoder struct {
+	w    io.Writer
+	seen map[string]bool
+}
+
+func (e *textEncoder) EncodeMatch(match map[string]interface{}) error {
+	address := resourceInstanceFor(match).String()
+	if e.seen[address] {
+		return nil
+	}
+	e.seen[address] = true
+	_, err := fmt.Fprintln(e.w, address)
+	return err
+}
+
+func (e *textEncoder) EncodeAttribute(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	_, err := fmt.Fprintln(e.w, formatAttributeValue(value))
+	return err
+}
+
+func (e *textEncoder) Flush() error { return nil }
+
+// jsonEncoder reproduces ProcessQuery's original "." (json mode) behavior:
+// one pretty-printed JSON value per match or attribute, not a single array.
+type jsonEncoder struct {
+	w io.Writer
+}
+
+func (e jsonEncoder) EncodeMatch(match map[string]interface{}) error {
+	return e.print(match)
+}
+
+func (e jsonEncoder) EncodeAttribute(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	return e.print(value)
+}
+
+func (e jsonEncoder) print(value interface{}) error {
+	b, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Errorf("json encode: %w", err)
+	}
+	_, err = fmt.Fprintln(e.w, string(b))
+	return err
+}
+
+func (e jsonEncoder) Flush() error { return nil }
+
+// ndjsonEncoder writes one compact JSON object per line, trivial to pipe
+// into "jq -c" or similar streaming tools.
+type ndjsonEncoder struct {
+	enc *json.Encoder
+}
+
+func (e *ndjsonEncoder) EncodeMatch(match map[string]interface{}) error {
+	return e.enc.Encode(match)
+}
+
+func (e *ndjsonEncoder) EncodeAttribute(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	return e.enc.Encode(value)
+}
+
+func (e *ndjsonEncoder) Flush() error { return nil }
+
+// yamlEncoder buffers every match or attribute value seen and marshals them
+// as a single YAML sequence on Flush.
+type yamlEncoder struct {
+	w     io.Writer
+	items []interface{}
+}
+
+func (e *yamlEncoder) EncodeMatch(match map[string]interface{}) error {
+	e.items = append(e.items, match)
+	return nil
+}
+
+func (e *yamlEncoder) EncodeAttribute(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	e.items = append(e.items, value)
+	return nil
+}
+
+func (e *yamlEncoder) Flush() error {
+	if len(e.items) == 0 {
+		return nil
+	}
+	b, err := yaml.Marshal(e.items)
+	if err != nil {
+		return fmt.Errorf("yaml encode: %w", err)
+	}
+	_, err = e.w.Write(b)
+	return err
+}
+
+// csvEncoder flattens each match's attributes.* into columns, buffering
+// every row so that the full column union can be computed (and written as
+// the header) before any row is emitted.
+type csvEncoder struct {
+	w    io.Writer
+	rows []map[string]string
+}
+
+func (e *csvEncoder) EncodeMatch(match map[string]interface{}) error {
+	row := map[string]string{"address": resourceInstanceFor(match).String()}
+	for _, k := range []string{"mode", "type", "name"} {
+		if v, ok := match[k]; ok {
+			row[k] = formatAttributeValue(v)
+		}
+	}
+	if attributes, ok := match["attributes"].(map[string]interface{}); ok {
+		for k, v := range attributes {
+			row["attributes."+k] = formatAttributeValue(v)
+		}
+	}
+	e.rows = append(e.rows, row)
+	return nil
+}
+
+func (e *csvEncoder) EncodeAttribute(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	e.rows = append(e.rows, map[string]string{"value": formatAttributeValue(value)})
+	return nil
+}
+
+func (e *csvEncoder) Flush() error {
+	if len(e.rows) == 0 {
+		return nil
+	}
+
+	cols := csvColumnUnion(e.rows)
+
+	cw := csv.NewWriter(e.w)
+	if err := cw.Write(cols); err != nil {
+		return fmt.Errorf("csv header: %w", err)
+	}
+
+	record := make([]string, len(cols))
+	for _, row := range e.rows {
+		for i, c := range cols {
+			record[i] = row[c]
+		}
+		if err := cw.Write(record); err != nil {
+			return fmt.Errorf("csv row: %w", err)
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// csvColumnUnion returns every column present across rows, "address",
+// "mode", "type", "name", and "value" first (in that order, when present),
+// followed by "attributes.*" columns sorted alphabetically.
+func csvColumnUnion(rows []map[string]string) []string {
+	var cols []string
+	for _, fixed := range []string{"address", "mode", "type", "name", "value"} {
+		for _, row := range rows {
+			if _, ok := row[fixed]; ok {
+				cols = append(cols, fixed)
+				break
+			}
+		}
+	}
+
+	seen := make(map[string]bool)
+	var attrCols []string
+	for _, row := range rows {
+		for k := range row {
+			if strings.HasPrefix(k, "attributes.") && !seen[k] {
+				seen[k] = true
+				attrCols = append(attrCols, k)
+			}
+		}
+	}
+	sort.Strings(attrCols)
+
+	return append(cols, attrCols...)
+}
+
+// hclEncoder renders each match as a `resource "type" "name" { ... }` block,
+// suitable as a starting point for a Terraform import stub. It ignores
+// module path and instance key, since import blocks are addressed
+// separately; attribute values are rendered as HCL expression literals.
+type hclEncoder struct {
+	w io.Writer
+}
+
+func (e hclEncoder) EncodeMatch(match map[string]interface{}) error {
+	resType, _ := match["type"].(string)
+	name, _ := match["name"].(string)
+
+	fmt.Fprintf(e.w, "resource %q %q {\n", resType, name)
+
+	if attributes, ok := match["attributes"].(map[string]interface{}); ok {
+		keys := make([]string, 0, len(attributes))
+		for k := range attributes {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			fmt.Fprintf(e.w, "  %s = %s\n", k, hclLiteral(attributes[k]))
+		}
+	}
+
+	_, err := fmt.Fprintln(e.w, "}")
+	return err
+}
+
+func (e hclEncoder) EncodeAttribute(value interface{}) error {
+	// There's no meaningful HCL attribute-only rendering, so fall back to
+	// the same plain-value output the text encoder would produce.
+	if value == nil {
+		return nil
+	}
+	_, err := fmt.Fprintln(e.w, formatAttributeValue(value))
+	return err
+}
+
+func (e hclEncoder) Flush() error { return nil }
+
+// hclLiteral renders a decoded JSON value as an HCL expression literal.
+func hclLiteral(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return strconv.Quote(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []interface{}:
+		parts := make([]string, len(v))
+		for i, e := range v {
+			parts[i] = hclLiteral(e)
+		}
+		return "[" + strings.Join(parts, ", ") + "]"
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		parts := make([]string, len(keys))
+		for i, k := range keys {
+			parts[i] = fmt.Sprintf("%s = %s", k, hclLiteral(v[k]))
+		}
+		return "{\n    " + strings.Join(parts, "\n    ") + "\n  }"
+	default:
+		return strconv.Quote(fmt.Sprintf("%v", v))
+	}
+}
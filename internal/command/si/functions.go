@@ -6,7 +6,7 @@ package si
 import (
 	"encoding/json"
 	"fmt"
-	"regexp"
+	"sort"
 
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/ext/tryfunc"
@@ -18,18 +18,21 @@ import (
 
 // evaluateFunction provides basic Terraform function evaluation
 func evaluateFunction(expression string, stateData map[string]interface{}) string {
-	// Preprocess terraform addresses in the expression before HCL evaluation
-	processedExpression := preprocessTerraformAddresses(expression, stateData)
+	expr, diags := hclsyntax.ParseExpression([]byte(expression), "", hcl.Pos{Line: 1, Column: 1})
+	if diags.HasErrors() {
+		return fmt.Sprintf("Error parsing expression: %s", diags.Error())
+	}
 
-	// Use HCL to evaluate the expression
 	ctx := &hcl.EvalContext{
 		Variables: buildVariableMap(stateData),
 		Functions: buildFunctionMap(),
 	}
-
-	expr, diags := hclsyntax.ParseExpression([]byte(processedExpression), "", hcl.Pos{Line: 1, Column: 1})
-	if diags.HasErrors() {
-		return fmt.Sprintf("Error parsing expression: %s", diags.Error())
+	// Overlay resolved Terraform addresses (aws_instance.web, module.x.y[0],
+	// data.aws_ami.ubuntu, ...) referenced anywhere in expr. See
+	// resolveAddressVariables for why this replaces the old regex
+	// preprocessor.
+	for name, value := range resolveAddressVariables(expr, stateData) {
+		ctx.Variables[name] = value
 	}
 
 	result, diags := expr.Value(ctx)
@@ -40,41 +43,230 @@ func evaluateFunction(expression string, stateData map[string]interface{}) strin
 	return formatCtyValue(result)
 }
 
-// preprocessTerraformAddresses finds terraform addresses in the expression and replaces them with their actual values
-func preprocessTerraformAddresses(expression string, stateData map[string]interface{}) string {
-	// This regex matches terraform addresses like:
-	// module.sample.aws_instance.web[0].arn
-	// aws_security_group.example.id
-	// data.aws_ami.ubuntu.id
-	addressPattern := regexp.MustCompile(`\b(module\.[a-zA-Z0-9_.-]+\.[a-zA-Z0-9_.-]+(?:\[[^\]]+\])?\.[a-zA-Z0-9_.-]+|[a-zA-Z0-9_]+\.[a-zA-Z0-9_.-]+(?:\[[^\]]+\])?\.[a-zA-Z0-9_.-]+|data\.[a-zA-Z0-9_.-]+\.[a-zA-Z0-9_.-]+(?:\[[^\]]+\])?\.[a-zA-Z0-9_.-]+)\b`)
-
-	return addressPattern.ReplaceAllStringFunc(expression, func(address string) string {
-		// Parse the terraform address and extract its value
-		parsed, err := ParseQuery(address)
-		if err != nil {
-			return address // Return original if parsing fails
+// resolveAddressVariables finds every Terraform resource address referenced
+// in expr and builds the cty.Value tree needed to resolve them, so that
+// expressions like module.sample.aws_instance.web[0].arn or
+// aws_instance.web[*].arn are evaluated natively by HCL's own traversal,
+// indexing, and splat logic instead of by splicing JSON-encoded strings into
+// the expression text (which misquotes values containing escaped quotes or
+// newlines, can't express string-keyed indices like foo["bar"].id, and
+// can't tell an address inside a string literal from one in code).
+//
+// Rather than re-walking the AST ourselves to find hclsyntax.ScopeTraversalExpr
+// nodes, this uses hcl.Expression.Variables(), which already performs that
+// walk (including through splats and relative traversals) and is the
+// standard way HCL code discovers the traversals an expression depends on.
+// Each traversal is resolved only up to the resource root (module path,
+// optional "data", type, name) — exactly the data Terraform itself would
+// bind into scope for that address — and any further indexing/attribute
+// access in the traversal (e.g. [0].arn, ["bar"].id, [*].arn) is left for
+// HCL to apply against the resulting cty.Value.
+func resolveAddressVariables(expr hcl.Expression, stateData map[string]interface{}) map[string]cty.Value {
+	root := newAddrNode()
+
+	for _, trav := range expr.Variables() {
+		path, ok := parseResourcePathFromTraversal(trav)
+		if !ok {
+			continue
+		}
+		leaf, ok := resolveResourcePath(stateData, path)
+		if !ok {
+			continue
 		}
+		root.insert(path, leaf)
+	}
+
+	vars := make(map[string]cty.Value)
+	for name, child := range root.children {
+		vars[name] = child.ctyValue()
+	}
+	return vars
+}
+
+// parseResourcePathFromTraversal consumes a traversal's leading
+// TraverseRoot/TraverseAttr steps to find a resource address: zero or more
+// "module".<name> pairs, an optional "data" marker, then <type>.<name>. Any
+// trailing steps (TraverseIndex, further TraverseAttr) are left untouched
+// for HCL to evaluate once the resource root is bound into scope. Returns
+// ok=false for traversals that aren't shaped like a resource address (e.g a
+// plain local variable or output reference).
+func parseResourcePathFromTraversal(trav hcl.Traversal) ([]string, bool) {
+	if len(trav) == 0 {
+		return nil, false
+	}
+	root, ok := trav[0].(hcl.TraverseRoot)
+	if !ok {
+		return nil, false
+	}
+
+	var path []string
+	name := root.Name
+	i := 1
 
-		// Find matching resources
-		matches := FindMatchingResources(stateData, parsed)
-		if len(matches) == 0 {
-			return address // Return original if no matches
+	for name == "module" {
+		modName, ok := nextAttr(trav, &i)
+		if !ok {
+			return nil, false
 		}
+		path = append(path, "module", modName)
 
-		// Extract the attribute value
-		attrValue := ExtractAttribute(matches[0], parsed)
-		if attrValue == nil {
-			return address // Return original if attribute not found
+		next, ok := nextAttr(trav, &i)
+		if !ok {
+			return nil, false
 		}
+		name = next
+	}
 
-		// Convert to JSON string for HCL evaluation
-		jsonBytes, err := json.Marshal(attrValue)
-		if err != nil {
-			return address // Return original if marshalling fails
+	if name == "data" {
+		resType, ok := nextAttr(trav, &i)
+		if !ok {
+			return nil, false
+		}
+		resName, ok := nextAttr(trav, &i)
+		if !ok {
+			return nil, false
 		}
+		return append(path, "data", resType, resName), true
+	}
+
+	resName, ok := nextAttr(trav, &i)
+	if !ok {
+		return nil, false
+	}
+	return append(path, name, resName), true
+}
 
-		return string(jsonBytes)
-	})
+// nextAttr returns the name of the TraverseAttr step at *i, advancing *i.
+func nextAttr(trav hcl.Traversal, i *int) (string, bool) {
+	if *i >= len(trav) {
+		return "", false
+	}
+	attr, ok := trav[*i].(hcl.TraverseAttr)
+	if !ok {
+		return "", false
+	}
+	*i++
+	return attr.Name, true
+}
+
+// resolveResourcePath looks up every instance of the resource addressed by
+// path (module/data/type/name, per parseResourcePathFromTraversal) and
+// builds the cty.Value Terraform itself would bind for that address: the
+// instance's attributes directly for a resource with no count/for_each, a
+// tuple of instances (ordered by index) for count, or an object keyed by
+// instance key for for_each.
+func resolveResourcePath(stateData map[string]interface{}, path []string) (cty.Value, bool) {
+	query := &ParsedQuery{Mode: "managed"}
+
+	i := 0
+	for i+1 < len(path) && path[i] == "module" {
+		query.Module = append(query.Module, path[i+1])
+		i += 2
+	}
+	if i < len(path) && path[i] == "data" {
+		query.Mode = "data"
+		i++
+	}
+	if i+1 >= len(path) {
+		return cty.NilVal, false
+	}
+	query.Type, query.Name = path[i], path[i+1]
+
+	matches := FindMatchingResources(stateData, query)
+	if len(matches) == 0 {
+		return cty.NilVal, false
+	}
+
+	if len(matches) == 1 {
+		if _, indexed := matches[0]["index_key"]; !indexed {
+			return instanceAttrsToCtyValue(matches[0]), true
+		}
+	}
+
+	stringKeyed := false
+	for _, m := range matches {
+		if _, ok := m["index_key"].(string); ok {
+			stringKeyed = true
+			break
+		}
+	}
+
+	if stringKeyed {
+		obj := make(map[string]cty.Value, len(matches))
+		for _, m := range matches {
+			key, _ := m["index_key"].(string)
+			obj[key] = instanceAttrsToCtyValue(m)
+		}
+		return cty.ObjectVal(obj), true
+	}
+
+	type indexedValue struct {
+		index int
+		value cty.Value
+	}
+	indexed := make([]indexedValue, len(matches))
+	for n, m := range matches {
+		idx := 0
+		if f, ok := m["index_key"].(float64); ok {
+			idx = int(f)
+		}
+		indexed[n] = indexedValue{idx, instanceAttrsToCtyValue(m)}
+	}
+	sort.Slice(indexed, func(a, b int) bool { return indexed[a].index < indexed[b].index })
+
+	vals := make([]cty.Value, len(indexed))
+	for n, iv := range indexed {
+		vals[n] = iv.value
+	}
+	return cty.TupleVal(vals), true
+}
+
+// instanceAttrsToCtyValue converts a flattened resource match's "attributes"
+// object (see createResourceMatch) into a cty.Value.
+func instanceAttrsToCtyValue(match map[string]interface{}) cty.Value {
+	attrs, ok := match["attributes"].(map[string]interface{})
+	if !ok {
+		return cty.EmptyObjectVal
+	}
+	return convertToCtyValue(attrs)
+}
+
+// addrNode is a scratch tree used to assemble the nested cty.Value structure
+// (module.foo.aws_instance.bar, data.aws_ami.ubuntu, ...) for every resolved
+// address before handing the top-level roots to resolveAddressVariables's
+// caller. A plain map[string]cty.Value can't be built incrementally this way
+// since cty.ObjectVal is immutable once constructed.
+type addrNode struct {
+	value    *cty.Value
+	children map[string]*addrNode
+}
+
+func newAddrNode() *addrNode {
+	return &addrNode{children: make(map[string]*addrNode)}
+}
+
+func (n *addrNode) insert(path []string, leaf cty.Value) {
+	if len(path) == 0 {
+		n.value = &leaf
+		return
+	}
+	child, ok := n.children[path[0]]
+	if !ok {
+		child = newAddrNode()
+		n.children[path[0]] = child
+	}
+	child.insert(path[1:], leaf)
+}
+
+func (n *addrNode) ctyValue() cty.Value {
+	if n.value != nil && len(n.children) == 0 {
+		return *n.value
+	}
+	attrs := make(map[string]cty.Value, len(n.children))
+	for name, child := range n.children {
+		attrs[name] = child.ctyValue()
+	}
+	return cty.ObjectVal(attrs)
 }
 
 // buildFunctionMap dynamically builds the function map
@@ -162,6 +354,15 @@ func buildFunctionMap() map[string]function.Function {
 	funcs["try"] = tryfunc.TryFunc
 	funcs["can"] = tryfunc.CanFunc
 
+	// Terraform doesn't export its own function implementations (they live in
+	// an internal package), so the networking/encoding/hashing/date/type-
+	// conversion builtins below are implemented from scratch in
+	// functions_builtin.go, matching stdlib's function.New/function.Spec
+	// style used throughout this file.
+	for name, fn := range builtinFunctions() {
+		funcs[name] = fn
+	}
+
 	// Note: This approach is much more maintainable than the previous hard-coded list.
 	// To add new functions from stdlib, just add them to the appropriate category above.
 	//
@@ -0,0 +1,662 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package si
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/md5"  //nolint:gosec
+	"crypto/sha1" //nolint:gosec
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/apparentlymart/go-cidr/cidr"
+	"github.com/google/uuid"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	"github.com/zclconf/go-cty/cty/function"
+	"github.com/zclconf/go-cty/cty/gocty"
+	"golang.org/x/crypto/bcrypt"
+	"gopkg.in/yaml.v3"
+)
+
+// FSConfig gates the filesystem-reading functions (file/fileexists/
+// filebase64/templatefile) below. They're disallowed by default since
+// evaluating arbitrary `si` expressions against the filesystem is otherwise
+// an easy path-traversal vector; command/si.go sets this from the
+// --allow-fs flag and the active RootDir before handing expressions to
+// evaluateFunction.
+var FSConfig = struct {
+	Allowed bool
+	BaseDir string
+}{}
+
+// builtinFunctions returns the Terraform-specific functions layered on top
+// of go-cty's stdlib: these aren't exported anywhere Terraform itself, so
+// they're reimplemented here against the same function.Function/cty.Value
+// contract as the stdlib functions in buildFunctionMap.
+func builtinFunctions() map[string]function.Function {
+	funcs := map[string]function.Function{
+		// Networking
+		"cidrhost":    cidrHostFunc,
+		"cidrnetmask": cidrNetmaskFunc,
+		"cidrsubnet":  cidrSubnetFunc,
+		"cidrsubnets": cidrSubnetsFunc,
+
+		// Encoding
+		"base64encode": base64EncodeFunc,
+		"base64decode": base64DecodeFunc,
+		"base64gzip":   base64GzipFunc,
+		"urlencode":    urlEncodeFunc,
+		"yamldecode":   yamlDecodeFunc,
+		"yamlencode":   yamlEncodeFunc,
+
+		// Hashing
+		"md5":    md5Func,
+		"sha1":   sha1Func,
+		"sha256": sha256Func,
+		"sha512": sha512Func,
+		"bcrypt": bcryptFunc,
+		"uuid":   uuidFunc,
+		"uuidv5": uuidV5Func,
+
+		// Filesystem (gated by FSConfig)
+		"file":         fileFunc,
+		"fileexists":   fileExistsFunc,
+		"filebase64":   fileBase64Func,
+		"templatefile": templateFileFunc,
+
+		// Date
+		"timestamp": timestampFunc,
+		"timecmp":   timeCmpFunc,
+
+		// Collection/type-conversion helpers Terraform adds on top of stdlib
+		"alltrue":   allTrueFunc,
+		"anytrue":   anyTrueFunc,
+		"matchkeys": matchKeysFunc,
+		"one":       oneFunc,
+		"toset":     toSetFunc,
+		"tolist":    toListFunc,
+		"tomap":     toMapFunc,
+		"tonumber":  toNumberFunc,
+		"tostring":  toStringFunc,
+	}
+	return funcs
+}
+
+// --- Networking ---
+
+var cidrHostFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "prefix", Type: cty.String},
+		{Name: "hostnum", Type: cty.Number},
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		_, network, err := net.ParseCIDR(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(cty.String), fmt.Errorf("invalid CIDR expression: %w", err)
+		}
+		hostNum := new(big.Int)
+		if _, err := fmt.Sscan(args[1].AsBigFloat().String(), hostNum); err != nil {
+			return cty.UnknownVal(cty.String), fmt.Errorf("invalid hostnum: %w", err)
+		}
+		ip, err := cidr.HostBig(network, hostNum)
+		if err != nil {
+			return cty.UnknownVal(cty.String), err
+		}
+		return cty.StringVal(ip.String()), nil
+	},
+})
+
+var cidrNetmaskFunc = function.New(&function.Spec{
+	Params: []function.Parameter{{Name: "prefix", Type: cty.String}},
+	Type:   function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		_, network, err := net.ParseCIDR(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(cty.String), fmt.Errorf("invalid CIDR expression: %w", err)
+		}
+		ip := net.IP(network.Mask)
+		return cty.StringVal(ip.String()), nil
+	},
+})
+
+var cidrSubnetFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "prefix", Type: cty.String},
+		{Name: "newbits", Type: cty.Number},
+		{Name: "netnum", Type: cty.Number},
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		_, network, err := net.ParseCIDR(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(cty.String), fmt.Errorf("invalid CIDR expression: %w", err)
+		}
+		var newbits int
+		if err := gocty.FromCtyValue(args[1], &newbits); err != nil {
+			return cty.UnknownVal(cty.String), err
+		}
+		netnum := new(big.Int)
+		if _, err := fmt.Sscan(args[2].AsBigFloat().String(), netnum); err != nil {
+			return cty.UnknownVal(cty.String), fmt.Errorf("invalid netnum: %w", err)
+		}
+		subnet, err := cidr.SubnetBig(network, newbits, netnum)
+		if err != nil {
+			return cty.UnknownVal(cty.String), err
+		}
+		return cty.StringVal(subnet.String()), nil
+	},
+})
+
+var cidrSubnetsFunc = function.New(&function.Spec{
+	Params: []function.Parameter{{Name: "prefix", Type: cty.String}},
+	VarParam: &function.Parameter{
+		Name: "newbits",
+		Type: cty.Number,
+	},
+	Type: function.StaticReturnType(cty.List(cty.String)),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		_, network, err := net.ParseCIDR(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(retType), fmt.Errorf("invalid CIDR expression: %w", err)
+		}
+
+		newbitsArgs := args[1:]
+		if len(newbitsArgs) == 0 {
+			return cty.UnknownVal(retType), errors.New("cidrsubnets requires at least one newbits argument")
+		}
+
+		newbits := make([]int, len(newbitsArgs))
+		maxNewBits := 0
+		for i, a := range newbitsArgs {
+			var nb int
+			if err := gocty.FromCtyValue(a, &nb); err != nil {
+				return cty.UnknownVal(retType), err
+			}
+			newbits[i] = nb
+			if nb > maxNewBits {
+				maxNewBits = nb
+			}
+		}
+
+		// next tracks the allocation cursor in units of the finest subnet
+		// granularity (maxNewBits); each subnet consumes
+		// 2^(maxNewBits-newbits) of those units, so coarser subnets use up
+		// several of the finer slots. The cursor is rounded up to a multiple
+		// of that count first, so every subnet starts on its own boundary and
+		// no ranges overlap.
+		next := big.NewInt(0)
+		results := make([]cty.Value, len(newbits))
+		for i, nb := range newbits {
+			scale := new(big.Int).Lsh(big.NewInt(1), uint(maxNewBits-nb))
+			if rem := new(big.Int).Mod(next, scale); rem.Sign() != 0 {
+				next.Add(next, new(big.Int).Sub(scale, rem))
+			}
+			netnum := new(big.Int).Div(next, scale)
+			subnet, err := cidr.SubnetBig(network, nb, netnum)
+			if err != nil {
+				return cty.UnknownVal(retType), err
+			}
+			results[i] = cty.StringVal(subnet.String())
+			next.Add(next, scale)
+		}
+
+		return cty.ListVal(results), nil
+	},
+})
+
+// --- Encoding ---
+
+var base64EncodeFunc = function.New(&function.Spec{
+	Params: []function.Parameter{{Name: "str", Type: cty.String}},
+	Type:   function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		return cty.StringVal(base64.StdEncoding.EncodeToString([]byte(args[0].AsString()))), nil
+	},
+})
+
+var base64DecodeFunc = function.New(&function.Spec{
+	Params: []function.Parameter{{Name: "str", Type: cty.String}},
+	Type:   function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		data, err := base64.StdEncoding.DecodeString(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(cty.String), fmt.Errorf("invalid base64 data: %w", err)
+		}
+		return cty.StringVal(string(data)), nil
+	},
+})
+
+var base64GzipFunc = function.New(&function.Spec{
+	Params: []function.Parameter{{Name: "str", Type: cty.String}},
+	Type:   function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		if _, err := gz.Write([]byte(args[0].AsString())); err != nil {
+			return cty.UnknownVal(cty.String), err
+		}
+		if err := gz.Close(); err != nil {
+			return cty.UnknownVal(cty.String), err
+		}
+		return cty.StringVal(base64.StdEncoding.EncodeToString(buf.Bytes())), nil
+	},
+})
+
+var urlEncodeFunc = function.New(&function.Spec{
+	Params: []function.Parameter{{Name: "str", Type: cty.String}},
+	Type:   function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		return cty.StringVal(url.QueryEscape(args[0].AsString())), nil
+	},
+})
+
+var yamlDecodeFunc = function.New(&function.Spec{
+	Params: []function.Parameter{{Name: "str", Type: cty.String}},
+	Type:   function.StaticReturnType(cty.DynamicPseudoType),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		var data interface{}
+		if err := yaml.Unmarshal([]byte(args[0].AsString()), &data); err != nil {
+			return cty.DynamicVal, fmt.Errorf("invalid YAML: %w", err)
+		}
+		return convertToCtyValue(data), nil
+	},
+})
+
+var yamlEncodeFunc = function.New(&function.Spec{
+	Params: []function.Parameter{{Name: "value", Type: cty.DynamicPseudoType, AllowDynamicType: true}},
+	Type:   function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		out, err := yaml.Marshal(ctyValueToGo(args[0]))
+		if err != nil {
+			return cty.UnknownVal(cty.String), err
+		}
+		return cty.StringVal(string(out)), nil
+	},
+})
+
+// --- Hashing ---
+
+func hashHexFunc(sum func([]byte) []byte) function.Function {
+	return function.New(&function.Spec{
+		Params: []function.Parameter{{Name: "str", Type: cty.String}},
+		Type:   function.StaticReturnType(cty.String),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			return cty.StringVal(hex.EncodeToString(sum([]byte(args[0].AsString())))), nil
+		},
+	})
+}
+
+var md5Func = hashHexFunc(func(b []byte) []byte { s := md5.Sum(b); return s[:] })   //nolint:gosec
+var sha1Func = hashHexFunc(func(b []byte) []byte { s := sha1.Sum(b); return s[:] }) //nolint:gosec
+var sha256Func = hashHexFunc(func(b []byte) []byte { s := sha256.Sum256(b); return s[:] })
+var sha512Func = hashHexFunc(func(b []byte) []byte { s := sha512.Sum512(b); return s[:] })
+
+var bcryptFunc = function.New(&function.Spec{
+	Params: []function.Parameter{{Name: "str", Type: cty.String}},
+	VarParam: &function.Parameter{
+		Name: "cost",
+		Type: cty.Number,
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		cost := bcrypt.DefaultCost
+		if len(args) > 1 {
+			if err := gocty.FromCtyValue(args[1], &cost); err != nil {
+				return cty.UnknownVal(cty.String), err
+			}
+		}
+		hashed, err := bcrypt.GenerateFromPassword([]byte(args[0].AsString()), cost)
+		if err != nil {
+			return cty.UnknownVal(cty.String), err
+		}
+		return cty.StringVal(string(hashed)), nil
+	},
+})
+
+var uuidFunc = function.New(&function.Spec{
+	Params: []function.Parameter{},
+	Type:   function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		return cty.StringVal(uuid.New().String()), nil
+	},
+})
+
+var uuidV5Func = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "namespace", Type: cty.String},
+		{Name: "name", Type: cty.String},
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		ns, err := uuidNamespace(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(cty.String), err
+		}
+		return cty.StringVal(uuid.NewSHA1(ns, []byte(args[1].AsString())).String()), nil
+	},
+})
+
+func uuidNamespace(name string) (uuid.UUID, error) {
+	switch name {
+	case "dns":
+		return uuid.NameSpaceDNS, nil
+	case "url":
+		return uuid.NameSpaceURL, nil
+	case "oid":
+		return uuid.NameSpaceOID, nil
+	case "x500":
+		return uuid.NameSpaceX500, nil
+	default:
+		parsed, err := uuid.Parse(name)
+		if err != nil {
+			return uuid.UUID{}, fmt.Errorf("invalid uuidv5 namespace %q: must be dns, url, oid, x500, or a UUID: %w", name, err)
+		}
+		return parsed, nil
+	}
+}
+
+// --- Filesystem (gated behind FSConfig.Allowed) ---
+
+// resolveFSPath checks that FSConfig allows filesystem access and that path
+// resolves to somewhere inside FSConfig.BaseDir, rejecting "../" escapes.
+func resolveFSPath(path string) (string, error) {
+	if !FSConfig.Allowed {
+		return "", errors.New("filesystem functions are disabled; pass --allow-fs to enable file/fileexists/filebase64/templatefile")
+	}
+	if FSConfig.BaseDir == "" {
+		return "", errors.New("filesystem functions require a base directory")
+	}
+
+	full := filepath.Join(FSConfig.BaseDir, path)
+	rel, err := filepath.Rel(FSConfig.BaseDir, full)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("path %q escapes the allowed base directory", path)
+	}
+	return full, nil
+}
+
+var fileFunc = function.New(&function.Spec{
+	Params: []function.Parameter{{Name: "path", Type: cty.String}},
+	Type:   function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		full, err := resolveFSPath(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(cty.String), err
+		}
+		data, err := os.ReadFile(full)
+		if err != nil {
+			return cty.UnknownVal(cty.String), fmt.Errorf("failed to read file: %w", err)
+		}
+		return cty.StringVal(string(data)), nil
+	},
+})
+
+var fileExistsFunc = function.New(&function.Spec{
+	Params: []function.Parameter{{Name: "path", Type: cty.String}},
+	Type:   function.StaticReturnType(cty.Bool),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		full, err := resolveFSPath(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(cty.Bool), err
+		}
+		_, err = os.Stat(full)
+		return cty.BoolVal(err == nil), nil
+	},
+})
+
+var fileBase64Func = function.New(&function.Spec{
+	Params: []function.Parameter{{Name: "path", Type: cty.String}},
+	Type:   function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		full, err := resolveFSPath(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(cty.String), err
+		}
+		data, err := os.ReadFile(full)
+		if err != nil {
+			return cty.UnknownVal(cty.String), fmt.Errorf("failed to read file: %w", err)
+		}
+		return cty.StringVal(base64.StdEncoding.EncodeToString(data)), nil
+	},
+})
+
+var templateFileFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "path", Type: cty.String},
+		{Name: "vars", Type: cty.DynamicPseudoType, AllowDynamicType: true},
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		full, err := resolveFSPath(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(cty.String), err
+		}
+		data, err := os.ReadFile(full)
+		if err != nil {
+			return cty.UnknownVal(cty.String), fmt.Errorf("failed to read file: %w", err)
+		}
+
+		expr, diags := hclsyntax.ParseTemplate(data, full, hcl.Pos{Line: 1, Column: 1})
+		if diags.HasErrors() {
+			return cty.UnknownVal(cty.String), fmt.Errorf("failed to parse template: %s", diags.Error())
+		}
+
+		vars := map[string]cty.Value{}
+		if !args[1].IsNull() {
+			for k, v := range args[1].AsValueMap() {
+				vars[k] = v
+			}
+		}
+
+		result, diags := expr.Value(&hcl.EvalContext{Variables: vars, Functions: buildFunctionMap()})
+		if diags.HasErrors() {
+			return cty.UnknownVal(cty.String), fmt.Errorf("failed to render template: %s", diags.Error())
+		}
+		return cty.StringVal(result.AsString()), nil
+	},
+})
+
+// --- Date ---
+
+var timestampFunc = function.New(&function.Spec{
+	Params: []function.Parameter{},
+	Type:   function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		return cty.StringVal(time.Now().UTC().Format(time.RFC3339)), nil
+	},
+})
+
+var timeCmpFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "a", Type: cty.String},
+		{Name: "b", Type: cty.String},
+	},
+	Type: function.StaticReturnType(cty.Number),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		a, err := time.Parse(time.RFC3339, args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(cty.Number), fmt.Errorf("invalid timestamp %q: %w", args[0].AsString(), err)
+		}
+		b, err := time.Parse(time.RFC3339, args[1].AsString())
+		if err != nil {
+			return cty.UnknownVal(cty.Number), fmt.Errorf("invalid timestamp %q: %w", args[1].AsString(), err)
+		}
+		switch {
+		case a.Before(b):
+			return cty.NumberIntVal(-1), nil
+		case a.After(b):
+			return cty.NumberIntVal(1), nil
+		default:
+			return cty.NumberIntVal(0), nil
+		}
+	},
+})
+
+// --- Collection/type-conversion helpers ---
+
+var allTrueFunc = function.New(&function.Spec{
+	Params: []function.Parameter{{Name: "list", Type: cty.DynamicPseudoType, AllowDynamicType: true}},
+	Type:   function.StaticReturnType(cty.Bool),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		list := args[0]
+		if !list.CanIterateElements() {
+			return cty.UnknownVal(cty.Bool), errors.New("alltrue requires a list, set, or tuple argument")
+		}
+		for it := list.ElementIterator(); it.Next(); {
+			_, v := it.Element()
+			b, err := convert.Convert(v, cty.Bool)
+			if err != nil || b.IsNull() || b.False() {
+				return cty.False, nil
+			}
+		}
+		return cty.True, nil
+	},
+})
+
+var anyTrueFunc = function.New(&function.Spec{
+	Params: []function.Parameter{{Name: "list", Type: cty.DynamicPseudoType, AllowDynamicType: true}},
+	Type:   function.StaticReturnType(cty.Bool),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		list := args[0]
+		if !list.CanIterateElements() {
+			return cty.UnknownVal(cty.Bool), errors.New("anytrue requires a list, set, or tuple argument")
+		}
+		for it := list.ElementIterator(); it.Next(); {
+			_, v := it.Element()
+			b, err := convert.Convert(v, cty.Bool)
+			if err == nil && !b.IsNull() && b.True() {
+				return cty.True, nil
+			}
+		}
+		return cty.False, nil
+	},
+})
+
+var oneFunc = function.New(&function.Spec{
+	Params: []function.Parameter{{Name: "list", Type: cty.DynamicPseudoType, AllowDynamicType: true}},
+	Type:   function.StaticReturnType(cty.DynamicPseudoType),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		list := args[0]
+		if !list.CanIterateElements() {
+			return cty.DynamicVal, errors.New("one requires a list, set, or tuple argument")
+		}
+		var elems []cty.Value
+		for it := list.ElementIterator(); it.Next(); {
+			_, v := it.Element()
+			elems = append(elems, v)
+		}
+		switch len(elems) {
+		case 0:
+			return cty.NullVal(cty.DynamicPseudoType), nil
+		case 1:
+			return elems[0], nil
+		default:
+			return cty.DynamicVal, fmt.Errorf("one requires exactly 0 or 1 elements, got %d", len(elems))
+		}
+	},
+})
+
+var matchKeysFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "values", Type: cty.DynamicPseudoType, AllowDynamicType: true},
+		{Name: "keys", Type: cty.DynamicPseudoType, AllowDynamicType: true},
+		{Name: "searchset", Type: cty.DynamicPseudoType, AllowDynamicType: true},
+	},
+	Type: function.StaticReturnType(cty.DynamicPseudoType),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		values, keys, searchset := args[0], args[1], args[2]
+		if !values.CanIterateElements() || !keys.CanIterateElements() || !searchset.CanIterateElements() {
+			return cty.DynamicVal, errors.New("matchkeys requires list/set/tuple arguments")
+		}
+
+		var valuesList, keysList []cty.Value
+		for it := values.ElementIterator(); it.Next(); {
+			_, v := it.Element()
+			valuesList = append(valuesList, v)
+		}
+		for it := keys.ElementIterator(); it.Next(); {
+			_, v := it.Element()
+			keysList = append(keysList, v)
+		}
+		if len(valuesList) != len(keysList) {
+			return cty.DynamicVal, errors.New("matchkeys requires values and keys to be the same length")
+		}
+
+		var search []cty.Value
+		for it := searchset.ElementIterator(); it.Next(); {
+			_, v := it.Element()
+			search = append(search, v)
+		}
+
+		var result []cty.Value
+		for i, k := range keysList {
+			for _, s := range search {
+				if k.RawEquals(s) {
+					result = append(result, valuesList[i])
+					break
+				}
+			}
+		}
+		if len(result) == 0 {
+			return cty.ListValEmpty(cty.DynamicPseudoType), nil
+		}
+		return cty.TupleVal(result), nil
+	},
+})
+
+var toSetFunc = function.New(&function.Spec{
+	Params: []function.Parameter{{Name: "v", Type: cty.DynamicPseudoType, AllowDynamicType: true}},
+	Type:   function.StaticReturnType(cty.Set(cty.DynamicPseudoType)),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		return convert.Convert(args[0], retType)
+	},
+})
+
+var toListFunc = function.New(&function.Spec{
+	Params: []function.Parameter{{Name: "v", Type: cty.DynamicPseudoType, AllowDynamicType: true}},
+	Type:   function.StaticReturnType(cty.List(cty.DynamicPseudoType)),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		return convert.Convert(args[0], retType)
+	},
+})
+
+var toMapFunc = function.New(&function.Spec{
+	Params: []function.Parameter{{Name: "v", Type: cty.DynamicPseudoType, AllowDynamicType: true}},
+	Type:   function.StaticReturnType(cty.Map(cty.DynamicPseudoType)),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		return convert.Convert(args[0], retType)
+	},
+})
+
+var toNumberFunc = function.New(&function.Spec{
+	Params: []function.Parameter{{Name: "v", Type: cty.DynamicPseudoType, AllowDynamicType: true}},
+	Type:   function.StaticReturnType(cty.Number),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		return convert.Convert(args[0], cty.Number)
+	},
+})
+
+var toStringFunc = function.New(&function.Spec{
+	Params: []function.Parameter{{Name: "v", Type: cty.DynamicPseudoType, AllowDynamicType: true}},
+	Type:   function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		return convert.Convert(args[0], cty.String)
+	},
+})
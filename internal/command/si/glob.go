@@ -0,0 +1,36 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package si
+
+import (
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+// Matcher reports whether a single path segment (a module name, resource
+// type, resource name, or index) matches a compiled glob pattern.
+type Matcher func(segment string) bool
+
+// hasGlobMeta reports whether s contains glob metacharacters doublestar
+// would treat specially. Literal queries -- the common case -- skip glob
+// compilation and matching entirely, so their output stays byte-identical
+// to before glob support existed.
+func hasGlobMeta(s string) bool {
+	return strings.ContainsAny(s, "*?[")
+}
+
+// compileMatcher compiles pattern into a Matcher evaluated via
+// doublestar.Match. It returns nil if pattern has no glob metacharacters,
+// signaling callers to fall back to a literal == comparison instead.
+func compileMatcher(pattern string) Matcher {
+	if !hasGlobMeta(pattern) {
+		return nil
+	}
+
+	return func(segment string) bool {
+		matched, err := doublestar.Match(pattern, segment)
+		return err == nil && matched
+	}
+}
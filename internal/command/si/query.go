@@ -5,6 +5,7 @@ package si
 
 import (
 	"fmt"
+	"os"
 	"strconv"
 	"strings"
 )
@@ -17,10 +18,56 @@ type ParsedQuery struct {
 	Name      string      // Resource name, e.g., "web"
 	Index     interface{} // Instance index (int, string, or nil for all)
 	Attribute string      // Attribute name, e.g., "arn", "id"
+
+	// AttributePath holds every step of a chained attribute path, e.g.
+	// "network_interface[0].private_ip" becomes [{Key:
+	// "network_interface"}, {Index: 0, IsIndex: true}, {Key:
+	// "private_ip"}]. Attribute above is always AttributePath[0].Key when
+	// AttributePath is non-empty.
+	AttributePath []AttributeStep
+	// Splat is true when the resource's instance index was "[*]", Terraform
+	// splat syntax requesting the attribute across every matching instance
+	// as a single array, rather than one result per instance.
+	Splat bool
+
+	// ModuleGlobs holds a compiled Matcher per Module component, or nil at
+	// that position when the component is a literal name. A "**" component
+	// is handled specially by matchesModule (it matches any number of
+	// module levels, not just one), so its ModuleGlobs entry is unused.
+	ModuleGlobs []Matcher
+	// ModuleHasGlob is true if any Module component is "**" or contains
+	// glob metacharacters, so matchesModule can fall back to the legacy
+	// literal comparison untouched when it's false.
+	ModuleHasGlob bool
+	// TypeGlob, NameGlob, and IndexGlob are non-nil when the corresponding
+	// field above contains glob metacharacters instead of a literal value.
+	TypeGlob  Matcher
+	NameGlob  Matcher
+	IndexGlob Matcher
 }
 
 // processQuery routes queries to appropriate handlers based on syntax
 func ProcessQuery(stateData map[string]interface{}, query string) {
+	ProcessQuerySampled(stateData, query, 0, "")
+}
+
+// ProcessQuerySampled is ProcessQuery with deterministic bucketed sampling:
+// when sampleN is greater than zero, the matches it would otherwise print
+// are first reduced via Sample(matches, sampleN, seed). ProcessQuery is
+// just this with sampling disabled; callers that need to make a very large
+// state tractable to eyeball (or want a reproducible spot check) can call
+// this instead.
+func ProcessQuerySampled(stateData map[string]interface{}, query string, sampleN int, seed string) {
+	ProcessQueryFormatted(stateData, query, sampleN, seed, "text")
+}
+
+// ProcessQueryFormatted is ProcessQuerySampled with a selectable output
+// format: "text" (the default, matching ProcessQuery's original behavior),
+// "json", "ndjson", "yaml", "csv", or "hcl" (see NewEncoder). A leading "."
+// on query still forces JSON output as a shortcut, overriding format, and a
+// leading "/" still forces function-evaluation mode untouched by format,
+// exactly as they did before encoders existed.
+func ProcessQueryFormatted(stateData map[string]interface{}, query string, sampleN int, seed string, format string) {
 	// Check for function evaluation mode
 	if strings.HasPrefix(query, "/") {
 		// Force function mode with leading /
@@ -63,41 +110,54 @@ func ProcessQuery(stateData map[string]interface{}, query string) {
 
 	// Find matching resources
 	matches := FindMatchingResources(stateData, parsed)
+	if sampleN > 0 {
+		matches = Sample(matches, sampleN, seed)
+	}
+
+	// "." is a shortcut for JSON output regardless of --output.
+	effectiveFormat := format
+	if jsonMode {
+		effectiveFormat = "json"
+	}
+	enc := NewEncoder(effectiveFormat, os.Stdout)
 
 	// Handle attribute extraction if specified
 	if parsed.Attribute != "" {
-		if jsonMode {
-			// Output JSON for attribute values
+		if parsed.Splat {
+			// Splat ("[*]") combines the attribute across every matching
+			// instance into a single JSON array, jq "[].attr" style,
+			// regardless of --output.
+			var values []interface{}
 			for _, match := range matches {
-				attrValue := ExtractAttribute(match, parsed)
-				if attrValue != nil {
-					printJSON(attrValue)
+				if attrValue := ExtractAttribute(match, parsed); attrValue != nil {
+					values = append(values, attrValue)
 				}
 			}
-		} else {
-			// Output attribute values as strings
-			for _, match := range matches {
-				attrValue := ExtractAttribute(match, parsed)
-				if attrValue != nil {
-					fmt.Println(formatAttributeValue(attrValue))
+			printJSON(values)
+			return
+		}
+
+		for _, match := range matches {
+			if attrValue := ExtractAttribute(match, parsed); attrValue != nil {
+				if err := enc.EncodeAttribute(attrValue); err != nil {
+					fmt.Printf("Error: %s\n", err)
+					return
 				}
 			}
 		}
 	} else {
 		// Normal resource output (no attribute specified)
-		if jsonMode {
-			// Output JSON for all matches
-			for _, match := range matches {
-				printJSON(match)
-			}
-		} else {
-			// Output list of resource addresses
-			addresses := generateResourceAddresses(matches)
-			for _, addr := range addresses {
-				fmt.Println(addr)
+		for _, match := range matches {
+			if err := enc.EncodeMatch(match); err != nil {
+				fmt.Printf("Error: %s\n", err)
+				return
 			}
 		}
 	}
+
+	if err := enc.Flush(); err != nil {
+		fmt.Printf("Error: %s\n", err)
+	}
 }
 
 // hasBalancedParens checks if a string has balanced parentheses
@@ -166,6 +226,14 @@ func ParseQuery(query string) (*ParsedQuery, error) {
 		pos++ // skip "module"
 		moduleName := parts[pos]
 		parsed.Module = append(parsed.Module, moduleName)
+		if moduleName == "**" {
+			parsed.ModuleHasGlob = true
+			parsed.ModuleGlobs = append(parsed.ModuleGlobs, nil)
+		} else {
+			matcher := compileMatcher(moduleName)
+			parsed.ModuleHasGlob = parsed.ModuleHasGlob || matcher != nil
+			parsed.ModuleGlobs = append(parsed.ModuleGlobs, matcher)
+		}
 		pos++ // move to next part
 	}
 
@@ -183,9 +251,12 @@ func ParseQuery(query string) (*ParsedQuery, error) {
 			parsed.Type = typeAndIndex[:idx]
 			indexStr := typeAndIndex[idx+1 : len(typeAndIndex)-1]
 			parsed.Index = parseIndex(indexStr)
+			parsed.IndexGlob = compileIndexGlob(indexStr)
+			parsed.Splat = parsed.Splat || indexStr == "*"
 		} else {
 			parsed.Type = typeAndIndex
 		}
+		parsed.TypeGlob = compileMatcher(parsed.Type)
 		pos++
 	}
 
@@ -197,31 +268,56 @@ func ParseQuery(query string) (*ParsedQuery, error) {
 			parsed.Name = nameAndIndex[:idx]
 			indexStr := nameAndIndex[idx+1 : len(nameAndIndex)-1]
 			parsed.Index = parseIndex(indexStr)
+			parsed.IndexGlob = compileIndexGlob(indexStr)
+			parsed.Splat = parsed.Splat || indexStr == "*"
 		} else {
 			parsed.Name = nameAndIndex
 		}
+		parsed.NameGlob = compileMatcher(parsed.Name)
 		pos++
 	}
 
-	// Get attribute (optional)
-	if pos < len(parts) {
-		parsed.Attribute = parts[pos]
+	// Get attribute path (optional): every remaining part, each possibly
+	// carrying its own "[N]" index, e.g. "network_interface[0].private_ip"
+	// becomes the steps {Key: "network_interface"}, {Index: 0}, {Key:
+	// "private_ip"}. parsed.Attribute is kept in sync with the first step's
+	// Key for callers that only care about a single top-level attribute.
+	for pos < len(parts) {
+		part := parts[pos]
 		pos++
-	}
 
-	// Ensure we've consumed all parts
-	if pos < len(parts) {
-		return nil, fmt.Errorf("unexpected extra parts in query: %v", parts[pos:])
+		if idx := strings.Index(part, "["); idx != -1 {
+			if !strings.HasSuffix(part, "]") {
+				return nil, fmt.Errorf("invalid attribute path %q: unterminated index", part)
+			}
+			if key := part[:idx]; key != "" {
+				parsed.AttributePath = append(parsed.AttributePath, AttributeStep{Key: key})
+			}
+			indexStr := part[idx+1 : len(part)-1]
+			n, err := strconv.Atoi(indexStr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid attribute path %q: index %q must be an integer", part, indexStr)
+			}
+			parsed.AttributePath = append(parsed.AttributePath, AttributeStep{Index: n, IsIndex: true})
+		} else {
+			parsed.AttributePath = append(parsed.AttributePath, AttributeStep{Key: part})
+		}
+	}
+	if len(parsed.AttributePath) > 0 {
+		parsed.Attribute = parsed.AttributePath[0].Key
 	}
 
 	return parsed, nil
 }
 
-// smartSplit splits a string by delimiter but respects quoted strings
+// smartSplit splits a string by delimiter but respects quoted strings and
+// "[...]" index brackets, so an expression like "[count.index]" survives
+// intact instead of being split apart at the "." it contains.
 func smartSplit(s, delimiter string) []string {
 	var parts []string
 	var current strings.Builder
 	inQuotes := false
+	bracketDepth := 0
 	i := 0
 
 	for i < len(s) {
@@ -230,7 +326,17 @@ func smartSplit(s, delimiter string) []string {
 			inQuotes = !inQuotes
 			current.WriteByte(s[i])
 			i++
-		case !inQuotes && i+len(delimiter) <= len(s) && s[i:i+len(delimiter)] == delimiter:
+		case !inQuotes && s[i] == '[':
+			bracketDepth++
+			current.WriteByte(s[i])
+			i++
+		case !inQuotes && s[i] == ']':
+			if bracketDepth > 0 {
+				bracketDepth--
+			}
+			current.WriteByte(s[i])
+			i++
+		case !inQuotes && bracketDepth == 0 && i+len(delimiter) <= len(s) && s[i:i+len(delimiter)] == delimiter:
 			parts = append(parts, current.String())
 			current.Reset()
 			i += len(delimiter)
@@ -262,3 +368,16 @@ func parseIndex(indexStr string) interface{} {
 	// Return as string
 	return indexStr
 }
+
+// compileIndexGlob compiles an index bracket's raw (possibly quoted) content
+// into a Matcher, so "[*]" or ["prod-*"] can match against the instance's
+// index_key regardless of whether it's numeric or string. Returns nil for a
+// literal index, falling back to matchesIndex's existing comparison.
+func compileIndexGlob(indexStr string) Matcher {
+	unquoted := indexStr
+	if strings.HasPrefix(unquoted, `"`) && strings.HasSuffix(unquoted, `"`) {
+		unquoted = unquoted[1 : len(unquoted)-1]
+	}
+
+	return compileMatcher(unquoted)
+}
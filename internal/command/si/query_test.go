@@ -0,0 +1,157 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package si
+
+import "testing"
+
+func TestParseQuery_LiteralUnaffectedByGlobSupport(t *testing.T) {
+	parsed, err := ParseQuery("module.sample.aws_instance.web[0]")
+	if err != nil {
+		t.Fatalf("ParseQuery returned error: %v", err)
+	}
+
+	if len(parsed.Module) != 1 || parsed.Module[0] != "sample" {
+		t.Fatalf("Module = %v, want [sample]", parsed.Module)
+	}
+	if parsed.Type != "aws_instance" {
+		t.Fatalf("Type = %q, want aws_instance", parsed.Type)
+	}
+	if parsed.Name != "web" {
+		t.Fatalf("Name = %q, want web", parsed.Name)
+	}
+	if parsed.Index != 0 {
+		t.Fatalf("Index = %v, want 0", parsed.Index)
+	}
+
+	if parsed.ModuleHasGlob {
+		t.Error("ModuleHasGlob = true for a fully-literal query")
+	}
+	if parsed.TypeGlob != nil || parsed.NameGlob != nil || parsed.IndexGlob != nil {
+		t.Error("a literal query should not compile any Matcher")
+	}
+}
+
+func TestFindMatchingResources_LiteralQueryByteIdentical(t *testing.T) {
+	stateData := sampleDriftState()
+
+	parsed, err := ParseQuery("aws_instance.web")
+	if err != nil {
+		t.Fatalf("ParseQuery returned error: %v", err)
+	}
+
+	matches := FindMatchingResources(stateData, parsed)
+	if len(matches) != 1 {
+		t.Fatalf("got %d matches, want 1", len(matches))
+	}
+	if matches[0]["name"] != "web" {
+		t.Fatalf("matched resource name = %v, want web", matches[0]["name"])
+	}
+}
+
+func TestFindMatchingResources_TypeGlob(t *testing.T) {
+	stateData := sampleDriftState()
+
+	parsed, err := ParseQuery("aws_*")
+	if err != nil {
+		t.Fatalf("ParseQuery returned error: %v", err)
+	}
+	if parsed.TypeGlob == nil {
+		t.Fatal("expected TypeGlob to be compiled for \"aws_*\"")
+	}
+
+	matches := FindMatchingResources(stateData, parsed)
+	if len(matches) != 4 {
+		t.Fatalf("got %d matches, want 4 (aws_instance.web, both aws_instance.cluster instances, and aws_s3_bucket.data)", len(matches))
+	}
+}
+
+func TestFindMatchingResources_IndexGlob(t *testing.T) {
+	stateData := sampleDriftState()
+
+	parsed, err := ParseQuery("aws_instance.cluster[*]")
+	if err != nil {
+		t.Fatalf("ParseQuery returned error: %v", err)
+	}
+	if parsed.IndexGlob == nil {
+		t.Fatal("expected IndexGlob to be compiled for \"[*]\"")
+	}
+
+	matches := FindMatchingResources(stateData, parsed)
+	if len(matches) != 2 {
+		t.Fatalf("got %d matches, want 2 (both cluster instances)", len(matches))
+	}
+}
+
+func TestFindMatchingResources_ModuleDoubleStar(t *testing.T) {
+	stateData := sampleDriftState()
+
+	parsed, err := ParseQuery("module.**.aws_instance.nested")
+	if err != nil {
+		t.Fatalf("ParseQuery returned error: %v", err)
+	}
+	if !parsed.ModuleHasGlob {
+		t.Fatal("expected ModuleHasGlob to be true for a \"**\" module component")
+	}
+
+	matches := FindMatchingResources(stateData, parsed)
+	if len(matches) != 1 {
+		t.Fatalf("got %d matches, want 1 (nested resource at any module depth)", len(matches))
+	}
+}
+
+// sampleDriftState returns a small, hand-built state document covering a
+// flat resource, an indexed resource, and a resource two module levels deep,
+// enough to exercise literal and glob matching across module/type/name/index.
+func sampleDriftState() map[string]interface{} {
+	return map[string]interface{}{
+		"resources": []interface{}{
+			map[string]interface{}{
+				"mode": "managed",
+				"type": "aws_instance",
+				"name": "web",
+				"instances": []interface{}{
+					map[string]interface{}{
+						"attributes": map[string]interface{}{"id": "i-web"},
+					},
+				},
+			},
+			map[string]interface{}{
+				"mode": "managed",
+				"type": "aws_instance",
+				"name": "cluster",
+				"instances": []interface{}{
+					map[string]interface{}{
+						"index_key":  float64(0),
+						"attributes": map[string]interface{}{"id": "i-cluster-0"},
+					},
+					map[string]interface{}{
+						"index_key":  float64(1),
+						"attributes": map[string]interface{}{"id": "i-cluster-1"},
+					},
+				},
+			},
+			map[string]interface{}{
+				"mode": "managed",
+				"type": "aws_s3_bucket",
+				"name": "data",
+				"instances": []interface{}{
+					map[string]interface{}{
+						"attributes": map[string]interface{}{"id": "my-bucket"},
+					},
+				},
+			},
+			map[string]interface{}{
+				"mode":   "managed",
+				"module": "module.a.module.b",
+				"type":   "aws_instance",
+				"name":   "nested",
+				"instances": []interface{}{
+					map[string]interface{}{
+						"attributes": map[string]interface{}{"id": "i-nested"},
+					},
+				},
+			},
+		},
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+
+	"github.com/tfctl/tfctl/internal/addrs"
 )
 
 // findMatchingResources finds resources in state data matching the query
@@ -35,20 +37,36 @@ func FindMatchingResources(stateData map[string]interface{}, query *ParsedQuery)
 		}
 
 		// Check module
-		if !matchesModule(res, query.Module) {
+		if !matchesModule(res, query) {
 			continue
 		}
 
 		// Check type (if specified)
 		if query.Type != "" {
-			if resType, ok := res["type"].(string); !ok || resType != query.Type {
+			resType, ok := res["type"].(string)
+			if !ok {
+				continue
+			}
+			if query.TypeGlob != nil {
+				if !query.TypeGlob(resType) {
+					continue
+				}
+			} else if resType != query.Type {
 				continue
 			}
 		}
 
 		// Check name (if specified)
 		if query.Name != "" {
-			if resName, ok := res["name"].(string); !ok || resName != query.Name {
+			resName, ok := res["name"].(string)
+			if !ok {
+				continue
+			}
+			if query.NameGlob != nil {
+				if !query.NameGlob(resName) {
+					continue
+				}
+			} else if resName != query.Name {
 				continue
 			}
 		}
@@ -62,7 +80,7 @@ func FindMatchingResources(stateData map[string]interface{}, query *ParsedQuery)
 					if !ok {
 						continue
 					}
-					if matchesIndex(inst, query.Index) {
+					if matchesIndex(inst, query.Index, query.IndexGlob) {
 						matches = append(matches, createResourceMatch(res, inst))
 					}
 				}
@@ -82,9 +100,12 @@ func FindMatchingResources(stateData map[string]interface{}, query *ParsedQuery)
 	return matches
 }
 
-// matchesModule checks if a resource belongs to the specified module path
-func matchesModule(resource map[string]interface{}, moduleQuery []string) bool {
-	if len(moduleQuery) == 0 {
+// matchesModule checks if a resource belongs to the specified module path.
+// A fully-literal query is compared exactly as before, byte-identical to
+// pre-glob behavior; a query with a glob or "**" component instead walks
+// the module path segment by segment via matchModulePath.
+func matchesModule(resource map[string]interface{}, query *ParsedQuery) bool {
+	if len(query.Module) == 0 {
 		// No module specified - match resources not in modules
 		return resource["module"] == nil
 	}
@@ -94,19 +115,70 @@ func matchesModule(resource map[string]interface{}, moduleQuery []string) bool {
 		return false
 	}
 
-	// Build expected module string
-	expected := "module." + strings.Join(moduleQuery, ".")
-	return moduleStr == expected
+	if !query.ModuleHasGlob {
+		// Build expected module string
+		expected := "module." + strings.Join(query.Module, ".")
+		return moduleStr == expected
+	}
+
+	actual := strings.Split(strings.TrimPrefix(moduleStr, "module."), ".module.")
+	return matchModulePath(query.Module, query.ModuleGlobs, actual)
+}
+
+// matchModulePath walks pattern segments against the module path's actual
+// segments. A "**" pattern segment matches zero or more actual segments, so
+// it can stand in for any number of nested module levels; every other
+// segment matches exactly one actual segment, literally or (via the
+// parallel globs Matcher) as a glob.
+func matchModulePath(pattern []string, globs []Matcher, actual []string) bool {
+	if len(pattern) == 0 {
+		return len(actual) == 0
+	}
+
+	if pattern[0] == "**" {
+		for i := 0; i <= len(actual); i++ {
+			if matchModulePath(pattern[1:], globs[1:], actual[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(actual) == 0 {
+		return false
+	}
+
+	if matcher := globs[0]; matcher != nil {
+		if !matcher(actual[0]) {
+			return false
+		}
+	} else if pattern[0] != actual[0] {
+		return false
+	}
+
+	return matchModulePath(pattern[1:], globs[1:], actual[1:])
 }
 
-// matchesIndex checks if an instance matches the specified index
-func matchesIndex(instance map[string]interface{}, queryIndex interface{}) bool {
+// matchesIndex checks if an instance matches the specified index. glob, if
+// non-nil, overrides the literal comparison below entirely. "count.index"
+// and "each.key" are Terraform expressions tfctl has no live plan to
+// evaluate, so -- rather than rejecting them by never matching -- they
+// match every instance, the same as a literal "*" splat.
+func matchesIndex(instance map[string]interface{}, queryIndex interface{}, glob Matcher) bool {
+	if queryIndex == "count.index" || queryIndex == "each.key" {
+		return true
+	}
+
 	indexKey, ok := instance["index_key"]
 	if !ok {
 		// No index key means this is the only instance (index 0)
 		return queryIndex == 0 || queryIndex == "0"
 	}
 
+	if glob != nil {
+		return glob(indexKeyString(indexKey))
+	}
+
 	switch v := queryIndex.(type) {
 	case int:
 		if idx, ok := indexKey.(float64); ok {
@@ -136,6 +208,21 @@ func matchesIndex(instance map[string]interface{}, queryIndex interface{}) bool
 	return false
 }
 
+// indexKeyString renders an instance's index_key (float64, int, or string,
+// per state's JSON decoding) as a string for glob matching.
+func indexKeyString(indexKey interface{}) string {
+	switch v := indexKey.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.Itoa(int(v))
+	case int:
+		return strconv.Itoa(v)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
 // createResourceMatch creates a flattened resource representation
 func createResourceMatch(resource map[string]interface{}, instance map[string]interface{}) map[string]interface{} {
 	// Create a combined view of resource + instance
@@ -156,67 +243,91 @@ func createResourceMatch(resource map[string]interface{}, instance map[string]in
 	return result
 }
 
-// generateResourceAddresses creates Terraform addresses for matched resources
+// ResourceAddresses is the exported form of generateResourceAddresses, for
+// callers outside the package (e.g. the "sq serve" HTTP API) that already
+// have a match list from FindMatchingResources and want the same deduped
+// address list ProcessQuery prints.
+func ResourceAddresses(matches []map[string]interface{}) []string {
+	return generateResourceAddresses(matches)
+}
+
+// generateResourceAddresses creates Terraform addresses for matched
+// resources, deduping on addrs.ResourceInstance's UniqueKey so the same
+// instance is never listed twice.
 func generateResourceAddresses(matches []map[string]interface{}) []string {
 	var addresses []string
+	seen := make(map[addrs.UniqueKey]bool, len(matches))
 
 	for _, match := range matches {
-		addr := buildResourceAddress(match)
-		addresses = append(addresses, addr)
+		ri := resourceInstanceFor(match)
+		if key := ri.UniqueKey(); !seen[key] {
+			seen[key] = true
+			addresses = append(addresses, ri.String())
+		}
 	}
 
 	return addresses
 }
 
-// buildResourceAddress constructs a Terraform address from resource data
-func buildResourceAddress(resource map[string]interface{}) string {
-	var parts []string
-
-	// Add module prefix if present
-	if module, ok := resource["module"].(string); ok && module != "" {
-		parts = append(parts, module)
+// resourceInstanceFor builds the canonical addrs.ResourceInstance for a
+// flattened resource match (as produced by createResourceMatch), replacing
+// the ad-hoc string concatenation buildResourceAddress used to do.
+func resourceInstanceFor(resource map[string]interface{}) addrs.ResourceInstance {
+	var module addrs.ModuleInstance
+	if mod, ok := resource["module"].(string); ok && mod != "" {
+		module = addrs.ModuleInstance(strings.Split(strings.TrimPrefix(mod, "module."), ".module."))
 	}
 
-	// Add mode prefix for data sources
-	if mode, ok := resource["mode"].(string); ok && mode == "data" {
-		parts = append(parts, "data")
+	mode := "managed"
+	if m, ok := resource["mode"].(string); ok && m != "" {
+		mode = m
 	}
 
-	// Add type
-	if resourceType, ok := resource["type"].(string); ok {
-		parts = append(parts, resourceType)
+	var resType, name string
+	if t, ok := resource["type"].(string); ok {
+		resType = t
+	}
+	if n, ok := resource["name"].(string); ok {
+		name = n
 	}
 
-	// Add name
-	if name, ok := resource["name"].(string); ok {
-		namePart := name
-
-		// Add index if present
-		if indexKey, ok := resource["index_key"]; ok {
-			switch v := indexKey.(type) {
-			case float64:
-				namePart += fmt.Sprintf("[%d]", int(v))
-			case int:
-				namePart += fmt.Sprintf("[%d]", v)
-			case string:
-				namePart += fmt.Sprintf("[%q]", v)
-			}
+	var key addrs.InstanceKey = addrs.NoKey{}
+	if indexKey, ok := resource["index_key"]; ok {
+		switch v := indexKey.(type) {
+		case float64:
+			key = addrs.IntKey(int(v))
+		case int:
+			key = addrs.IntKey(v)
+		case string:
+			key = addrs.StringKey(v)
 		}
-
-		parts = append(parts, namePart)
 	}
 
-	return strings.Join(parts, ".")
+	return addrs.ResourceInstance{
+		Resource: addrs.AbsResource{
+			Module: module,
+			Mode:   mode,
+			Type:   resType,
+			Name:   name,
+		},
+		Key: key,
+	}
 }
 
-// extractAttribute extracts the specified attribute from a resource, handling indices
+// extractAttribute extracts the attribute (or chained attribute path)
+// specified by parsed from a resource, handling indices.
 func ExtractAttribute(resource map[string]interface{}, parsed *ParsedQuery) interface{} {
+	path := parsed.AttributePath
+	if len(path) == 0 && parsed.Attribute != "" {
+		path = []AttributeStep{{Key: parsed.Attribute}}
+	}
+	if len(path) == 0 {
+		return nil
+	}
+
 	// Check if this is a flattened resource match (has attributes directly)
 	if attributes, ok := resource["attributes"].(map[string]interface{}); ok {
-		if attrValue, exists := attributes[parsed.Attribute]; exists {
-			return attrValue
-		}
-		return nil
+		return walkAttributePath(attributes, path)
 	}
 
 	// Fall back to original instances array logic for unflattened resources
@@ -231,7 +342,7 @@ func ExtractAttribute(resource map[string]interface{}, parsed *ParsedQuery) inte
 		for _, instance := range instances {
 			if instanceMap, ok := instance.(map[string]interface{}); ok {
 				if attributes, ok := instanceMap["attributes"].(map[string]interface{}); ok {
-					if attrValue, exists := attributes[parsed.Attribute]; exists {
+					if attrValue := walkAttributePath(attributes, path); attrValue != nil {
 						results = append(results, attrValue)
 					}
 				}
@@ -249,7 +360,7 @@ func ExtractAttribute(resource map[string]interface{}, parsed *ParsedQuery) inte
 			if indexKey, exists := instanceMap["index_key"]; exists {
 				if indexMatchesValue(indexKey, parsed.Index) {
 					if attributes, ok := instanceMap["attributes"].(map[string]interface{}); ok {
-						if attrValue, exists := attributes[parsed.Attribute]; exists {
+						if attrValue := walkAttributePath(attributes, path); attrValue != nil {
 							return attrValue
 						}
 					}
@@ -257,7 +368,7 @@ func ExtractAttribute(resource map[string]interface{}, parsed *ParsedQuery) inte
 			} else if parsed.Index == 0 || parsed.Index == "0" {
 				// No index_key means this is the first (and possibly only) instance
 				if attributes, ok := instanceMap["attributes"].(map[string]interface{}); ok {
-					if attrValue, exists := attributes[parsed.Attribute]; exists {
+					if attrValue := walkAttributePath(attributes, path); attrValue != nil {
 						return attrValue
 					}
 				}
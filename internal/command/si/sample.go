@@ -0,0 +1,41 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package si
+
+import (
+	"fmt"
+	"hash/fnv"
+)
+
+// Sample deterministically keeps roughly n of matches: it hashes each
+// match's canonical address (its addrs.ResourceInstance.String(), salted
+// with seed) into a bucket in [0,1) via FNV-1a, and keeps the match when its
+// bucket falls below n/len(matches). The bucket function is pure and
+// depends only on the address and seed, never on matches' iteration order,
+// so identical --seed values reproduce identical samples across runs, state
+// versions, or any re-sharding of the input.
+func Sample(matches []map[string]interface{}, n int, seed string) []map[string]interface{} {
+	if n <= 0 || n >= len(matches) {
+		return matches
+	}
+
+	threshold := float64(n) / float64(len(matches))
+
+	sampled := make([]map[string]interface{}, 0, n)
+	for _, match := range matches {
+		address := resourceInstanceFor(match).String()
+		if bucket(address, seed) < threshold {
+			sampled = append(sampled, match)
+		}
+	}
+	return sampled
+}
+
+// bucket hashes address+seed with FNV-1a into a float uniformly distributed
+// over [0,1).
+func bucket(address, seed string) float64 {
+	h := fnv.New64a()
+	_, _ = fmt.Fprintf(h, "%s\x00%s", address, seed)
+	return float64(h.Sum64()) / float64(^uint64(0))
+}
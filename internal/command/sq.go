@@ -12,13 +12,18 @@ import (
 	"strings"
 
 	"github.com/apex/log"
+	"github.com/tidwall/gjson"
 	"github.com/urfave/cli/v3"
 
+	"github.com/tfctl/tfctl/internal/attrs"
 	"github.com/tfctl/tfctl/internal/backend"
 	"github.com/tfctl/tfctl/internal/config"
+	"github.com/tfctl/tfctl/internal/diff"
 	"github.com/tfctl/tfctl/internal/differ"
+	"github.com/tfctl/tfctl/internal/filters"
 	"github.com/tfctl/tfctl/internal/meta"
 	"github.com/tfctl/tfctl/internal/output"
+	"github.com/tfctl/tfctl/internal/snapshot"
 	"github.com/tfctl/tfctl/internal/state"
 )
 
@@ -36,6 +41,18 @@ func sqCommandAction(ctx context.Context, cmd *cli.Command) error {
 
 	config.Config.Namespace = "sq"
 
+	// --recursive discovers and aggregates across every Terraform root under
+	// RootDir instead of reading RootDir itself as a single root, so it has
+	// nothing in common with the single-root path below beyond the flags it
+	// shares; hand off to its own action rather than threading a branch
+	// through the rest of this function.
+	if cmd.Bool("recursive") {
+		if cmd.Bool("diff") {
+			return fmt.Errorf("--recursive does not support --diff")
+		}
+		return sqRecursiveAction(ctx, cmd)
+	}
+
 	// Figure out what type of Backend we're in.
 	be, err := backend.NewBackend(ctx, *cmd)
 	if err != nil {
@@ -52,7 +69,39 @@ func sqCommandAction(ctx context.Context, cmd *cli.Command) error {
 				return diffErr
 			}
 
-			return differ.Diff(ctx, cmd, states)
+			anyDiff := false
+			for i, pair := range differ.Pairs(cmd, states) {
+				// A --timeout/Ctrl-C cancellation here still leaves whatever
+				// pairs were already rendered on stdout above this point -
+				// that's this loop's equivalent of a partial-results flush,
+				// there being no accumulated slice to hand off the way
+				// PaginateWithOptions does for mq/oq/pq/rq/svq/wq.
+				if err := ctx.Err(); err != nil {
+					return fmt.Errorf("sq: %w", err)
+				}
+
+				if i > 0 {
+					fmt.Fprintln(os.Stdout, strings.Repeat("=", 72))
+				}
+
+				result, err := diff.Compute(pair[0], pair[1], diff.Options{
+					IgnoreAttrs: splitCSV(cmd.String("ignore-attr")),
+				})
+				if err != nil {
+					return fmt.Errorf("failed to compute diff: %w", err)
+				}
+				if len(result.Resources) > 0 {
+					anyDiff = true
+				}
+				if err := diff.Render(os.Stdout, cmd.String("format"), result); err != nil {
+					return err
+				}
+			}
+
+			if anyDiff && cmd.Bool("exit-code") {
+				return fmt.Errorf("states differ")
+			}
+			return nil
 		} else {
 			log.Debug("Backend does not implement SelfDiffer")
 		}
@@ -84,13 +133,26 @@ func sqCommandAction(ctx context.Context, cmd *cli.Command) error {
 				passphrase, _ = state.GetPassphrase()
 			}
 
-			doc, err = state.DecryptOpenTofuState(doc, passphrase)
+			doc, err = state.DecryptOpenTofuStateWithCache(doc, passphrase, cmd.Bool("no-key-cache"))
 			if err != nil {
 				return fmt.Errorf("failed to decrypt: %w", err)
 			}
 		}
 	}
 
+	// Persist a snapshot of the state actually pulled, before --sample
+	// potentially thins it out. This is the one place every backend's
+	// state content flows through regardless of type, so it's a more
+	// reliable hook than any single backend's own load/pull method.
+	snapshotState(cmd, doc)
+
+	if sampleN := cmd.Int("sample"); sampleN > 0 {
+		doc, err = sampleStateResources(doc, int(sampleN), cmd.String("seed"))
+		if err != nil {
+			return fmt.Errorf("failed to sample state: %w", err)
+		}
+	}
+
 	var raw bytes.Buffer
 	raw.Write(doc)
 
@@ -102,11 +164,125 @@ func sqCommandAction(ctx context.Context, cmd *cli.Command) error {
 		return nil
 	}
 
-	output.SliceDiceSpit(raw, attrs, cmd, "", os.Stdout, postProcess)
+	output.SliceDiceSpit(raw, attrs, cmd, "", os.Stdout, postProcess, nil)
 
 	return nil
 }
 
+// sqRecursiveAction is sqCommandAction's --recursive path. It discovers every
+// Terraform root under GetMeta(cmd).RootDir and runs sqFetchRootRows once per
+// root, each against its own root-scoped *cli.Command (see RunRecursiveRows),
+// aggregating the rows into a single sorted emit instead of one per root.
+func sqRecursiveAction(ctx context.Context, cmd *cli.Command) error {
+	al := BuildAttrs(cmd, "!.mode", "!.type", ".resource", "id", "name")
+	log.Debugf("attrs: %v", al)
+
+	postProcess := func(dataset []map[string]interface{}) error {
+		if cmd.Bool("chop") {
+			chopPrefix(dataset)
+		}
+
+		return nil
+	}
+
+	rows, err := RunRecursiveRows(ctx, cmd, &al, func(ctx context.Context, scoped *cli.Command) ([]map[string]interface{}, error) {
+		return sqFetchRootRows(ctx, scoped, al)
+	})
+	if err != nil {
+		return err
+	}
+
+	return EmitJSONAPIRows(rows, al, cmd, postProcess)
+}
+
+// sqFetchRootRows runs a single root's state-query pipeline -- backend
+// resolution, state fetch, decrypt-if-encrypted, history snapshot, and
+// --sample thinning, exactly as sqCommandAction's single-root path does --
+// then flattens and filters (but does not transform; --recursive defers that
+// to its one terminal EmitJSONAPIRows/SliceDiceSpit call) the result into
+// rows for --recursive's aggregate.
+func sqFetchRootRows(ctx context.Context, cmd *cli.Command, al attrs.AttrList) ([]map[string]interface{}, error) {
+	be, err := backend.NewBackend(ctx, *cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := be.State()
+	if err != nil {
+		return nil, err
+	}
+
+	var jsonData map[string]interface{}
+	if err := json.Unmarshal(doc, &jsonData); err == nil {
+		if _, exists := jsonData["encrypted_data"]; exists {
+			passphrase := cmd.String("passphrase")
+			if passphrase == "" {
+				passphrase = os.Getenv("TFCTL_PASSPHRASE")
+			}
+			if passphrase == "" {
+				passphrase, _ = state.GetPassphrase()
+			}
+
+			doc, err = state.DecryptOpenTofuStateWithCache(doc, passphrase, cmd.Bool("no-key-cache"))
+			if err != nil {
+				return nil, fmt.Errorf("failed to decrypt: %w", err)
+			}
+		}
+	}
+
+	snapshotState(cmd, doc)
+
+	if sampleN := cmd.Int("sample"); sampleN > 0 {
+		doc, err = sampleStateResources(doc, int(sampleN), cmd.String("seed"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to sample state: %w", err)
+		}
+	}
+
+	flat, ok := output.FlattenStateResources(doc)
+	if !ok {
+		return nil, fmt.Errorf("state document has no \"resources\"")
+	}
+
+	flatJSON, err := json.Marshal(flat)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal flattened state: %w", err)
+	}
+
+	candidates := gjson.Parse(string(flatJSON))
+	return filters.FilterDataset(candidates, al, cmd.String("filter")), nil
+}
+
+// snapshotState persists doc's flattened resources as a state history
+// snapshot, identified by the --workspace flag (falling back to "default"
+// for backends, like local or s3, that have no workspace concept) and the
+// state document's own "serial" field. Failures are logged, not returned:
+// history is a nice-to-have audit trail, not something that should fail an
+// otherwise-successful sq invocation.
+func snapshotState(cmd *cli.Command, doc []byte) {
+	rows, ok := output.FlattenStateResources(doc)
+	if !ok {
+		return
+	}
+
+	var serialDoc struct {
+		Serial int64 `json:"serial"`
+	}
+	if err := json.Unmarshal(doc, &serialDoc); err != nil {
+		log.Debugf("snapshotState: failed to read serial: %v", err)
+		return
+	}
+
+	ws := cmd.String("workspace")
+	if ws == "" {
+		ws = "default"
+	}
+
+	if err := snapshot.Save(ws, serialDoc.Serial, rows); err != nil {
+		log.Debugf("snapshotState: failed to save snapshot: %v", err)
+	}
+}
+
 // sqCommandBuilder constructs the cli.Command for "sq", wiring metadata,
 // flags, and action/validator handlers.
 func sqCommandBuilder(meta meta.Meta) *cli.Command {
@@ -135,9 +311,24 @@ func sqCommandBuilder(meta meta.Meta) *cli.Command {
 				Value: false,
 			},
 			&cli.StringFlag{
-				Name:   "diff_filter",
+				Name:      "format",
+				Usage:     "diff output format",
+				Value:     "unified",
+				Validator: EnvAwareValidator("format", nil, EnumValidator(diff.Formats...)),
+			},
+			&cli.StringFlag{
+				Name:  "ignore-attr",
+				Usage: "comma-separated list of attribute names to exclude from --diff",
+			},
+			&cli.BoolFlag{
+				Name:  "exit-code",
+				Usage: "with --diff, exit with a non-zero status if the states differ",
+				Value: false,
+			},
+			&cli.StringFlag{
+				Name:   "diff_mode",
 				Hidden: true,
-				Value:  "check_results",
+				Value:  "timeline",
 			},
 			&cli.IntFlag{
 				Name:   "limit",
@@ -154,12 +345,23 @@ func sqCommandBuilder(meta meta.Meta) *cli.Command {
 				Name:  "passphrase",
 				Usage: "encrypted state passphrase",
 			},
+			noKeyCacheFlag,
 			&cli.StringFlag{
 				Name:        "sv",
 				Usage:       "state version to query",
 				Value:       "0",
 				HideDefault: true,
 			},
+			&cli.IntFlag{
+				Name:        "sample",
+				Usage:       "deterministically sample roughly N resource instances",
+				HideDefault: true,
+			},
+			&cli.StringFlag{
+				Name:        "seed",
+				Usage:       "seed for --sample, so repeat runs pick the same sample",
+				HideDefault: true,
+			},
 			// We don't want sq to get default host and org values from the config.
 			// Instead, we'll depend on the backend or, in exceptional cases, explicit
 			// --host and --org flags.
@@ -167,6 +369,13 @@ func sqCommandBuilder(meta meta.Meta) *cli.Command {
 			NewOrgFlag("sq"),
 			tldrFlag,
 			workspaceFlag,
+			noInitFlag,
+			concurrencyFlag,
+			allEpochsFlag,
+			epochFlag,
+			atFlag,
+			recursiveFlag,
+			maxWorkersFlag,
 		}, NewGlobalFlags("sq")...),
 		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
 			// If --chop is set, --short must not be set.
@@ -177,6 +386,9 @@ func sqCommandBuilder(meta meta.Meta) *cli.Command {
 			return ctx, GlobalFlagsValidator(ctx, cmd)
 		},
 		Action: sqCommandAction,
+		Commands: []*cli.Command{
+			sqServeCommandBuilder(meta),
+		},
 	}
 }
 
@@ -0,0 +1,139 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"strings"
+
+	"github.com/tfctl/tfctl/internal/addrs"
+)
+
+// sampleStateResources deterministically keeps roughly n of doc's resource
+// instances: it hashes each instance's canonical addrs.ResourceInstance
+// address (salted with seed) into a bucket in [0,1) via FNV-1a, and keeps
+// the instance when its bucket falls below n/total, the same pure,
+// order-independent approach si.Sample uses for the "si" console. Identical
+// --seed values reproduce identical samples across runs and state
+// versions, useful for eyeballing or scripting spot checks against states
+// with tens of thousands of resources. Resources left with no instances
+// after sampling are dropped entirely.
+func sampleStateResources(doc []byte, n int, seed string) ([]byte, error) {
+	var parsed map[string]interface{}
+	if err := json.Unmarshal(doc, &parsed); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal state for sampling: %w", err)
+	}
+
+	resources, ok := parsed["resources"].([]interface{})
+	if !ok {
+		return doc, nil
+	}
+
+	total := 0
+	for _, r := range resources {
+		res, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if instances, ok := res["instances"].([]interface{}); ok {
+			total += len(instances)
+		}
+	}
+	if n <= 0 || n >= total {
+		return doc, nil
+	}
+	threshold := float64(n) / float64(total)
+
+	var sampledResources []interface{}
+	for _, r := range resources {
+		res, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		instances, ok := res["instances"].([]interface{})
+		if !ok {
+			continue
+		}
+
+		var kept []interface{}
+		for _, inst := range instances {
+			instMap, ok := inst.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			address := resourceInstanceAddress(res, instMap).String()
+			if bucket(address, seed) < threshold {
+				kept = append(kept, inst)
+			}
+		}
+		if len(kept) == 0 {
+			continue
+		}
+
+		sampled := make(map[string]interface{}, len(res))
+		for k, v := range res {
+			sampled[k] = v
+		}
+		sampled["instances"] = kept
+		sampledResources = append(sampledResources, sampled)
+	}
+
+	parsed["resources"] = sampledResources
+	return json.Marshal(parsed)
+}
+
+// resourceInstanceAddress builds the canonical addrs.ResourceInstance for a
+// raw state resource/instance pair, the same shape si.resourceInstanceFor
+// builds for its own (already-flattened) match maps.
+func resourceInstanceAddress(res, inst map[string]interface{}) addrs.ResourceInstance {
+	var module addrs.ModuleInstance
+	if mod, ok := res["module"].(string); ok && mod != "" {
+		module = addrs.ModuleInstance(strings.Split(strings.TrimPrefix(mod, "module."), ".module."))
+	}
+
+	mode := "managed"
+	if m, ok := res["mode"].(string); ok && m != "" {
+		mode = m
+	}
+
+	var resType, name string
+	if t, ok := res["type"].(string); ok {
+		resType = t
+	}
+	if nm, ok := res["name"].(string); ok {
+		name = nm
+	}
+
+	var key addrs.InstanceKey = addrs.NoKey{}
+	if indexKey, ok := inst["index_key"]; ok {
+		switch v := indexKey.(type) {
+		case float64:
+			key = addrs.IntKey(int(v))
+		case int:
+			key = addrs.IntKey(v)
+		case string:
+			key = addrs.StringKey(v)
+		}
+	}
+
+	return addrs.ResourceInstance{
+		Resource: addrs.AbsResource{
+			Module: module,
+			Mode:   mode,
+			Type:   resType,
+			Name:   name,
+		},
+		Key: key,
+	}
+}
+
+// bucket hashes address+seed with FNV-1a into a float uniformly distributed
+// over [0,1), mirroring si's own bucket function.
+func bucket(address, seed string) float64 {
+	h := fnv.New64a()
+	_, _ = fmt.Fprintf(h, "%s\x00%s", address, seed)
+	return float64(h.Sum64()) / float64(^uint64(0))
+}
@@ -0,0 +1,309 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+
+	"github.com/apex/log"
+	"github.com/urfave/cli/v3"
+
+	"github.com/tfctl/tfctl/internal/backend"
+	"github.com/tfctl/tfctl/internal/command/si"
+	"github.com/tfctl/tfctl/internal/config"
+	"github.com/tfctl/tfctl/internal/diff"
+	"github.com/tfctl/tfctl/internal/meta"
+	"github.com/tfctl/tfctl/internal/state"
+)
+
+// sqServeCommandAction is the action handler for "sq serve". It loads state
+// once, the same way sqCommandAction does, then exposes it over a small
+// read-only HTTP/JSON API instead of reparsing state on every invocation --
+// a lightweight Terraboard-style browsing daemon, embeddable behind a
+// dashboard.
+func sqServeCommandAction(ctx context.Context, cmd *cli.Command) error {
+	m := GetMeta(cmd)
+	log.Debugf("Executing action for %v", m.Args[1:])
+
+	if ShortCircuitTLDR(ctx, cmd, "serve") {
+		return nil
+	}
+
+	config.Config.Namespace = "sq"
+
+	be, err := backend.NewBackend(ctx, *cmd)
+	if err != nil {
+		return err
+	}
+
+	stateData, err := loadStateDataFromBackend(be, cmd)
+	if err != nil {
+		return err
+	}
+
+	srv := &sqServer{ctx: ctx, cmd: cmd, be: be, stateData: stateData}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /resources", srv.handleResourceList)
+	mux.HandleFunc("GET /resources/{address}", srv.handleResource)
+	mux.HandleFunc("GET /outputs/{name}", srv.handleOutput)
+	mux.HandleFunc("GET /versions", srv.handleVersions)
+	mux.HandleFunc("GET /diff", srv.handleDiff)
+
+	addr := cmd.String("addr")
+	fmt.Fprintf(os.Stdout, "tfctl sq serve listening on %s\n", addr)
+	return http.ListenAndServe(addr, mux)
+}
+
+// loadStateDataFromBackend fetches and, if needed, decrypts be's state, the
+// same decrypt-on-demand logic sqCommandAction uses -- duplicated rather
+// than shared via state.LoadStateData because this handler also needs the
+// Backend itself (for StateVersions/DiffStates), not just the parsed state.
+func loadStateDataFromBackend(be backend.Backend, cmd *cli.Command) (map[string]interface{}, error) {
+	doc, err := be.State()
+	if err != nil {
+		return nil, err
+	}
+
+	var jsonData map[string]interface{}
+	if err := json.Unmarshal(doc, &jsonData); err == nil {
+		if _, exists := jsonData["encrypted_data"]; exists {
+			passphrase := cmd.String("passphrase")
+			if passphrase == "" {
+				passphrase = os.Getenv("TFCTL_PASSPHRASE")
+			}
+			if passphrase == "" {
+				passphrase, _ = state.GetPassphrase()
+			}
+
+			doc, err = state.DecryptOpenTofuStateWithCache(doc, passphrase, cmd.Bool("no-key-cache"))
+			if err != nil {
+				return nil, fmt.Errorf("failed to decrypt: %w", err)
+			}
+
+			jsonData = nil
+			if err := json.Unmarshal(doc, &jsonData); err != nil {
+				return nil, fmt.Errorf("failed to parse decrypted state: %w", err)
+			}
+			return jsonData, nil
+		}
+	}
+
+	return jsonData, nil
+}
+
+// sqServer holds the state a "sq serve" handler needs: the state document
+// (loaded once, at startup) and the backend it came from, for the
+// version/diff endpoints that query the backend directly.
+type sqServer struct {
+	ctx       context.Context
+	cmd       *cli.Command
+	be        backend.Backend
+	stateData map[string]interface{}
+}
+
+// queryFromParams builds an si query string from the "module", "type",
+// "name", and "index" query params, mirroring the module/type/name/index
+// positions sq's own CLI query syntax uses.
+func queryFromParams(q url.Values) string {
+	var query string
+	if m := q.Get("module"); m != "" {
+		query += "module." + m + "."
+	}
+	query += q.Get("type")
+	if n := q.Get("name"); n != "" {
+		query += "." + n
+	}
+	if idx := q.Get("index"); idx != "" {
+		query += "[" + idx + "]"
+	}
+	return query
+}
+
+// handleResourceList serves GET /resources: the deduped address list for
+// resources matching the "module"/"type"/"name"/"index" filter params,
+// mirroring the sq CLI's own query positions.
+func (s *sqServer) handleResourceList(w http.ResponseWriter, r *http.Request) {
+	parsed, err := si.ParseQuery(queryFromParams(r.URL.Query()))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	matches := si.FindMatchingResources(s.stateData, parsed)
+	writeJSON(w, si.ResourceAddresses(matches))
+}
+
+// handleResource serves GET /resources/{address}: the flattened resource
+// match createResourceMatch builds for that one address, or 404 if no
+// resource matches it.
+func (s *sqServer) handleResource(w http.ResponseWriter, r *http.Request) {
+	parsed, err := si.ParseQuery(r.PathValue("address"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	matches := si.FindMatchingResources(s.stateData, parsed)
+	if len(matches) == 0 {
+		writeError(w, http.StatusNotFound, fmt.Errorf("no resource matches %q", r.PathValue("address")))
+		return
+	}
+	writeJSON(w, matches[0])
+}
+
+// handleOutput serves GET /outputs/{name}: that output's "value", or 404 if
+// it isn't defined.
+func (s *sqServer) handleOutput(w http.ResponseWriter, r *http.Request) {
+	outputs, ok := s.stateData["outputs"].(map[string]interface{})
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("no outputs in state"))
+		return
+	}
+
+	output, ok := outputs[r.PathValue("name")].(map[string]interface{})
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("output %q not found", r.PathValue("name")))
+		return
+	}
+	writeJSON(w, output["value"])
+}
+
+// handleVersions serves GET /versions: the raw state versions the backend's
+// SelfDiffer would diff between, summarized as index + terraform_version +
+// serial, so a caller can pick "from"/"to" indices for GET /diff.
+func (s *sqServer) handleVersions(w http.ResponseWriter, r *http.Request) {
+	sd, ok := s.be.(backend.SelfDiffer)
+	if !ok {
+		writeError(w, http.StatusNotImplemented, fmt.Errorf("backend does not support listing versions"))
+		return
+	}
+
+	states, err := sd.DiffStates(s.ctx, s.cmd)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	type versionSummary struct {
+		Index            int    `json:"index"`
+		TerraformVersion string `json:"terraform_version,omitempty"`
+		Serial           int    `json:"serial,omitempty"`
+	}
+
+	summaries := make([]versionSummary, 0, len(states))
+	for i, doc := range states {
+		var head struct {
+			TerraformVersion string `json:"terraform_version"`
+			Serial           int    `json:"serial"`
+		}
+		_ = json.Unmarshal(doc, &head)
+		summaries = append(summaries, versionSummary{Index: i, TerraformVersion: head.TerraformVersion, Serial: head.Serial})
+	}
+
+	writeJSON(w, summaries)
+}
+
+// handleDiff serves GET /diff?from=X&to=Y, where X/Y are indices into the
+// same version list GET /versions reports, and renders the structured diff
+// internal/diff computes between them as JSON.
+func (s *sqServer) handleDiff(w http.ResponseWriter, r *http.Request) {
+	sd, ok := s.be.(backend.SelfDiffer)
+	if !ok {
+		writeError(w, http.StatusNotImplemented, fmt.Errorf("backend does not support diffing"))
+		return
+	}
+
+	from, err := strconv.Atoi(r.URL.Query().Get("from"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid 'from' index: %w", err))
+		return
+	}
+	to, err := strconv.Atoi(r.URL.Query().Get("to"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid 'to' index: %w", err))
+		return
+	}
+
+	states, err := sd.DiffStates(s.ctx, s.cmd)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if from < 0 || from >= len(states) || to < 0 || to >= len(states) {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("'from'/'to' must be within [0, %d)", len(states)))
+		return
+	}
+
+	result, err := diff.Compute(states[from], states[to], diff.Options{
+		IgnoreAttrs: splitCSV(s.cmd.String("ignore-attr")),
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := diff.Render(w, "json", result); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+	}
+}
+
+// writeJSON writes v to w as indented JSON with a 200 status.
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		log.Errorf("failed to encode response: %v", err)
+	}
+}
+
+// writeError writes err's message to w as a JSON {"error": "..."} body with
+// the given status code.
+func writeError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
+
+// sqServeCommandBuilder constructs the "serve" subcommand of "sq", wiring
+// metadata, flags, and its action handler.
+func sqServeCommandBuilder(meta meta.Meta) *cli.Command {
+	return &cli.Command{
+		Name:      "serve",
+		Usage:     "serve state over a read-only HTTP/JSON API",
+		UsageText: "tfctl sq serve [RootDir] [options]",
+		Metadata: map[string]any{
+			"meta": meta,
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "addr",
+				Usage: "address to listen on",
+				Value: "127.0.0.1:8080",
+			},
+			&cli.StringFlag{
+				Name:  "passphrase",
+				Usage: "encrypted state passphrase",
+			},
+			noKeyCacheFlag,
+			NewHostFlag("sq"),
+			NewOrgFlag("sq"),
+			tldrFlag,
+			workspaceFlag,
+			noInitFlag,
+		},
+		Before: func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+			return ctx, GlobalFlagsValidator(ctx, cmd)
+		},
+		Action: sqServeCommandAction,
+	}
+}
@@ -0,0 +1,501 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package command
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/apex/log"
+	"github.com/urfave/cli/v3"
+
+	"github.com/tfctl/tfctl/internal/attrs"
+	"github.com/tfctl/tfctl/internal/meta"
+	"github.com/tfctl/tfctl/internal/output"
+	"github.com/tfctl/tfctl/internal/snapshot"
+	"github.com/tfctl/tfctl/internal/state"
+)
+
+// historyDefaultAttrs specifies the default attributes displayed for
+// `tfctl state history` rows.
+var historyDefaultAttrs = []string{".serial", ".timestamp", ".added", ".changed", ".removed"}
+
+// stateDiffDefaultAttrs specifies the default attributes displayed for
+// `tfctl state diff` rows. Matches diffDefaultAttrs in diff.go, since
+// snapshot.Row is the same shape as diffRow.
+var stateDiffDefaultAttrs = []string{".resource", ".action", ".attribute", ".old", ".new"}
+
+// stateWorkspace resolves the --workspace flag value, falling back to
+// "default" for backends (local, s3, ...) that have no workspace concept of
+// their own, matching the fallback snapshotState uses when persisting.
+func stateWorkspace(cmd *cli.Command) string {
+	if ws := cmd.String("workspace"); ws != "" {
+		return ws
+	}
+	return "default"
+}
+
+// stateHistoryCommandAction is the action handler for `tfctl state
+// history`. It lists every persisted snapshot for the active workspace,
+// oldest first, with the added/changed/removed counts against its
+// predecessor.
+func stateHistoryCommandAction(ctx context.Context, cmd *cli.Command) error {
+	log.Debugf("Executing action for state history")
+
+	ws := stateWorkspace(cmd)
+	history, err := snapshot.History(ws)
+	if err != nil {
+		return fmt.Errorf("failed to list snapshot history: %w", err)
+	}
+	if len(history) == 0 {
+		fmt.Fprintf(os.Stdout, "no snapshot history for workspace %q\n", ws)
+		return nil
+	}
+
+	jsonData, err := json.Marshal(history)
+	if err != nil {
+		return fmt.Errorf("failed to marshal dataset: %w", err)
+	}
+
+	attrList := attrs.AttrList{}
+	for _, a := range historyDefaultAttrs {
+		_ = attrList.Set(a)
+	}
+	if userAttrs := cmd.String("attrs"); userAttrs != "" {
+		_ = attrList.Set(userAttrs)
+	}
+
+	var raw bytes.Buffer
+	raw.Write(jsonData)
+
+	output.SliceDiceSpit(raw, attrList, cmd, "", os.Stdout, nil, nil)
+
+	return nil
+}
+
+// stateDiffCommandAction is the action handler for `tfctl state diff
+// <serialA> <serialB>`. It loads both snapshots for the active workspace and
+// renders their added/removed/changed resources, reusing the same
+// classifyDiffAction row coloring `tfctl diff` uses since snapshot.Row
+// shares diffRow's Action vocabulary.
+func stateDiffCommandAction(ctx context.Context, cmd *cli.Command) error {
+	log.Debugf("Executing action for state diff")
+
+	args := cmd.Args().Slice()
+	if len(args) < 2 {
+		return fmt.Errorf("usage: tfctl state diff <serialA> <serialB>")
+	}
+
+	serialA, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid serial %q: %w", args[0], err)
+	}
+	serialB, err := strconv.ParseInt(args[1], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid serial %q: %w", args[1], err)
+	}
+
+	ws := stateWorkspace(cmd)
+
+	from, err := snapshot.Load(ws, serialA)
+	if err != nil {
+		return err
+	}
+	to, err := snapshot.Load(ws, serialB)
+	if err != nil {
+		return err
+	}
+
+	rows := snapshot.Diff(from, to)
+
+	jsonData, err := json.Marshal(rows)
+	if err != nil {
+		return fmt.Errorf("failed to marshal dataset: %w", err)
+	}
+
+	attrList := attrs.AttrList{}
+	for _, a := range stateDiffDefaultAttrs {
+		_ = attrList.Set(a)
+	}
+	if userAttrs := cmd.String("attrs"); userAttrs != "" {
+		_ = attrList.Set(userAttrs)
+	}
+
+	var raw bytes.Buffer
+	raw.Write(jsonData)
+
+	output.SliceDiceSpit(raw, attrList, cmd, "", os.Stdout, nil, classifyDiffAction)
+
+	return nil
+}
+
+// stateReadInput reads the --in file, or stdin if --in is unset.
+func stateReadInput(cmd *cli.Command) ([]byte, error) {
+	if in := cmd.String("in"); in != "" {
+		data, err := os.ReadFile(in)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %q: %w", in, err)
+		}
+		return data, nil
+	}
+	return io.ReadAll(os.Stdin)
+}
+
+// stateWriteOutput writes data to the --out file, or stdout if --out is
+// unset.
+func stateWriteOutput(cmd *cli.Command, data []byte) error {
+	if out := cmd.String("out"); out != "" {
+		if err := os.WriteFile(out, data, 0o600); err != nil {
+			return fmt.Errorf("failed to write %q: %w", out, err)
+		}
+		return nil
+	}
+	_, err := os.Stdout.Write(data)
+	return err
+}
+
+// stateResolvePassphrase resolves a passphrase from the named flag, falling
+// back to an interactive prompt -- the same flag-then-prompt order
+// LoadStateData uses, minus the config-file and TF_VAR_passphrase fallbacks,
+// which only make sense for the ambient backend state rather than an
+// arbitrary --in file.
+func stateResolvePassphrase(cmd *cli.Command, flagName string) (string, error) {
+	if p := cmd.String(flagName); p != "" {
+		return p, nil
+	}
+	return state.GetPassphrase()
+}
+
+// stateGenKeyPassphrase generates a fresh random passphrase for --genkey
+// mode: 32 random bytes, base64-encoded, printed to stderr so the operator
+// can capture it before it scrolls off -- the encrypted envelope itself
+// never contains the passphrase.
+func stateGenKeyPassphrase() (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("failed to generate passphrase: %w", err)
+	}
+	passphrase := base64.StdEncoding.EncodeToString(raw)
+	fmt.Fprintf(os.Stderr, "generated passphrase: %s\n", passphrase)
+	return passphrase, nil
+}
+
+// stateKDFParamsFromCmd builds a state.KDFParams from the --kdf-* flags,
+// leaving unset fields at their zero value so EncryptOpenTofuStateWithKDF's
+// own defaulting (pbkdf2 iterations) and each KDF's own validation
+// (argon2id/scrypt requiring their parameters) still apply.
+func stateKDFParamsFromCmd(cmd *cli.Command) state.KDFParams {
+	return state.KDFParams{
+		Iterations:  int(cmd.Int("kdf-iterations")),
+		Memory:      uint32(cmd.Int("kdf-memory")),
+		Time:        uint32(cmd.Int("kdf-time")),
+		Parallelism: uint8(cmd.Int("kdf-parallelism")),
+		N:           int(cmd.Int("kdf-n")),
+		R:           int(cmd.Int("kdf-r")),
+		P:           int(cmd.Int("kdf-p")),
+	}
+}
+
+// stateKDFFlags are shared by encrypt and rekey: which KDF to derive the AES
+// key with, and that KDF's tuning parameters. Left unset, encryption stays
+// on pbkdf2 with tfctl's existing default iteration count.
+var stateKDFFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:  "kdf",
+		Usage: "key derivation function: pbkdf2 (default), argon2id, or scrypt",
+		Value: "pbkdf2",
+	},
+	&cli.IntFlag{
+		Name:  "kdf-iterations",
+		Usage: "pbkdf2: iteration count (default: tfctl's built-in default)",
+	},
+	&cli.IntFlag{
+		Name:  "kdf-memory",
+		Usage: "argon2id: memory cost in KiB",
+	},
+	&cli.IntFlag{
+		Name:  "kdf-time",
+		Usage: "argon2id: time (pass) cost",
+	},
+	&cli.IntFlag{
+		Name:  "kdf-parallelism",
+		Usage: "argon2id: degree of parallelism",
+	},
+	&cli.IntFlag{
+		Name:  "kdf-n",
+		Usage: "scrypt: CPU/memory cost parameter N",
+	},
+	&cli.IntFlag{
+		Name:  "kdf-r",
+		Usage: "scrypt: block size parameter r",
+	},
+	&cli.IntFlag{
+		Name:  "kdf-p",
+		Usage: "scrypt: parallelization parameter p",
+	},
+}
+
+// stateEncryptCommandAction is the action handler for `tfctl state encrypt`.
+// It reads plaintext state/plan JSON from --in (or stdin) and writes an
+// OpenTofu-compatible encrypted envelope to --out (or stdout).
+func stateEncryptCommandAction(ctx context.Context, cmd *cli.Command) error {
+	plaintext, err := stateReadInput(cmd)
+	if err != nil {
+		return err
+	}
+
+	var passphrase string
+	if cmd.Bool("genkey") {
+		passphrase, err = stateGenKeyPassphrase()
+	} else {
+		passphrase, err = stateResolvePassphrase(cmd, "passphrase")
+	}
+	if err != nil {
+		return err
+	}
+
+	encrypted, err := state.EncryptOpenTofuStateWithKDF(plaintext, passphrase, cmd.String("kdf"), stateKDFParamsFromCmd(cmd))
+	if err != nil {
+		return fmt.Errorf("failed to encrypt: %w", err)
+	}
+
+	return stateWriteOutput(cmd, encrypted)
+}
+
+// stateDecryptCommandAction is the action handler for `tfctl state decrypt`.
+// It reads an encrypted envelope from --in (or stdin) and writes the
+// decrypted plaintext to --out (or stdout).
+func stateDecryptCommandAction(ctx context.Context, cmd *cli.Command) error {
+	encrypted, err := stateReadInput(cmd)
+	if err != nil {
+		return err
+	}
+
+	passphrase, err := stateResolvePassphrase(cmd, "passphrase")
+	if err != nil {
+		return err
+	}
+
+	plaintext, err := state.DecryptOpenTofuStateWithCache(encrypted, passphrase, cmd.Bool("no-key-cache"))
+	if err != nil {
+		return fmt.Errorf("failed to decrypt: %w", err)
+	}
+
+	return stateWriteOutput(cmd, plaintext)
+}
+
+// stateRekeyCommandAction is the action handler for `tfctl state rekey`. It
+// decrypts --in with --passphrase and re-encrypts the result with
+// --new-passphrase (or a freshly generated one under --genkey), so an
+// operator can rotate a state file's passphrase without a plaintext copy
+// ever touching disk.
+func stateRekeyCommandAction(ctx context.Context, cmd *cli.Command) error {
+	encrypted, err := stateReadInput(cmd)
+	if err != nil {
+		return err
+	}
+
+	oldPassphrase, err := stateResolvePassphrase(cmd, "passphrase")
+	if err != nil {
+		return err
+	}
+
+	plaintext, err := state.DecryptOpenTofuStateWithCache(encrypted, oldPassphrase, cmd.Bool("no-key-cache"))
+	if err != nil {
+		return fmt.Errorf("failed to decrypt with old passphrase: %w", err)
+	}
+
+	var newPassphrase string
+	if cmd.Bool("genkey") {
+		newPassphrase, err = stateGenKeyPassphrase()
+	} else {
+		newPassphrase, err = stateResolvePassphrase(cmd, "new-passphrase")
+	}
+	if err != nil {
+		return err
+	}
+
+	reencrypted, err := state.EncryptOpenTofuStateWithKDF(plaintext, newPassphrase, cmd.String("kdf"), stateKDFParamsFromCmd(cmd))
+	if err != nil {
+		return fmt.Errorf("failed to re-encrypt with new passphrase: %w", err)
+	}
+
+	return stateWriteOutput(cmd, reencrypted)
+}
+
+// stateKDFBenchCommandAction is the action handler for `tfctl state
+// kdf-bench`. It measures how long Argon2id or scrypt takes on this host at
+// increasing cost, and suggests the first parameter set that meets
+// --budget, so --kdf-memory/--kdf-time/--kdf-n/etc. can be set from
+// something other than a copy-pasted default.
+func stateKDFBenchCommandAction(ctx context.Context, cmd *cli.Command) error {
+	name := cmd.String("kdf")
+	if name == "" || name == "pbkdf2" {
+		return fmt.Errorf("kdf-bench only supports argon2id and scrypt; pass --kdf argon2id or --kdf scrypt")
+	}
+
+	budget := cmd.Duration("budget")
+	params, elapsed, err := state.BenchmarkKDFParams(name, budget)
+	if err != nil {
+		return err
+	}
+
+	switch name {
+	case "argon2id":
+		fmt.Fprintf(os.Stdout, "suggested argon2id parameters (measured %s on this host):\n", elapsed)
+		fmt.Fprintf(os.Stdout, "  --kdf-memory %d --kdf-time %d --kdf-parallelism %d\n", params.Memory, params.Time, params.Parallelism)
+	case "scrypt":
+		fmt.Fprintf(os.Stdout, "suggested scrypt parameters (measured %s on this host):\n", elapsed)
+		fmt.Fprintf(os.Stdout, "  --kdf-n %d --kdf-r %d --kdf-p %d\n", params.N, params.R, params.P)
+	}
+
+	return nil
+}
+
+// stateKeysPurgeCommandAction is the action handler for `tfctl state keys
+// purge`. It flushes every derived state encryption key cached in the OS
+// keyring, so a stale or no-longer-trusted cached key can't outlive its
+// state.key_cache.ttl-minutes window.
+func stateKeysPurgeCommandAction(ctx context.Context, cmd *cli.Command) error {
+	if err := state.PurgeKeyCache(); err != nil {
+		return fmt.Errorf("failed to purge key cache: %w", err)
+	}
+
+	fmt.Fprintln(os.Stdout, "purged cached state encryption keys from the OS keyring")
+	return nil
+}
+
+// stateInOutFlags are shared by encrypt, decrypt, and rekey: where to read
+// the source document from and where to write the result, each defaulting
+// to stdin/stdout so the subcommands compose in a shell pipeline.
+var stateInOutFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:  "in",
+		Usage: "input file (default: stdin)",
+	},
+	&cli.StringFlag{
+		Name:  "out",
+		Usage: "output file (default: stdout)",
+	},
+}
+
+// stateCommandBuilder constructs the "state" subcommand, grouping local
+// state-history verbs (history, diff) backed by internal/snapshot -- as
+// opposed to sq's own --diff, which compares two *backend* state versions
+// directly rather than locally persisted snapshots.
+func stateCommandBuilder(meta meta.Meta) *cli.Command {
+	return &cli.Command{
+		Name:  "state",
+		Usage: "inspect locally persisted state history",
+		Metadata: map[string]any{
+			"meta": meta,
+		},
+		Commands: []*cli.Command{
+			{
+				Name:      "history",
+				Usage:     "list persisted snapshots for the active workspace",
+				UsageText: "tfctl state history [options]",
+				Flags:     append([]cli.Flag{workspaceFlag}, NewGlobalFlags("state")...),
+				Action:    stateHistoryCommandAction,
+			},
+			{
+				Name:      "diff",
+				Usage:     "diff two persisted snapshots",
+				UsageText: "tfctl state diff <serialA> <serialB> [options]",
+				Flags:     append([]cli.Flag{workspaceFlag}, NewGlobalFlags("state")...),
+				Action:    stateDiffCommandAction,
+			},
+			{
+				Name:      "encrypt",
+				Usage:     "encrypt a plaintext state or plan file into OpenTofu's envelope format",
+				UsageText: "tfctl state encrypt [--in FILE] [--out FILE] [--passphrase PASSPHRASE | --genkey] [--kdf KDF]",
+				Flags: append(append(append([]cli.Flag{
+					&cli.StringFlag{
+						Name:  "passphrase",
+						Usage: "passphrase to encrypt with; prompted for if unset",
+					},
+					&cli.BoolFlag{
+						Name:        "genkey",
+						Usage:       "generate a fresh random passphrase instead of using --passphrase, printed to stderr",
+						HideDefault: true,
+					},
+				}, stateInOutFlags...), stateKDFFlags...), NewGlobalFlags("state")...),
+				Action: stateEncryptCommandAction,
+			},
+			{
+				Name:      "decrypt",
+				Usage:     "decrypt an OpenTofu-encrypted state or plan file",
+				UsageText: "tfctl state decrypt [--in FILE] [--out FILE] [--passphrase PASSPHRASE]",
+				Flags: append(append([]cli.Flag{
+					&cli.StringFlag{
+						Name:  "passphrase",
+						Usage: "encrypted state passphrase; prompted for if unset",
+					},
+					noKeyCacheFlag,
+				}, stateInOutFlags...), NewGlobalFlags("state")...),
+				Action: stateDecryptCommandAction,
+			},
+			{
+				Name:      "rekey",
+				Usage:     "decrypt with one passphrase and re-encrypt with another",
+				UsageText: "tfctl state rekey [--in FILE] [--out FILE] --passphrase OLD [--new-passphrase NEW | --genkey] [--kdf KDF]",
+				Flags: append(append(append([]cli.Flag{
+					&cli.StringFlag{
+						Name:  "passphrase",
+						Usage: "current encrypted state passphrase; prompted for if unset",
+					},
+					&cli.StringFlag{
+						Name:  "new-passphrase",
+						Usage: "new passphrase to re-encrypt with; prompted for if unset",
+					},
+					&cli.BoolFlag{
+						Name:        "genkey",
+						Usage:       "generate a fresh random new passphrase instead of --new-passphrase, printed to stderr",
+						HideDefault: true,
+					},
+					noKeyCacheFlag,
+				}, stateInOutFlags...), stateKDFFlags...), NewGlobalFlags("state")...),
+				Action: stateRekeyCommandAction,
+			},
+			{
+				Name:      "kdf-bench",
+				Usage:     "suggest argon2id/scrypt parameters that take about --budget on this host",
+				UsageText: "tfctl state kdf-bench --kdf argon2id|scrypt [--budget 500ms]",
+				Flags: append([]cli.Flag{
+					&cli.StringFlag{
+						Name:  "kdf",
+						Usage: "kdf to benchmark: argon2id or scrypt",
+					},
+					&cli.DurationFlag{
+						Name:  "budget",
+						Usage: "target wall-clock time for one key derivation",
+						Value: 500 * time.Millisecond,
+					},
+				}, NewGlobalFlags("state")...),
+				Action: stateKDFBenchCommandAction,
+			},
+			{
+				Name:  "keys",
+				Usage: "manage cached derived state encryption keys",
+				Commands: []*cli.Command{
+					{
+						Name:      "purge",
+						Usage:     "flush all cached state encryption keys from the OS keyring",
+						UsageText: "tfctl state keys purge",
+						Flags:     NewGlobalFlags("state"),
+						Action:    stateKeysPurgeCommandAction,
+					},
+				},
+			},
+		},
+	}
+}
@@ -5,12 +5,17 @@ package command
 
 import (
 	"context"
+	"fmt"
 	"reflect"
+	"strconv"
+	"time"
 
 	"github.com/apex/log"
 	"github.com/hashicorp/go-tfe"
 	"github.com/urfave/cli/v3"
 
+	"github.com/tfctl/tfctl/internal/backend/remote"
+	"github.com/tfctl/tfctl/internal/filters"
 	"github.com/tfctl/tfctl/internal/meta"
 )
 
@@ -18,17 +23,85 @@ import (
 // versions in the "svq" command output.
 var svqDefaultAttrs = []string{".id", "serial", "created-at"}
 
+// PushdownResult records which --filter predicates SvqServerSideFilterAugmenter
+// was able to push into the StateVersions.List call itself, so svqCommandAction's
+// fetcher can scope the list to a single workspace and stop paginating as
+// soon as it's clear no further (older) page can match, instead of
+// downloading every state version and filtering client-side.
+type PushdownResult struct {
+	// Workspace is set when a workspace.name/workspace.id filter resolved to
+	// a specific workspace, in which case the list call is scoped to it
+	// instead of the current directory's default workspace.
+	Workspace *tfe.Workspace
+	// MinSerial/MinCreatedAt bound a "serial>N"/"created-at>T" filter. Since
+	// TFE returns state versions newest-first, once a page's oldest item
+	// falls below this bound, every remaining (older) page will too, so
+	// pagination can stop. There's no equivalent early-stop for "<" bounds:
+	// the matching region starts somewhere in the middle of the
+	// newest-first list and runs to the end, so those are still enforced by
+	// ordinary client-side filtering.
+	MinSerial    *int64
+	MinCreatedAt *time.Time
+	// Satisfied lists the filter keys already enforced by the chosen list
+	// call. It is only logged with the rest of the result; client-side
+	// filtering (filters.FilterDataset) does not read it and still re-checks
+	// every key.
+	Satisfied []string
+}
+
 // svqCommandAction is the action handler for the "svq" subcommand. It lists
 // state versions via the active backend, supports --tldr/--schema shortcuts,
 // and emits results per common flags.
 func svqCommandAction(ctx context.Context, cmd *cli.Command) error {
-	be, err := InitLocalBackendQuery(ctx, cmd)
-	if err != nil {
-		return err
-	}
-
+	// The backend is resolved inside fn, not once up front, so that
+	// --recursive (which calls fn once per root, each against its own
+	// root-scoped *cli.Command) re-resolves against the right root every
+	// time instead of reusing the first root's backend for all of them.
 	fn := func(ctx context.Context, cmd *cli.Command) ([]*tfe.StateVersion, error) {
-		return be.StateVersions(SvqServerSideFilterAugmenter)
+		be, err := InitLocalBackendQuery(ctx, cmd)
+		if err != nil {
+			return nil, err
+		}
+
+		rbe, isRemote := be.(*remote.BackendRemote)
+		if !isRemote {
+			// Only the remote backend's API supports server-side filter
+			// pushdown; local and S3 ignore the augmenter entirely.
+			return be.StateVersions()
+		}
+
+		client, err := rbe.Client()
+		if err != nil {
+			return nil, err
+		}
+		org, err := rbe.Organization()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get organization: %w", err)
+		}
+		workspace, err := rbe.WorkspaceName()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get workspace name: %w", err)
+		}
+
+		limit := cmd.Int("limit")
+		pageSize := 100
+		if limit > 0 && limit < pageSize {
+			pageSize = limit
+		}
+
+		options := tfe.StateVersionListOptions{
+			Workspace:    workspace,
+			Organization: org,
+			ListOptions:  tfe.ListOptions{PageNumber: 1, PageSize: pageSize},
+		}
+
+		pushdown, err := SvqServerSideFilterAugmenter(client, org)(ctx, cmd, &options)
+		if err != nil {
+			return nil, fmt.Errorf("failed to augment state version options: %w", err)
+		}
+		log.Debugf("pushdown: %+v", pushdown)
+
+		return fetchStateVersionsPushdown(ctx, client, &options, pushdown, limit)
 	}
 
 	return NewQueryActionRunner(
@@ -36,25 +109,140 @@ func svqCommandAction(ctx context.Context, cmd *cli.Command) error {
 		reflect.TypeOf((*tfe.StateVersion)(nil)).Elem(),
 		svqDefaultAttrs,
 		fn,
-	).Run(ctx, cmd)
+	).WithErrorContext(func() remote.ErrorContext {
+		// Resolved once against the original RootDir, so under --recursive
+		// this diagnostic context reflects the first root rather than
+		// whichever one actually failed -- acceptable since it's only used
+		// to enrich an error message, not to drive the fetch itself.
+		be, err := InitLocalBackendQuery(ctx, cmd)
+		if err != nil {
+			return remote.ErrorContext{Operation: "list state versions", Resource: "stateversion"}
+		}
+		rbe, ok := be.(*remote.BackendRemote)
+		if !ok {
+			return remote.ErrorContext{Operation: "list state versions", Resource: "stateversion"}
+		}
+		org, _ := rbe.Organization()
+		return remote.ErrorContext{
+			Host:      rbe.Backend.Config.Hostname,
+			Org:       org,
+			Operation: "list state versions",
+			Resource:  "stateversion",
+		}
+	}).Run(ctx, cmd)
 }
 
-// SvqServerSideFilterAugmenter augments the StateVersionListOptions with
-// server-side filters extracted from the --filter flag. Flags with
-// ServerSide=true populate matching fields in opts based on the filter key
-// prefix (project, tag, or xtag). For tag filters, dot-separated keys are
-// parsed to extract the tag name and add create TagBinding entries.
-// NOTE The signature departure from the typical factory pattern used by other
-// commands - this func is public.
-// NOTE Unimplemented for now as StateVersionListOptions has no server-side
-// filter fields.
-func SvqServerSideFilterAugmenter(
-	_ context.Context,
-	cmd *cli.Command,
-	opts *tfe.StateVersionListOptions,
-) error {
-	log.Debugf("opts after augmentation: %+v", opts)
-	return nil
+// fetchStateVersionsPushdown paginates client.StateVersions.List, stopping
+// early once pushdown.MinSerial/MinCreatedAt rules out every remaining
+// (older) page. When ctx carries a --stream page sink (see withPageSink),
+// each page's items are handed to it as soon as they're fetched.
+func fetchStateVersionsPushdown(
+	ctx context.Context,
+	client *tfe.Client,
+	options *tfe.StateVersionListOptions,
+	pushdown PushdownResult,
+	limit int,
+) ([]*tfe.StateVersion, error) {
+	var results []*tfe.StateVersion
+	sink, streaming := pageSinkFromContext[*tfe.StateVersion](ctx)
+
+	for {
+		page, err := client.StateVersions.List(ctx, options)
+		if err != nil {
+			return nil, err
+		}
+
+		if streaming && len(page.Items) > 0 {
+			if err := sink(page.Items); err != nil {
+				return nil, err
+			}
+		}
+		results = append(results, page.Items...)
+
+		if limit > 0 && len(results) >= limit {
+			results = results[:limit]
+			break
+		}
+
+		if len(page.Items) > 0 {
+			oldest := page.Items[len(page.Items)-1]
+			if pushdown.MinSerial != nil && oldest.Serial < *pushdown.MinSerial {
+				break
+			}
+			if pushdown.MinCreatedAt != nil && oldest.CreatedAt.Before(*pushdown.MinCreatedAt) {
+				break
+			}
+		}
+
+		if page.Pagination.NextPage == 0 {
+			break
+		}
+		options.ListOptions.PageNumber = page.Pagination.NextPage
+	}
+
+	return results, nil
+}
+
+// SvqServerSideFilterAugmenter returns a StateVersionListOptions augmenter
+// for the given client/org that resolves workspace.name=/workspace.id=
+// filters to a specific workspace (scoping the list away from the current
+// directory's default) and extracts serial/created-at lower-bound filters
+// into the PushdownResult it returns, for the fetcher's pagination loop to
+// short-circuit on.
+// NOTE The signature departure from the typical factory pattern used by
+// other commands - this func is public, and its returned closure returns a
+// PushdownResult alongside the usual error.
+func SvqServerSideFilterAugmenter(client *tfe.Client, org string) func(
+	context.Context,
+	*cli.Command,
+	*tfe.StateVersionListOptions,
+) (PushdownResult, error) {
+	return func(ctx context.Context, cmd *cli.Command, opts *tfe.StateVersionListOptions) (PushdownResult, error) {
+		var result PushdownResult
+
+		for _, f := range filters.BuildFilters(cmd.String("filter")) {
+			switch {
+			case f.Key == "workspace.name" && f.Operand == "=":
+				ws, err := client.Workspaces.Read(ctx, org, f.Value)
+				if err != nil {
+					return result, fmt.Errorf("failed to resolve workspace %q: %w", f.Value, err)
+				}
+				opts.Workspace = ws.Name
+				result.Workspace = ws
+				result.Satisfied = append(result.Satisfied, f.Key)
+
+			case f.Key == "workspace.id" && f.Operand == "=":
+				ws, err := client.Workspaces.ReadByID(ctx, f.Value)
+				if err != nil {
+					return result, fmt.Errorf("failed to resolve workspace %q: %w", f.Value, err)
+				}
+				opts.Workspace = ws.Name
+				result.Workspace = ws
+				result.Satisfied = append(result.Satisfied, f.Key)
+
+			case f.Key == "serial" && f.Operand == ">":
+				n, err := strconv.ParseInt(f.Value, 10, 64)
+				if err != nil {
+					log.Debugf("ignoring unparseable serial filter %q: %v", f.Value, err)
+					continue
+				}
+				result.MinSerial = &n
+				result.Satisfied = append(result.Satisfied, f.Key)
+
+			case f.Key == "created-at" && f.Operand == ">":
+				t, err := time.Parse(time.RFC3339, f.Value)
+				if err != nil {
+					log.Debugf("ignoring unparseable created-at filter %q: %v", f.Value, err)
+					continue
+				}
+				result.MinCreatedAt = &t
+				result.Satisfied = append(result.Satisfied, f.Key)
+			}
+		}
+
+		log.Debugf("opts after augmentation: %+v", opts)
+		return result, nil
+	}
 }
 
 // svqCommandBuilder constructs the cli.Command for "svq", wiring metadata,
@@ -66,14 +254,21 @@ func svqCommandBuilder(meta meta.Meta) *cli.Command {
 		UsageText: "tfctl svq [RootDir] [options]",
 		Flags: []cli.Flag{
 			&cli.IntFlag{
-				Name:    "limit",
-				Aliases: []string{"l"},
-				Usage:   "limit state versions returned",
-				Value:   99999,
+				Name:      "limit",
+				Aliases:   []string{"l"},
+				Usage:     "limit state versions returned",
+				Value:     99999,
+				Validator: IntRangeValidator(1, 99999),
 			},
 			NewHostFlag("svq"),
 			NewOrgFlag("svq"),
 			workspaceFlag,
+			concurrencyFlag,
+			allEpochsFlag,
+			epochFlag,
+			atFlag,
+			recursiveFlag,
+			maxWorkersFlag,
 		},
 		Action: svqCommandAction,
 		Meta:   meta,
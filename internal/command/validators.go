@@ -6,6 +6,9 @@ package command
 import (
 	"context"
 	"fmt"
+	"os"
+	"regexp"
+	"time"
 
 	"github.com/urfave/cli/v3"
 )
@@ -25,8 +28,79 @@ func GlobalFlagsValidator(ctx context.Context, c *cli.Command) error {
 	return nil
 }
 
+// FlagValidateFunc validates a single flag's raw string value, returning a
+// plain validation error (e.g. "must be one of [...]") with no mention of
+// the flag's name -- EnvAwareValidator adds that context.
+type FlagValidateFunc func(string) error
+
+// EnvAwareValidator wraps validate so a failure names the offending flag and,
+// when the value matches one of envVars rather than having been typed on the
+// command line, the specific env var it came from -- one consistent
+// diagnostic instead of a bare validation error with no provenance. This is
+// the pattern Terragrunt's cli package uses for its own flag errors.
+func EnvAwareValidator(flagName string, envVars []string, validate FlagValidateFunc) func(string) error {
+	return func(value string) error {
+		err := validate(value)
+		if err == nil {
+			return nil
+		}
+
+		for _, env := range envVars {
+			if v, ok := os.LookupEnv(env); ok && v == value {
+				return fmt.Errorf("invalid value %q for env %s (flag --%s): %w", value, env, flagName, err)
+			}
+		}
+		return fmt.Errorf("invalid value %q for flag --%s: %w", value, flagName, err)
+	}
+}
+
+// EnumValidator returns a FlagValidateFunc accepting only one of valid.
+func EnumValidator(valid ...string) FlagValidateFunc {
+	return func(value string) error {
+		for _, v := range valid {
+			if v == value {
+				return nil
+			}
+		}
+		return fmt.Errorf("must be one of %v", valid)
+	}
+}
+
+// RegexValidator returns a FlagValidateFunc requiring value to match pattern.
+func RegexValidator(pattern string) FlagValidateFunc {
+	re := regexp.MustCompile(pattern)
+	return func(value string) error {
+		if !re.MatchString(value) {
+			return fmt.Errorf("must match pattern %s", pattern)
+		}
+		return nil
+	}
+}
+
+// DurationValidator returns a FlagValidateFunc requiring value to parse as a
+// time.Duration (e.g. "5m", "1h30m").
+func DurationValidator() FlagValidateFunc {
+	return func(value string) error {
+		if _, err := time.ParseDuration(value); err != nil {
+			return fmt.Errorf("must be a valid duration: %w", err)
+		}
+		return nil
+	}
+}
+
+// IntRangeValidator returns an IntFlag-compatible Validator rejecting values
+// outside [min, max].
+func IntRangeValidator(min, max int64) func(int64) error {
+	return func(value int64) error {
+		if value < min || value > max {
+			return fmt.Errorf("must be between %d and %d", min, max)
+		}
+		return nil
+	}
+}
+
 func OutputValidator(value any) error {
-	var validOutputFlagValues = []string{"text", "json", "raw", "yaml"}
+	var validOutputFlagValues = []string{"text", "json", "raw", "yaml", "ndjson", "csv", "tsv", "parquet", "markdown", "html", "hcl"}
 	valid := false
 	for _, v := range validOutputFlagValues {
 		if v == value {
@@ -39,3 +113,18 @@ func OutputValidator(value any) error {
 	}
 	return nil
 }
+
+func PlanFormatValidator(value any) error {
+	var validPlanFormatFlagValues = []string{"text", "json"}
+	valid := false
+	for _, v := range validPlanFormatFlagValues {
+		if v == value {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return fmt.Errorf("must be one of %v", validPlanFormatFlagValues)
+	}
+	return nil
+}
@@ -6,9 +6,7 @@ package command
 import (
 	"context"
 	"reflect"
-	"strings"
 
-	"github.com/apex/log"
 	"github.com/hashicorp/go-tfe"
 	"github.com/urfave/cli/v3"
 
@@ -20,39 +18,66 @@ import (
 // in the "wq" command output.
 var wqDefaultAttrs = []string{".id", "name"}
 
+// init registers wq's server-side filter adapters with the shared
+// filters.ServerSideAugmenter registry.
+func init() {
+	filters.RegisterAugmenter("wq", "name", func(f filters.Filter, opts *tfe.WorkspaceListOptions) error {
+		opts.Search = f.Value
+		return nil
+	})
+	filters.RegisterAugmenter("wq", "project", func(f filters.Filter, opts *tfe.WorkspaceListOptions) error {
+		opts.ProjectID = f.Value
+		return nil
+	})
+	filters.RegisterAugmenter("wq", "tag.*", func(f filters.Filter, opts *tfe.WorkspaceListOptions) error {
+		_, tag, _ := splitFilterKey(f.Key)
+		opts.TagBindings = append(opts.TagBindings, &tfe.TagBinding{
+			Key:   tag,
+			Value: f.Value,
+		})
+		return nil
+	})
+	filters.RegisterAugmenter("wq", "xtag.*", func(f filters.Filter, opts *tfe.WorkspaceListOptions) error {
+		_, tag, _ := splitFilterKey(f.Key)
+		opts.ExcludeTags = tag
+		return nil
+	})
+}
+
 // wqCommandAction is the action handler for the "wq" subcommand. It lists
 // workspaces for the selected organization.
 func wqCommandAction(ctx context.Context, cmd *cli.Command) error {
-	// We need to build the builder inside the action so we can access the
-	// client. The builder will handle backend/org init, but we need a way to
-	// pass the client-bound fetcher. Let's use a custom approach.
-	be, org, client, err := InitRemoteOrgQuery(ctx, cmd)
-	if err != nil {
-		return err
-	}
-
-	// Create a fetcher that captures the client in a closure
-	fetcher := func(
-		ctx context.Context,
-		org string,
-		opts *tfe.WorkspaceListOptions,
-	) ([]*tfe.Workspace, *tfe.Pagination, error) {
-		page, err := client.Workspaces.List(ctx, org, opts)
+	// be/org/client are resolved inside fn, not once up front, so that
+	// --recursive (which calls fn once per root, each against its own
+	// root-scoped *cli.Command) re-resolves against the right root's
+	// host/org every time, rather than reusing the first root's for all of
+	// them -- a monorepo's subdirectories can point at different orgs.
+	fn := func(ctx context.Context, cmd *cli.Command) ([]*tfe.Workspace, error) {
+		be, org, client, err := InitRemoteOrgQuery(ctx, cmd)
 		if err != nil {
-			return nil, nil, err
+			return nil, err
 		}
-		return page.Items, page.Pagination, nil
-	}
 
-	// Manually call RemoteQueryFetcherFactory and QueryActionRunner since we
-	// already have be, org, client initialized
-	fn := RemoteQueryFetcherFactory(
-		be,
-		org,
-		fetcher,
-		wqServerSideFilterAugmenter,
-		"list workspaces",
-	)
+		fetcher := func(
+			ctx context.Context,
+			org string,
+			opts *tfe.WorkspaceListOptions,
+		) ([]*tfe.Workspace, *tfe.Pagination, error) {
+			page, err := client.Workspaces.List(ctx, org, opts)
+			if err != nil {
+				return nil, nil, err
+			}
+			return page.Items, page.Pagination, nil
+		}
+
+		return RemoteQueryFetcherFactory(
+			be,
+			org,
+			fetcher,
+			wqServerSideFilterAugmenter,
+			"list workspaces",
+		)(ctx, cmd)
+	}
 
 	return NewQueryActionRunner(
 		"wq",
@@ -63,43 +88,14 @@ func wqCommandAction(ctx context.Context, cmd *cli.Command) error {
 }
 
 // wqServerSideFilterAugmenter augments the WorkspaceListOptions with
-// server-side filters extracted from the --filter flag. Flags with
-// ServerSide=true populate matching fields in opts based on the filter key
-// prefix (project, tag, or xtag). For tag filters, dot-separated keys are
-// parsed to extract the tag name and create TagBinding entries.
+// server-side filters extracted from the --filter flag, dispatching through
+// the shared filters.ServerSideAugmenter registry.
 func wqServerSideFilterAugmenter(
 	_ context.Context,
 	cmd *cli.Command,
 	opts *tfe.WorkspaceListOptions,
 ) error {
-	spec := cmd.String("filter")
-	filterList := filters.BuildFilters(spec)
-
-	for _, f := range filterList {
-		// We only care about server-side filters.
-		if f.ServerSide {
-			parts := strings.Split(f.Key, ".")
-			if len(parts) > 1 {
-				switch parts[0] {
-				case "name":
-					opts.Search = f.Value
-				case "project":
-					opts.ProjectID = f.Value
-				case "tag":
-					opts.TagBindings = append(opts.TagBindings, &tfe.TagBinding{
-						Key:   parts[1],
-						Value: f.Value,
-					})
-				case "xtag":
-					opts.ExcludeTags = parts[1]
-				}
-			}
-		}
-	}
-
-	log.Debugf("opts after augmentation: %+v", opts)
-
-	return nil
+	return filters.ApplyServerSide("wq", cmd, opts)
 }
 
 // wqCommandBuilder constructs the cli.Command for "wq", wiring metadata,
@@ -111,13 +107,16 @@ func wqCommandBuilder(meta meta.Meta) *cli.Command {
 		UsageText: "tfctl wq [RootDir] [options]",
 		Flags: []cli.Flag{
 			&cli.IntFlag{
-				Name:    "limit",
-				Aliases: []string{"l"},
-				Usage:   "limit workspaces returned",
-				Value:   99999,
+				Name:      "limit",
+				Aliases:   []string{"l"},
+				Usage:     "limit workspaces returned",
+				Value:     99999,
+				Validator: IntRangeValidator(1, 99999),
 			},
 			NewHostFlag("wq", meta.Config.Source),
 			NewOrgFlag("wq", meta.Config.Source),
+			recursiveFlag,
+			maxWorkersFlag,
 		},
 		Action: wqCommandAction,
 		Meta:   meta,
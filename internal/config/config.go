@@ -4,13 +4,18 @@
 package config
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
+	"sync"
 
 	"github.com/apex/log"
+	"github.com/dustin/go-humanize"
+	"github.com/fsnotify/fsnotify"
 	"gopkg.in/yaml.v3"
 )
 
@@ -21,18 +26,113 @@ import (
 //   - Namespace: optional dot-prefixed keyspace used to prefer namespaced
 //     lookups (e.g. "backend.s3.region").
 //   - Data: raw key/value tree unmarshaled from YAML.
+//   - EnvBindings: keys bound via BindEnv, in declared precedence order.
+//   - Defaults: programmatic fallbacks registered via SetDefault.
 //
 // Note: Data is intentionally kept as map[string]any to allow flexible shapes.
 // Callers should use typed getters (GetString, GetInt) for convenience.
 type Type struct {
-	Source    string
-	Namespace string
-	Data      map[string]interface{}
+	Source      string
+	Namespace   string
+	Data        map[string]interface{}
+	EnvBindings map[string][]string
+	Defaults    map[string]interface{}
+	Overrides   map[string]interface{}
 }
 
 // Config holds the global, lazily-initialized configuration instance.
 var Config Type
 
+// mu guards concurrent access to Config's mutable maps. It lives outside Type
+// so Type values (e.g. the one returned by Load) remain copyable.
+var mu sync.RWMutex
+
+// BindEnv registers one or more environment variables for the given config
+// key, in order of precedence (the first set variable wins). Bound env vars
+// are consulted ahead of the config file but behind an explicit override, per
+// the resolution order documented on Type.get.
+func BindEnv(key string, envVars ...string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if Config.EnvBindings == nil {
+		Config.EnvBindings = make(map[string][]string)
+	}
+	Config.EnvBindings[key] = append(Config.EnvBindings[key], envVars...)
+}
+
+// SetDefault registers a programmatic fallback value for key, used when no
+// override, bound env var, or config file entry resolves it.
+func SetDefault(key string, val interface{}) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if Config.Defaults == nil {
+		Config.Defaults = make(map[string]interface{})
+	}
+	Config.Defaults[key] = val
+}
+
+// Watch reloads the config file whenever it changes on disk and invokes
+// onChange after each successful reload. It blocks until ctx is canceled, so
+// callers should run it in its own goroutine (e.g. during long-running
+// pq/wq polling). The returned error is only non-nil if the watcher could not
+// be established; errors encountered while watching are logged and do not
+// stop the watch loop.
+func Watch(ctx context.Context, onChange func()) error {
+	path, err := getConfigFile()
+	if err != nil {
+		return err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create config watcher: %w", err)
+	}
+
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		watcher.Close()
+		return fmt.Errorf("failed to watch config dir: %w", err)
+	}
+
+	go func() {
+		defer watcher.Close()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Clean(event.Name) != path {
+					continue
+				}
+				if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+					continue
+				}
+
+				if _, err := Load(); err != nil {
+					log.Debugf("config reload failed: err=%v", err)
+					continue
+				}
+
+				if onChange != nil {
+					onChange()
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Debugf("config watch error: err=%v", err)
+			}
+		}
+	}()
+
+	return nil
+}
+
 // init attempts to load configuration at process start. Errors are ignored so
 // the application can still run without a config file; callers of getters will
 // trigger a lazy reload when needed.
@@ -73,9 +173,78 @@ func GetInt(key string, defaultValue ...int) (int, error) {
 	}
 }
 
+// GetByteSize returns the given dotted key path as a count of bytes. The
+// value may be a plain YAML number (already bytes) or a human-readable size
+// string such as "1GiB" or "500MB" (see humanize.ParseBytes), so operators
+// can write cache.max-bytes: 1GiB in the config file or export
+// TFCTL_CACHE_MAX_BYTES=1GiB without doing the arithmetic themselves.
+func GetByteSize(key string, defaultValue ...int64) (int64, error) {
+	if len(Config.Data) == 0 {
+		_, _ = Load()
+	}
+
+	val, err := Config.get(key)
+	if err != nil && Config.Namespace != "" {
+		val, err = Config.get(Config.Namespace + "." + key)
+	}
+
+	if err != nil {
+		if len(defaultValue) == 1 {
+			return defaultValue[0], nil
+		}
+		return 0, err
+	}
+
+	switch v := val.(type) {
+	case int:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case float64:
+		return int64(v), nil
+	case string:
+		n, perr := humanize.ParseBytes(v)
+		if perr != nil {
+			return 0, fmt.Errorf("invalid byte size %q for %s: %w", v, key, perr)
+		}
+		return int64(n), nil
+	default:
+		return 0, errors.New("value is not a byte size")
+	}
+}
+
+// GetBool returns the boolean value for the given dotted key path. A single
+// defaultValue may be provided and is returned when the key is missing.
+func GetBool(key string, defaultValue ...bool) (bool, error) {
+	if len(Config.Data) == 0 {
+		_, _ = Load()
+	}
+
+	val, err := Config.get(key)
+	if err != nil && Config.Namespace != "" {
+		val, err = Config.get(Config.Namespace + "." + key)
+	}
+
+	if err != nil {
+		if len(defaultValue) == 1 {
+			return defaultValue[0], nil
+		}
+		return false, err
+	}
+
+	b, ok := val.(bool)
+	if !ok {
+		return false, errors.New("value is not a bool")
+	}
+
+	return b, nil
+}
+
 // GetString returns the string value for the given dotted key path. If the key
 // is not found and a single defaultValue is provided, the default is returned.
-// Returns an error if the value exists but is not a string.
+// Returns an error if the value exists but is not a string. A value stored as
+// an encrypted "!enc:<base64>" string (see EncryptValue) is transparently
+// decrypted before being returned.
 func GetString(key string, defaultValue ...string) (string, error) {
 	if len(Config.Data) == 0 {
 		_, _ = Load()
@@ -94,12 +263,14 @@ func GetString(key string, defaultValue ...string) (string, error) {
 		return "", errors.New("value is not a string")
 	}
 
-	return s, nil
+	return resolveEncrypted(s)
 }
 
 // GetStringSlice returns the string slice value for the given dotted key path.
 // If the key is not found and a single default slice is provided, that default
 // is returned. Returns an error if the value exists but is not a string slice.
+// Elements stored as encrypted "!enc:<base64>" strings (see EncryptValue) are
+// transparently decrypted before being returned.
 func GetStringSlice(key string, defaultValue ...[]string) ([]string, error) {
 	if len(Config.Data) == 0 {
 		_, _ = Load()
@@ -116,62 +287,312 @@ func GetStringSlice(key string, defaultValue ...[]string) ([]string, error) {
 		return nil, err
 	}
 
+	var raw []interface{}
 	switch v := val.(type) {
 	case []string:
-		return v, nil
-	case []interface{}:
-		result := make([]string, len(v))
-		for i, item := range v {
-			s, ok := item.(string)
-			if !ok {
-				return nil, errors.New("slice element is not a string")
-			}
-			result[i] = s
+		raw = make([]interface{}, len(v))
+		for i, s := range v {
+			raw[i] = s
 		}
-		return result, nil
+	case []interface{}:
+		raw = v
 	default:
 		return nil, errors.New("value is not a slice")
 	}
+
+	result := make([]string, len(raw))
+	for i, item := range raw {
+		s, ok := item.(string)
+		if !ok {
+			return nil, errors.New("slice element is not a string")
+		}
+		decrypted, err := resolveEncrypted(s)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = decrypted
+	}
+	return result, nil
 }
 
-// Load reads the YAML configuration file from the standard user config
-// directory and populates the global Config. If cfgFilePath is provided in the
-// future, it can be used to override the path selection (currently ignored).
+// Load reads and deep-merges tfctl's configuration sources -- repo-local
+// ./tfctl.yaml, $TFCTL_CFG_FILE, $XDG_CONFIG_HOME/tfctl.yaml (falling back
+// to os.UserConfigDir), then /etc/tfctl.yaml -- in that order, with later
+// sources' keys overriding earlier ones on conflict (so /etc/tfctl.yaml is
+// this repo's "most authoritative" source, not its most general one; this
+// follows the request that introduced multi-source merging literally).
+// Each source's own include: [...] directive is resolved (paths relative
+// to the including file) before that source is merged in, and every
+// string value, in every source, has ${ENV_VAR}/${ENV_VAR:-default}
+// references expanded against the process environment.
 //
-// Returns the loaded Type or an error if the file could not be located or
-// parsed.
+// cfgFilePath is accepted, but intentionally left unused: InitApp passes a
+// per-command namespace hint rather than a path, Type.get passes the
+// Source of the previous load (already one of the sources above), and an
+// explicit path override is already covered by $TFCTL_CFG_FILE above.
+//
+// Returns the loaded Type, or an error if no source could be found or a
+// source failed to resolve/parse.
 func Load(cfgFilePath ...string) (Type, error) {
-	path, err := getConfigFile()
+	paths, err := resolveConfigSources()
 	if err != nil {
 		return Type{}, err
 	}
+	if len(paths) == 0 {
+		return Type{}, fmt.Errorf("no config file found in standard locations")
+	}
+
+	data := make(map[string]interface{})
+	var lastPath string
+	for _, path := range paths {
+		fileData, err := loadFile(path, make(map[string]bool))
+		if err != nil {
+			return Type{}, err
+		}
+		data = deepMerge(data, fileData)
+		lastPath = path
+	}
+
+	mu.Lock()
+	Config.Source = lastPath
+	Config.Data = data
+	mu.Unlock()
+
+	return Config, nil
+}
+
+// loadFile reads path as YAML, expands ${ENV_VAR}/${ENV_VAR:-default}
+// references in its string values, resolves its include: [...] directive
+// (if any) as lower-precedence bases merged underneath path's own keys --
+// include paths are relative to path's own directory -- and returns the
+// merged tree. visited guards against include cycles by absolute path and
+// must be shared across the whole recursive call chain for one Load.
+func loadFile(path string, visited map[string]bool) (map[string]interface{}, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", path, err)
+	}
+	if visited[abs] {
+		return nil, fmt.Errorf("include cycle detected at %s", path)
+	}
+	visited[abs] = true
 
-	bytes, err := os.ReadFile(path)
+	raw, err := os.ReadFile(path)
 	if err != nil {
-		return Type{}, err
+		return nil, err
 	}
 
 	var data map[string]interface{}
-	if err := yaml.Unmarshal(bytes, &data); err != nil {
-		return Type{}, err
+	if err := yaml.Unmarshal(raw, &data); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if data == nil {
+		data = make(map[string]interface{})
+	}
+	data = expandEnvInValue(data).(map[string]interface{})
+
+	merged := make(map[string]interface{})
+	if rawIncludes, ok := data["include"]; ok {
+		includes, ok := toStringSlice(rawIncludes)
+		if !ok {
+			return nil, fmt.Errorf("%s: include must be a list of strings", path)
+		}
+
+		dir := filepath.Dir(abs)
+		for _, inc := range includes {
+			incPath := inc
+			if !filepath.IsAbs(incPath) {
+				incPath = filepath.Join(dir, incPath)
+			}
+
+			incData, err := loadFile(incPath, visited)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load include %q from %s: %w", inc, path, err)
+			}
+			merged = deepMerge(merged, incData)
+		}
 	}
+	delete(data, "include")
 
-	Config = Type{
-		Source: path,
-		Data:   data}
+	return deepMerge(merged, data), nil
+}
 
-	return Config, nil
+// resolveConfigSources returns the config sources Load deep-merges, in
+// ascending precedence order, skipping any that don't exist -- except an
+// explicitly-set $TFCTL_CFG_FILE, which is a hard error if it doesn't
+// resolve to a readable file, the same way getConfigFile always treated it.
+func resolveConfigSources() ([]string, error) {
+	var paths []string
+
+	if isFile("tfctl.yaml") {
+		paths = append(paths, "tfctl.yaml")
+	}
+
+	if cfgPath := os.Getenv("TFCTL_CFG_FILE"); cfgPath != "" {
+		fileInfo, err := os.Stat(cfgPath)
+		if err != nil {
+			return nil, fmt.Errorf("config file not found at TFCTL_CFG_FILE path: %s", cfgPath)
+		}
+		if fileInfo.IsDir() {
+			return nil, fmt.Errorf("TFCTL_CFG_FILE points to a directory: %s", cfgPath)
+		}
+		log.Debugf("using config file from TFCTL_CFG_FILE: %s", cfgPath)
+		paths = append(paths, cfgPath)
+	}
+
+	xdgDir := os.Getenv("XDG_CONFIG_HOME")
+	if xdgDir == "" {
+		if dir, err := os.UserConfigDir(); err == nil {
+			xdgDir = dir
+		}
+	}
+	if xdgDir != "" {
+		if xdgPath := filepath.Join(xdgDir, "tfctl.yaml"); isFile(xdgPath) {
+			log.Debugf("using config file: %s", xdgPath)
+			paths = append(paths, xdgPath)
+		}
+	}
+
+	if isFile("/etc/tfctl.yaml") {
+		paths = append(paths, "/etc/tfctl.yaml")
+	}
+
+	return paths, nil
+}
+
+// isFile reports whether path exists and is a regular file (not a directory).
+func isFile(path string) bool {
+	fileInfo, err := os.Stat(path)
+	return err == nil && !fileInfo.IsDir()
+}
+
+// envExpandPattern matches ${ENV_VAR} and ${ENV_VAR:-default} references.
+var envExpandPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+// expandEnv replaces every ${ENV_VAR}/${ENV_VAR:-default} reference in s
+// with the named environment variable's value, or its default (if given)
+// when the variable is unset or empty. A reference with no default and an
+// unset/empty variable expands to "".
+func expandEnv(s string) string {
+	return envExpandPattern.ReplaceAllStringFunc(s, func(match string) string {
+		groups := envExpandPattern.FindStringSubmatch(match)
+		name, hasDefault, def := groups[1], groups[2] != "", groups[3]
+
+		if val := os.Getenv(name); val != "" {
+			return val
+		}
+		if hasDefault {
+			return def
+		}
+		return ""
+	})
+}
+
+// expandEnvInValue recursively expands ${ENV_VAR} references in every
+// string found within v (a map/slice/string from a yaml.Unmarshal tree),
+// mutating maps and slices in place. Other scalar types pass through
+// unchanged.
+func expandEnvInValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case string:
+		return expandEnv(t)
+	case map[string]interface{}:
+		for k, val := range t {
+			t[k] = expandEnvInValue(val)
+		}
+		return t
+	case []interface{}:
+		for i, val := range t {
+			t[i] = expandEnvInValue(val)
+		}
+		return t
+	default:
+		return v
+	}
+}
+
+// deepMerge recursively merges src into dst, with src's values overriding
+// dst's on conflict. Nested maps are merged key-by-key; any other value
+// (scalar or slice) in src replaces dst's value outright. dst is mutated
+// and returned for convenience; pass a fresh map if that's undesirable.
+func deepMerge(dst, src map[string]interface{}) map[string]interface{} {
+	if dst == nil {
+		dst = make(map[string]interface{})
+	}
+
+	for k, srcVal := range src {
+		if dstVal, ok := dst[k]; ok {
+			dstMap, dstIsMap := dstVal.(map[string]interface{})
+			srcMap, srcIsMap := srcVal.(map[string]interface{})
+			if dstIsMap && srcIsMap {
+				dst[k] = deepMerge(dstMap, srcMap)
+				continue
+			}
+		}
+		dst[k] = srcVal
+	}
+
+	return dst
+}
+
+// toStringSlice converts a YAML-decoded value (either []string, from a
+// Go-constructed map, or []interface{}, the usual yaml.v3 shape) into a
+// []string, reporting false if any element isn't a string.
+func toStringSlice(v interface{}) ([]string, bool) {
+	switch t := v.(type) {
+	case []string:
+		return t, true
+	case []interface{}:
+		out := make([]string, 0, len(t))
+		for _, item := range t {
+			s, ok := item.(string)
+			if !ok {
+				return nil, false
+			}
+			out = append(out, s)
+		}
+		return out, true
+	default:
+		return nil, false
+	}
+}
+
+// SetOverride registers an explicit, highest-precedence value for key,
+// bypassing bound env vars, the config file, and defaults. Intended for
+// flag-sourced values that should always win over configuration.
+func SetOverride(key string, val interface{}) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if Config.Overrides == nil {
+		Config.Overrides = make(map[string]interface{})
+	}
+	Config.Overrides[key] = val
 }
 
-// get traverses the configuration tree using a dotted key path (e.g.
-// "backend.s3.bucket"). If Namespace is set, a namespaced candidate key is
-// attempted first (Namespace + "." + kspec), then the unnamespaced key.
-// Returns the raw value (any) if found.
+// get resolves kspec in precedence order: an explicit SetOverride value,
+// then the first set environment variable bound via BindEnv, then a
+// namespaced key in the config file (Namespace + "." + kspec), then the
+// fully-qualified key in the config file, then a SetDefault value. Returns
+// an error if none of those resolve the key.
 func (cfg *Type) get(kspec string) (any, error) {
 	if len(cfg.Data) == 0 {
 		_, _ = Load(cfg.Source)
 	}
 
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if val, ok := cfg.Overrides[kspec]; ok {
+		return val, nil
+	}
+
+	for _, envVar := range cfg.EnvBindings[kspec] {
+		if val, ok := os.LookupEnv(envVar); ok {
+			return val, nil
+		}
+	}
+
 	candidateKeys := []string{"", kspec}
 	if cfg.Namespace != "" {
 		candidateKeys[0] = cfg.Namespace + "." + kspec
@@ -200,40 +621,26 @@ func (cfg *Type) get(kspec string) (any, error) {
 		}
 	}
 
+	if val, ok := cfg.Defaults[kspec]; ok {
+		return val, nil
+	}
+
 	return nil, fmt.Errorf("no valid path found among: %v", candidateKeys)
 }
 
-// getConfigFile returns the absolute path to the YAML config file. If the
-// TFCTL_CFG_FILE environment variable is set, it is treated as the full path to
-// the config file. Otherwise, the OS-specific user configuration directory
-// returned by os.UserConfigDir is used with the filename "tfctl.yaml". The file
-// must exist and not be a directory.
+// getConfigFile returns the single highest-precedence config source Watch
+// should watch for changes -- the last path resolveConfigSources would
+// merge in, mirroring Load's own precedence order. Load itself watches
+// every resolved source only indirectly, by being re-run wholesale on any
+// change to this one file; edits to a lower-precedence source (or to an
+// include: target) require a restart to pick up.
 func getConfigFile() (string, error) {
-	// Check for TFCTL_CFG_FILE environment variable first
-	if cfgPath := os.Getenv("TFCTL_CFG_FILE"); cfgPath != "" {
-		if fileInfo, err := os.Stat(cfgPath); err == nil {
-			if !fileInfo.IsDir() {
-				log.Debugf("using config file from TFCTL_CFG_FILE: %s", cfgPath)
-				return cfgPath, nil
-			}
-			return "", fmt.Errorf("TFCTL_CFG_FILE points to a directory: %s", cfgPath)
-		}
-		return "", fmt.Errorf("config file not found at TFCTL_CFG_FILE path: %s", cfgPath)
-	}
-
-	// Fall back to user config directory
-	dir, err := os.UserConfigDir()
+	paths, err := resolveConfigSources()
 	if err != nil {
 		return "", err
 	}
-
-	file := filepath.Join(dir, "tfctl.yaml")
-	if fileInfo, err := os.Stat(file); err == nil {
-		if !fileInfo.IsDir() {
-			log.Debugf("using config file: %s", file)
-			return file, nil
-		}
+	if len(paths) == 0 {
+		return "", fmt.Errorf("no config file found in standard locations")
 	}
-
-	return "", fmt.Errorf("no config file found in standard locations")
+	return paths[len(paths)-1], nil
 }
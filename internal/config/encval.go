@@ -0,0 +1,139 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// encValuePrefix marks a config value as encrypted-at-rest; see
+// EncryptValue/DecryptValue.
+const encValuePrefix = "!enc:"
+
+// encValueSaltSize is the random salt length EncryptValue generates.
+const encValueSaltSize = 16
+
+// encValueIterations is the PBKDF2-SHA512 iteration count EncryptValue
+// uses, matching internal/state's default pbkdf2 key provider iteration
+// count. Encrypted config values get their own key derivation rather than
+// reusing internal/state directly: internal/state already imports
+// internal/config (for key-cache settings), so the reverse import isn't
+// possible without a cycle.
+const encValueIterations = 600000
+
+// EncryptValue derives a key from passphrase via PBKDF2-SHA512 under a
+// fresh random salt, AES-256-GCM-seals plaintext, and returns the
+// "!enc:<base64>" form config files store it as: base64 of
+// salt||nonce||ciphertext.
+func EncryptValue(plaintext, passphrase string) (string, error) {
+	salt := make([]byte, encValueSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	aesGCM, err := encValueCipher(passphrase, salt)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, aesGCM.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	sealed := aesGCM.Seal(nonce, nonce, []byte(plaintext), nil)
+
+	blob := append(salt, sealed...)
+	return encValuePrefix + base64.StdEncoding.EncodeToString(blob), nil
+}
+
+// DecryptValue reverses EncryptValue: encoded must carry the "!enc:"
+// prefix, and passphrase must match the one it was encrypted with.
+func DecryptValue(encoded, passphrase string) (string, error) {
+	payload, ok := strings.CutPrefix(encoded, encValuePrefix)
+	if !ok {
+		return "", fmt.Errorf("value is not an encrypted (%q-prefixed) config value", encValuePrefix)
+	}
+
+	blob, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode base64: %w", err)
+	}
+	if len(blob) < encValueSaltSize {
+		return "", fmt.Errorf("encrypted value too short")
+	}
+	salt, rest := blob[:encValueSaltSize], blob[encValueSaltSize:]
+
+	aesGCM, err := encValueCipher(passphrase, salt)
+	if err != nil {
+		return "", err
+	}
+
+	nonceSize := aesGCM.NonceSize()
+	if len(rest) < nonceSize {
+		return "", fmt.Errorf("encrypted value too short")
+	}
+	nonce, ciphertext := rest[:nonceSize], rest[nonceSize:]
+
+	plaintext, err := aesGCM.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt: %w", err)
+	}
+
+	return string(plaintext), nil
+}
+
+// encValueCipher derives a PBKDF2-SHA512 key from passphrase and salt and
+// wraps it in an AES-256-GCM cipher.AEAD, shared by EncryptValue and
+// DecryptValue.
+func encValueCipher(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key := pbkdf2.Key([]byte(passphrase), salt, encValueIterations, 32, sha512.New)
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	aesGCM, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCM: %w", err)
+	}
+
+	return aesGCM, nil
+}
+
+// IsEncryptedValue reports whether s carries the "!enc:" prefix
+// EncryptValue produces.
+func IsEncryptedValue(s string) bool {
+	return strings.HasPrefix(s, encValuePrefix)
+}
+
+// resolveEncrypted transparently decrypts val if it carries the "!enc:"
+// prefix, using the TFCTL_CONFIG_PASSPHRASE environment variable -- the
+// getters this feeds (GetString, GetStringSlice) are called from all over
+// the codebase, not just CLI entry points, so there's no good place to
+// prompt interactively; `tfctl config decrypt-value` is the interactive
+// path for callers who don't already have the passphrase in their
+// environment. Values without the prefix pass through unchanged.
+func resolveEncrypted(val string) (string, error) {
+	if !IsEncryptedValue(val) {
+		return val, nil
+	}
+
+	passphrase := os.Getenv("TFCTL_CONFIG_PASSPHRASE")
+	if passphrase == "" {
+		return "", fmt.Errorf("value is encrypted but TFCTL_CONFIG_PASSPHRASE is not set")
+	}
+
+	return DecryptValue(val, passphrase)
+}
@@ -0,0 +1,184 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeepMerge(t *testing.T) {
+	dst := map[string]interface{}{
+		"region": "us-east-1",
+		"backend": map[string]interface{}{
+			"bucket":      "dst-bucket",
+			"max_retries": 3,
+		},
+		"tags": []interface{}{"a", "b"},
+	}
+	src := map[string]interface{}{
+		"backend": map[string]interface{}{
+			"bucket": "src-bucket",
+		},
+		"tags": []interface{}{"c"},
+	}
+
+	merged := deepMerge(dst, src)
+
+	assert.Equal(t, "us-east-1", merged["region"])
+	backend, ok := merged["backend"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "src-bucket", backend["bucket"], "src should override matching scalar keys")
+	assert.Equal(t, 3, backend["max_retries"], "dst-only nested keys survive the merge")
+	assert.Equal(t, []interface{}{"c"}, merged["tags"], "src slices replace dst slices outright")
+}
+
+func TestExpandEnv(t *testing.T) {
+	t.Setenv("MERGE_TEST_VAR", "hello")
+	t.Setenv("MERGE_TEST_EMPTY", "")
+	os.Unsetenv("MERGE_TEST_UNSET")
+
+	assert.Equal(t, "hello world", expandEnv("${MERGE_TEST_VAR} world"))
+	assert.Equal(t, "fallback", expandEnv("${MERGE_TEST_UNSET:-fallback}"))
+	assert.Equal(t, "fallback", expandEnv("${MERGE_TEST_EMPTY:-fallback}"), "an empty var falls back like bash's :- operator")
+	assert.Equal(t, "", expandEnv("${MERGE_TEST_UNSET}"), "an unset var with no default expands to empty")
+}
+
+func TestToStringSlice(t *testing.T) {
+	out, ok := toStringSlice([]interface{}{"a", "b"})
+	assert.True(t, ok)
+	assert.Equal(t, []string{"a", "b"}, out)
+
+	_, ok = toStringSlice([]interface{}{"a", 1})
+	assert.False(t, ok, "a non-string element should fail the conversion")
+
+	_, ok = toStringSlice("not a slice")
+	assert.False(t, ok)
+}
+
+// TestLoadFile_Include verifies that an include: [...] directive is
+// resolved relative to the including file, that included data is the
+// lower-precedence base, and that the including file's own keys win on
+// conflict.
+func TestLoadFile_Include(t *testing.T) {
+	dir := t.TempDir()
+
+	basePath := filepath.Join(dir, "base.yaml")
+	require.NoError(t, os.WriteFile(basePath, []byte("region: us-east-1\nbucket: base-bucket\n"), 0o600))
+
+	mainPath := filepath.Join(dir, "main.yaml")
+	require.NoError(t, os.WriteFile(mainPath, []byte("include: [base.yaml]\nbucket: main-bucket\n"), 0o600))
+
+	data, err := loadFile(mainPath, make(map[string]bool))
+	require.NoError(t, err)
+
+	assert.Equal(t, "us-east-1", data["region"], "keys only present in the include should survive")
+	assert.Equal(t, "main-bucket", data["bucket"], "the including file's own keys override the include")
+	_, hasInclude := data["include"]
+	assert.False(t, hasInclude, "the include directive itself should not leak into the merged data")
+}
+
+// TestLoadFile_IncludeCycle verifies that a file that (transitively)
+// includes itself is rejected rather than recursing forever.
+func TestLoadFile_IncludeCycle(t *testing.T) {
+	dir := t.TempDir()
+
+	aPath := filepath.Join(dir, "a.yaml")
+	bPath := filepath.Join(dir, "b.yaml")
+	require.NoError(t, os.WriteFile(aPath, []byte("include: [b.yaml]\n"), 0o600))
+	require.NoError(t, os.WriteFile(bPath, []byte("include: [a.yaml]\n"), 0o600))
+
+	_, err := loadFile(aPath, make(map[string]bool))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "include cycle")
+}
+
+// TestLoadFile_EnvExpansion verifies ${ENV_VAR} expansion runs over every
+// string value loadFile loads, not just top-level ones.
+func TestLoadFile_EnvExpansion(t *testing.T) {
+	t.Setenv("MERGE_TEST_REGION", "eu-west-1")
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "main.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("backend:\n  s3:\n    region: ${MERGE_TEST_REGION}\n    bucket: ${MERGE_TEST_BUCKET:-default-bucket}\n"), 0o600))
+
+	data, err := loadFile(path, make(map[string]bool))
+	require.NoError(t, err)
+
+	backend, ok := data["backend"].(map[string]interface{})
+	require.True(t, ok)
+	s3, ok := backend["s3"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "eu-west-1", s3["region"])
+	assert.Equal(t, "default-bucket", s3["bucket"])
+}
+
+// TestLoad_MultiSourceMerge verifies that Load deep-merges $TFCTL_CFG_FILE
+// and $XDG_CONFIG_HOME/tfctl.yaml, with the XDG source (later in Load's
+// documented precedence order) overriding $TFCTL_CFG_FILE on conflict.
+func TestLoad_MultiSourceMerge(t *testing.T) {
+	cfgDir := t.TempDir()
+	cfgPath := filepath.Join(cfgDir, "cfg-file.yaml")
+	require.NoError(t, os.WriteFile(cfgPath, []byte("region: us-east-1\nbucket: cfg-file-bucket\n"), 0o600))
+
+	xdgDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(xdgDir, "tfctl.yaml"), []byte("bucket: xdg-bucket\n"), 0o600))
+
+	t.Setenv("TFCTL_CFG_FILE", cfgPath)
+	t.Setenv("XDG_CONFIG_HOME", xdgDir)
+	Config = Type{}
+	defer func() { Config = Type{} }()
+
+	cfg, err := Load()
+	require.NoError(t, err)
+
+	assert.Equal(t, "us-east-1", cfg.Data["region"], "a key only set in $TFCTL_CFG_FILE should survive the merge")
+	assert.Equal(t, "xdg-bucket", cfg.Data["bucket"], "XDG_CONFIG_HOME is higher precedence than TFCTL_CFG_FILE")
+}
+
+func TestEncryptDecryptValue_RoundTrip(t *testing.T) {
+	encrypted, err := EncryptValue("s3cr3t", "correct-horse-battery-staple")
+	require.NoError(t, err)
+	assert.True(t, IsEncryptedValue(encrypted))
+
+	decrypted, err := DecryptValue(encrypted, "correct-horse-battery-staple")
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", decrypted)
+}
+
+func TestDecryptValue_WrongPassphrase(t *testing.T) {
+	encrypted, err := EncryptValue("s3cr3t", "correct-horse-battery-staple")
+	require.NoError(t, err)
+
+	_, err = DecryptValue(encrypted, "wrong-passphrase")
+	assert.Error(t, err)
+}
+
+func TestDecryptValue_MissingPrefix(t *testing.T) {
+	_, err := DecryptValue("not-an-encrypted-value", "whatever")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not an encrypted")
+}
+
+func TestResolveEncrypted(t *testing.T) {
+	plain, err := resolveEncrypted("plaintext")
+	require.NoError(t, err)
+	assert.Equal(t, "plaintext", plain)
+
+	encrypted, err := EncryptValue("s3cr3t", "correct-horse-battery-staple")
+	require.NoError(t, err)
+
+	os.Unsetenv("TFCTL_CONFIG_PASSPHRASE")
+	_, err = resolveEncrypted(encrypted)
+	assert.Error(t, err, "decrypting without TFCTL_CONFIG_PASSPHRASE set should fail, not silently pass through")
+
+	t.Setenv("TFCTL_CONFIG_PASSPHRASE", "correct-horse-battery-staple")
+	decrypted, err := resolveEncrypted(encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", decrypted)
+}
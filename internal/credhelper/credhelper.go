@@ -0,0 +1,180 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package credhelper resolves tokens via a Terraform CLI credentials helper,
+// the same `credentials_helper "name" { args = [...] }` mechanism configured
+// in ~/.terraformrc that the terraform binary itself uses. It execs the
+// `terraform-credentials-<name>` binary on PATH with a `get <host>`
+// subcommand and parses its `{"token": "..."}` JSON response.
+package credhelper
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+)
+
+// ErrEmptyToken is returned when a credentials helper exits successfully but
+// its response carries no token, e.g. `{}` instead of `{"token": "..."}` --
+// a blank token is never a valid resolution, so callers must treat this the
+// same as any other helper failure rather than caching or returning it.
+var ErrEmptyToken = errors.New("credentials helper returned an empty token")
+
+// DefaultTimeout bounds how long a terraform-credentials-<name> helper
+// process is allowed to run before being killed.
+const DefaultTimeout = 10 * time.Second
+
+// Config describes one credentials_helper block parsed from .terraformrc.
+type Config struct {
+	Name string
+	Args []string
+}
+
+var cliConfigSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "credentials_helper", LabelNames: []string{"name"}},
+	},
+}
+
+var credentialsHelperBodySchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "args"},
+	},
+}
+
+// Load parses the credentials_helper block, if any, out of the user's
+// Terraform CLI config file (~/.terraformrc, %APPDATA%\terraform.rc on
+// Windows, or TF_CLI_CONFIG_FILE if set). ok is false, with a nil error,
+// when the file or the block simply doesn't exist -- both are normal.
+func Load() (cfg Config, ok bool, err error) {
+	path, err := cliConfigPath()
+	if err != nil {
+		return Config{}, false, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Config{}, false, nil
+		}
+		return Config{}, false, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	f, diags := hclparse.NewParser().ParseHCL(data, path)
+	if diags.HasErrors() {
+		return Config{}, false, fmt.Errorf("failed to parse %s: %w", path, diags)
+	}
+
+	content, _, _ := f.Body.PartialContent(cliConfigSchema)
+	for _, b := range content.Blocks {
+		inner, diags := b.Body.PartialContent(credentialsHelperBodySchema)
+		if diags.HasErrors() {
+			return Config{}, false, fmt.Errorf("failed to parse credentials_helper block: %w", diags)
+		}
+
+		cfg = Config{Name: b.Labels[0]}
+		if attr, ok := inner.Attributes["args"]; ok {
+			v, diags := attr.Expr.Value(nil)
+			if diags.HasErrors() {
+				return Config{}, false, fmt.Errorf("failed to evaluate credentials_helper args: %w", diags)
+			}
+			if v.CanIterateElements() {
+				it := v.ElementIterator()
+				for it.Next() {
+					_, ev := it.Element()
+					sv, err := convert.Convert(ev, cty.String)
+					if err != nil {
+						return Config{}, false, fmt.Errorf("credentials_helper args must be strings: %w", err)
+					}
+					cfg.Args = append(cfg.Args, sv.AsString())
+				}
+			}
+		}
+		return cfg, true, nil
+	}
+
+	return Config{}, false, nil
+}
+
+func cliConfigPath() (string, error) {
+	if p := os.Getenv("TF_CLI_CONFIG_FILE"); p != "" {
+		return p, nil
+	}
+
+	if runtime.GOOS == "windows" {
+		return filepath.Join(os.Getenv("APPDATA"), "terraform.rc"), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user home directory: %w", err)
+	}
+	return filepath.Join(home, ".terraformrc"), nil
+}
+
+var (
+	mu    sync.Mutex
+	cache = map[string]string{}
+)
+
+// Get resolves the token for host via cfg's credentials helper binary,
+// caching the result in-process keyed by host so repeated lookups within one
+// tfctl invocation don't re-exec the helper.
+func Get(ctx context.Context, cfg Config, host string) (string, error) {
+	mu.Lock()
+	if token, ok := cache[host]; ok {
+		mu.Unlock()
+		return token, nil
+	}
+	mu.Unlock()
+
+	binary := "terraform-credentials-" + cfg.Name
+	if _, err := exec.LookPath(binary); err != nil {
+		return "", fmt.Errorf("credentials helper binary %q not found on PATH: %w", binary, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, DefaultTimeout)
+	defer cancel()
+
+	args := append(append([]string{}, cfg.Args...), "get", host)
+	execCmd := exec.CommandContext(ctx, binary, args...)
+
+	var stdout, stderr bytes.Buffer
+	execCmd.Stdout = &stdout
+	execCmd.Stderr = &stderr
+
+	if err := execCmd.Run(); err != nil {
+		return "", fmt.Errorf("credentials helper %s get %s failed: %w: %s",
+			binary, host, err, strings.TrimSpace(stderr.String()))
+	}
+
+	var resp struct {
+		Token string `json:"token"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &resp); err != nil {
+		return "", fmt.Errorf("failed to parse credentials helper response: %w", err)
+	}
+	if resp.Token == "" {
+		return "", fmt.Errorf("credentials helper %s get %s: %w", binary, host, ErrEmptyToken)
+	}
+
+	mu.Lock()
+	cache[host] = resp.Token
+	mu.Unlock()
+
+	return resp.Token, nil
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+// no-cloc
+
+package credhelper
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubHelper writes a fake terraform-credentials-<name> binary that prints
+// stdout verbatim, and prepends its directory to PATH for the test's
+// duration so Get resolves it via exec.LookPath the same way it would a
+// real helper.
+func stubHelper(t *testing.T, name, stdout string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("stub helper script is POSIX shell only")
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "terraform-credentials-"+name)
+	script := "#!/bin/sh\ncat <<'EOF'\n" + stdout + "\nEOF\n"
+	require.NoError(t, os.WriteFile(path, []byte(script), 0o755))
+
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+// TestGet_EmptyTokenIsAnError verifies a helper that exits successfully but
+// reports no token is treated as a failure rather than a blank credential,
+// so a caller never silently proceeds unauthenticated.
+func TestGet_EmptyTokenIsAnError(t *testing.T) {
+	stubHelper(t, "empty", `{}`)
+
+	_, err := Get(context.Background(), Config{Name: "empty"}, "app.terraform.io")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrEmptyToken))
+}
+
+// TestGet_ReturnsAndCachesToken verifies a well-formed helper response is
+// returned and cached per host for the process lifetime.
+func TestGet_ReturnsAndCachesToken(t *testing.T) {
+	stubHelper(t, "ok-"+t.Name(), `{"token": "s.abc123"}`)
+
+	cfg := Config{Name: "ok-" + t.Name()}
+	token, err := Get(context.Background(), cfg, "cached.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "s.abc123", token)
+
+	// Remove the helper from PATH entirely -- a cache hit shouldn't need it.
+	t.Setenv("PATH", t.TempDir())
+	token, err = Get(context.Background(), cfg, "cached.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "s.abc123", token)
+}
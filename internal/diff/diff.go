@@ -0,0 +1,240 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package diff computes a structured, resource-address-keyed diff between
+// two Terraform/OpenTofu state documents, the shared engine backing every
+// backend.SelfDiffer implementation (remote, s3, local). Unlike a raw JSON
+// diff, it understands state's resources/instances/attributes shape well
+// enough to report per-attribute add/remove/change entries against a stable
+// "type.name[index]" address, and to keep sensitive attribute values out of
+// the result entirely.
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/tfctl/tfctl/internal/addrs"
+)
+
+// Action describes what happened to a resource or attribute between the two
+// states being compared.
+type Action string
+
+const (
+	Added   Action = "add"
+	Removed Action = "remove"
+	Changed Action = "change"
+)
+
+// AttributeChange describes a single attribute's difference within a
+// resource instance. Old/New are left nil and Sensitive is set when the
+// attribute is marked sensitive in either state; OldHash/NewHash then carry
+// a stable SHA-256 digest of the respective value so callers can tell a
+// sensitive value changed without the diff ever holding the value itself.
+type AttributeChange struct {
+	Attribute string `json:"attribute"`
+	Action    Action `json:"action"`
+	Old       any    `json:"old,omitempty"`
+	New       any    `json:"new,omitempty"`
+	Sensitive bool   `json:"sensitive,omitempty"`
+	OldHash   string `json:"old_hash,omitempty"`
+	NewHash   string `json:"new_hash,omitempty"`
+}
+
+// ResourceDiff describes one resource instance's change between the two
+// states: whole-resource Added/Removed, or Changed with the per-attribute
+// detail in Changes.
+type ResourceDiff struct {
+	Address string            `json:"address"`
+	Action  Action            `json:"action"`
+	Changes []AttributeChange `json:"changes,omitempty"`
+}
+
+// Result is the full diff between two states, one entry per resource
+// instance that differs. Resources identical in both states are omitted.
+type Result struct {
+	Resources []ResourceDiff `json:"resources"`
+}
+
+// Options configures Compute's comparison behavior.
+type Options struct {
+	// IgnoreAttrs lists attribute names excluded from diffAttributes
+	// entirely, e.g. "timestamps,etag" for values that legitimately change
+	// on every apply and would otherwise drown out real changes.
+	IgnoreAttrs []string
+}
+
+// instance is the subset of a state resource's instance shape Compute needs.
+type instance struct {
+	IndexKey   any                 `json:"index_key,omitempty"`
+	Attributes map[string]any      `json:"attributes"`
+	Sensitive  [][]sensitivePathEl `json:"sensitive_attributes,omitempty"`
+}
+
+// resource is the subset of a state document's "resources[]" shape Compute
+// needs; mirrors the fields already relied on in internal/command/si.
+type resource struct {
+	Module    string     `json:"module"`
+	Mode      string     `json:"mode"`
+	Type      string     `json:"type"`
+	Name      string     `json:"name"`
+	Instances []instance `json:"instances"`
+}
+
+type stateDoc struct {
+	Resources []resource `json:"resources"`
+}
+
+// Compute diffs the "to" document against "from" and returns one
+// ResourceDiff per resource instance that was added, removed, or changed.
+func Compute(from, to []byte, opts Options) (*Result, error) {
+	ignore := make(map[string]bool, len(opts.IgnoreAttrs))
+	for _, a := range opts.IgnoreAttrs {
+		ignore[a] = true
+	}
+
+	var fromDoc, toDoc stateDoc
+	if err := json.Unmarshal(from, &fromDoc); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal from state: %w", err)
+	}
+	if err := json.Unmarshal(to, &toDoc); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal to state: %w", err)
+	}
+
+	fromByKey, fromRI := indexByUniqueKey(fromDoc)
+	toByKey, toRI := indexByUniqueKey(toDoc)
+
+	keys := make(map[addrs.UniqueKey]bool, len(fromByKey)+len(toByKey))
+	for key := range fromByKey {
+		keys[key] = true
+	}
+	for key := range toByKey {
+		keys[key] = true
+	}
+
+	result := &Result{}
+	for key := range keys {
+		fromInst, inFrom := fromByKey[key]
+		toInst, inTo := toByKey[key]
+
+		addr := fromRI[key].String()
+		if !inFrom {
+			addr = toRI[key].String()
+		}
+
+		switch {
+		case !inFrom:
+			result.Resources = append(result.Resources, ResourceDiff{Address: addr, Action: Added})
+		case !inTo:
+			result.Resources = append(result.Resources, ResourceDiff{Address: addr, Action: Removed})
+		default:
+			changes := diffAttributes(fromInst, toInst, ignore)
+			if len(changes) > 0 {
+				result.Resources = append(result.Resources, ResourceDiff{Address: addr, Action: Changed, Changes: changes})
+			}
+		}
+	}
+
+	sort.Slice(result.Resources, func(i, j int) bool {
+		return result.Resources[i].Address < result.Resources[j].Address
+	})
+
+	return result, nil
+}
+
+// indexByUniqueKey flattens a state document's resources/instances into a
+// map keyed by each instance's addrs.ResourceInstance.UniqueKey, so Compute
+// can join the "from" and "to" documents by structured address instead of
+// by raw string comparison. It also returns the UniqueKey -> ResourceInstance
+// mapping, so callers can recover the address string to report.
+func indexByUniqueKey(doc stateDoc) (map[addrs.UniqueKey]instance, map[addrs.UniqueKey]addrs.ResourceInstance) {
+	instances := make(map[addrs.UniqueKey]instance)
+	byKey := make(map[addrs.UniqueKey]addrs.ResourceInstance)
+	for _, r := range doc.Resources {
+		for _, inst := range r.Instances {
+			ri := resourceInstance(r, inst)
+			key := ri.UniqueKey()
+			instances[key] = inst
+			byKey[key] = ri
+		}
+	}
+	return instances, byKey
+}
+
+// diffAttributes compares two instances' Attributes maps, returning one
+// AttributeChange per key that was added, removed, or whose value differs.
+// Keys present in ignore are skipped entirely, as if identical on both
+// sides. Sensitive attributes (per sensitiveAttributeNames) never carry
+// their raw value; only a stable hash of each side is reported.
+func diffAttributes(from, to instance, ignore map[string]bool) []AttributeChange {
+	sensitive := sensitiveAttributeNames(from)
+	for name := range sensitiveAttributeNames(to) {
+		sensitive[name] = true
+	}
+
+	keys := make(map[string]bool, len(from.Attributes)+len(to.Attributes))
+	for k := range from.Attributes {
+		keys[k] = true
+	}
+	for k := range to.Attributes {
+		keys[k] = true
+	}
+
+	var changes []AttributeChange
+	for key := range keys {
+		if ignore[key] {
+			continue
+		}
+
+		oldVal, inOld := from.Attributes[key]
+		newVal, inNew := to.Attributes[key]
+
+		var action Action
+		switch {
+		case !inOld:
+			action = Added
+		case !inNew:
+			action = Removed
+		case !valuesEqual(oldVal, newVal):
+			action = Changed
+		default:
+			continue
+		}
+
+		change := AttributeChange{Attribute: key, Action: action, Sensitive: sensitive[key]}
+		if change.Sensitive {
+			if inOld {
+				change.OldHash = hashValue(oldVal)
+			}
+			if inNew {
+				change.NewHash = hashValue(newVal)
+			}
+		} else {
+			if inOld {
+				change.Old = oldVal
+			}
+			if inNew {
+				change.New = newVal
+			}
+		}
+		changes = append(changes, change)
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Attribute < changes[j].Attribute })
+	return changes
+}
+
+// valuesEqual compares two attribute values via their canonical JSON
+// encoding, sidestepping map/slice key-order and numeric-type (float64 vs
+// int) noise that a direct reflect.DeepEqual would otherwise flag as a
+// difference.
+func valuesEqual(a, b any) bool {
+	aj, aerr := json.Marshal(a)
+	bj, berr := json.Marshal(b)
+	if aerr != nil || berr != nil {
+		return false
+	}
+	return string(aj) == string(bj)
+}
@@ -0,0 +1,227 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// Formats is the set of values accepted by the --format flag on commands
+// that render a Result, in the order they should be listed in help text.
+var Formats = []string{"unified", "json", "table", "json-patch"}
+
+// Render writes result to w in the requested format ("unified", "json",
+// "table", or "json-patch"); an empty format defaults to "unified".
+func Render(w io.Writer, format string, result *Result) error {
+	switch format {
+	case "", "unified":
+		return renderUnified(w, result)
+	case "json":
+		return renderJSON(w, result)
+	case "table":
+		return renderTable(w, result)
+	case "json-patch":
+		return renderJSONPatch(w, result)
+	default:
+		return fmt.Errorf("unknown diff format %q, must be one of %v", format, Formats)
+	}
+}
+
+// renderUnified prints a +/-/~ summary per resource and attribute, the
+// default, human-scannable format.
+func renderUnified(w io.Writer, result *Result) error {
+	if len(result.Resources) == 0 {
+		fmt.Fprintln(w, "The states are identical.")
+		return nil
+	}
+
+	for _, r := range result.Resources {
+		switch r.Action {
+		case Added:
+			fmt.Fprintf(w, "+ %s\n", r.Address)
+		case Removed:
+			fmt.Fprintf(w, "- %s\n", r.Address)
+		case Changed:
+			fmt.Fprintf(w, "~ %s\n", r.Address)
+			for _, c := range r.Changes {
+				fmt.Fprintf(w, "  %s %s: %s\n", changeSymbol(c.Action), c.Attribute, formatChange(c))
+			}
+		}
+	}
+	return nil
+}
+
+// changeSymbol returns the unified-diff-style marker for an attribute
+// change's action.
+func changeSymbol(a Action) string {
+	switch a {
+	case Added:
+		return "+"
+	case Removed:
+		return "-"
+	default:
+		return "~"
+	}
+}
+
+// formatChange renders a single attribute change's old/new values, masking
+// sensitive ones behind their stable hash instead of the value itself.
+func formatChange(c AttributeChange) string {
+	if c.Sensitive {
+		switch c.Action {
+		case Added:
+			return fmt.Sprintf("(sensitive; sha256:%s)", c.NewHash)
+		case Removed:
+			return fmt.Sprintf("(sensitive; sha256:%s)", c.OldHash)
+		default:
+			return fmt.Sprintf("(sensitive; sha256:%s) => (sensitive; sha256:%s)", c.OldHash, c.NewHash)
+		}
+	}
+
+	switch c.Action {
+	case Added:
+		return fmt.Sprintf("%v", c.New)
+	case Removed:
+		return fmt.Sprintf("%v", c.Old)
+	default:
+		return fmt.Sprintf("%v => %v", c.Old, c.New)
+	}
+}
+
+// renderJSON writes result as indented JSON.
+func renderJSON(w io.Writer, result *Result) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(result); err != nil {
+		return fmt.Errorf("failed to render diff as json: %w", err)
+	}
+	return nil
+}
+
+// jsonPatchOp is one operation in an RFC 6902 JSON Patch document.
+type jsonPatchOp struct {
+	Op    string `json:"op"`
+	Path  string `json:"path"`
+	Value any    `json:"value,omitempty"`
+}
+
+// renderJSONPatch writes result as an RFC 6902 JSON Patch: one "replace" per
+// changed attribute, one "add"/"remove" per added/removed attribute within a
+// changed resource, and one "add"/"remove" per whole added/removed resource.
+// Compute only records a whole added/removed resource's address, not its
+// attributes, so a whole-resource "add" op carries no "value" member -
+// technically short of RFC 6902, which requires one, but there's nothing
+// else to put there without Compute capturing full resource snapshots.
+// Sensitive attribute values are masked behind their stable hash exactly as
+// the other formats mask them, so the patch never leaks a secret.
+func renderJSONPatch(w io.Writer, result *Result) error {
+	var ops []jsonPatchOp
+
+	for _, r := range result.Resources {
+		addr := jsonPointerEscape(r.Address)
+		switch r.Action {
+		case Added:
+			ops = append(ops, jsonPatchOp{Op: "add", Path: "/" + addr})
+		case Removed:
+			ops = append(ops, jsonPatchOp{Op: "remove", Path: "/" + addr})
+		case Changed:
+			for _, c := range r.Changes {
+				path := "/" + addr + "/" + jsonPointerEscape(c.Attribute)
+				switch c.Action {
+				case Added:
+					ops = append(ops, jsonPatchOp{Op: "add", Path: path, Value: patchValue(c, c.NewHash)})
+				case Removed:
+					ops = append(ops, jsonPatchOp{Op: "remove", Path: path})
+				default:
+					ops = append(ops, jsonPatchOp{Op: "replace", Path: path, Value: patchValue(c, c.NewHash)})
+				}
+			}
+		}
+	}
+
+	if ops == nil {
+		ops = []jsonPatchOp{}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(ops); err != nil {
+		return fmt.Errorf("failed to render diff as json-patch: %w", err)
+	}
+	return nil
+}
+
+// patchValue returns the value to carry on a JSON Patch op for an add or
+// replace, masking a sensitive attribute behind its new-side hash instead of
+// its raw value.
+func patchValue(c AttributeChange, hash string) any {
+	if c.Sensitive {
+		return fmt.Sprintf("(sensitive; sha256:%s)", hash)
+	}
+	return c.New
+}
+
+// jsonPointerEscape escapes s for use as one segment of an RFC 6901 JSON
+// Pointer path ("~" before "/", per the spec, so the decode order un-escapes
+// correctly).
+func jsonPointerEscape(s string) string {
+	s = strings.ReplaceAll(s, "~", "~0")
+	return strings.ReplaceAll(s, "/", "~1")
+}
+
+// renderTable writes one row per attribute change (and one summary row per
+// whole added/removed resource), tab-aligned the way other tfctl table
+// output does.
+func renderTable(w io.Writer, result *Result) error {
+	tw := tabwriter.NewWriter(w, 0, 2, 2, ' ', 0) //nolint:mnd
+	fmt.Fprintln(tw, "ADDRESS\tACTION\tATTRIBUTE\tOLD\tNEW")
+
+	for _, r := range result.Resources {
+		if r.Action != Changed {
+			fmt.Fprintf(tw, "%s\t%s\t\t\t\n", r.Address, r.Action)
+			continue
+		}
+		for _, c := range r.Changes {
+			oldVal, newVal := tableValues(c)
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", r.Address, c.Action, c.Attribute, oldVal, newVal)
+		}
+	}
+
+	return tw.Flush()
+}
+
+// FormatAttributeChange returns c's old/new values formatted for display,
+// masking sensitive ones behind their stable hash. It's exported so callers
+// outside this package that flatten a Result into rows of their own (e.g.
+// the command package's "diff" subcommand, which feeds rows to the
+// SliceDiceSpit pipeline instead of calling Render) can format values the
+// same way renderTable does.
+func FormatAttributeChange(c AttributeChange) (oldVal, newVal string) {
+	return tableValues(c)
+}
+
+// tableValues returns the old/new column values for an attribute change,
+// masking sensitive ones behind their stable hash.
+func tableValues(c AttributeChange) (oldVal, newVal string) {
+	if c.Sensitive {
+		if c.OldHash != "" {
+			oldVal = "(sensitive; sha256:" + c.OldHash + ")"
+		}
+		if c.NewHash != "" {
+			newVal = "(sensitive; sha256:" + c.NewHash + ")"
+		}
+		return
+	}
+	if c.Old != nil {
+		oldVal = fmt.Sprintf("%v", c.Old)
+	}
+	if c.New != nil {
+		newVal = fmt.Sprintf("%v", c.New)
+	}
+	return
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package diff
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/tfctl/tfctl/internal/addrs"
+)
+
+// sensitivePathEl is one segment of a state instance's "sensitive_attributes"
+// path, e.g. {"type":"get_attr","value":"password"}. State only ever marks
+// whole top-level attributes this way in practice, so Compute only looks at
+// each path's first segment; deeper nesting is treated as sensitive at the
+// top-level attribute name too, the same conservative call genconfig makes
+// when it can't prove a nested value is safe to print.
+type sensitivePathEl struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// sensitiveAttributeNames returns the set of top-level attribute names an
+// instance's "sensitive_attributes" paths mark as sensitive.
+func sensitiveAttributeNames(inst instance) map[string]bool {
+	names := make(map[string]bool, len(inst.Sensitive))
+	for _, path := range inst.Sensitive {
+		if len(path) == 0 {
+			continue
+		}
+		names[path[0].Value] = true
+	}
+	return names
+}
+
+// hashValue returns a stable, short SHA-256 digest of value's canonical JSON
+// encoding, mirroring how Terraform's genconfig masks sensitive values: never
+// show the value itself, but give the user enough of a fingerprint to tell
+// whether two sensitive values are the same or different.
+func hashValue(value any) string {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		encoded = []byte(fmt.Sprintf("%v", value))
+	}
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// resourceInstance builds the canonical addrs.ResourceInstance for a state
+// resource's instance, so Compute can join and report addresses via
+// addrs.ResourceInstance's String/UniqueKey instead of ad-hoc string
+// concatenation.
+func resourceInstance(r resource, inst instance) addrs.ResourceInstance {
+	var module addrs.ModuleInstance
+	if r.Module != "" {
+		module = addrs.ModuleInstance(strings.Split(strings.TrimPrefix(r.Module, "module."), ".module."))
+	}
+
+	var key addrs.InstanceKey = addrs.NoKey{}
+	switch v := inst.IndexKey.(type) {
+	case float64:
+		key = addrs.IntKey(int(v))
+	case string:
+		key = addrs.StringKey(v)
+	}
+
+	return addrs.ResourceInstance{
+		Resource: addrs.AbsResource{
+			Module: module,
+			Mode:   r.Mode,
+			Type:   r.Type,
+			Name:   r.Name,
+		},
+		Key: key,
+	}
+}
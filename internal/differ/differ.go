@@ -5,68 +5,42 @@ package differ
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
-	"os"
 	"strconv"
 	"strings"
 
-	"github.com/apex/log"
 	"github.com/urfave/cli/v3"
-	"github.com/yudai/gojsondiff"
-	"github.com/yudai/gojsondiff/formatter"
 
 	"github.com/tfctl/tfctl/internal/meta"
 )
 
-// Diff compares two states.
-func Diff(ctx context.Context, cmd *cli.Command, states [][]byte) error {
-	log.Debugf(">> differ()")
-
-	if len(states[0]) == 0 || len(states[1]) == 0 {
+// Pairs groups states into the from/to pairs a diff renderer should walk,
+// per cmd's diff_mode ("timeline" by default, or "pinned" when the
+// interactive picker pinned a base version). "pinned" pairs states[0] (the
+// pinned base) against every other state in order; "timeline" pairs each
+// state against the one immediately following it.
+func Pairs(cmd *cli.Command, states [][]byte) [][2][]byte {
+	if len(states) < 2 {
 		return nil
 	}
 
-	log.Debugf("len(states): %d %d", len(states[0]), len(states[1]))
-
-	differ := gojsondiff.New()
-
-	delta, err := differ.Compare(states[0], states[1])
-	if err != nil {
-		return fmt.Errorf("failed to compare states: %w", err)
+	mode := cmd.String("diff_mode")
+	if mode == "" {
+		mode = "timeline"
 	}
 
-	if delta.Modified() {
-		var jdoc map[string]interface{}
-		if err := json.Unmarshal(states[0], &jdoc); err != nil {
-			return fmt.Errorf("failed to unmarshal state: %w", err)
-		}
-
-		filter := cmd.String("diff_filter")
-
-		for key := range strings.SplitSeq(filter, ",") {
-			if key != "" {
-				delete(jdoc, key)
-			}
-		}
+	pairs := make([][2][]byte, 0, len(states)-1)
 
-		config := formatter.AsciiFormatterConfig{
-			ShowArrayIndex: false,
-			Coloring:       true,
+	if mode == "pinned" {
+		for i := 1; i < len(states); i++ {
+			pairs = append(pairs, [2][]byte{states[0], states[i]})
 		}
-
-		formatter := formatter.NewAsciiFormatter(jdoc, config)
-		diffString, err := formatter.Format(delta)
-		if err != nil {
-			return err
-		}
-
-		fmt.Fprintln(os.Stdout, diffString)
-		return nil
+		return pairs
 	}
 
-	fmt.Fprintln(os.Stdout, "The states are identical.")
-	return nil
+	for i := 0; i < len(states)-1; i++ {
+		pairs = append(pairs, [2][]byte{states[i], states[i+1]})
+	}
+	return pairs
 }
 
 func ParseDiffArgs(ctx context.Context, cmd *cli.Command) (args []string) {
@@ -80,11 +54,6 @@ func ParseDiffArgs(ctx context.Context, cmd *cli.Command) (args []string) {
 			continue
 		}
 
-		// We've collected the max diff args, bail out.
-		if len(args) == 2 {
-			return
-		}
-
 		if diffFound {
 			// If the next arg up is a flag, bail out.  The definition of what is a
 			// flag is a little indeterminate.
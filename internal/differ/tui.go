@@ -5,65 +5,218 @@ package differ
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/hashicorp/go-tfe"
 )
 
-func SelectStateVersions(items []*tfe.StateVersion) []*tfe.StateVersion {
-	p := tea.NewProgram(model{items: items})
+// Selection is the result of the interactive state-version picker.
+// Mode is "timeline" (the default) or "pinned" when the user pinned a base
+// version with 'p'. SpecsForSelection turns this into the svSpecs order
+// BackendX.States expects.
+type Selection struct {
+	Versions []*tfe.StateVersion
+	Mode     string
+	Base     *tfe.StateVersion
+}
+
+// SelectStateVersions launches an interactive picker over items and returns
+// the versions the user chose (>=2 to proceed, zero on cancel) along with
+// the diff mode selected via 'p'.
+func SelectStateVersions(items []*tfe.StateVersion) Selection {
+	p := tea.NewProgram(model{items: items, filtered: visibleIndices(items, "")})
 	m, _ := p.Run()
-	return m.(model).selected
+	fm := m.(model)
+
+	if len(fm.selected) < 2 {
+		return Selection{}
+	}
+
+	mode := "timeline"
+	if fm.base != nil {
+		mode = "pinned"
+	}
+	return Selection{Versions: fm.selected, Mode: mode, Base: fm.base}
+}
+
+// SpecsForSelection converts an interactive Selection into the ordered
+// svSpecs list BackendX.States expects. Pinned mode puts Base first
+// followed by every other selected version in selection order, so
+// differ.Pairs (given diff_mode=pinned) pairs each of them against Base.
+// Timeline mode orders the versions oldest to newest, so sequential diffs
+// read as v1->v2->v3.
+func SpecsForSelection(sel Selection) []string {
+	if sel.Mode == "pinned" && sel.Base != nil {
+		specs := make([]string, 0, len(sel.Versions))
+		specs = append(specs, sel.Base.ID)
+		for _, v := range sel.Versions {
+			if v.ID != sel.Base.ID {
+				specs = append(specs, v.ID)
+			}
+		}
+		return specs
+	}
+
+	ordered := make([]*tfe.StateVersion, len(sel.Versions))
+	copy(ordered, sel.Versions)
+	sort.Slice(ordered, func(i, j int) bool {
+		return ordered[i].CreatedAt.Before(ordered[j].CreatedAt)
+	})
+
+	specs := make([]string, len(ordered))
+	for i, v := range ordered {
+		specs[i] = v.ID
+	}
+	return specs
 }
 
 type model struct {
-	items    []*tfe.StateVersion
-	cursor   int
-	selected []*tfe.StateVersion
+	items     []*tfe.StateVersion
+	cursor    int
+	selected  []*tfe.StateVersion
+	base      *tfe.StateVersion
+	filter    string
+	filtering bool
+	filtered  []int // indices into items currently visible
 }
 
 func (m model) Init() tea.Cmd { return nil }
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	if key, ok := msg.(tea.KeyMsg); ok {
-		switch key.String() {
-		case "w":
-			return m, tea.WindowSize()
-		case "q", "esc":
-			m.selected = nil
-			return m, tea.Quit
-		case "up":
-			if m.cursor > 0 {
-				m.cursor--
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	if m.filtering {
+		switch key.Type {
+		case tea.KeyEsc:
+			m.filtering = false
+			m.filter = ""
+			m.recomputeFilter()
+		case tea.KeyEnter:
+			m.filtering = false
+		case tea.KeyBackspace:
+			if len(m.filter) > 0 {
+				m.filter = m.filter[:len(m.filter)-1]
+				m.recomputeFilter()
 			}
-		case "down":
-			if m.cursor < len(m.items)-1 {
-				m.cursor++
+		case tea.KeyRunes:
+			m.filter += string(key.Runes)
+			m.recomputeFilter()
+		}
+		return m, nil
+	}
+
+	switch key.String() {
+	case "w":
+		return m, tea.WindowSize()
+	case "q", "esc":
+		m.selected = nil
+		return m, tea.Quit
+	case "up", "k":
+		m.moveCursor(-1)
+	case "down", "j":
+		m.moveCursor(1)
+	case "g":
+		if len(m.filtered) > 0 {
+			m.cursor = m.filtered[0]
+		}
+	case "G":
+		if len(m.filtered) > 0 {
+			m.cursor = m.filtered[len(m.filtered)-1]
+		}
+	case "/":
+		m.filtering = true
+	case "p":
+		if len(m.items) > 0 {
+			cur := m.items[m.cursor]
+			if m.base != nil && m.base.ID == cur.ID {
+				m.base = nil
+			} else {
+				m.base = cur
+				if !contains(m.selected, cur) {
+					m.selected = append(m.selected, cur)
+				}
 			}
-		case " ":
-			if contains(m.selected, m.items[m.cursor]) {
-				// Remove item from selected
-				for i, v := range m.selected {
-					if v.ID == m.items[m.cursor].ID {
-						m.selected = append(m.selected[:i], m.selected[i+1:]...)
-						break
-					}
+		}
+	case "a":
+		for _, idx := range m.filtered {
+			item := m.items[idx]
+			if !contains(m.selected, item) {
+				m.selected = append(m.selected, item)
+			}
+		}
+	case " ":
+		if len(m.items) == 0 {
+			return m, nil
+		}
+		cur := m.items[m.cursor]
+		if contains(m.selected, cur) {
+			for i, v := range m.selected {
+				if v.ID == cur.ID {
+					m.selected = append(m.selected[:i], m.selected[i+1:]...)
+					break
 				}
-			} else if len(m.selected) < 2 {
-				m.selected = append(m.selected, m.items[m.cursor])
 			}
-		case "enter":
-			if len(m.selected) == 2 {
-				return m, tea.Quit
+			if m.base != nil && m.base.ID == cur.ID {
+				m.base = nil
 			}
+		} else {
+			m.selected = append(m.selected, cur)
+		}
+	case "enter":
+		if len(m.selected) >= 2 {
+			return m, tea.Quit
 		}
 	}
 	return m, nil
 }
 
+// moveCursor steps the cursor by delta positions within m.filtered,
+// clamping at either end.
+func (m *model) moveCursor(delta int) {
+	if len(m.filtered) == 0 {
+		return
+	}
+	pos := indexOf(m.filtered, m.cursor)
+	if pos == -1 {
+		m.cursor = m.filtered[0]
+		return
+	}
+	pos += delta
+	if pos < 0 {
+		pos = 0
+	}
+	if pos > len(m.filtered)-1 {
+		pos = len(m.filtered) - 1
+	}
+	m.cursor = m.filtered[pos]
+}
+
+// recomputeFilter re-derives m.filtered from m.filter, moving the cursor
+// onto the first visible item if it fell outside the new result set.
+func (m *model) recomputeFilter() {
+	m.filtered = visibleIndices(m.items, m.filter)
+	if indexOf(m.filtered, m.cursor) == -1 && len(m.filtered) > 0 {
+		m.cursor = m.filtered[0]
+	}
+}
+
 func (m model) View() string {
-	s := "Select two state versions:\n\n"
-	for i, sv := range m.items {
+	mode := "timeline"
+	if m.base != nil {
+		mode = "pinned"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Select state versions to diff (%d selected, mode=%s):\n\n", len(m.selected), mode)
+
+	for _, i := range m.filtered {
+		sv := m.items[i]
 		cursor := " "
 		if m.cursor == i {
 			cursor = ">"
@@ -72,10 +225,21 @@ func (m model) View() string {
 		if contains(m.selected, sv) {
 			mark = "x"
 		}
+		pin := " "
+		if m.base != nil && m.base.ID == sv.ID {
+			pin = "*"
+		}
+		fmt.Fprintf(&b, "%s [%s%s] %s %4d %s\n", cursor, mark, pin, sv.ID, sv.Serial, sv.CreatedAt.Format("2006-01-02T15:04:05Z"))
+	}
 
-		s += fmt.Sprintf("%s [%s] %s %4d %s\n", cursor, mark, sv.ID, sv.Serial, sv.CreatedAt.Format("2006-01-02T15:04:05Z"))
+	if m.filtering {
+		fmt.Fprintf(&b, "\n/%s\n", m.filter)
+	} else if m.filter != "" {
+		fmt.Fprintf(&b, "\nfilter: %s (/ to edit, esc to clear)\n", m.filter)
 	}
-	return s + "\nSPACE: toggle, ENTER: go, Q/ESCAPE: quit\n"
+
+	b.WriteString("\nSPACE: toggle, P: pin base, A: select all visible, /: filter, g/G: top/bottom, ENTER: go, Q/ESC: quit\n")
+	return b.String()
 }
 
 func contains(versions []*tfe.StateVersion, version *tfe.StateVersion) bool {
@@ -86,3 +250,39 @@ func contains(versions []*tfe.StateVersion, version *tfe.StateVersion) bool {
 	}
 	return false
 }
+
+// visibleIndices returns the indices of items whose ID, serial, or created
+// date contain filter (case-insensitive); an empty filter matches everything.
+func visibleIndices(items []*tfe.StateVersion, filter string) []int {
+	needle := strings.ToLower(filter)
+	idx := make([]int, 0, len(items))
+	for i, sv := range items {
+		if needle == "" || matchesFilter(sv, needle) {
+			idx = append(idx, i)
+		}
+	}
+	return idx
+}
+
+func matchesFilter(sv *tfe.StateVersion, needle string) bool {
+	haystacks := []string{
+		sv.ID,
+		strconv.FormatInt(sv.Serial, 10),
+		sv.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+	for _, h := range haystacks {
+		if strings.Contains(strings.ToLower(h), needle) {
+			return true
+		}
+	}
+	return false
+}
+
+func indexOf(s []int, v int) int {
+	for i, x := range s {
+		if x == v {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,204 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package disco implements Terraform's remote service discovery protocol:
+// https://developer.hashicorp.com/terraform/internals/remote-service-discovery.
+// A GET to https://<host>/.well-known/terraform.json returns a flat JSON
+// object mapping service IDs (e.g. "tfe.v2.2", "state.v2", "modules.v1") to
+// the URLs that implement them, each relative to the discovery document's
+// own (possibly redirected) URL unless already absolute.
+//
+// The documented protocol has no "version"/"constraints" field on a service
+// entry -- that belongs to the separate module/provider registry protocol,
+// not this one -- so Document only ever resolves URLs.
+//
+// One entry, "login.v1", is an object rather than a URL string (it
+// describes the OAuth2 login protocol's client ID, endpoints, and allowed
+// callback ports), so it's decoded separately into LoginV1 and surfaced via
+// Login instead of Document.
+package disco
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// DefaultTTL is how long a host's discovery document is cached before
+// Discover re-fetches it.
+const DefaultTTL = 5 * time.Minute
+
+// Document is a discovered service ID -> absolute URL map.
+type Document map[string]string
+
+// Service returns the URL for the first of ids found in d, trying each in
+// order. Callers pass minor-version-specific IDs first (e.g. "tfe.v2.2",
+// "tfe.v2.1", "tfe.v2") so a server advertising a narrower service still
+// resolves.
+func (d Document) Service(ids ...string) (string, bool) {
+	for _, id := range ids {
+		if u, ok := d[id]; ok && u != "" {
+			return u, true
+		}
+	}
+	return "", false
+}
+
+// LoginV1 describes Terraform's OAuth2 login protocol, published as the
+// "login.v1" discovery entry. Unlike every other service entry (a bare URL
+// string), login.v1 is an object, so it can't be represented in Document
+// and is surfaced separately via Login.
+type LoginV1 struct {
+	Client     string   `json:"client"`
+	GrantTypes []string `json:"grant_types"`
+	Authz      string   `json:"authz"`
+	Token      string   `json:"token"`
+	Ports      []int    `json:"ports"`
+	Scopes     []string `json:"scopes"`
+}
+
+type cacheEntry struct {
+	doc        Document
+	found      bool
+	login      LoginV1
+	loginFound bool
+	fetched    time.Time
+}
+
+var (
+	mu    sync.Mutex
+	cache = map[string]cacheEntry{}
+)
+
+// Discover fetches and caches host's discovery document. found is false
+// (with a nil error) when the host has no discovery document at all
+// (.well-known/terraform.json returned 404) -- callers should fall back to
+// their own hard-coded defaults in that case, not treat it as an error. A
+// non-404 HTTP error or a malformed document is returned as err.
+func Discover(ctx context.Context, client *http.Client, host string) (Document, bool, error) {
+	mu.Lock()
+	if entry, ok := cache[host]; ok && time.Since(entry.fetched) < DefaultTTL {
+		mu.Unlock()
+		return entry.doc, entry.found, nil
+	}
+	mu.Unlock()
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://"+host+"/.well-known/terraform.json", nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to build discovery request for %s: %w", host, err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to fetch discovery document for %s: %w", host, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		mu.Lock()
+		cache[host] = cacheEntry{fetched: time.Now(), found: false}
+		mu.Unlock()
+		return nil, false, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, false, fmt.Errorf("discovery request for %s returned %s", host, resp.Status)
+	}
+
+	var raw map[string]any
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, false, fmt.Errorf("failed to parse discovery document for %s: %w", host, err)
+	}
+
+	// Resolve against the document's own (possibly redirected) URL, so a host
+	// that redirects its discovery document elsewhere -- as api.terraform.io
+	// does -- still yields correct absolute service URLs.
+	base := resp.Request.URL
+
+	doc := make(Document, len(raw))
+	var login LoginV1
+	var loginFound bool
+	for id, v := range raw {
+		if id == "login.v1" {
+			if obj, ok := v.(map[string]any); ok {
+				if l, err := decodeLoginV1(obj, base); err == nil {
+					login, loginFound = l, true
+				}
+			}
+			continue
+		}
+
+		s, ok := v.(string)
+		if !ok {
+			continue
+		}
+		u, err := url.Parse(s)
+		if err != nil {
+			continue
+		}
+		doc[id] = base.ResolveReference(u).String()
+	}
+
+	mu.Lock()
+	cache[host] = cacheEntry{doc: doc, found: true, login: login, loginFound: loginFound, fetched: time.Now()}
+	mu.Unlock()
+
+	return doc, true, nil
+}
+
+// decodeLoginV1 decodes the "login.v1" discovery object, resolving its
+// authz/token paths against base the same way Service's URLs are resolved.
+func decodeLoginV1(obj map[string]any, base *url.URL) (LoginV1, error) {
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		return LoginV1{}, err
+	}
+
+	var l LoginV1
+	if err := json.Unmarshal(raw, &l); err != nil {
+		return LoginV1{}, err
+	}
+
+	if l.Authz != "" {
+		if u, err := url.Parse(l.Authz); err == nil {
+			l.Authz = base.ResolveReference(u).String()
+		}
+	}
+	if l.Token != "" {
+		if u, err := url.Parse(l.Token); err == nil {
+			l.Token = base.ResolveReference(u).String()
+		}
+	}
+
+	return l, nil
+}
+
+// Login returns the login.v1 discovery entry cached for host by a prior
+// Discover call, if the host published one. Unlike Document.Service, it
+// doesn't trigger a fetch -- callers should call Discover first (as they
+// normally would anyway, to resolve the API root).
+func Login(host string) (LoginV1, bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	entry, ok := cache[host]
+	if !ok {
+		return LoginV1{}, false
+	}
+	return entry.login, entry.loginFound
+}
+
+// Purge clears the in-process discovery cache. Tests that spin up multiple
+// httptest.Server instances (which can reuse host:port strings across runs)
+// should call this between them to avoid a stale cache hit.
+func Purge() {
+	mu.Lock()
+	cache = map[string]cacheEntry{}
+	mu.Unlock()
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package disco
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDiscoverHappyPath(t *testing.T) {
+	Purge()
+
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/.well-known/terraform.json" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"tfe.v2.2": "/api/v2/",
+			"state.v2": "https://other.example.com/api/state/v2/"
+		}`))
+	}))
+	defer srv.Close()
+
+	host := srv.Listener.Addr().String()
+
+	doc, found, err := Discover(context.Background(), srv.Client(), host)
+	if err != nil {
+		t.Fatalf("Discover returned error: %v", err)
+	}
+	if !found {
+		t.Fatal("Discover reported not found for a host that serves a discovery document")
+	}
+
+	if u, ok := doc.Service("tfe.v2.2", "tfe.v2.1", "tfe.v2"); !ok || u != srv.URL+"/api/v2/" {
+		t.Errorf("doc.Service(tfe.v2.2) = %q, %v; want %q, true", u, ok, srv.URL+"/api/v2/")
+	}
+
+	if u, ok := doc.Service("state.v2"); !ok || u != "https://other.example.com/api/state/v2/" {
+		t.Errorf("doc.Service(state.v2) = %q, %v; want the absolute URL unchanged", u, ok)
+	}
+
+	if _, ok := doc.Service("modules.v1"); ok {
+		t.Error("doc.Service(modules.v1) found an entry that wasn't in the document")
+	}
+}
+
+func TestDiscoverLoginV1(t *testing.T) {
+	Purge()
+
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/.well-known/terraform.json" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"tfe.v2.2": "/api/v2/",
+			"login.v1": {
+				"client": "tfctl",
+				"grant_types": ["authz_code"],
+				"authz": "/app/oauth/authorize",
+				"token": "/oauth/token",
+				"ports": [10000, 10010],
+				"scopes": ["app"]
+			}
+		}`))
+	}))
+	defer srv.Close()
+
+	host := srv.Listener.Addr().String()
+
+	doc, found, err := Discover(context.Background(), srv.Client(), host)
+	if err != nil {
+		t.Fatalf("Discover returned error: %v", err)
+	}
+	if !found {
+		t.Fatal("Discover reported not found for a host that serves a discovery document")
+	}
+	if _, ok := doc["login.v1"]; ok {
+		t.Error("login.v1 leaked into Document as a plain string entry")
+	}
+
+	login, ok := Login(host)
+	if !ok {
+		t.Fatal("Login reported not found for a host that published login.v1")
+	}
+	if login.Client != "tfctl" {
+		t.Errorf("login.Client = %q, want %q", login.Client, "tfctl")
+	}
+	if login.Authz != srv.URL+"/app/oauth/authorize" {
+		t.Errorf("login.Authz = %q, want %q", login.Authz, srv.URL+"/app/oauth/authorize")
+	}
+	if login.Token != srv.URL+"/oauth/token" {
+		t.Errorf("login.Token = %q, want %q", login.Token, srv.URL+"/oauth/token")
+	}
+	if len(login.Ports) != 2 || login.Ports[0] != 10000 {
+		t.Errorf("login.Ports = %v, want [10000 10010]", login.Ports)
+	}
+}
+
+func TestDiscoverNotFoundFallsBack(t *testing.T) {
+	Purge()
+
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	host := srv.Listener.Addr().String()
+
+	doc, found, err := Discover(context.Background(), srv.Client(), host)
+	if err != nil {
+		t.Fatalf("Discover returned error on 404: %v", err)
+	}
+	if found {
+		t.Error("Discover reported found for a host that 404s its discovery document")
+	}
+	if doc != nil {
+		t.Errorf("Discover returned a non-nil document on 404: %v", doc)
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package aws registers drift.Scanner implementations for aws_instance and
+// aws_s3_bucket, the first cloud provider drift detection supports. GCP and
+// Azure scanners can plug in the same way: implement drift.Scanner and
+// drift.Register a Factory for each resource type from an init().
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	ec2v2 "github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2v2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	s3v2 "github.com/aws/aws-sdk-go-v2/service/s3"
+
+	tfctlaws "github.com/tfctl/tfctl/internal/aws"
+	"github.com/tfctl/tfctl/internal/drift"
+)
+
+func init() {
+	drift.Register("aws_instance", func() drift.Scanner { return &instanceScanner{} })
+	drift.Register("aws_s3_bucket", func() drift.Scanner { return &bucketScanner{} })
+}
+
+// instanceScanner implements drift.Scanner for aws_instance. It resolves
+// credentials the same way internal/aws already does for the S3 backend --
+// env, shared config/credentials files, IMDS, SSO -- matching the Terraform
+// AWS provider's own precedence.
+type instanceScanner struct{}
+
+func (s *instanceScanner) client(ctx context.Context) (*ec2v2.Client, error) {
+	cfg, err := tfctlaws.LoadAWSConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	return ec2v2.NewFromConfig(cfg), nil
+}
+
+// Enumerate lists every non-terminated EC2 instance in the account/region,
+// regardless of whether Terraform manages it.
+func (s *instanceScanner) Enumerate(ctx context.Context, resourceType string) ([]drift.RemoteResource, error) {
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []drift.RemoteResource
+	paginator := ec2v2.NewDescribeInstancesPaginator(client, &ec2v2.DescribeInstancesInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe instances: %w", err)
+		}
+		for _, res := range page.Reservations {
+			for _, inst := range res.Instances {
+				if inst.State != nil && inst.State.Name == ec2v2types.InstanceStateNameTerminated {
+					continue
+				}
+				out = append(out, drift.RemoteResource{ID: derefString(inst.InstanceId), Attributes: instanceAttributes(inst)})
+			}
+		}
+	}
+
+	return out, nil
+}
+
+// Read fetches one instance's current attributes by ID.
+func (s *instanceScanner) Read(ctx context.Context, id string) (map[string]any, error) {
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := client.DescribeInstances(ctx, &ec2v2.DescribeInstancesInput{InstanceIds: []string{id}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe instance %s: %w", id, err)
+	}
+	for _, res := range out.Reservations {
+		for _, inst := range res.Instances {
+			return instanceAttributes(inst), nil
+		}
+	}
+
+	return nil, fmt.Errorf("instance %s not found", id)
+}
+
+// instanceAttributes projects the subset of EC2 instance fields that have a
+// direct counterpart in the aws_instance state schema.
+func instanceAttributes(inst ec2v2types.Instance) map[string]any {
+	attrs := map[string]any{
+		"id":            derefString(inst.InstanceId),
+		"instance_type": string(inst.InstanceType),
+		"ami":           derefString(inst.ImageId),
+	}
+	if inst.State != nil {
+		attrs["instance_state"] = string(inst.State.Name)
+	}
+	if inst.SubnetId != nil {
+		attrs["subnet_id"] = derefString(inst.SubnetId)
+	}
+	if inst.PrivateIpAddress != nil {
+		attrs["private_ip"] = derefString(inst.PrivateIpAddress)
+	}
+	if inst.PublicIpAddress != nil {
+		attrs["public_ip"] = derefString(inst.PublicIpAddress)
+	}
+
+	var tags map[string]any
+	for _, t := range inst.Tags {
+		if tags == nil {
+			tags = map[string]any{}
+		}
+		tags[derefString(t.Key)] = derefString(t.Value)
+	}
+	if tags != nil {
+		attrs["tags"] = tags
+	}
+
+	return attrs
+}
+
+// bucketScanner implements drift.Scanner for aws_s3_bucket.
+type bucketScanner struct{}
+
+func (s *bucketScanner) client(ctx context.Context) (*s3v2.Client, error) {
+	cfg, err := tfctlaws.LoadAWSConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	return tfctlaws.NewS3(cfg), nil
+}
+
+// Enumerate lists every S3 bucket visible to the caller's credentials.
+func (s *bucketScanner) Enumerate(ctx context.Context, resourceType string) ([]drift.RemoteResource, error) {
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := client.ListBuckets(ctx, &s3v2.ListBucketsInput{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list buckets: %w", err)
+	}
+
+	var remotes []drift.RemoteResource
+	for _, b := range out.Buckets {
+		name := derefString(b.Name)
+		remotes = append(remotes, drift.RemoteResource{ID: name, Attributes: map[string]any{"id": name, "bucket": name}})
+	}
+
+	return remotes, nil
+}
+
+// Read confirms the bucket still exists and fetches its tags, best-effort --
+// GetBucketTagging errors (e.g. no tag set) are treated as "no tags" rather
+// than a hard failure.
+func (s *bucketScanner) Read(ctx context.Context, id string) (map[string]any, error) {
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := client.HeadBucket(ctx, &s3v2.HeadBucketInput{Bucket: &id}); err != nil {
+		return nil, fmt.Errorf("failed to head bucket %s: %w", id, err)
+	}
+
+	attrs := map[string]any{"id": id, "bucket": id}
+
+	if tagging, err := client.GetBucketTagging(ctx, &s3v2.GetBucketTaggingInput{Bucket: &id}); err == nil {
+		tags := map[string]any{}
+		for _, t := range tagging.TagSet {
+			tags[derefString(t.Key)] = derefString(t.Value)
+		}
+		if len(tags) > 0 {
+			attrs["tags"] = tags
+		}
+	}
+
+	return attrs, nil
+}
+
+func derefString(p *string) string {
+	if p == nil {
+		return ""
+	}
+	return *p
+}
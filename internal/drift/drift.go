@@ -0,0 +1,62 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package drift compares a loaded Terraform/OpenTofu state document against
+// the live cloud resources it's supposed to describe, reporting resources
+// that are managed but missing remotely, present remotely but unmanaged, or
+// present on both sides with attributes that no longer agree -- the same
+// shape driftctl produces, wired into this repo's existing state loading.
+//
+// Cloud providers plug in by registering a Scanner per resource type (see
+// Register/Lookup); internal/drift/aws is the first such provider.
+package drift
+
+import "context"
+
+// RemoteResource is one resource as enumerated directly from the cloud
+// provider, independent of whether it appears in state.
+type RemoteResource struct {
+	ID         string
+	Attributes map[string]any
+}
+
+// Scanner is implemented by a cloud provider package for one Terraform
+// resource type. Enumerate lists every live instance of that type regardless
+// of whether Terraform manages it, so Scan can detect unmanaged resources;
+// Read fetches one instance's current attributes by ID, so Scan can detect
+// attribute drift on resources it already knows about from state.
+type Scanner interface {
+	Enumerate(ctx context.Context, resourceType string) ([]RemoteResource, error)
+	Read(ctx context.Context, id string) (map[string]any, error)
+}
+
+// Factory constructs a Scanner for one resource type, mirroring the
+// constructor-function option pattern backend.Factory already uses.
+type Factory func() Scanner
+
+// registry maps a Terraform resource type (e.g. "aws_instance") to the
+// Factory that constructs its Scanner. Provider packages populate this from
+// their own init(), analogous to internal/backend's per-type registration.
+var registry = map[string]Factory{}
+
+// Register adds a Factory for the given Terraform resource type. Intended to
+// be called from a provider package's init().
+func Register(resourceType string, f Factory) {
+	registry[resourceType] = f
+}
+
+// Lookup returns the Factory registered for resourceType, if any.
+func Lookup(resourceType string) (Factory, bool) {
+	f, ok := registry[resourceType]
+	return f, ok
+}
+
+// SupportedTypes returns the resource types with a registered Scanner, for
+// --only/--ignore validation and help text.
+func SupportedTypes() []string {
+	types := make([]string, 0, len(registry))
+	for t := range registry {
+		types = append(types, t)
+	}
+	return types
+}
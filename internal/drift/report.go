@@ -0,0 +1,117 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package drift
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// ChangeKind describes how a resource address differs between state and the
+// live cloud provider.
+type ChangeKind string
+
+const (
+	// Missing means the resource is in state but no longer exists remotely.
+	Missing ChangeKind = "missing"
+	// Unmanaged means the resource exists remotely but isn't in state.
+	Unmanaged ChangeKind = "unmanaged"
+	// Modified means the resource exists on both sides but one or more
+	// attributes disagree.
+	Modified ChangeKind = "modified"
+)
+
+// AttributeDrift describes a single attribute whose state value no longer
+// matches the live value.
+type AttributeDrift struct {
+	Attribute string `json:"attribute"`
+	State     any    `json:"state,omitempty"`
+	Remote    any    `json:"remote,omitempty"`
+}
+
+// Change is one drifted resource, keyed by its Terraform address (for
+// Missing/Modified) or its cloud-native ID (for Unmanaged, which has no
+// address since Terraform never saw it).
+type Change struct {
+	Address string           `json:"address,omitempty"`
+	ID      string           `json:"id,omitempty"`
+	Type    string           `json:"type"`
+	Kind    ChangeKind       `json:"kind"`
+	Drift   []AttributeDrift `json:"drift,omitempty"`
+}
+
+// Report is the full result of a Scan: one Change per resource that's
+// missing, unmanaged, or modified. Resources that match are omitted.
+type Report struct {
+	Changes []Change `json:"changes"`
+}
+
+// Formats is the set of values accepted by the --format flag on commands
+// that render a Report, in the order they should be listed in help text.
+var Formats = []string{"unified", "json"}
+
+// Render writes report to w in the requested format ("unified" or "json");
+// an empty format defaults to "unified".
+func Render(w io.Writer, format string, report *Report) error {
+	switch format {
+	case "", "unified":
+		return renderUnified(w, report)
+	case "json":
+		return renderJSON(w, report)
+	default:
+		return fmt.Errorf("unknown drift format %q, must be one of %v", format, Formats)
+	}
+}
+
+// renderUnified prints a +/-/~ summary per changed resource, mirroring
+// internal/diff's unified output.
+func renderUnified(w io.Writer, report *Report) error {
+	if len(report.Changes) == 0 {
+		fmt.Fprintln(w, "No drift detected.")
+		return nil
+	}
+
+	for _, c := range report.Changes {
+		switch c.Kind {
+		case Missing:
+			fmt.Fprintf(w, "+ %s (managed but missing remotely)\n", c.Address)
+		case Unmanaged:
+			fmt.Fprintf(w, "- %s %s (unmanaged in cloud)\n", c.Type, c.ID)
+		case Modified:
+			fmt.Fprintf(w, "~ %s\n", c.Address)
+			for _, d := range c.Drift {
+				fmt.Fprintf(w, "  ~ attribute drift: %s: state=%v remote=%v\n", d.Attribute, d.State, d.Remote)
+			}
+		}
+	}
+
+	return nil
+}
+
+// renderJSON writes report as indented JSON.
+func renderJSON(w io.Writer, report *Report) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		return fmt.Errorf("failed to render drift report as json: %w", err)
+	}
+	return nil
+}
+
+// sortChanges orders a Report's Changes by address (falling back to ID for
+// Unmanaged entries, which have no address) so output is stable run to run.
+func sortChanges(changes []Change) {
+	sort.Slice(changes, func(i, j int) bool {
+		return changeKey(changes[i]) < changeKey(changes[j])
+	})
+}
+
+func changeKey(c Change) string {
+	if c.Address != "" {
+		return c.Address
+	}
+	return c.Type + "." + c.ID
+}
@@ -0,0 +1,214 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package drift
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// instance is the subset of a state resource's instance shape Scan needs.
+type instance struct {
+	IndexKey   any            `json:"index_key,omitempty"`
+	Attributes map[string]any `json:"attributes"`
+}
+
+// resource is the subset of a state document's "resources[]" shape Scan
+// needs; mirrors the fields internal/diff.resource already relies on.
+type resource struct {
+	Module    string     `json:"module"`
+	Mode      string     `json:"mode"`
+	Type      string     `json:"type"`
+	Name      string     `json:"name"`
+	Instances []instance `json:"instances"`
+}
+
+type stateDoc struct {
+	Resources []resource `json:"resources"`
+}
+
+// ScanOptions controls which resources a Scan considers.
+type ScanOptions struct {
+	// Only, if non-empty, restricts scanning to these resource types.
+	Only []string
+	// Ignore excludes these resource types, applied after Only.
+	Ignore []string
+	// Address, if set, restricts scanning to the resource whose address
+	// equals or starts with this value (so "aws_instance.web" also matches
+	// "aws_instance.web[0]"). Unmanaged-resource detection is skipped when
+	// Address is set, since it has no address to match against.
+	Address string
+}
+
+// Scan compares every managed resource in stateData against its live cloud
+// state, one registered Scanner per resource type, and returns a Report of
+// what's missing, unmanaged, or modified. Resource types with no registered
+// Scanner are skipped silently -- drift detection is opt-in per provider.
+func Scan(ctx context.Context, stateData map[string]interface{}, opts ScanOptions) (*Report, error) {
+	raw, err := json.Marshal(stateData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal state: %w", err)
+	}
+
+	var doc stateDoc
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse state: %w", err)
+	}
+
+	report := &Report{}
+	scanners := map[string]Scanner{}
+	remoteByType := map[string]map[string]RemoteResource{}
+
+	for _, r := range doc.Resources {
+		if r.Mode != "managed" || !includeType(r.Type, opts) {
+			continue
+		}
+
+		scanner, ok := scanners[r.Type]
+		if !ok {
+			factory, registered := Lookup(r.Type)
+			if !registered {
+				continue
+			}
+
+			scanner = factory()
+			scanners[r.Type] = scanner
+
+			remotes, err := scanner.Enumerate(ctx, r.Type)
+			if err != nil {
+				return nil, fmt.Errorf("failed to enumerate %s: %w", r.Type, err)
+			}
+			byID := make(map[string]RemoteResource, len(remotes))
+			for _, rr := range remotes {
+				byID[rr.ID] = rr
+			}
+			remoteByType[r.Type] = byID
+		}
+
+		for _, inst := range r.Instances {
+			addr := resourceAddress(r, inst)
+			if opts.Address != "" && !addressMatches(addr, opts.Address) {
+				continue
+			}
+
+			id, _ := inst.Attributes["id"].(string)
+			remotes := remoteByType[r.Type]
+			if _, ok := remotes[id]; !ok {
+				report.Changes = append(report.Changes, Change{Address: addr, Type: r.Type, Kind: Missing})
+				continue
+			}
+			// Claim the remote ID so the unmanaged pass below doesn't also
+			// report it as unmanaged.
+			delete(remotes, id)
+
+			live, err := scanner.Read(ctx, id)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %s %s: %w", r.Type, id, err)
+			}
+			if drift := diffAttributes(inst.Attributes, live); len(drift) > 0 {
+				report.Changes = append(report.Changes, Change{Address: addr, Type: r.Type, Kind: Modified, Drift: drift})
+			}
+		}
+	}
+
+	if opts.Address == "" {
+		for typ, remotes := range remoteByType {
+			for id := range remotes {
+				report.Changes = append(report.Changes, Change{ID: id, Type: typ, Kind: Unmanaged})
+			}
+		}
+	}
+
+	sortChanges(report.Changes)
+	return report, nil
+}
+
+// includeType reports whether resourceType passes opts' Only/Ignore filters.
+func includeType(resourceType string, opts ScanOptions) bool {
+	if len(opts.Only) > 0 && !contains(opts.Only, resourceType) {
+		return false
+	}
+	return !contains(opts.Ignore, resourceType)
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// addressMatches reports whether addr is pattern or one of its indexed
+// instances (pattern "aws_instance.web" matches "aws_instance.web[0]").
+func addressMatches(addr, pattern string) bool {
+	return addr == pattern || strings.HasPrefix(addr, pattern+"[")
+}
+
+// resourceAddress builds a Terraform address for a resource instance in the
+// "module.foo.data.type.name[index]" shape.
+func resourceAddress(r resource, inst instance) string {
+	var parts []string
+
+	if r.Module != "" {
+		parts = append(parts, r.Module)
+	}
+	if r.Mode == "data" {
+		parts = append(parts, "data")
+	}
+
+	name := r.Name
+	switch v := inst.IndexKey.(type) {
+	case float64:
+		name += fmt.Sprintf("[%d]", int(v))
+	case string:
+		name += fmt.Sprintf("[%q]", v)
+	}
+
+	parts = append(parts, r.Type+"."+name)
+
+	return strings.Join(parts, ".")
+}
+
+// diffAttributes compares a state instance's attributes against the live
+// attributes Scanner.Read returned, following the same canonical-JSON
+// equality internal/diff.valuesEqual uses to sidestep map/slice key-order
+// and numeric-type noise.
+func diffAttributes(state, remote map[string]any) []AttributeDrift {
+	keys := make(map[string]bool, len(state)+len(remote))
+	for k := range state {
+		keys[k] = true
+	}
+	for k := range remote {
+		keys[k] = true
+	}
+
+	var drift []AttributeDrift
+	for key := range keys {
+		stateVal := state[key]
+		remoteVal := remote[key]
+		if valuesEqual(stateVal, remoteVal) {
+			continue
+		}
+		drift = append(drift, AttributeDrift{Attribute: key, State: stateVal, Remote: remoteVal})
+	}
+
+	sort.Slice(drift, func(i, j int) bool { return drift[i].Attribute < drift[j].Attribute })
+	return drift
+}
+
+// valuesEqual compares two attribute values via their canonical JSON
+// encoding, mirroring internal/diff.valuesEqual.
+func valuesEqual(a, b any) bool {
+	aj, aerr := json.Marshal(a)
+	bj, berr := json.Marshal(b)
+	if aerr != nil || berr != nil {
+		return false
+	}
+	return string(aj) == string(bj)
+}
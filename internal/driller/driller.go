@@ -4,6 +4,7 @@
 package driller
 
 import (
+	"encoding/json"
 	"regexp"
 	"strconv"
 	"strings"
@@ -11,48 +12,71 @@ import (
 	"github.com/tidwall/gjson"
 )
 
-// Driller navigates JSON using a flexible dot path supporting arrays
-func Driller(jsonData string, path string) gjson.Result {
-	parts := strings.Split(path, ".")
-	current := gjson.Parse(jsonData)
+// segmentRe matches one dot-path segment: a bare key, optionally followed by
+// a bracket. The bracket body can be empty (dump-the-array shorthand), a
+// numeric index, "*" (wildcard fan-out), or a "key=value" filter predicate.
+var segmentRe = regexp.MustCompile(`^([a-zA-Z0-9_-]+)(\[([^\]]*)\])?$`)
 
-	re := regexp.MustCompile(`^([a-zA-Z0-9_-]+)(\[(\d|\*)?\])?$`)
+// Driller navigates JSON using a flexible dot path supporting arrays,
+// wildcard fan-out ("[*]"), and "[key=value]" filter predicates, e.g.
+// "resources[type=aws_s3_bucket].instances[*].attributes.arn".
+func Driller(jsonData string, path string) gjson.Result {
+	return drill(gjson.Parse(jsonData), strings.Split(path, "."))
+}
 
-	for _, p := range parts {
-		matches := re.FindStringSubmatch(p)
-		if len(matches) == 0 {
+// drill applies parts in order against current. A "[*]" segment hands the
+// remaining parts off to drillEach, which evaluates them independently
+// against every element of the array found at that point in the path.
+func drill(current gjson.Result, parts []string) gjson.Result {
+	for i, p := range parts {
+		matches := segmentRe.FindStringSubmatch(p)
+		if matches == nil {
 			return gjson.Result{} // Invalid path segment
 		}
 
 		key := matches[1]
+		hasBracket := matches[2] != ""
+		bracket := matches[3]
 
-		// matches[2] is the [], which we can throw away.
+		val := current.Get(key)
 
-		index := -1
-		if matches[3] != "" {
-			// Array index specified
-			i, err := strconv.Atoi(matches[3])
-			if err != nil {
+		switch {
+		case !hasBracket || bracket == "":
+			// No index specified: default to the lone element of a
+			// single-element array, otherwise leave the whole array for the
+			// next segment (or the caller) to deal with.
+			if val.IsArray() {
+				if arr := val.Array(); len(arr) == 1 {
+					val = arr[0]
+				}
+			}
+
+		case bracket == "*":
+			if !val.IsArray() {
 				return gjson.Result{}
 			}
-			index = i
-		}
+			return drillEach(val.Array(), parts[i+1:])
 
-		val := current.Get(key)
-		if val.IsArray() {
-			// If index is specified, use it; otherwise default to [0]
+		case isFilterPredicate(bracket):
+			if !val.IsArray() {
+				return gjson.Result{}
+			}
+			match, ok := filterOne(val.Array(), bracket)
+			if !ok {
+				return gjson.Result{}
+			}
+			val = match
+
+		default:
+			idx, err := strconv.Atoi(bracket)
+			if err != nil || !val.IsArray() {
+				return gjson.Result{}
+			}
 			arr := val.Array()
-			switch {
-			case index == -1:
-				if len(arr) == 1 {
-					val = arr[0]
-				}
-				// Otherwise do nothing. We'll dump the whole list.
-			case index >= 0 && index < len(arr):
-				val = arr[index]
-			default:
+			if idx < 0 || idx >= len(arr) {
 				return gjson.Result{}
 			}
+			val = arr[idx]
 		}
 
 		current = val
@@ -60,3 +84,44 @@ func Driller(jsonData string, path string) gjson.Result {
 
 	return current
 }
+
+// drillEach applies restParts to every item independently and collects the
+// existing results into a single array Result, re-marshaling the matched
+// raw JSON values and reparsing them with gjson.Parse.
+func drillEach(items []gjson.Result, restParts []string) gjson.Result {
+	raws := make([]json.RawMessage, 0, len(items))
+	for _, item := range items {
+		next := item
+		if len(restParts) > 0 {
+			next = drill(item, restParts)
+		}
+		if !next.Exists() {
+			continue
+		}
+		raws = append(raws, json.RawMessage(next.Raw))
+	}
+
+	out, err := json.Marshal(raws)
+	if err != nil {
+		return gjson.Result{}
+	}
+
+	return gjson.Parse(string(out))
+}
+
+// isFilterPredicate reports whether a bracket body is a "key=value" filter
+// rather than a plain numeric index.
+func isFilterPredicate(bracket string) bool {
+	return strings.Contains(bracket, "=")
+}
+
+// filterOne returns the first item whose key matches value in "key=value".
+func filterOne(items []gjson.Result, bracket string) (gjson.Result, bool) {
+	key, want, _ := strings.Cut(bracket, "=")
+	for _, item := range items {
+		if item.Get(key).String() == want {
+			return item, true
+		}
+	}
+	return gjson.Result{}, false
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package filters
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/apex/log"
+	"github.com/urfave/cli/v3"
+)
+
+// ServerSideAugmenterFunc mutates a *q command's list options in response to
+// a single server-side Filter. T is the command's own options type (e.g.
+// tfe.ProjectListOptions, tfe.WorkspaceListOptions).
+type ServerSideAugmenterFunc[T any] func(f Filter, opts *T) error
+
+// serverSideRegistry maps commandName -> filterKey -> ServerSideAugmenterFunc[T]
+// (stored as any since a single map cannot hold distinct T per entry; type
+// assertions happen in ApplyServerSide, which knows T statically).
+var serverSideRegistry = map[string]map[string]any{}
+
+// RegisterAugmenter registers fn as the handler for filterKey within
+// commandName's server-side filter set. filterKey may be a literal key
+// ("name", "status") or a wildcard prefix ("tag.*") matched against any key
+// sharing that prefix. Re-registering a (commandName, filterKey) pair
+// overwrites the previous handler.
+func RegisterAugmenter[T any](commandName, filterKey string, fn ServerSideAugmenterFunc[T]) {
+	cmdRegistry, ok := serverSideRegistry[commandName]
+	if !ok {
+		cmdRegistry = make(map[string]any)
+		serverSideRegistry[commandName] = cmdRegistry
+	}
+	cmdRegistry[filterKey] = fn
+}
+
+// ApplyServerSide parses cmd's --filter flag and dispatches each server-side
+// Filter to the augmenter registered for commandName, matching on the
+// filter's key (trying the literal key first, then a "<prefix>.*" wildcard).
+// Unknown server-side keys produce a structured warning via log rather than
+// being silently dropped.
+func ApplyServerSide[T any](commandName string, cmd *cli.Command, opts *T) error {
+	cmdRegistry := serverSideRegistry[commandName]
+
+	spec := cmd.String("filter")
+	for _, f := range BuildFilters(spec) {
+		if !f.ServerSide {
+			continue
+		}
+
+		fn, ok := lookupAugmenter[T](cmdRegistry, f.Key)
+		if !ok {
+			log.Warnf("no server-side augmenter registered for %s filter key: %s", commandName, f.Key)
+			continue
+		}
+
+		if err := fn(f, opts); err != nil {
+			return fmt.Errorf("augmenting %s filter %q: %w", commandName, f.Key, err)
+		}
+	}
+
+	log.Debugf("opts after augmentation: %+v", opts)
+	return nil
+}
+
+// lookupAugmenter finds the registered handler for key, trying the literal
+// key first and then a "<prefix>.*" wildcard derived from key's first
+// dot-separated segment (e.g. "tag.env" falls back to "tag.*").
+func lookupAugmenter[T any](cmdRegistry map[string]any, key string) (ServerSideAugmenterFunc[T], bool) {
+	if cmdRegistry == nil {
+		return nil, false
+	}
+
+	if raw, ok := cmdRegistry[key]; ok {
+		if fn, ok := raw.(ServerSideAugmenterFunc[T]); ok {
+			return fn, true
+		}
+	}
+
+	if prefix, _, ok := strings.Cut(key, "."); ok {
+		if raw, ok := cmdRegistry[prefix+".*"]; ok {
+			if fn, ok := raw.(ServerSideAugmenterFunc[T]); ok {
+				return fn, true
+			}
+		}
+	}
+
+	return nil, false
+}
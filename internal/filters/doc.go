@@ -5,8 +5,7 @@
 // Package filters provides filtering capabilities for Terraform state resources.
 //
 // The package parses filter expressions to select subsets of resources based on
-// attribute values. Filters are specified as key-operator-target expressions and
-// can be combined using a configurable delimiter (default: comma).
+// attribute values. Each predicate is a key-operator-target expression:
 //
 // Operators include:
 //
@@ -16,7 +15,10 @@
 //   - < : less than (numeric comparison)
 //   - > : greater than (numeric comparison)
 //   - @ : contains substring (supports negation with !@)
-//   - / : JSON path match (supports negation with !/)
+//   - / : regex match against a marshaled non-scalar value, or (with a
+//     "<gjson-path>@<expected>" target) an equality check against a nested
+//     field reached by a gjson path, e.g. "vcs-repo/identifier@my-org"
+//     (supports negation with !/)
 //
 // Examples:
 //
@@ -26,6 +28,14 @@
 //   - "count>5" : matches resources where count is greater than 5
 //   - "name!@test" : matches resources where name does not contain "test"
 //
+// Predicates can be combined two ways. A spec with none of the AND/OR/NOT
+// keywords or parentheses is the original flat form: predicates delimited
+// by a configurable delimiter (default: comma), implicitly ANDed together.
+// A spec using that grammar is parsed as a proper boolean expression with
+// AND/OR/NOT and parens for grouping, e.g.
+// `tag.env=prod OR tag.env=stg AND NOT locked=true`. See ParseExpr and the
+// Expr/AndNode/OrNode/NotNode/PredicateNode types.
+//
 // Filter Keys and Attributes:
 //
 // Filter keys are matched against the OutputKey of attributes (see attrs package).
@@ -34,15 +44,20 @@
 //
 // Filter Parsing:
 //
-// The BuildFilters function parses a comma-delimited (or custom-delimited) filter
-// specification string. Invalid specifications (unsupported operands or malformed
-// expressions) are logged as warnings and skipped, allowing partial filter sets
-// to be processed.
+// BuildFilters parses a spec and returns the predicates that are safe to
+// evaluate as an unconditional conjunction - every predicate for the flat
+// form, or whatever subset of a boolean expression isn't guarded by an OR
+// or NOT (see ConjunctivePredicates). It's what server-side augmenters use
+// to push filters down to the API. In the flat form, invalid entries
+// (unsupported operands or malformed expressions) are logged and skipped,
+// allowing partial filter sets to be processed; a boolean expression that
+// fails to parse is logged and yields no predicates at all.
 //
 // Filter Application:
 //
-// The Apply function filters a list of candidate resources, keeping only those
-// that match all provided filter expressions. Attributes specified in the attrs
-// parameter are used to determine which fields from the resource are included
-// in the filtered result.
+// FilterDataset filters a list of candidate resources against the full
+// parsed expression (AND/OR/NOT, not just the conjunctive subset),
+// returning only those that match. Attributes specified in the attrs
+// parameter are used to determine which fields from the resource are
+// included in the filtered result.
 package filters
@@ -0,0 +1,336 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package filters
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Expr is a parsed --filter expression. Match evaluates the expression
+// against row, a flattened view of a candidate keyed by attrs.Attr.
+// OutputKey (the shape FilterDataset's result rows already take). Match has
+// no access to the full candidate document, so (unlike FilterDataset's own
+// matchExpr, used internally) it can't evaluate virtual keys such as
+// "tainted" - those require the whole gjson.Result.
+type Expr interface {
+	Match(row map[string]interface{}) bool
+}
+
+// AndNode matches when both Left and Right match.
+type AndNode struct {
+	Left, Right Expr
+}
+
+// Match implements Expr.
+func (n *AndNode) Match(row map[string]interface{}) bool {
+	return n.Left.Match(row) && n.Right.Match(row)
+}
+
+// OrNode matches when either Left or Right matches.
+type OrNode struct {
+	Left, Right Expr
+}
+
+// Match implements Expr.
+func (n *OrNode) Match(row map[string]interface{}) bool {
+	return n.Left.Match(row) || n.Right.Match(row)
+}
+
+// NotNode matches when Expr does not.
+type NotNode struct {
+	Expr Expr
+}
+
+// Match implements Expr.
+func (n *NotNode) Match(row map[string]interface{}) bool {
+	return !n.Expr.Match(row)
+}
+
+// PredicateNode is a leaf node wrapping a single parsed Filter.
+type PredicateNode struct {
+	Filter Filter
+}
+
+// Match implements Expr. Server-side filters are assumed already satisfied
+// by the API and always match here; virtual keys aren't evaluated (see
+// Expr's doc comment) and fall through to Filter.evaluate like any other
+// unresolvable key.
+func (n *PredicateNode) Match(row map[string]interface{}) bool {
+	if n.Filter.ServerSide {
+		return true
+	}
+	return n.Filter.evaluate(row)
+}
+
+// emptyExpr matches everything; it's what an empty --filter spec parses to.
+type emptyExpr struct{}
+
+func (emptyExpr) Match(map[string]interface{}) bool { return true }
+
+// andAll builds a left-associative AndNode chain over filters, in order.
+// An empty slice yields emptyExpr; a single filter yields a bare
+// PredicateNode with no surrounding AndNode.
+func andAll(filters []Filter) Expr {
+	if len(filters) == 0 {
+		return emptyExpr{}
+	}
+
+	expr := Expr(&PredicateNode{Filter: filters[0]})
+	for _, f := range filters[1:] {
+		expr = &AndNode{Left: expr, Right: &PredicateNode{Filter: f}}
+	}
+	return expr
+}
+
+// ConjunctivePredicates returns every predicate reachable from e without
+// crossing an OrNode or NotNode boundary - the leaves that are safe to push
+// down as an unconditional server-side AND filter. A predicate guarded by
+// OR or NOT can't be expressed as an unconditional narrowing of the result
+// set (matching it might let a row through only in combination with some
+// other branch, or might need to exclude rows instead of including them),
+// so those are left for client-side evaluation via the full Expr instead.
+func ConjunctivePredicates(e Expr) []Filter {
+	//nolint:prealloc
+	var out []Filter
+	var walk func(Expr)
+	walk = func(n Expr) {
+		switch v := n.(type) {
+		case *AndNode:
+			walk(v.Left)
+			walk(v.Right)
+		case *PredicateNode:
+			out = append(out, v.Filter)
+		}
+	}
+	walk(e)
+	return out
+}
+
+// booleanTokenRegex detects the AND/OR/NOT keywords as whole words,
+// case-insensitively.
+var booleanTokenRegex = regexp.MustCompile(`(?i)\b(AND|OR|NOT)\b`)
+
+// hasBooleanSyntax reports whether spec uses the boolean grammar (AND/OR/
+// NOT keywords or parentheses) rather than the legacy flat comma-delimited
+// form.
+func hasBooleanSyntax(spec string) bool {
+	return strings.ContainsAny(spec, "()") || booleanTokenRegex.MatchString(spec)
+}
+
+// ParseExpr parses a --filter spec into an Expr. When spec contains none of
+// the AND/OR/NOT/parens boolean grammar, it's parsed in the original flat,
+// comma- (or TFCTL_FILTER_DELIM-) delimited form and ANDed together, so
+// every existing --filter value keeps behaving exactly as it did before
+// boolean composition existed. Otherwise it's tokenized and parsed as a
+// proper recursive-descent expression, with NOT binding tighter than AND,
+// which in turn binds tighter than OR, and parens overriding both.
+func ParseExpr(spec string) (Expr, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return emptyExpr{}, nil
+	}
+
+	if !hasBooleanSyntax(spec) {
+		return andAll(legacyBuildFilters(spec)), nil
+	}
+
+	tokens, err := lexExpr(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &exprParser{tokens: tokens}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos != len(p.tokens) {
+		return nil, fmt.Errorf("filters: unexpected token %q", p.tokens[p.pos].text)
+	}
+
+	return expr, nil
+}
+
+// tokenKind identifies the lexical class of a token produced by lexExpr.
+type tokenKind int
+
+const (
+	tokPredicate tokenKind = iota
+	tokAnd
+	tokOr
+	tokNot
+	tokLParen
+	tokRParen
+)
+
+// exprToken is a single lexed unit of a boolean --filter expression.
+type exprToken struct {
+	kind tokenKind
+	text string
+}
+
+// lexExpr tokenizes spec, recognizing '(' and ')' as standalone tokens, the
+// AND/OR/NOT keywords (case-insensitive) as operators, and everything else,
+// whitespace-delimited, as a predicate token. A predicate's target may be
+// double-quoted to embed whitespace (e.g. name="my resource"); the quotes
+// are stripped before the token is handed to parseOneFilter.
+func lexExpr(spec string) ([]exprToken, error) {
+	var tokens []exprToken
+	var buf strings.Builder
+	inQuote := false
+
+	flush := func() {
+		if buf.Len() == 0 {
+			return
+		}
+		word := buf.String()
+		buf.Reset()
+		switch strings.ToUpper(word) {
+		case "AND":
+			tokens = append(tokens, exprToken{tokAnd, word})
+		case "OR":
+			tokens = append(tokens, exprToken{tokOr, word})
+		case "NOT":
+			tokens = append(tokens, exprToken{tokNot, word})
+		default:
+			tokens = append(tokens, exprToken{tokPredicate, word})
+		}
+	}
+
+	for _, r := range spec {
+		switch {
+		case r == '"':
+			inQuote = !inQuote
+		case inQuote:
+			buf.WriteRune(r)
+		case r == '(':
+			flush()
+			tokens = append(tokens, exprToken{tokLParen, "("})
+		case r == ')':
+			flush()
+			tokens = append(tokens, exprToken{tokRParen, ")"})
+		case unicode.IsSpace(r):
+			flush()
+		default:
+			buf.WriteRune(r)
+		}
+	}
+	flush()
+
+	if inQuote {
+		return nil, errors.New("filters: unterminated quoted value in expression")
+	}
+
+	return tokens, nil
+}
+
+// exprParser is a recursive-descent parser over the token stream produced
+// by lexExpr, implementing the grammar:
+//
+//	or   := and (OR and)*
+//	and  := not (AND not)*
+//	not  := NOT not | primary
+//	primary := '(' or ')' | PREDICATE
+type exprParser struct {
+	tokens []exprToken
+	pos    int
+}
+
+func (p *exprParser) peek() (exprToken, bool) {
+	if p.pos >= len(p.tokens) {
+		return exprToken{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+func (p *exprParser) parseOr() (Expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		tok, ok := p.peek()
+		if !ok || tok.kind != tokOr {
+			return left, nil
+		}
+		p.pos++
+
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &OrNode{Left: left, Right: right}
+	}
+}
+
+func (p *exprParser) parseAnd() (Expr, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		tok, ok := p.peek()
+		if !ok || tok.kind != tokAnd {
+			return left, nil
+		}
+		p.pos++
+
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &AndNode{Left: left, Right: right}
+	}
+}
+
+func (p *exprParser) parseNot() (Expr, error) {
+	if tok, ok := p.peek(); ok && tok.kind == tokNot {
+		p.pos++
+		inner, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &NotNode{Expr: inner}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *exprParser) parsePrimary() (Expr, error) {
+	tok, ok := p.peek()
+	if !ok {
+		return nil, errors.New("filters: unexpected end of expression")
+	}
+
+	switch tok.kind {
+	case tokLParen:
+		p.pos++
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		closing, ok := p.peek()
+		if !ok || closing.kind != tokRParen {
+			return nil, errors.New("filters: missing closing ')'")
+		}
+		p.pos++
+		return inner, nil
+
+	case tokPredicate:
+		p.pos++
+		f, err := parseOneFilter(tok.text)
+		if err != nil {
+			return nil, err
+		}
+		return &PredicateNode{Filter: f}, nil
+
+	default:
+		return nil, fmt.Errorf("filters: unexpected token %q", tok.text)
+	}
+}
@@ -4,6 +4,7 @@
 package filters
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"regexp"
@@ -15,42 +16,110 @@ import (
 
 	"github.com/tfctl/tfctl/internal/attrs"
 	"github.com/tfctl/tfctl/internal/driller"
-	"github.com/tfctl/tfctl/internal/hungarian"
 )
 
 // filterRegex is the pattern used to parse filter expressions into key,
 // operator, and target components. It matches an optional leading underscore
 // (indicating server-side filter), followed by a key, and optionally an
 // operator (with optional negation) and target. Operators are one of
-// = ^ ~ < > @ or /, optionally prefixed with '!'. Examples:
-// "name" (key only), "name=value" (key + operator + target),
-// "name=" (key + operator, no target), "_tags=prod" (server-side key +
-// operator + target).
-var filterRegex = regexp.MustCompile(`^(_)?([^!?=^~<>@/]*)(!?[=^~<>@/])?(.*)$`)
+// = ^ ~ < > @ / ? or the case-insensitive set-membership variant ?~,
+// optionally prefixed with '!'. Examples: "name" (key only),
+// "name=value" (key + operator + target), "name=" (key + operator, no
+// target), "_tags=prod" (server-side key + operator + target),
+// "status?applied|errored" (set-membership key + operator + value list).
+var filterRegex = regexp.MustCompile(`^(_)?([^!?=^~<>@/]*)(!?(?:\?~|[=^~<>@/?]))?(.*)$`)
 
 // Filter is a single parsed --filter expression including the key, operand,
 // optional negation, server-side flag and value to match against.
 type Filter struct {
-	Key        string `yaml:"key" json:"Key"`
-	Negate     bool   `yaml:"negate" json:"Negate"`
-	Operand    string `yaml:"operand" json:"Operand"`
-	ServerSide bool   `yaml:"serverSide" json:"ServerSide"`
-	Value      string `yaml:"value" json:"Value"`
+	Key        string   `yaml:"key" json:"Key"`
+	Negate     bool     `yaml:"negate" json:"Negate"`
+	Operand    string   `yaml:"operand" json:"Operand"`
+	ServerSide bool     `yaml:"serverSide" json:"ServerSide"`
+	Value      string   `yaml:"value" json:"Value"`
+	Values     []string `yaml:"values" json:"Values"`
 }
 
-// BuildFilters parses a filter specification string into a slice of Filter.
-// Invalid specs (unsupported operand or malformed expression) are skipped.
+// BuildFilters parses a filter specification string and returns the
+// predicates that are safe to evaluate as an unconditional conjunction
+// (AND): for a plain comma-delimited spec that's every predicate, exactly
+// as before boolean composition existed; for a spec using AND/OR/NOT/
+// parens, it's whatever subset ParseExpr's tree can prove is ANDed
+// together (see ConjunctivePredicates). Callers that only ever pushed
+// filters down as a conjunction (server-side augmenters) get boolean
+// support for free without changing their own code; callers that need full
+// AND/OR/NOT semantics should call ParseExpr and evaluate the Expr
+// directly (see FilterDataset).
 func BuildFilters(spec string) []Filter {
+	expr, err := ParseExpr(spec)
+	if err != nil {
+		log.Error("invalid filter: " + err.Error())
+		return nil
+	}
+	return ConjunctivePredicates(expr)
+}
+
+// parseOneFilter parses a single "[_]key[op]target" filter expression (one
+// comma-delimited entry, or one predicate token inside a boolean
+// expression) into a Filter.
+func parseOneFilter(filterSpec string) (Filter, error) {
+	parts := filterRegex.FindStringSubmatch(filterSpec)
+
+	// Regex should always match, so check for nil just in case.
+	if parts == nil {
+		return Filter{}, fmt.Errorf("invalid filter: %s", filterSpec)
+	}
+
+	// parts[1] is the optional leading underscore (for server-side filters)
+	// parts[2] is the key
+	// parts[3] is the optional operator (may include negation like "!")
+	// parts[4] is the optional target
+
+	serverSide := parts[1] == "_"
+	key := strings.TrimSpace(parts[2])
+	operand := parts[3]
+	target := parts[4]
+
+	// If key is empty, this filter is invalid.
+	if key == "" {
+		return Filter{}, fmt.Errorf("invalid filter: empty key in %s", filterSpec)
+	}
+
+	// Handle operator negation.
+	negate := strings.HasPrefix(operand, "!")
+	if negate {
+		operand = strings.TrimPrefix(operand, "!")
+	}
+
+	// Set-membership operands (? and ?~) carry a list of values separated
+	// by TFCTL_FILTER_LIST_DELIM (default "|") rather than a single target.
+	var values []string
+	if operand == "?" || operand == "?~" {
+		values = splitFilterList(target)
+	}
+
+	return Filter{
+		Key:        key,
+		ServerSide: serverSide,
+		Negate:     negate,
+		Operand:    operand,
+		Value:      target,
+		Values:     values,
+	}, nil
+}
+
+// legacyBuildFilters parses spec as the original comma- (or
+// TFCTL_FILTER_DELIM-) delimited flat form, with no boolean composition.
+// Invalid entries (unsupported operand or malformed expression) are logged
+// and skipped, allowing partial filter sets to be processed. This is the
+// compatibility mode ParseExpr falls back to when spec contains none of the
+// AND/OR/NOT/parens boolean grammar.
+func legacyBuildFilters(spec string) []Filter {
 	// Don't prealloc because we don't know what len will be and performance is
 	// not critical.
 	//nolint:prealloc
 	var filters []Filter
 
-	// If there are no filters specified, go home early.
-	if spec == "" {
-		return filters
-	}
-
 	// Default delimiter is ",", allow an override for situations where the value
 	// contains commas.
 	delim := ","
@@ -59,54 +128,43 @@ func BuildFilters(spec string) []Filter {
 	}
 
 	// Split the spec and iterate over each filter spec entry.
-	filterSpecs := strings.Split(spec, delim)
-	for _, filterSpec := range filterSpecs {
+	for _, filterSpec := range strings.Split(spec, delim) {
 		filterSpec = strings.TrimSpace(filterSpec)
 		if filterSpec == "" {
 			continue
 		}
 
-		parts := filterRegex.FindStringSubmatch(filterSpec)
-
-		// Regex should always match, so check for nil just in case.
-		if parts == nil {
-			log.Error("invalid filter: " + filterSpec)
+		f, err := parseOneFilter(filterSpec)
+		if err != nil {
+			log.Error(err.Error())
 			continue
 		}
 
-		// parts[1] is the optional leading underscore (for server-side filters)
-		// parts[2] is the key
-		// parts[3] is the optional operator (may include negation like "!")
-		// parts[4] is the optional target
+		filters = append(filters, f)
+	}
 
-		serverSide := parts[1] == "_"
-		key := strings.TrimSpace(parts[2])
-		operand := parts[3]
-		target := parts[4]
+	return filters
+}
 
-		// If key is empty, skip this filter.
-		if key == "" {
-			log.Error("invalid filter: empty key in " + filterSpec)
-			continue
-		}
+// splitFilterList splits a set-membership target into its individual
+// elements, using TFCTL_FILTER_LIST_DELIM (default "|") as the separator so
+// the list doesn't collide with the outer TFCTL_FILTER_DELIM. Empty elements
+// (e.g. from a trailing delimiter) are dropped.
+func splitFilterList(target string) []string {
+	listDelim := "|"
+	if d, ok := os.LookupEnv("TFCTL_FILTER_LIST_DELIM"); ok {
+		listDelim = d
+	}
 
-		// Handle operator negation.
-		negate := strings.HasPrefix(operand, "!")
-		if negate {
-			operand = strings.TrimPrefix(operand, "!")
+	//nolint:prealloc
+	var values []string
+	for _, v := range strings.Split(target, listDelim) {
+		v = strings.TrimSpace(v)
+		if v != "" {
+			values = append(values, v)
 		}
-
-		// We've got a valid filter, append it to the result set.
-		filters = append(filters, Filter{
-			Key:        key,
-			ServerSide: serverSide,
-			Negate:     negate,
-			Operand:    operand,
-			Value:      target,
-		})
 	}
-
-	return filters
+	return values
 }
 
 // FilterDataset returns a result set filtered per the provided spec. It is the
@@ -116,115 +174,119 @@ func FilterDataset(candidates gjson.Result, attrs attrs.AttrList, spec string) [
 	//nolint:prealloc // Don't prealloc because we don't know what len will be.
 	var filteredResults []map[string]interface{}
 
-	// Build a slice of filters from the spec once so we can discard invalid
-	// entries and avoid reparsing for each candidate row.
-	filters := BuildFilters(spec)
+	// Parse the spec into an expression tree once so we can discard an
+	// invalid spec and avoid reparsing for each candidate row.
+	expr, err := ParseExpr(spec)
+	if err != nil {
+		log.Error("invalid filter: " + err.Error())
+		return filteredResults
+	}
 
-	// Iterate over the candidate dataset, checking each against the filters.
+	// Iterate over the candidate dataset, checking each against expr.
 	for _, candidate := range candidates.Array() {
-		if !applyFilters(candidate, attrs, filters) {
+		// Intentionally defer Transform to SliceDiceSpit output phase. This
+		// function is responsible for filtering only. Transformations are
+		// applied downstream during output formatting.
+		row := rowFromCandidate(candidate, attrs)
+
+		if !matchExpr(candidate, attrs, row, expr) {
 			continue
 		}
 
-		// If the filter check was successful, add each attribute from the candidate
-		// to the filtered result set.
-		result := make(map[string]interface{})
-		for i := range attrs {
-			attr := attrs[i]
-			// Intentionally defer Transform to SliceDiceSpit output phase.
-			// This function is responsible for filtering only. Transformations
-			// are applied downstream during output formatting.
-			value := driller.Driller(candidate.Raw, attr.Key)
-			result[attr.OutputKey] = value.Value()
-		}
-		filteredResults = append(filteredResults, result)
+		filteredResults = append(filteredResults, row)
 	}
 
 	return filteredResults
 }
 
-// applyFilters returns true if the candidate row matches all of the
-// provided filters. Server-side TF API filter keys (prefixed with _) are
-// ignored here.
-func applyFilters(candidate gjson.Result, attrs attrs.AttrList,
-	filters []Filter) bool {
-	// No filters, so go home early.
-	if len(filters) == 0 {
-		return true
+// rowFromCandidate projects candidate's attrs-selected fields into a flat
+// map keyed by OutputKey, the shape both FilterDataset's result rows and
+// Expr.Match's row-based evaluation expect.
+func rowFromCandidate(candidate gjson.Result, attrs attrs.AttrList) map[string]interface{} {
+	row := make(map[string]interface{}, len(attrs))
+	for i := range attrs {
+		attr := attrs[i]
+		row[attr.OutputKey] = driller.Driller(candidate.Raw, attr.Key).Value()
 	}
+	return row
+}
 
-	// Iterate over the filters, checking each against the candidate.
-	for _, filter := range filters {
-		var key string
-
-		// Skip server-side filters as they were applied by the API and we're not
-		// interested in them here.
-		if filter.ServerSide {
-			continue
-		}
+// applyFilters returns true if candidate matches every filter in filters,
+// treated as an implicit AND - the semantics a flat []Filter always had
+// before filter expressions gained boolean composition. Kept for callers
+// that already have a flat []Filter rather than an Expr.
+func applyFilters(candidate gjson.Result, attrs attrs.AttrList, filters []Filter) bool {
+	return matchExpr(candidate, attrs, rowFromCandidate(candidate, attrs), andAll(filters))
+}
 
-		// Handle the special case of the hungarian filter. This filter checks if
-		// the resource name follows Hungarian notation (i.e., contains tokens
-		// from the resource type).
-		if filter.Key == "hungarian" {
-			// Get the resource type and name from the candidate.
-			hungarian := isHungarian(candidate, filter)
-			return hungarian == hungarianPass
+// matchExpr evaluates e against candidate, consulting the virtual key
+// registry for each predicate before falling back to row, the flattened
+// attrs-projected view of candidate. Virtual keys need the full
+// gjson.Result, which is why this (unlike Expr.Match) can evaluate them.
+func matchExpr(candidate gjson.Result, attrs attrs.AttrList, row map[string]interface{}, e Expr) bool {
+	switch n := e.(type) {
+	case *AndNode:
+		return matchExpr(candidate, attrs, row, n.Left) && matchExpr(candidate, attrs, row, n.Right)
+	case *OrNode:
+		return matchExpr(candidate, attrs, row, n.Left) || matchExpr(candidate, attrs, row, n.Right)
+	case *NotNode:
+		return !matchExpr(candidate, attrs, row, n.Expr)
+	case *PredicateNode:
+		// Skip server-side filters as they were applied by the API and we're
+		// not interested in them here.
+		if n.Filter.ServerSide {
+			return true
 		}
 
-		// Find the attribute that matches the filter key.
-		for _, attr := range attrs {
-			if attr.OutputKey == filter.Key {
-				key = attr.Key
-				break
+		// Consult the virtual key registry before falling back to attribute
+		// lookup. Virtual keys (e.g. "hungarian", "tainted") evaluate against
+		// the whole candidate rather than a single attribute value.
+		if vk, ok := registry[n.Filter.Key]; ok {
+			match, handled := vk.Evaluate(candidate, n.Filter, VirtualKeyContext{Attrs: attrs})
+			if handled {
+				return match != n.Filter.Negate
 			}
 		}
 
-		// If an attribute matching the filter key was not found, log the condition
-		// and skip this filter (continue processing other filters).
-		// This allows invalid filters to be reported without rejecting the entire row.
-		if key == "" {
-			msg := fmt.Sprintf("filter key not found: %s", filter.Key)
-			log.Error(msg)
-			fmt.Fprintf(os.Stderr, "warning: %s\n", msg)
-			continue
-		}
-
-		// Get the value from the candidate for the key. If it's nil, fail early.
-		value := driller.Driller(candidate.Raw, key).Value()
-		if value == nil {
-			return false
-		}
+		return n.Filter.evaluate(row)
+	default:
+		return true
+	}
+}
 
-		// Check the value against the filter. If it fails the check, fail early as
-		// there's no need to continue checking the remaining filters.
-		result := true
-		if v, ok := value.(string); ok {
-			result = checkStringOperand(v, filter)
-		} else if v, ok := value.(bool); ok {
-			result = checkStringOperand(fmt.Sprintf("%v", v), filter)
-		} else if num, ok := toFloat64(value); ok {
-			result = checkNumericOperand(num, filter)
-		} else if filter.Operand == "@" {
-			result = checkContainsOperand(value, filter)
-		}
+// evaluate checks row[f.Key] against f, returning true (pass) if f.Key
+// isn't a projected attribute at all (an unknown filter key is logged and
+// skipped rather than failing the row), false if the attribute resolved to
+// a nil value, and otherwise the result of the operand-appropriate check.
+func (f Filter) evaluate(row map[string]interface{}) bool {
+	value, ok := row[f.Key]
+	if !ok {
+		msg := fmt.Sprintf("filter key not found: %s", f.Key)
+		log.Error(msg)
+		fmt.Fprintf(os.Stderr, "warning: %s\n", msg)
+		return true
+	}
+	if value == nil {
+		return false
+	}
 
-		if !result {
-			return false
+	switch v := value.(type) {
+	case string:
+		return checkStringOperand(v, f)
+	case bool:
+		return checkStringOperand(fmt.Sprintf("%v", v), f)
+	default:
+		if num, ok := toFloat64(value); ok {
+			return checkNumericOperand(num, f)
+		} else if f.Operand == "@" {
+			return checkContainsOperand(value, f)
+		} else if f.Operand == "/" {
+			return checkJSONPathOperand(value, f)
 		}
+		return true
 	}
-
-	return true
 }
 
-// hungarianCheckType represents the type of filter operand.
-type hungarianCheckType int
-
-const (
-	hungarianPass hungarianCheckType = iota
-	hungarianFail
-)
-
 // checkContainsOperand evaluates a membership style filter (operand '@')
 // against slice or map values.
 func checkContainsOperand(value interface{}, filter Filter) bool {
@@ -266,12 +328,31 @@ func checkNumericOperand(value float64, filter Filter) bool {
 		return (value > tgt) == !filter.Negate
 	case "<":
 		return (value < tgt) == !filter.Negate
+	case "?":
+		return checkInOperand(value, filter)
 	default:
 		log.Error("unsupported numeric operand: " + filter.Operand)
 		return false
 	}
 }
 
+// checkInOperand evaluates a numeric set-membership filter (operand '?'),
+// parsing each of filter.Values via strconv.ParseFloat and returning true if
+// value matches any of them. Negate inverts this to "not in" semantics.
+func checkInOperand(value float64, filter Filter) bool {
+	for _, v := range filter.Values {
+		tgt, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			log.Error("invalid numeric value in list: " + v)
+			continue
+		}
+		if value == tgt {
+			return !filter.Negate
+		}
+	}
+	return filter.Negate
+}
+
 // checkStringOperand evaluates a string comparison style filter against the
 // provided value using the operand semantics.
 func checkStringOperand(value string, filter Filter) bool {
@@ -295,49 +376,57 @@ func checkStringOperand(value string, filter Filter) bool {
 			return false
 		}
 		return matched == !filter.Negate
+	case "?":
+		for _, v := range filter.Values {
+			if value == v {
+				return !filter.Negate
+			}
+		}
+		return filter.Negate
+	case "?~":
+		for _, v := range filter.Values {
+			if strings.EqualFold(value, v) {
+				return !filter.Negate
+			}
+		}
+		return filter.Negate
 	default:
 		log.Error("unsupported filtering operand: " + filter.Operand)
 		return false
 	}
 }
 
-// isHungarian() checks to see if the current candidate passes or fails the
-// test.  There are two components of this after ensuring both fields are
-// present and can be converted to string.  First, a determination to whether
-// we're looking for Hungarian notation (filter.Value is "" or "true") or not
-// (filter.Value is "false").  Second, we need to apply negation if specified.
-func isHungarian(candidate gjson.Result, filter Filter) hungarianCheckType {
-	typeVal := driller.Driller(candidate.Raw, "type").Value()
-	nameVal := driller.Driller(candidate.Raw, "name").Value()
-
-	// Both type and name must be present.
-	if typeVal == nil || nameVal == nil {
-		return hungarianPass
+// checkJSONPathOperand evaluates the '/' operand against a non-scalar value
+// (typically a nested object or array drilled out of the candidate),
+// marshaling it to JSON and running a gjson path lookup against it.
+// filter.Value has the form "<gjson-path>@<expected>" (e.g.
+// "identifier@my-org" against a vcs-repo object matches
+// vcs-repo.identifier == "my-org"); with no "@" present, the whole
+// marshaled value is regex-matched instead, preserving the operand's
+// original flat-string behavior for values checkStringOperand can't handle
+// directly.
+func checkJSONPathOperand(value interface{}, filter Filter) bool {
+	data, err := json.Marshal(value)
+	if err != nil {
+		log.Error(fmt.Sprintf("jsonpath filter: can't marshal value: %v", err))
+		return false
 	}
 
-	// Convert to strings.
-	typeStr, typeOK := typeVal.(string)
-	nameStr, nameOK := nameVal.(string)
-	if !typeOK || !nameOK {
-		return hungarianPass
+	path, expected, hasPath := strings.Cut(filter.Value, "@")
+	if !hasPath {
+		matched, err := regexp.MatchString(filter.Value, string(data))
+		if err != nil {
+			log.Error("invalid regex: " + filter.Value)
+			return false
+		}
+		return matched == !filter.Negate
 	}
 
-	// Determine if the resource is Hungarian notation.
-	found := hungarian.IsHungarian(typeStr, nameStr)
-
-	// Determine the result based on the filter value and negation.
-	// If filter.Value is empty or "true", keep Hungarian resources.
-	// If filter.Value is "false", keep non-Hungarian resources.
-	mode := filter.Value == "" || filter.Value == "true"
-
-	switch {
-	case mode && !found:
-		return hungarianFail
-	case !mode && found:
-		return hungarianFail
+	result := gjson.GetBytes(data, path)
+	if !result.Exists() {
+		return filter.Negate
 	}
-
-	return hungarianPass
+	return (result.String() == expected) == !filter.Negate
 }
 
 // toFloat64 attempts to normalize various numeric types to float64.
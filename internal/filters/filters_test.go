@@ -199,6 +199,296 @@ func TestApplyFilters(t *testing.T) {
 	}
 }
 
+// TestBuildFilters_SetMembership covers parsing of the "?" and "?~" operands,
+// including server-side prefix, negation, and empty element handling.
+func TestBuildFilters_SetMembership(t *testing.T) {
+	tests := []struct {
+		name        string
+		spec        string
+		listDelim   string
+		wantOperand string
+		wantNegate  bool
+		wantServer  bool
+		wantValues  []string
+	}{
+		{
+			name:        "basic in",
+			spec:        "status?applied|errored|canceled",
+			wantOperand: "?",
+			wantValues:  []string{"applied", "errored", "canceled"},
+		},
+		{
+			name:        "case-insensitive in",
+			spec:        "status?~Applied|ERRORED",
+			wantOperand: "?~",
+			wantValues:  []string{"Applied", "ERRORED"},
+		},
+		{
+			name:        "negated",
+			spec:        "status!?applied|errored",
+			wantOperand: "?",
+			wantNegate:  true,
+			wantValues:  []string{"applied", "errored"},
+		},
+		{
+			name:        "server-side prefix",
+			spec:        "_status?applied|errored",
+			wantOperand: "?",
+			wantServer:  true,
+			wantValues:  []string{"applied", "errored"},
+		},
+		{
+			name:        "empty elements dropped",
+			spec:        "status?applied||errored|",
+			wantOperand: "?",
+			wantValues:  []string{"applied", "errored"},
+		},
+		{
+			name:        "custom list delimiter",
+			spec:        "status?applied;errored",
+			listDelim:   ";",
+			wantOperand: "?",
+			wantValues:  []string{"applied", "errored"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.listDelim != "" {
+				t.Setenv("TFCTL_FILTER_LIST_DELIM", tt.listDelim)
+			}
+
+			got := BuildFilters(tt.spec)
+			require.Len(t, got, 1)
+			assert.Equal(t, "status", got[0].Key)
+			assert.Equal(t, tt.wantOperand, got[0].Operand)
+			assert.Equal(t, tt.wantNegate, got[0].Negate)
+			assert.Equal(t, tt.wantServer, got[0].ServerSide)
+			assert.Equal(t, tt.wantValues, got[0].Values)
+		})
+	}
+}
+
+// TestCheckStringOperand_SetMembership covers the "?" and "?~" string
+// set-membership checks, including negation.
+func TestCheckStringOperand_SetMembership(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  string
+		filter Filter
+		want   bool
+	}{
+		{
+			name:   "matches exact",
+			value:  "applied",
+			filter: Filter{Operand: "?", Values: []string{"applied", "errored"}},
+			want:   true,
+		},
+		{
+			name:   "no match exact",
+			value:  "planned",
+			filter: Filter{Operand: "?", Values: []string{"applied", "errored"}},
+			want:   false,
+		},
+		{
+			name:   "negated match excludes",
+			value:  "applied",
+			filter: Filter{Operand: "?", Negate: true, Values: []string{"applied", "errored"}},
+			want:   false,
+		},
+		{
+			name:   "negated no-match includes",
+			value:  "planned",
+			filter: Filter{Operand: "?", Negate: true, Values: []string{"applied", "errored"}},
+			want:   true,
+		},
+		{
+			name:   "case-insensitive matches",
+			value:  "Applied",
+			filter: Filter{Operand: "?~", Values: []string{"applied", "errored"}},
+			want:   true,
+		},
+		{
+			name:   "case-sensitive rejects different case",
+			value:  "Applied",
+			filter: Filter{Operand: "?", Values: []string{"applied", "errored"}},
+			want:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := checkStringOperand(tt.value, tt.filter)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// TestCheckNumericOperand_SetMembership covers the "?" numeric set-membership
+// check, including mixed numeric element parsing via toFloat64-style
+// coercion of the comparison value.
+func TestCheckNumericOperand_SetMembership(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  interface{}
+		filter Filter
+		want   bool
+	}{
+		{
+			name:   "int matches",
+			value:  3,
+			filter: Filter{Operand: "?", Values: []string{"1", "2", "3"}},
+			want:   true,
+		},
+		{
+			name:   "float64 matches",
+			value:  float64(2.5),
+			filter: Filter{Operand: "?", Values: []string{"1", "2.5", "3"}},
+			want:   true,
+		},
+		{
+			name:   "no match",
+			value:  4,
+			filter: Filter{Operand: "?", Values: []string{"1", "2", "3"}},
+			want:   false,
+		},
+		{
+			name:   "negated excludes match",
+			value:  3,
+			filter: Filter{Operand: "?", Negate: true, Values: []string{"1", "2", "3"}},
+			want:   false,
+		},
+		{
+			name:   "invalid element skipped",
+			value:  3,
+			filter: Filter{Operand: "?", Values: []string{"not-a-number", "3"}},
+			want:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			num, ok := toFloat64(tt.value)
+			require.True(t, ok)
+			got := checkNumericOperand(num, tt.filter)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// fakeVirtualKey is a test-only VirtualKey used to verify registry dispatch
+// and ordering against normal attribute filters.
+type fakeVirtualKey struct {
+	name    string
+	match   bool
+	handled bool
+	calls   *int
+}
+
+func (f fakeVirtualKey) Name() string { return f.name }
+
+func (f fakeVirtualKey) Evaluate(_ gjson.Result, _ Filter, _ VirtualKeyContext) (bool, bool) {
+	if f.calls != nil {
+		*f.calls++
+	}
+	return f.match, f.handled
+}
+
+// TestVirtualKeyRegistry covers dispatch through the registry, uniform
+// Negate handling, the unhandled fallback to attribute lookup, and ordering
+// relative to a normal attribute filter in the same filter list.
+func TestVirtualKeyRegistry(t *testing.T) {
+	testData := `{"name": "my-bucket", "type": "aws_s3_bucket", "region": "us-east-1"}`
+	attrList := attrs.AttrList{
+		{Key: "region", OutputKey: "region", Include: true},
+	}
+
+	t.Run("matching virtual key passes", func(t *testing.T) {
+		Register(fakeVirtualKey{name: "fake-pass", match: true, handled: true})
+		defer delete(registry, "fake-pass")
+
+		result := gjson.Parse(testData)
+		got := applyFilters(result, attrList, []Filter{{Key: "fake-pass"}})
+		assert.True(t, got)
+	})
+
+	t.Run("non-matching virtual key fails", func(t *testing.T) {
+		Register(fakeVirtualKey{name: "fake-fail", match: false, handled: true})
+		defer delete(registry, "fake-fail")
+
+		result := gjson.Parse(testData)
+		got := applyFilters(result, attrList, []Filter{{Key: "fake-fail"}})
+		assert.False(t, got)
+	})
+
+	t.Run("negate inverts the match uniformly", func(t *testing.T) {
+		Register(fakeVirtualKey{name: "fake-negate", match: true, handled: true})
+		defer delete(registry, "fake-negate")
+
+		result := gjson.Parse(testData)
+		got := applyFilters(result, attrList, []Filter{{Key: "fake-negate", Negate: true}})
+		assert.False(t, got)
+	})
+
+	t.Run("unhandled falls back to attribute lookup", func(t *testing.T) {
+		calls := 0
+		Register(fakeVirtualKey{name: "region", match: true, handled: false, calls: &calls})
+		defer delete(registry, "region")
+
+		result := gjson.Parse(testData)
+		// "region" is both a registered (but unhandled) virtual key and a real
+		// attribute; with handled=false it must fall through to the normal
+		// attribute-based check rather than short-circuit.
+		got := applyFilters(result, attrList, []Filter{{Key: "region", Operand: "=", Value: "us-east-1"}})
+		assert.True(t, got)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("ordering: virtual key and normal filter both evaluated", func(t *testing.T) {
+		Register(fakeVirtualKey{name: "fake-ordering", match: true, handled: true})
+		defer delete(registry, "fake-ordering")
+
+		result := gjson.Parse(testData)
+		filters := []Filter{
+			{Key: "fake-ordering"},
+			{Key: "region", Operand: "=", Value: "us-east-1"},
+		}
+		assert.True(t, applyFilters(result, attrList, filters))
+
+		// A failing normal filter after a passing virtual key still fails the
+		// whole row.
+		filters = []Filter{
+			{Key: "fake-ordering"},
+			{Key: "region", Operand: "=", Value: "us-west-2"},
+		}
+		assert.False(t, applyFilters(result, attrList, filters))
+	})
+}
+
+// TestTaintedVirtualKey covers the built-in "tainted" virtual key.
+func TestTaintedVirtualKey(t *testing.T) {
+	tests := []struct {
+		name        string
+		candidate   string
+		wantMatch   bool
+		wantHandled bool
+	}{
+		{"tainted status", `{"status": "tainted"}`, true, true},
+		{"empty status", `{"status": ""}`, false, true},
+		{"no status field", `{"name": "foo"}`, false, true},
+	}
+
+	vk := taintedVirtualKey{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			candidate := gjson.Parse(tt.candidate)
+			match, handled := vk.Evaluate(candidate, Filter{}, VirtualKeyContext{})
+			assert.Equal(t, tt.wantMatch, match)
+			assert.Equal(t, tt.wantHandled, handled)
+		})
+	}
+}
+
 func TestFilterDataset(t *testing.T) {
 	var tests []testFilterDatasetCase
 	require.NoError(t, loadTestData("filters_test_filter_dataset.yaml", &tests))
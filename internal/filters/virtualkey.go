@@ -0,0 +1,38 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package filters
+
+import (
+	"github.com/tidwall/gjson"
+
+	"github.com/tfctl/tfctl/internal/attrs"
+)
+
+// VirtualKeyContext carries ambient context that a VirtualKey may need beyond
+// the candidate and filter, such as the full AttrList used to build the
+// current query's output (e.g. to look up an attribute by OutputKey).
+type VirtualKeyContext struct {
+	Attrs attrs.AttrList
+}
+
+// VirtualKey is a named filter key that evaluates against the whole candidate
+// row rather than a single attribute value (e.g. "hungarian", "tainted").
+// Evaluate returns the raw (pre-negation) match result; applyFilters applies
+// Negate uniformly across all virtual keys. handled is false if the key
+// declines to evaluate (e.g. required fields are missing from the
+// candidate), in which case applyFilters falls back to normal attribute
+// lookup.
+type VirtualKey interface {
+	Name() string
+	Evaluate(candidate gjson.Result, filter Filter, ctx VirtualKeyContext) (match bool, handled bool)
+}
+
+// registry holds the virtual keys registered via Register, keyed by Name().
+var registry = map[string]VirtualKey{}
+
+// Register adds a VirtualKey to the registry. Sub-packages that implement a
+// VirtualKey should call this from their own init().
+func Register(vk VirtualKey) {
+	registry[vk.Name()] = vk
+}
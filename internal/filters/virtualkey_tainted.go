@@ -0,0 +1,34 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package filters
+
+import (
+	"github.com/tidwall/gjson"
+
+	"github.com/tfctl/tfctl/internal/driller"
+)
+
+// taintedVirtualKey implements VirtualKey for the "tainted" filter key,
+// matching resource instances whose state "status" field is "tainted".
+type taintedVirtualKey struct{}
+
+func (taintedVirtualKey) Name() string { return "tainted" }
+
+func (taintedVirtualKey) Evaluate(candidate gjson.Result, _ Filter, _ VirtualKeyContext) (bool, bool) {
+	statusVal := driller.Driller(candidate.Raw, "status").Value()
+	if statusVal == nil {
+		return false, true
+	}
+
+	status, ok := statusVal.(string)
+	if !ok {
+		return false, true
+	}
+
+	return status == "tainted", true
+}
+
+func init() {
+	Register(taintedVirtualKey{})
+}
@@ -0,0 +1,36 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package hungarian
+
+import (
+	"github.com/tidwall/gjson"
+
+	"github.com/tfctl/tfctl/internal/driller"
+	"github.com/tfctl/tfctl/internal/filters"
+)
+
+// hungarianVirtualKey implements filters.VirtualKey for the "hungarian"
+// filter key, matching resources whose name follows Hungarian notation
+// (i.e., contains a token from the resource type).
+type hungarianVirtualKey struct{}
+
+func (hungarianVirtualKey) Name() string { return "hungarian" }
+
+func (hungarianVirtualKey) Evaluate(candidate gjson.Result, _ filters.Filter, _ filters.VirtualKeyContext) (bool, bool) {
+	typeVal := driller.Driller(candidate.Raw, "type").Value()
+	nameVal := driller.Driller(candidate.Raw, "name").Value()
+
+	// Both type and name must be present and strings for this key to apply.
+	typeStr, typeOK := typeVal.(string)
+	nameStr, nameOK := nameVal.(string)
+	if !typeOK || !nameOK {
+		return false, false
+	}
+
+	return IsHungarian(typeStr, nameStr), true
+}
+
+func init() {
+	filters.Register(hungarianVirtualKey{})
+}
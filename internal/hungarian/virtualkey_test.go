@@ -0,0 +1,59 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+// no-cloc
+
+package hungarian
+
+import (
+	"testing"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/tfctl/tfctl/internal/filters"
+)
+
+func TestHungarianVirtualKey_Evaluate(t *testing.T) {
+	tests := []struct {
+		name        string
+		candidate   string
+		wantMatch   bool
+		wantHandled bool
+	}{
+		{
+			name:        "hungarian name matches",
+			candidate:   `{"type": "aws_instance", "name": "instance_prod"}`,
+			wantMatch:   true,
+			wantHandled: true,
+		},
+		{
+			name:        "non-hungarian name",
+			candidate:   `{"type": "aws_security_group", "name": "sg_app"}`,
+			wantMatch:   false,
+			wantHandled: true,
+		},
+		{
+			name:        "missing type declines",
+			candidate:   `{"name": "sg_app"}`,
+			wantMatch:   false,
+			wantHandled: false,
+		},
+	}
+
+	vk := hungarianVirtualKey{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			candidate := gjson.Parse(tt.candidate)
+			match, handled := vk.Evaluate(candidate, filters.Filter{}, filters.VirtualKeyContext{})
+			if match != tt.wantMatch || handled != tt.wantHandled {
+				t.Errorf("Evaluate() = (%v, %v), want (%v, %v)", match, handled, tt.wantMatch, tt.wantHandled)
+			}
+		})
+	}
+}
+
+func TestHungarianVirtualKey_Registered(t *testing.T) {
+	vk := hungarianVirtualKey{}
+	if vk.Name() != "hungarian" {
+		t.Errorf("Name() = %q, want %q", vk.Name(), "hungarian")
+	}
+}
@@ -13,6 +13,7 @@ import (
 )
 
 var traceEnabled bool
+var debugEnabled bool
 
 // InitLogger sets up Apex with a custom handler and a log level from the
 // TFCTL_LOG env variable.
@@ -22,6 +23,7 @@ func InitLogger() {
 		envLevel = "error"
 	}
 	traceEnabled = envLevel == "trace"
+	debugEnabled = envLevel == "trace" || envLevel == "debug"
 	var apexLevel log.Level
 	switch envLevel {
 	case "trace":
@@ -72,6 +74,13 @@ func (h *CustomHandler) HandleLog(e *log.Entry) error {
 	return nil
 }
 
+// DebugEnabled reports whether TFCTL_LOG is set to "debug" or "trace",
+// for callers (like panic-recovery middleware) that want to include extra
+// detail, such as a stack trace, only in verbose runs.
+func DebugEnabled() bool {
+	return debugEnabled
+}
+
 // Tracef logs at Trace level (below Debug).
 func Tracef(format string, args ...interface{}) {
 	if traceEnabled {
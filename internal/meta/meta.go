@@ -6,6 +6,7 @@ package meta
 import (
 	"context"
 
+	"github.com/tfctl/tfctl/internal/audit"
 	"github.com/tfctl/tfctl/internal/config"
 )
 
@@ -25,4 +26,7 @@ type Meta struct {
 	Context context.Context
 	RootDirSpec
 	StartingDir string
+	// Audit is the handle remote-query commands use to log TFE API calls
+	// (config key "audit.sink"). Nil disables auditing entirely.
+	Audit *audit.Handle
 }
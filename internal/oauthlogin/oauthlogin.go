@@ -0,0 +1,236 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package oauthlogin implements the browser-based half of Terraform's OAuth2
+// login protocol (https://developer.hashicorp.com/terraform/internals/login-protocol):
+// a PKCE authorization-code exchange against the endpoints a host publishes
+// in its "login.v1" service discovery entry (see internal/disco), with the
+// redirect landing on a short-lived localhost listener instead of a
+// registered app callback URL.
+package oauthlogin
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/tfctl/tfctl/internal/disco"
+)
+
+// CallbackTimeout bounds how long the local callback listener waits for the
+// browser to complete the authorization step before giving up.
+const CallbackTimeout = 2 * time.Minute
+
+// PKCE holds one authorization attempt's random verifier, its derived
+// challenge, and the anti-CSRF state nonce, all generated by NewPKCE.
+type PKCE struct {
+	Verifier  string
+	Challenge string
+	State     string
+}
+
+// NewPKCE generates a random code_verifier (and its S256 code_challenge)
+// and a random state nonce, per RFC 7636.
+func NewPKCE() (PKCE, error) {
+	verifier, err := randomURLSafeString(32)
+	if err != nil {
+		return PKCE{}, fmt.Errorf("failed to generate code_verifier: %w", err)
+	}
+	state, err := randomURLSafeString(16)
+	if err != nil {
+		return PKCE{}, fmt.Errorf("failed to generate state: %w", err)
+	}
+
+	sum := sha256.Sum256([]byte(verifier))
+	challenge := base64.RawURLEncoding.EncodeToString(sum[:])
+
+	return PKCE{Verifier: verifier, Challenge: challenge, State: state}, nil
+}
+
+func randomURLSafeString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// Listener is a localhost HTTP listener bound to one of login.v1's allowed
+// ports, waiting to receive the authorization server's redirect.
+type Listener struct {
+	listener net.Listener
+	port     int
+}
+
+// Listen binds to the first of ports that's free on 127.0.0.1, as
+// login.v1's "ports" field requires the redirect_uri's port to be one it
+// explicitly allows.
+func Listen(ports []int) (*Listener, error) {
+	var lastErr error
+	for _, port := range ports {
+		l, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		return &Listener{listener: l, port: port}, nil
+	}
+	if lastErr == nil {
+		lastErr = fmt.Errorf("no ports offered")
+	}
+	return nil, fmt.Errorf("failed to bind a local callback listener on any of %v: %w", ports, lastErr)
+}
+
+// RedirectURI is the redirect_uri this listener answers on.
+func (l *Listener) RedirectURI() string {
+	return fmt.Sprintf("http://127.0.0.1:%d/", l.port)
+}
+
+// Await serves a single request on the listener, validates its state
+// parameter against wantState, and returns the authorization code. The
+// browser tab is shown a simple confirmation page; anything else fails the
+// request with a 400. Await stops listening once it has handled one request
+// (success or failure) or CallbackTimeout elapses.
+func (l *Listener) Await(ctx context.Context, wantState string) (code string, err error) {
+	defer l.listener.Close() //nolint:errcheck
+
+	type result struct {
+		code string
+		err  error
+	}
+	resultCh := make(chan result, 1)
+
+	srv := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			q := r.URL.Query()
+			if errParam := q.Get("error"); errParam != "" {
+				http.Error(w, "authorization denied", http.StatusBadRequest)
+				resultCh <- result{err: fmt.Errorf("authorization server returned error: %s", errParam)}
+				return
+			}
+			if q.Get("state") != wantState {
+				http.Error(w, "state mismatch", http.StatusBadRequest)
+				resultCh <- result{err: fmt.Errorf("callback state %q did not match the request's state", q.Get("state"))}
+				return
+			}
+			c := q.Get("code")
+			if c == "" {
+				http.Error(w, "missing code", http.StatusBadRequest)
+				resultCh <- result{err: fmt.Errorf("callback had no code parameter")}
+				return
+			}
+			fmt.Fprintln(w, "Login complete. You can close this tab and return to the terminal.")
+			resultCh <- result{code: c}
+		}),
+	}
+
+	go srv.Serve(l.listener) //nolint:errcheck
+
+	ctx, cancel := context.WithTimeout(ctx, CallbackTimeout)
+	defer cancel()
+
+	select {
+	case res := <-resultCh:
+		return res.code, res.err
+	case <-ctx.Done():
+		return "", fmt.Errorf("timed out waiting for the browser to complete login: %w", ctx.Err())
+	}
+}
+
+// AuthzURL builds the URL to open in the user's browser, per login.v1's
+// "authz" endpoint: an authorization_code grant with the given client ID,
+// redirect_uri, scopes, state, and PKCE challenge.
+func AuthzURL(login disco.LoginV1, redirectURI, state, codeChallenge string) (string, error) {
+	u, err := url.Parse(login.Authz)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse authz endpoint %q: %w", login.Authz, err)
+	}
+
+	q := u.Query()
+	q.Set("response_type", "code")
+	q.Set("client_id", login.Client)
+	q.Set("redirect_uri", redirectURI)
+	q.Set("state", state)
+	q.Set("code_challenge", codeChallenge)
+	q.Set("code_challenge_method", "S256")
+	if len(login.Scopes) > 0 {
+		q.Set("scope", strings.Join(login.Scopes, " "))
+	}
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
+}
+
+// ExchangeCode trades an authorization code for an access token at
+// login.v1's "token" endpoint, per RFC 6749 section 4.1.3 plus the PKCE
+// code_verifier RFC 7636 adds to it.
+func ExchangeCode(ctx context.Context, login disco.LoginV1, code, codeVerifier, redirectURI string) (string, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"client_id":     {login.Client},
+		"code":          {code},
+		"redirect_uri":  {redirectURI},
+		"code_verifier": {codeVerifier},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, login.Token, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("failed to build token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to reach token endpoint: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		AccessToken string `json:"access_token"`
+		Error       string `json:"error"`
+		ErrorDesc   string `json:"error_description"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("failed to parse token response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK || body.AccessToken == "" {
+		if body.Error != "" {
+			return "", fmt.Errorf("token endpoint returned %s: %s", body.Error, body.ErrorDesc)
+		}
+		return "", fmt.Errorf("token endpoint returned %s with no access_token", resp.Status)
+	}
+
+	return body.AccessToken, nil
+}
+
+// OpenBrowser attempts to open rawURL in the user's default browser,
+// mirroring `terraform login`'s best-effort behavior: callers should print
+// rawURL regardless, since there's no reliable way to detect a headless
+// environment up front.
+func OpenBrowser(rawURL string) error {
+	var name string
+	var args []string
+
+	switch runtime.GOOS {
+	case "darwin":
+		name, args = "open", []string{rawURL}
+	case "windows":
+		name, args = "rundll32", []string{"url.dll,FileProtocolHandler", rawURL}
+	default:
+		name, args = "xdg-open", []string{rawURL}
+	}
+
+	return exec.Command(name, args...).Start()
+}
@@ -0,0 +1,422 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+
+	"github.com/parquet-go/parquet-go"
+	"gopkg.in/yaml.v2"
+
+	"github.com/tfctl/tfctl/internal/attrs"
+)
+
+// Encoder renders a filtered, sorted dataset (the same []map[string]interface{}
+// shape SortDataset consumes) to w. titles mirrors TableWriter's --titles
+// flag: when true, a header row of attrs.Include column names is written
+// before the data; formats without a meaningful header (ndjson, parquet)
+// ignore it.
+type Encoder interface {
+	Encode(w io.Writer, resultSet []map[string]interface{}, al attrs.AttrList, titles bool) error
+}
+
+// Encoders maps an --output value to its Encoder implementation.
+var Encoders = map[string]Encoder{
+	"ndjson":   ndjsonEncoder{},
+	"csv":      delimitedEncoder{comma: ','},
+	"tsv":      delimitedEncoder{comma: '\t'},
+	"parquet":  parquetEncoder{},
+	"markdown": markdownEncoder{},
+	"html":     htmlEncoder{},
+}
+
+// includedColumns returns the OutputKey of every attr marked Include, in
+// declared order.
+func includedColumns(al attrs.AttrList) []string {
+	cols := make([]string, 0, len(al))
+	for _, a := range al {
+		if a.Include {
+			cols = append(cols, a.OutputKey)
+		}
+	}
+	return cols
+}
+
+// ndjsonEncoder writes one JSON object per line, trivial to pipe into jq.
+type ndjsonEncoder struct{}
+
+func (ndjsonEncoder) Encode(w io.Writer, resultSet []map[string]interface{}, al attrs.AttrList, _ bool) error {
+	cols := includedColumns(al)
+	enc := json.NewEncoder(w)
+	for _, row := range resultSet {
+		record := make(map[string]interface{}, len(cols))
+		for _, c := range cols {
+			record[c] = row[c]
+		}
+		if err := enc.Encode(record); err != nil {
+			return fmt.Errorf("ndjson encode: %w", err)
+		}
+	}
+	return nil
+}
+
+// delimitedEncoder writes a CSV/TSV document, honoring attrs.Include
+// ordering the same way TableWriter does, writing a header row only when
+// titles is set, and leaving numeric values unquoted since encoding/csv
+// only quotes fields containing commas, quotes, or newlines. csv and tsv
+// differ only in the csv.Writer's Comma.
+type delimitedEncoder struct {
+	comma rune
+}
+
+func (e delimitedEncoder) Encode(w io.Writer, resultSet []map[string]interface{}, al attrs.AttrList, titles bool) error {
+	cols := includedColumns(al)
+	cw := csv.NewWriter(w)
+	cw.Comma = e.comma
+
+	if titles {
+		if err := cw.Write(cols); err != nil {
+			return fmt.Errorf("header: %w", err)
+		}
+	}
+
+	record := make([]string, len(cols))
+	for _, row := range resultSet {
+		for i, c := range cols {
+			record[i] = InterfaceToString(row[c])
+		}
+		if err := cw.Write(record); err != nil {
+			return fmt.Errorf("row: %w", err)
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// markdownEncoder writes a GitHub-flavored Markdown table, honoring
+// attrs.Include ordering the same way delimitedEncoder does. A header row
+// is only meaningful for a Markdown table when paired with the "|---|"
+// separator GFM requires to recognize it as one, so unlike delimitedEncoder
+// titles isn't optional here: it's always written.
+type markdownEncoder struct{}
+
+func (markdownEncoder) Encode(w io.Writer, resultSet []map[string]interface{}, al attrs.AttrList, _ bool) error {
+	cols := includedColumns(al)
+
+	escape := func(s string) string {
+		return strings.ReplaceAll(strings.ReplaceAll(s, "|", "\\|"), "\n", " ")
+	}
+
+	fmt.Fprintf(w, "| %s |\n", strings.Join(cols, " | "))
+	fmt.Fprintf(w, "|%s|\n", strings.Repeat(" --- |", len(cols)))
+
+	for _, row := range resultSet {
+		cells := make([]string, len(cols))
+		for i, c := range cols {
+			cells[i] = escape(InterfaceToString(row[c], "-"))
+		}
+		fmt.Fprintf(w, "| %s |\n", strings.Join(cells, " | "))
+	}
+
+	return nil
+}
+
+// htmlEncoder writes a self-contained HTML page: a single <table> with a
+// small inline <script> that sorts by whichever column header is clicked,
+// Terraboard-style, so the output needs nothing else to be useful --no
+// external stylesheet, no server. Column headers are always rendered
+// regardless of titles, since a header-less table has nothing to click to
+// sort by.
+type htmlEncoder struct{}
+
+func (htmlEncoder) Encode(w io.Writer, resultSet []map[string]interface{}, al attrs.AttrList, _ bool) error {
+	cols := includedColumns(al)
+
+	fmt.Fprint(w, "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>tfctl</title>\n<style>\n")
+	fmt.Fprint(w, "table { border-collapse: collapse; font-family: sans-serif; font-size: 14px; }\n")
+	fmt.Fprint(w, "th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; }\n")
+	fmt.Fprint(w, "th { cursor: pointer; background: #eee; user-select: none; }\n")
+	fmt.Fprint(w, "</style>\n</head>\n<body>\n<table id=\"tfctl\">\n<thead>\n<tr>\n")
+
+	for i, c := range cols {
+		fmt.Fprintf(w, "<th onclick=\"tfctlSort(%d)\">%s</th>\n", i, html.EscapeString(c))
+	}
+	fmt.Fprint(w, "</tr>\n</thead>\n<tbody>\n")
+
+	for _, row := range resultSet {
+		fmt.Fprint(w, "<tr>\n")
+		for _, c := range cols {
+			fmt.Fprintf(w, "<td>%s</td>\n", html.EscapeString(InterfaceToString(row[c], "-")))
+		}
+		fmt.Fprint(w, "</tr>\n")
+	}
+
+	fmt.Fprint(w, "</tbody>\n</table>\n<script>\n")
+	fmt.Fprint(w, `function tfctlSort(col) {
+  const table = document.getElementById("tfctl");
+  const tbody = table.tBodies[0];
+  const rows = Array.from(tbody.rows);
+  const asc = table.dataset.sortCol == col && table.dataset.sortDir != "asc";
+  rows.sort((a, b) => {
+    const x = a.cells[col].innerText, y = b.cells[col].innerText;
+    const nx = parseFloat(x), ny = parseFloat(y);
+    const cmp = (!isNaN(nx) && !isNaN(ny)) ? nx - ny : x.localeCompare(y);
+    return asc ? cmp : -cmp;
+  });
+  rows.forEach(r => tbody.appendChild(r));
+  table.dataset.sortCol = col;
+  table.dataset.sortDir = asc ? "asc" : "desc";
+}
+`)
+	fmt.Fprint(w, "</script>\n</body>\n</html>\n")
+
+	return nil
+}
+
+// StreamEncoder incrementally writes one projected result row at a time, in
+// contrast to Encoder's Encode, which requires the complete dataset up
+// front. --stream uses this so rows reach stdout as each page of a
+// paginated query returns rather than only once every page has been
+// fetched. Unlike Encode, calls are spread across the lifetime of a query,
+// so each implementation owns whatever per-call state it needs (e.g. the
+// csv.Writer and whether its header has been written yet).
+type StreamEncoder interface {
+	WriteRow(row map[string]interface{}, al attrs.AttrList) error
+	Close() error
+}
+
+// NewStreamEncoder returns a StreamEncoder for format, one of "ndjson",
+// "csv", "tsv", or "yaml". parquet and jsonapi have no streaming form:
+// parquet's schema is inferred from the complete dataset before the first
+// byte is written, and jsonapi's payload is a single top-level {"data":
+// [...]} document -- both defeat the purpose of --stream.
+func NewStreamEncoder(format string, w io.Writer) (StreamEncoder, error) {
+	switch format {
+	case "ndjson":
+		return &ndjsonStreamEncoder{enc: json.NewEncoder(w)}, nil
+	case "csv":
+		return newDelimitedStreamEncoder(w, ','), nil
+	case "tsv":
+		return newDelimitedStreamEncoder(w, '\t'), nil
+	case "yaml":
+		return &yamlStreamEncoder{w: w}, nil
+	default:
+		return nil, fmt.Errorf("--stream: unsupported format %q", format)
+	}
+}
+
+// ndjsonStreamEncoder is ndjsonEncoder's row-at-a-time counterpart.
+type ndjsonStreamEncoder struct {
+	enc *json.Encoder
+}
+
+func (e *ndjsonStreamEncoder) WriteRow(row map[string]interface{}, al attrs.AttrList) error {
+	cols := includedColumns(al)
+	record := make(map[string]interface{}, len(cols))
+	for _, c := range cols {
+		record[c] = row[c]
+	}
+	if err := e.enc.Encode(record); err != nil {
+		return fmt.Errorf("ndjson encode: %w", err)
+	}
+	return nil
+}
+
+func (e *ndjsonStreamEncoder) Close() error { return nil }
+
+// delimitedStreamEncoder backs both the csv and tsv stream formats, which
+// differ only in the csv.Writer's Comma.
+type delimitedStreamEncoder struct {
+	cw        *csv.Writer
+	wroteHead bool
+}
+
+func newDelimitedStreamEncoder(w io.Writer, comma rune) *delimitedStreamEncoder {
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+	return &delimitedStreamEncoder{cw: cw}
+}
+
+func (e *delimitedStreamEncoder) WriteRow(row map[string]interface{}, al attrs.AttrList) error {
+	cols := includedColumns(al)
+	if !e.wroteHead {
+		if err := e.cw.Write(cols); err != nil {
+			return fmt.Errorf("header: %w", err)
+		}
+		e.wroteHead = true
+	}
+
+	record := make([]string, len(cols))
+	for i, c := range cols {
+		record[i] = InterfaceToString(row[c])
+	}
+	if err := e.cw.Write(record); err != nil {
+		return fmt.Errorf("row: %w", err)
+	}
+
+	e.cw.Flush()
+	return e.cw.Error()
+}
+
+func (e *delimitedStreamEncoder) Close() error {
+	e.cw.Flush()
+	return e.cw.Error()
+}
+
+// yamlStreamEncoder writes one "---"-delimited YAML document per row,
+// same multi-document stream shape `yq` and friends already expect.
+type yamlStreamEncoder struct {
+	w io.Writer
+}
+
+func (e *yamlStreamEncoder) WriteRow(row map[string]interface{}, al attrs.AttrList) error {
+	cols := includedColumns(al)
+	record := make(map[string]interface{}, len(cols))
+	for _, c := range cols {
+		record[c] = row[c]
+	}
+
+	doc, err := yaml.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("yaml encode: %w", err)
+	}
+
+	if _, err := fmt.Fprint(e.w, "---\n"); err != nil {
+		return err
+	}
+	_, err = e.w.Write(doc)
+	return err
+}
+
+func (e *yamlStreamEncoder) Close() error { return nil }
+
+// parquetColumnType tracks the widest type observed for a column so far.
+// Widening order: bool < int64 < float64 < string (string is the catch-all).
+type parquetColumnType int
+
+const (
+	parquetUnknown parquetColumnType = iota
+	parquetBool
+	parquetInt64
+	parquetFloat64
+	parquetString
+)
+
+func widen(current parquetColumnType, value interface{}) parquetColumnType {
+	var observed parquetColumnType
+	switch value.(type) {
+	case bool:
+		observed = parquetBool
+	case int, int64:
+		observed = parquetInt64
+	case float64:
+		observed = parquetFloat64
+	case nil:
+		return current
+	default:
+		observed = parquetString
+	}
+
+	if observed > current {
+		return observed
+	}
+	return current
+}
+
+func (t parquetColumnType) node() parquet.Node {
+	switch t {
+	case parquetBool:
+		return parquet.Leaf(parquet.BooleanType)
+	case parquetInt64:
+		return parquet.Int(64)
+	case parquetFloat64:
+		return parquet.Leaf(parquet.DoubleType)
+	default:
+		return parquet.String()
+	}
+}
+
+// parquetEncoder infers a schema from the dataset's key set, widening
+// numeric columns as later records are seen, and writes the result as a
+// single-row-group Parquet file via github.com/parquet-go/parquet-go.
+type parquetEncoder struct{}
+
+func (parquetEncoder) Encode(w io.Writer, resultSet []map[string]interface{}, al attrs.AttrList, _ bool) error {
+	if len(resultSet) == 0 {
+		return nil
+	}
+
+	cols := includedColumns(al)
+
+	colTypes := make(map[string]parquetColumnType, len(cols))
+	for _, row := range resultSet {
+		for _, c := range cols {
+			colTypes[c] = widen(colTypes[c], row[c])
+		}
+	}
+
+	group := make(parquet.Group, len(cols))
+	for _, c := range cols {
+		group[c] = parquet.Optional(colTypes[c].node())
+	}
+	schema := parquet.NewSchema("row", group)
+
+	pw := parquet.NewGenericWriter[any](w, schema)
+	for _, row := range resultSet {
+		record := make(map[string]interface{}, len(cols))
+		for _, c := range cols {
+			record[c] = coerceTo(colTypes[c], row[c])
+		}
+		if _, err := pw.Write([]any{record}); err != nil {
+			return fmt.Errorf("parquet write: %w", err)
+		}
+	}
+
+	return pw.Close()
+}
+
+// coerceTo widens value to match t, since every row in a column must share
+// the same physical type once the schema has been fixed.
+func coerceTo(t parquetColumnType, value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+
+	switch t {
+	case parquetInt64:
+		switch v := value.(type) {
+		case int:
+			return int64(v)
+		case float64:
+			return int64(v)
+		case bool:
+			if v {
+				return int64(1)
+			}
+			return int64(0)
+		}
+	case parquetFloat64:
+		switch v := value.(type) {
+		case int:
+			return float64(v)
+		case int64:
+			return float64(v)
+		case bool:
+			if v {
+				return float64(1)
+			}
+			return float64(0)
+		}
+	case parquetString:
+		return InterfaceToString(value)
+	}
+
+	return value
+}
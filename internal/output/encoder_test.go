@@ -0,0 +1,107 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+// no-cloc
+
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tfctl/tfctl/internal/attrs"
+)
+
+// TestDelimitedEncoder_QuotesOnlyWhenNeeded verifies csv/tsv rows are quoted
+// by encoding/csv's own rules (commas, quotes, newlines) and are otherwise
+// left bare, matching the ordering SortDataset leaves the result set in.
+func TestDelimitedEncoder_QuotesOnlyWhenNeeded(t *testing.T) {
+	al := attrs.AttrList{
+		attrs.Attr{OutputKey: "name", Include: true},
+		attrs.Attr{OutputKey: "note", Include: true},
+	}
+	resultSet := []map[string]interface{}{
+		{"name": "alpha", "note": "plain"},
+		{"name": "beta", "note": "needs, a quote"},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, Encoders["csv"].Encode(&buf, resultSet, al, true))
+
+	want := "name,note\nalpha,plain\nbeta,\"needs, a quote\"\n"
+	assert.Equal(t, want, buf.String())
+}
+
+// TestDelimitedEncoder_TSVUsesTabSeparator verifies the tsv encoder differs
+// from csv only in its field separator.
+func TestDelimitedEncoder_TSVUsesTabSeparator(t *testing.T) {
+	al := attrs.AttrList{
+		attrs.Attr{OutputKey: "name", Include: true},
+		attrs.Attr{OutputKey: "count", Include: true},
+	}
+	resultSet := []map[string]interface{}{
+		{"name": "alpha", "count": 1},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, Encoders["tsv"].Encode(&buf, resultSet, al, true))
+
+	assert.Equal(t, "name\tcount\nalpha\t1\n", buf.String())
+}
+
+// TestWiden_BoolThenNumericWidensToWidestNumericType verifies a column that
+// mixes bool and numeric values widens to the numeric type rather than
+// staying at parquetBool, since bool < int64 < float64 in widening order.
+func TestWiden_BoolThenNumericWidensToWidestNumericType(t *testing.T) {
+	got := widen(parquetUnknown, true)
+	assert.Equal(t, parquetBool, got)
+
+	got = widen(got, 2)
+	assert.Equal(t, parquetInt64, got)
+
+	got = widen(got, 1.5)
+	assert.Equal(t, parquetFloat64, got)
+}
+
+// TestCoerceTo_BoolToNumeric verifies coerceTo converts a bool value to the
+// numeric type a column was widened to, rather than passing it through
+// unconverted and breaking the parquet-go writer's schema check.
+func TestCoerceTo_BoolToNumeric(t *testing.T) {
+	tests := []struct {
+		name string
+		t    parquetColumnType
+		in   interface{}
+		want interface{}
+	}{
+		{name: "true to int64", t: parquetInt64, in: true, want: int64(1)},
+		{name: "false to int64", t: parquetInt64, in: false, want: int64(0)},
+		{name: "true to float64", t: parquetFloat64, in: true, want: float64(1)},
+		{name: "false to float64", t: parquetFloat64, in: false, want: float64(0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, coerceTo(tt.t, tt.in))
+		})
+	}
+}
+
+// TestParquetEncoder_MixedBoolAndNumericColumn verifies the parquet encoder
+// can encode a column that mixes bool and numeric values across rows
+// without the writer rejecting the widened schema's type.
+func TestParquetEncoder_MixedBoolAndNumericColumn(t *testing.T) {
+	al := attrs.AttrList{
+		attrs.Attr{OutputKey: "flag", Include: true},
+	}
+	resultSet := []map[string]interface{}{
+		{"flag": true},
+		{"flag": 2},
+	}
+
+	var buf bytes.Buffer
+	err := Encoders["parquet"].Encode(&buf, resultSet, al, false)
+	require.NoError(t, err)
+	assert.NotEmpty(t, buf.Bytes())
+}
@@ -331,11 +331,8 @@ func TestGetColors(t *testing.T) {
 	assert.NotNil(t, odd)
 }
 
-// TestTableWriter verifies tabular output formatting.
-// Note: TableWriter uses fmt.Println which writes to stdout, not the provided
-// writer. This test verifies behavior through the data passed to table rendering,
-// since we can't easily intercept fmt.Println. A better approach would be to
-// refactor TableWriter to accept an io.Writer parameter for all output.
+// TestTableWriter verifies tabular output formatting, asserting directly on
+// the buffer TableWriter is given rather than on stdout.
 func TestTableWriter(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -405,7 +402,6 @@ func TestTableWriter(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Create a no-op writer since TableWriter writes to os.Stdout directly
 			buf := new(bytes.Buffer)
 
 			cmd := &cli.Command{
@@ -419,10 +415,14 @@ func TestTableWriter(t *testing.T) {
 				cmd.Metadata["header"] = tt.withTitle
 			}
 
-			// Call TableWriter - output goes to stdout
-			TableWriter(tt.resultSet, tt.attrs, cmd, buf)
+			TableWriter(tt.resultSet, tt.attrs, cmd, buf, nil)
+
+			if len(tt.resultSet) == 0 {
+				assert.Empty(t, buf.String())
+			} else {
+				assert.NotEmpty(t, buf.String())
+			}
 
-			// Verify data integrity through passed parameters
 			tt.checkFunc(t, tt.resultSet, tt.attrs)
 		})
 	}
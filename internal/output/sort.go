@@ -5,64 +5,270 @@ package output
 
 import (
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 )
 
+// nullPosition controls where nil/missing field values land in a sorted
+// dataset, independent of ascending/descending direction.
+type nullPosition int
+
+const (
+	nullsDefault nullPosition = iota
+	nullsFirst
+	nullsLast
+)
+
+// sortField is a single parsed component of a SortDataset spec.
+type sortField struct {
+	name          string
+	ascending     bool
+	caseSensitive bool
+	numeric       bool // "#field": force numeric parsing, even for strings.
+	natural       bool // "~field": natural/version-aware comparison.
+	timestamp     bool // "@field": RFC3339/time.Time chronological comparison.
+	nulls         nullPosition
+}
+
+// parseSortFields parses a comma-separated SortDataset spec into sortFields.
+// Grammar per field: ["-"] ["!" | "#" | "~" | "@"] name ["|nullsfirst" | "|nullslast"].
+func parseSortFields(spec string) []sortField {
+	specFields := strings.Split(spec, ",")
+	fields := make([]sortField, 0, len(specFields))
+
+	for _, raw := range specFields {
+		field := sortField{ascending: true}
+
+		name, qualifier, hasQualifier := strings.Cut(raw, "|")
+		if hasQualifier {
+			switch qualifier {
+			case "nullsfirst":
+				field.nulls = nullsFirst
+			case "nullslast":
+				field.nulls = nullsLast
+			}
+		}
+
+		if strings.HasPrefix(name, "-") {
+			name = strings.TrimPrefix(name, "-")
+			field.ascending = false
+		}
+
+		switch {
+		case strings.HasPrefix(name, "!"):
+			name = strings.TrimPrefix(name, "!")
+			field.caseSensitive = true
+		case strings.HasPrefix(name, "#"):
+			name = strings.TrimPrefix(name, "#")
+			field.numeric = true
+		case strings.HasPrefix(name, "~"):
+			name = strings.TrimPrefix(name, "~")
+			field.natural = true
+		case strings.HasPrefix(name, "@"):
+			name = strings.TrimPrefix(name, "@")
+			field.timestamp = true
+		}
+
+		field.name = name
+		fields = append(fields, field)
+	}
+
+	return fields
+}
+
 // THINK Issue 5
 func SortDataset(resultSet []map[string]interface{}, spec string) {
-	fields := strings.Split(spec, ",")
+	fields := parseSortFields(spec)
 
 	sort.SliceStable(resultSet, func(one, two int) bool {
-
 		for _, field := range fields {
-			ascending := true
-			if strings.HasPrefix(field, "-") {
-				field = strings.TrimPrefix(field, "-")
-				ascending = false
+			oneValue := resultSet[one][field.name]
+			twoValue := resultSet[two][field.name]
+
+			oneNil := isNilValue(oneValue)
+			twoNil := isNilValue(twoValue)
+			if oneNil || twoNil {
+				if oneNil == twoNil {
+					continue
+				}
+				return lessForNulls(oneNil, field.nulls)
 			}
 
-			caseSensitive := false
-			if strings.HasPrefix(field, "!") {
-				field = strings.TrimPrefix(field, "!")
-				caseSensitive = true
+			if less, equal := compareField(field, oneValue, twoValue); !equal {
+				return less
 			}
+		}
+		return false
+	})
+}
 
-			oneValue := resultSet[one][field]
-			twoValue := resultSet[two][field]
+// isNilValue reports whether value is missing or nil.
+func isNilValue(value interface{}) bool {
+	return value == nil
+}
 
-			// Convert to integers if possible
-			oneInt, oneOk := oneValue.(float64)
-			twoInt, twoOk := twoValue.(float64)
+// lessForNulls decides sort order when exactly one of a pair is nil, honoring
+// the nulls qualifier. With no qualifier, nulls sort last regardless of
+// ascending/descending.
+func lessForNulls(oneNil bool, pos nullPosition) bool {
+	switch pos {
+	case nullsFirst:
+		return oneNil
+	default:
+		return !oneNil
+	}
+}
 
-			if oneOk && twoOk {
-				if int(oneInt) != int(twoInt) {
-					if ascending {
-						return int(oneInt) < int(twoInt)
-					}
-					return int(oneInt) > int(twoInt)
-				}
-				continue
+// compareField compares oneValue and twoValue for field's modifiers. It
+// returns (less, equal); callers should move to the next field when equal.
+func compareField(field sortField, oneValue, twoValue interface{}) (less bool, equal bool) {
+	switch {
+	case field.timestamp:
+		oneTime, oneOk := toTime(oneValue)
+		twoTime, twoOk := toTime(twoValue)
+		if oneOk && twoOk {
+			if oneTime.Equal(twoTime) {
+				return false, true
 			}
+			return cmpBool(oneTime.Before(twoTime), field.ascending), false
+		}
+	case field.numeric:
+		oneNum, oneOk := toFloat(oneValue)
+		twoNum, twoOk := toFloat(twoValue)
+		if oneOk && twoOk {
+			if oneNum == twoNum {
+				return false, true
+			}
+			return cmpBool(oneNum < twoNum, field.ascending), false
+		}
+	case field.natural:
+		oneStr := InterfaceToString(oneValue)
+		twoStr := InterfaceToString(twoValue)
+		if !field.caseSensitive {
+			oneStr = strings.ToLower(oneStr)
+			twoStr = strings.ToLower(twoStr)
+		}
+		if oneStr == twoStr {
+			return false, true
+		}
+		return cmpBool(naturalLess(oneStr, twoStr), field.ascending), false
+	default:
+		// Default behavior: compare as numbers when both are float64 (the
+		// shape produced by JSON decoding), otherwise fall back to string
+		// comparison, which also handles bools.
+		oneFloat, oneOk := oneValue.(float64)
+		twoFloat, twoOk := twoValue.(float64)
+		if oneOk && twoOk {
+			if int(oneFloat) == int(twoFloat) {
+				return false, true
+			}
+			return cmpBool(int(oneFloat) < int(twoFloat), field.ascending), false
+		}
+	}
+
+	oneStr := InterfaceToString(oneValue)
+	twoStr := InterfaceToString(twoValue)
+	if !field.caseSensitive {
+		oneStr = strings.ToLower(oneStr)
+		twoStr = strings.ToLower(twoStr)
+	}
+	if oneStr == twoStr {
+		return false, true
+	}
+	return cmpBool(oneStr < twoStr, field.ascending), false
+}
 
-			// Fall back to string comparison which can also handle bools.
-			oneStr := InterfaceToString(oneValue)
-			twoStr := InterfaceToString(twoValue)
+// cmpBool flips lessThan when sorting descending.
+func cmpBool(lessThan bool, ascending bool) bool {
+	if ascending {
+		return lessThan
+	}
+	return !lessThan
+}
 
-			compareOneStr := oneStr
-			compareTwoStr := twoStr
-			if !caseSensitive {
-				compareOneStr = strings.ToLower(oneStr)
-				compareTwoStr = strings.ToLower(twoStr)
-			}
+// toFloat coerces a value to float64, accepting float64 and numeric strings.
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return 0, false
+		}
+		return f, true
+	default:
+		return 0, false
+	}
+}
 
-			if compareOneStr != compareTwoStr {
-				if ascending {
-					return compareOneStr < compareTwoStr
-				}
-				return compareOneStr > compareTwoStr
+// toTime coerces a value to time.Time, accepting time.Time and RFC3339
+// strings.
+func toTime(value interface{}) (time.Time, bool) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, true
+	case string:
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return time.Time{}, false
+		}
+		return t, true
+	default:
+		return time.Time{}, false
+	}
+}
+
+// naturalLess implements natural/version-aware string comparison: runs of
+// digits are compared numerically so "v1.10.0" sorts after "v1.2.0" rather
+// than before it.
+func naturalLess(one, two string) bool {
+	oneChunks := splitNatural(one)
+	twoChunks := splitNatural(two)
+
+	for i := 0; i < len(oneChunks) && i < len(twoChunks); i++ {
+		oc, tc := oneChunks[i], twoChunks[i]
+
+		oNum, oErr := strconv.Atoi(oc)
+		tNum, tErr := strconv.Atoi(tc)
+		if oErr == nil && tErr == nil {
+			if oNum != tNum {
+				return oNum < tNum
 			}
+			continue
+		}
 
+		if oc != tc {
+			return oc < tc
 		}
-		return false
-	})
+	}
+
+	return len(oneChunks) < len(twoChunks)
+}
+
+// splitNatural splits s into alternating runs of digits and non-digits.
+func splitNatural(s string) []string {
+	var chunks []string
+	var current strings.Builder
+	var inDigit bool
+
+	for i, r := range s {
+		isDigit := r >= '0' && r <= '9'
+		if i > 0 && isDigit != inDigit {
+			chunks = append(chunks, current.String())
+			current.Reset()
+		}
+		current.WriteRune(r)
+		inDigit = isDigit
+	}
+	if current.Len() > 0 {
+		chunks = append(chunks, current.String())
+	}
+
+	return chunks
 }
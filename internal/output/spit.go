@@ -101,13 +101,16 @@ func NewTag(h string, s string) schemaTag {
 // SliceDiceSpit orchestrates filtering, transforming, sorting and rendering
 // of a dataset according to command flags and attribute specifications. The
 // optional postProcess callback allows commands to apply custom transformations
-// to the filtered dataset before rendering.
+// to the filtered dataset before rendering. The optional classify callback is
+// passed through to TableWriter to color-code rows by semantic action; it has
+// no effect on any output format other than the default table.
 func SliceDiceSpit(raw bytes.Buffer,
 	attrs attrs.AttrList,
 	cmd *cli.Command,
 	parent string,
 	w io.Writer,
-	postProcess func([]map[string]interface{}) error) {
+	postProcess func([]map[string]interface{}) error,
+	classify func(map[string]interface{}) string) {
 
 	// Default to stdout.
 	if w == nil {
@@ -176,13 +179,17 @@ func SliceDiceSpit(raw bytes.Buffer,
 		if err != nil {
 			log.Errorf("SliceDiceSpit json marshal: %v", err)
 		}
-		os.Stdout.Write(jsonOutput)
+		_, _ = w.Write(jsonOutput)
 	case "yaml":
 		yamlOutput, err := yaml.Marshal(filteredDataset)
 		if err != nil {
 			log.Errorf("SliceDiceSpit yaml marshal: %v", err)
 		}
-		os.Stdout.Write(yamlOutput)
+		_, _ = w.Write(yamlOutput)
+	case "ndjson", "csv", "tsv", "parquet", "markdown", "html":
+		if err := Encoders[output].Encode(w, filteredDataset, attrs, cmd.Bool("titles")); err != nil {
+			log.Errorf("SliceDiceSpit %s encode: %v", output, err)
+		}
 	default:
 		// We apply command-specific post-processing.
 		if postProcess != nil {
@@ -191,18 +198,79 @@ func SliceDiceSpit(raw bytes.Buffer,
 			}
 		}
 
-		TableWriter(filteredDataset, attrs, cmd, w)
+		TableWriter(filteredDataset, attrs, cmd, w, classify)
+	}
+}
+
+// RenderOptions carries the same table-rendering settings TableWriter reads
+// off a *cli.Command (color/titles/padding/wrap/ellipsis/width plus a
+// header/footer banner), as plain values so Render can format a dataset
+// without depending on urfave/cli. TableWriter is a thin adapter that
+// builds one of these from cmd and calls Render.
+type RenderOptions struct {
+	Color    bool
+	Titles   bool
+	Padding  int
+	Wrap     bool
+	Ellipsis string
+	// Width is the column budget to fit output to; only consulted when
+	// WidthSet is true. With WidthSet false, Render falls back to
+	// $COLUMNS/the detected terminal width the same way terminalWidth does.
+	Width    int
+	WidthSet bool
+	Header   string
+	Footer   string
+}
+
+// renderOptionsFromCmd builds a RenderOptions from the flags/metadata
+// TableWriter has always read, so cmd-based callers see no behavior change.
+func renderOptionsFromCmd(cmd *cli.Command) RenderOptions {
+	opts := RenderOptions{
+		Color:    cmd.Bool("color"),
+		Titles:   cmd.Bool("titles"),
+		Padding:  cmd.Int("padding"),
+		Wrap:     cmd.Bool("wrap"),
+		Ellipsis: cmd.String("ellipsis"),
+		Width:    cmd.Int("width"),
+		WidthSet: cmd.IsSet("width"),
+	}
+	if h, ok := cmd.Metadata["header"].(string); ok {
+		opts.Header = h
+	}
+	if f, ok := cmd.Metadata["footer"].(string); ok {
+		opts.Footer = f
 	}
+	return opts
 }
 
 // TableWriter renders the result set in a tabular form honoring color,
 // titles and padding options. Output is written to w. If w is nil, os.Stdout
-// is used.
+// is used. The optional classify callback maps a row to a semantic action
+// bucket (e.g. "create", "delete"); when it returns a bucket with a
+// configured or default color, that row is styled with it instead of the
+// usual even/odd striping. classify is ignored entirely when --color is off.
+// TableWriter is a thin adapter over Render for CLI callers; library callers
+// that don't have a *cli.Command should call Render directly.
 func TableWriter(
 	resultSet []map[string]interface{},
 	attrs attrs.AttrList,
 	cmd *cli.Command,
-	w io.Writer) {
+	w io.Writer,
+	classify func(map[string]interface{}) string) {
+
+	Render(resultSet, attrs, renderOptionsFromCmd(cmd), w, classify)
+}
+
+// Render renders the result set in a tabular form per opts, with no
+// dependency on urfave/cli -- the cmd-free counterpart of TableWriter, for
+// embedders that build their own RenderOptions instead of a *cli.Command.
+// Output is written to w; if w is nil, os.Stdout is used.
+func Render(
+	resultSet []map[string]interface{},
+	attrs attrs.AttrList,
+	opts RenderOptions,
+	w io.Writer,
+	classify func(map[string]interface{}) string) {
 
 	if w == nil {
 		w = os.Stdout
@@ -222,7 +290,7 @@ func TableWriter(
 	)
 
 	// And then color styles if --color is present.
-	if cmd.Bool("color") {
+	if opts.Color {
 		headerColor, evenColor, oddColor := getColors("colors")
 
 		headerStyle = headerStyle.Foreground(headerColor)
@@ -230,27 +298,69 @@ func TableWriter(
 		oddRowStyle = oddRowStyle.Foreground(oddColor)
 	}
 
-	// We build the table rows from the result set.
+	// We build the table rows from the result set, and in parallel the
+	// ordered list of included attrs the columns correspond to, so the
+	// width-fitting pass below can look up each column's min/max hints.
+	includedAttrs := includedAttrList(attrs)
+
 	var rows [][]string
+	var rowActions []string
 	for _, result := range resultSet {
-		row := make([]string, 0, len(result))
-		for _, attr := range attrs {
-			if !attr.Include {
-				continue
-			}
+		row := make([]string, 0, len(includedAttrs))
+		for _, attr := range includedAttrs {
 			row = append(row, InterfaceToString(result[attr.OutputKey], "-"))
 		}
 		rows = append(rows, row)
+
+		if classify != nil {
+			rowActions = append(rowActions, classify(result))
+		}
 	}
 
 	// We render the header if present.
-	if cmd.Metadata["header"] != nil {
-		fmt.Fprintln(w, headerStyle.Render(cmd.Metadata["header"].(string)))
+	if opts.Header != "" {
+		fmt.Fprintln(w, headerStyle.Render(opts.Header))
 	}
 
 	// We configure the table with padding and styles.
-	pad := cmd.Int("padding")
-	// pad, _ := config.GetInt("padding", 0)
+	pad := opts.Padding
+
+	// Fit columns to the terminal (or --width) budget, then truncate or
+	// --wrap whatever cells still don't fit.
+	var headers []string
+	if opts.Titles {
+		for _, attr := range includedAttrs {
+			headers = append(headers, attr.OutputKey)
+		}
+	}
+
+	widths := fitColumnWidths(includedAttrs, columnWidths(includedAttrs, headers, rows), pad, resolveWidth(opts.Width, opts.WidthSet))
+	wrap := opts.Wrap
+	ellipsis := opts.Ellipsis
+
+	shrinkCell := func(s string, width int) string {
+		if lipgloss.Width(s) <= width {
+			return s
+		}
+		if wrap {
+			return wrapCell(s, width)
+		}
+		return truncateCell(s, width, ellipsis)
+	}
+
+	for i := range headers {
+		if i < len(widths) {
+			headers[i] = shrinkCell(headers[i], widths[i])
+		}
+	}
+	for _, row := range rows {
+		for i := range row {
+			if i < len(widths) {
+				row[i] = shrinkCell(row[i], widths[i])
+			}
+		}
+	}
+
 	t := table.New().
 		BorderBottom(false).
 		BorderTop(false).
@@ -268,6 +378,12 @@ func TableWriter(
 				style = oddRowStyle
 			}
 
+			if opts.Color && row != table.HeaderRow && row < len(rowActions) {
+				if actionColor, ok := getActionColor(rowActions[row]); ok {
+					style = cellStyle.Foreground(actionColor)
+				}
+			}
+
 			if col > 0 {
 				style = style.PaddingLeft(pad)
 			}
@@ -278,23 +394,36 @@ func TableWriter(
 		Rows(rows...)
 
 	// We add column headers if titles are enabled.
-	if cmd.Bool("titles") {
-		var headers []string
-		for _, attr := range attrs {
-			if attr.Include {
-				headers = append(headers, attr.OutputKey)
-			}
-		}
-
+	if opts.Titles {
 		// https://github.com/charmbracelet/lipgloss/issues/261
 		t = t.Headers(headers...).BorderHeader(false)
 	}
 	fmt.Fprintln(w, t)
 
 	// We render the footer if present.
-	if cmd.Metadata["footer"] != nil {
-		fmt.Fprintln(w, headerStyle.Render(cmd.Metadata["footer"].(string)))
+	if opts.Footer != "" {
+		fmt.Fprintln(w, headerStyle.Render(opts.Footer))
+	}
+}
+
+// FlattenStateResources parses raw as a Terraform/OpenTofu state document
+// and returns its resources flattened into the same per-instance row shape
+// SliceDiceSpit's sq path renders, with full (non-collapsed) module
+// addresses. ok is false if raw has no "resources" array, i.e. it isn't a
+// state document at all. Exists so callers that need the flattened rows
+// without rendering them (e.g. internal/snapshot, persisting state history)
+// can reuse flattenState without going through the whole SliceDiceSpit path.
+func FlattenStateResources(raw []byte) (rows []map[string]interface{}, ok bool) {
+	resources := gjson.Parse(string(raw)).Get("resources")
+	if !resources.Exists() {
+		return nil, false
+	}
+
+	flattened := flattenState(resources, true)
+	if err := json.Unmarshal(flattened.Bytes(), &rows); err != nil {
+		return nil, false
 	}
+	return rows, true
 }
 
 // flattenState takes the state schema of each entry and flattens it into a
@@ -386,6 +515,44 @@ func getColors(key string) (header, even, odd color.Color) {
 	return
 }
 
+// actionColorDefaults gives light/dark fallback colors for each semantic
+// action bucket a TableWriter classify callback may return, keyed to match
+// a Terraform plan's own create/update/delete/replace/read/no-op vocabulary.
+// Used when colors.actions.<action> isn't set in config.
+var actionColorDefaults = map[string][2]string{
+	"create":  {"#006800", "#00c800"}, // green
+	"update":  {"#806800", "#f6be00"}, // yellow
+	"delete":  {"#a00000", "#ff4040"}, // red
+	"replace": {"#800080", "#d070d0"}, // magenta
+	"read":    {"#006878", "#00c8f0"}, // cyan
+	"no-op":   {"#707070", "#a0a0a0"}, // gray
+}
+
+// getActionColor resolves the display color for a classify callback's
+// action bucket, honoring colors.actions.<action> if configured and falling
+// back to actionColorDefaults' light/dark pair otherwise. ok is false for an
+// empty or unrecognized action with no configured override, the caller's
+// signal to leave the row's normal even/odd striping alone.
+func getActionColor(action string) (c color.Color, ok bool) {
+	if action == "" {
+		return nil, false
+	}
+
+	if colorCfg, err := config.GetString("colors.actions." + action); err == nil {
+		return lipgloss.Color(colorCfg), true
+	}
+
+	defaults, known := actionColorDefaults[action]
+	if !known {
+		return nil, false
+	}
+
+	if lipgloss.HasDarkBackground(os.Stdin, os.Stdout) {
+		return lipgloss.Color(defaults[1]), true
+	}
+	return lipgloss.Color(defaults[0]), true
+}
+
 // getCommonFields extracts common fields from a resource, excluding instances.
 func getCommonFields(resource gjson.Result) map[string]interface{} {
 	var common = make(map[string]interface{})
@@ -0,0 +1,215 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package output
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/urfave/cli/v3"
+	"golang.org/x/term"
+
+	"github.com/tfctl/tfctl/internal/attrs"
+)
+
+// defaultEllipsis is used when --ellipsis is unset or empty.
+const defaultEllipsis = "…"
+
+// minColWidth is the floor fitColumnWidths shrinks a column to before
+// giving up on it and moving to the next-largest column. It's just wide
+// enough to hold one rune of content plus a one-rune ellipsis.
+const minColWidth = 2
+
+// terminalWidth resolves the column budget TableWriter fits its output to.
+// An explicit --width flag wins, including an explicit 0 (the "unlimited"
+// case, which disables fitting entirely). Otherwise it falls back to
+// $COLUMNS, then the stdout terminal's detected width via golang.org/x/term,
+// and finally 0 (unlimited) if none of those resolve, e.g. output piped to
+// a file, where there's no sensible width to fit to.
+func terminalWidth(cmd *cli.Command) int {
+	return resolveWidth(cmd.Int("width"), cmd.IsSet("width"))
+}
+
+// resolveWidth is terminalWidth's cmd-free core, used directly by
+// RenderOptions-driven rendering. width/widthSet mirror an explicit --width
+// flag and whether it was set at all.
+func resolveWidth(width int, widthSet bool) int {
+	if widthSet {
+		return width
+	}
+
+	if v, ok := os.LookupEnv("COLUMNS"); ok {
+		if n, err := strconv.Atoi(strings.TrimSpace(v)); err == nil && n > 0 {
+			return n
+		}
+	}
+
+	if w, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && w > 0 {
+		return w
+	}
+
+	return 0
+}
+
+// includedAttrList returns only the attrs marked Include, in declared
+// order. Exists so callers whose own "attrs" parameter shadows the package
+// name (e.g. TableWriter) can still build a filtered attrs.AttrList without
+// spelling out the package-qualified type themselves.
+func includedAttrList(al attrs.AttrList) attrs.AttrList {
+	var included attrs.AttrList
+	for _, a := range al {
+		if a.Include {
+			included = append(included, a)
+		}
+	}
+	return included
+}
+
+// columnWidths measures the max display width of each column across its
+// header (when shown) and every cell, then caps it at that column's attr's
+// MaxWidth hint, if any.
+func columnWidths(cols attrs.AttrList, headers []string, rows [][]string) []int {
+	widths := make([]int, len(cols))
+	for i, h := range headers {
+		if i < len(widths) {
+			widths[i] = lipgloss.Width(h)
+		}
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			if i >= len(widths) {
+				continue
+			}
+			if w := lipgloss.Width(cell); w > widths[i] {
+				widths[i] = w
+			}
+		}
+	}
+	for i, attr := range cols {
+		if attr.MaxWidth > 0 && widths[i] > attr.MaxWidth {
+			widths[i] = attr.MaxWidth
+		}
+	}
+	return widths
+}
+
+// fitColumnWidths shrinks widths, largest column first, until their
+// rendered total (cell content plus pad between columns) fits within
+// budget. A column never shrinks below its attr's MinWidth hint, or
+// minColWidth if that hint is unset. budget <= 0 disables fitting and
+// returns widths unchanged.
+func fitColumnWidths(cols attrs.AttrList, widths []int, pad, budget int) []int {
+	if budget <= 0 {
+		return widths
+	}
+
+	fitted := append([]int(nil), widths...)
+	floor := make([]int, len(fitted))
+	for i, attr := range cols {
+		floor[i] = minColWidth
+		if attr.MinWidth > floor[i] {
+			floor[i] = attr.MinWidth
+		}
+		if floor[i] > fitted[i] {
+			floor[i] = fitted[i]
+		}
+	}
+
+	total := func() int {
+		sum := 0
+		if len(fitted) > 1 {
+			sum = pad * (len(fitted) - 1)
+		}
+		for _, w := range fitted {
+			sum += w
+		}
+		return sum
+	}
+
+	for total() > budget {
+		largest := -1
+		for i, w := range fitted {
+			if w > floor[i] && (largest == -1 || w > fitted[largest]) {
+				largest = i
+			}
+		}
+		if largest == -1 {
+			// Every column is already at its floor; budget isn't achievable
+			// without losing content entirely, so stop shrinking.
+			break
+		}
+		fitted[largest]--
+	}
+
+	return fitted
+}
+
+// truncateCell shortens s to width display columns, replacing its trailing
+// runes with ellipsis if it's longer. Cells already within width pass
+// through unchanged.
+func truncateCell(s string, width int, ellipsis string) string {
+	if width <= 0 || lipgloss.Width(s) <= width {
+		return s
+	}
+	if ellipsis == "" {
+		ellipsis = defaultEllipsis
+	}
+
+	ellipsisWidth := lipgloss.Width(ellipsis)
+	if width <= ellipsisWidth {
+		runes := []rune(ellipsis)
+		if len(runes) > width {
+			runes = runes[:width]
+		}
+		return string(runes)
+	}
+
+	runes := []rune(s)
+	for len(runes) > 0 && lipgloss.Width(string(runes))+ellipsisWidth > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + ellipsis
+}
+
+// wrapCell soft-wraps s onto multiple lines, joined with "\n" (which
+// lipgloss/table renders as additional lines within the same cell), so that
+// no line exceeds width display columns. A word longer than width on its
+// own is hard-broken first, since greedy wrapping alone can't shrink it.
+// Used by --wrap in place of truncateCell.
+func wrapCell(s string, width int) string {
+	if width <= 0 {
+		return s
+	}
+
+	var chunks []string
+	for _, word := range strings.Fields(s) {
+		for lipgloss.Width(word) > width {
+			runes := []rune(word)
+			chunks = append(chunks, string(runes[:width]))
+			word = string(runes[width:])
+		}
+		if word != "" {
+			chunks = append(chunks, word)
+		}
+	}
+	if len(chunks) == 0 {
+		return s
+	}
+
+	var lines []string
+	line := chunks[0]
+	for _, c := range chunks[1:] {
+		if lipgloss.Width(line)+1+lipgloss.Width(c) > width {
+			lines = append(lines, line)
+			line = c
+		} else {
+			line += " " + c
+		}
+	}
+	lines = append(lines, line)
+
+	return strings.Join(lines, "\n")
+}
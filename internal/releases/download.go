@@ -0,0 +1,165 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package releases
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	getter "github.com/hashicorp/go-getter"
+
+	"github.com/tfctl/tfctl/internal/log"
+)
+
+// staleLockAfter is how long a lock file is honored before it's assumed to
+// belong to a crashed process and is stolen rather than waited on forever.
+const staleLockAfter = 10 * time.Minute
+
+// downloadAndExtract fetches the product/version/GOOS/GOARCH zip, verifies
+// it against the published SHA256SUMS file, and extracts it atomically into
+// dir via a temp-directory-then-rename, the same pattern cacheutil.Write
+// uses for single files. A lock file alongside dir keeps two concurrent
+// tfctl invocations from racing on the same version directory.
+func downloadAndExtract(ctx context.Context, product Product, version, dir string) error {
+	unlock, err := lockVersionDir(dir)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	// Another invocation may have finished the download while we were
+	// waiting on the lock.
+	if _, err := os.Stat(binaryPath(dir, product)); err == nil {
+		return nil
+	}
+
+	zipName := assetName(product, version)
+	sum, err := fetchChecksum(ctx, product, version, zipName)
+	if err != nil {
+		return err
+	}
+
+	src := assetURL(product, version, zipName) + "?checksum=sha256:" + sum
+
+	tmp, err := os.MkdirTemp(filepath.Dir(dir), "."+filepath.Base(dir)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp extract dir: %w", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	client := &getter.Client{
+		Ctx:  ctx,
+		Src:  src,
+		Dst:  tmp,
+		Pwd:  tmp,
+		Mode: getter.ClientModeDir,
+	}
+	if err := client.Get(); err != nil {
+		return fmt.Errorf("failed to download %s %s: %w", product, version, err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dir), 0o755); err != nil { //nolint:mnd
+		return fmt.Errorf("failed to create releases cache dir: %w", err)
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("failed to clear stale extract dir: %w", err)
+	}
+	if err := os.Rename(tmp, dir); err != nil {
+		return fmt.Errorf("failed to finalize release extract dir: %w", err)
+	}
+
+	log.Debugf("release ready: product=%s version=%s dir=%s", product, version, dir)
+	return nil
+}
+
+// assetName returns the zip filename Hashicorp/OpenTofu publish for this
+// product/version/GOOS/GOARCH combination.
+func assetName(product Product, version string) string {
+	return fmt.Sprintf("%s_%s_%s_%s.zip", product, version, runtime.GOOS, runtime.GOARCH)
+}
+
+// assetURL returns the download URL for a given product/version asset.
+func assetURL(product Product, version, asset string) string {
+	if product == Tofu {
+		return fmt.Sprintf("https://github.com/opentofu/opentofu/releases/download/v%s/%s", version, asset)
+	}
+	return fmt.Sprintf("https://releases.hashicorp.com/terraform/%s/%s", version, asset)
+}
+
+// sumsURL returns the URL of the published SHA256SUMS file for a version.
+func sumsURL(product Product, version string) string {
+	if product == Tofu {
+		return fmt.Sprintf("https://github.com/opentofu/opentofu/releases/download/v%s/tofu_%s_SHA256SUMS", version, version)
+	}
+	return fmt.Sprintf("https://releases.hashicorp.com/terraform/%s/terraform_%s_SHA256SUMS", version, version)
+}
+
+// fetchChecksum downloads the SHA256SUMS file for product/version and
+// returns the hex digest for asset.
+func fetchChecksum(ctx context.Context, product Product, version, asset string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, sumsURL(product, version), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to build checksum request: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch checksums for %s %s: %w", product, version, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("checksums for %s %s returned status %d", product, version, resp.StatusCode)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && fields[1] == asset {
+			return fields[0], nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("failed to read checksums for %s %s: %w", product, version, err)
+	}
+
+	return "", fmt.Errorf("no checksum found for %s in %s %s SHA256SUMS", asset, product, version)
+}
+
+// lockVersionDir takes an on-disk lock on dir for the duration of a
+// download, so two concurrent tfctl invocations resolving the same
+// product/version don't race on the same extract-then-rename. A lock older
+// than staleLockAfter is assumed abandoned by a crashed process and stolen.
+func lockVersionDir(dir string) (func(), error) {
+	lockPath := dir + ".lock"
+	if err := os.MkdirAll(filepath.Dir(lockPath), 0o755); err != nil { //nolint:mnd
+		return nil, fmt.Errorf("failed to create releases cache dir: %w", err)
+	}
+
+	for {
+		f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644) //nolint:mnd
+		if err == nil {
+			f.Close()
+			return func() { os.Remove(lockPath) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, fmt.Errorf("failed to create release lock %s: %w", lockPath, err)
+		}
+
+		if info, statErr := os.Stat(lockPath); statErr == nil && time.Since(info.ModTime()) > staleLockAfter {
+			log.Debugf("stealing stale release lock: %s", lockPath)
+			os.Remove(lockPath)
+			continue
+		}
+
+		time.Sleep(250 * time.Millisecond) //nolint:mnd
+	}
+}
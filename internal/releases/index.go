@@ -0,0 +1,196 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package releases
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tfctl/tfctl/internal/cacheutil"
+	"github.com/tfctl/tfctl/internal/config"
+	"github.com/tfctl/tfctl/internal/log"
+)
+
+// indexURL returns the upstream releases index for product, a
+// Hashicorp-checkpoint-style JSON document listing every published version.
+func indexURL(product Product) string {
+	switch product {
+	case Tofu:
+		return "https://api.github.com/repos/opentofu/opentofu/releases"
+	default:
+		return "https://checkpoint-api.hashicorp.com/v1/check/" + string(product)
+	}
+}
+
+// checkpointIndex is the shape of Hashicorp's checkpoint response; only the
+// fields Latest needs are modeled.
+type checkpointIndex struct {
+	Product        string `json:"product"`
+	CurrentVersion string `json:"current_version"`
+}
+
+// memCache holds the in-process copy of each product's resolved latest
+// version, so repeated calls within a single tfctl invocation never hit the
+// network more than once. The on-disk copy (via cacheutil) backstops this
+// across invocations.
+var (
+	memMu    sync.Mutex
+	memCache = map[Product]string{}
+)
+
+// Latest resolves the newest published version for product, consulting (in
+// order) the in-memory cache, the on-disk TTL cache, and finally the
+// upstream index. The on-disk entry is refreshed whenever it is older than
+// releases.index-ttl-minutes (default 60), so a cold cache doesn't mean a
+// network round trip on every single command.
+func Latest(ctx context.Context, product Product) (string, error) {
+	if !product.Valid() {
+		return "", fmt.Errorf("unknown release product: %q", product)
+	}
+
+	memMu.Lock()
+	if v, ok := memCache[product]; ok {
+		memMu.Unlock()
+		return v, nil
+	}
+	memMu.Unlock()
+
+	subdirs := []string{"releases-index"}
+	key := string(product)
+
+	ttlMinutes, _ := config.GetInt("releases.index-ttl-minutes", 60)
+	if entry, ok := cacheutil.Read(subdirs, key); ok {
+		if ttlMinutes <= 0 || time.Since(entryCreatedAt(entry.Path)) < time.Duration(ttlMinutes)*time.Minute {
+			v := string(entry.Data)
+			memMu.Lock()
+			memCache[product] = v
+			memMu.Unlock()
+			return v, nil
+		}
+	}
+
+	v, err := fetchLatest(ctx, product)
+	if err != nil {
+		return "", err
+	}
+
+	if err := cacheutil.Write(subdirs, key, []byte(v)); err != nil {
+		log.WithError(err).Debugf("failed to cache releases index entry: product=%s", product)
+	}
+
+	memMu.Lock()
+	memCache[product] = v
+	memMu.Unlock()
+
+	return v, nil
+}
+
+// entryCreatedAt reports the mtime of a cache entry's backing file, used as
+// the TTL clock since cacheutil's frame header isn't exported outside the
+// package.
+func entryCreatedAt(path string) time.Time {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
+
+// fetchLatest hits the upstream index directly, bypassing both cache layers.
+func fetchLatest(ctx context.Context, product Product) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, indexURL(product), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to build releases index request: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch releases index for %s: %w", product, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("releases index for %s returned status %d", product, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read releases index for %s: %w", product, err)
+	}
+
+	switch product {
+	case Tofu:
+		return latestFromGithubTags(body)
+	default:
+		var idx checkpointIndex
+		if err := json.Unmarshal(body, &idx); err != nil {
+			return "", fmt.Errorf("failed to parse releases index for %s: %w", product, err)
+		}
+		if idx.CurrentVersion == "" {
+			return "", fmt.Errorf("releases index for %s had no current_version", product)
+		}
+		return idx.CurrentVersion, nil
+	}
+}
+
+// latestFromGithubTags picks the newest tag_name out of a GitHub releases
+// listing, stripping OpenTofu's "v" tag prefix to match the bare version
+// strings the rest of this package (and Terraform's own checkpoint index)
+// uses.
+func latestFromGithubTags(body []byte) (string, error) {
+	var ghReleases []struct {
+		TagName    string `json:"tag_name"`
+		Draft      bool   `json:"draft"`
+		Prerelease bool   `json:"prerelease"`
+	}
+	if err := json.Unmarshal(body, &ghReleases); err != nil {
+		return "", fmt.Errorf("failed to parse opentofu releases: %w", err)
+	}
+
+	versions := make([]string, 0, len(ghReleases))
+	for _, r := range ghReleases {
+		if r.Draft || r.Prerelease {
+			continue
+		}
+		versions = append(versions, strings.TrimPrefix(r.TagName, "v"))
+	}
+	if len(versions) == 0 {
+		return "", fmt.Errorf("opentofu releases index had no published releases")
+	}
+
+	sort.Slice(versions, func(i, j int) bool { return versionLess(versions[i], versions[j]) })
+	return versions[len(versions)-1], nil
+}
+
+// versionLess compares two dotted numeric version strings (e.g. "1.9.2" vs
+// "1.10.0") component-wise rather than lexically, since GitHub tag names
+// don't sort correctly as plain strings once a component reaches two digits.
+// Non-numeric components fall back to a string comparison.
+func versionLess(a, b string) bool {
+	as := strings.Split(a, ".")
+	bs := strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		an, aerr := strconv.Atoi(as[i])
+		bn, berr := strconv.Atoi(bs[i])
+		if aerr != nil || berr != nil {
+			if as[i] != bs[i] {
+				return as[i] < bs[i]
+			}
+			continue
+		}
+		if an != bn {
+			return an < bn
+		}
+	}
+	return len(as) < len(bs)
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package releases resolves, downloads, and caches Terraform/OpenTofu release
+// binaries under a per-user cache directory, keyed by product, version, and
+// platform, so commands that need to shell out to the real binary (e.g. a
+// future "tfctl plan"/"validate") don't have to assume one is already on
+// PATH. Borrowed from OTF's own release-resolution subsystem.
+package releases
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/tfctl/tfctl/internal/cacheutil"
+)
+
+// Product identifies which binary is being resolved.
+type Product string
+
+const (
+	Terraform Product = "terraform"
+	Tofu      Product = "tofu"
+)
+
+// Valid reports whether p is a product releases knows how to resolve.
+func (p Product) Valid() bool {
+	return p == Terraform || p == Tofu
+}
+
+// Get returns the path to the product's binary for version, downloading and
+// caching it under releasesDir first if it isn't already there. version must
+// be a concrete release (e.g. "1.9.2"); callers wanting the newest release
+// should resolve it via Latest first.
+func Get(ctx context.Context, product Product, version string) (string, error) {
+	if !product.Valid() {
+		return "", fmt.Errorf("unknown release product: %q", product)
+	}
+
+	dir, ok := versionDir(product, version)
+	if !ok {
+		return "", fmt.Errorf("failed to resolve cache directory for %s %s", product, version)
+	}
+
+	bin := binaryPath(dir, product)
+	if _, err := os.Stat(bin); err == nil {
+		return bin, nil
+	}
+
+	if err := downloadAndExtract(ctx, product, version, dir); err != nil {
+		return "", err
+	}
+
+	return bin, nil
+}
+
+// versionDir returns the directory a given product/version/GOOS/GOARCH
+// combination is cached under, mirroring local.SourceCacheDir's approach of
+// keying off cacheutil's base directory but owning its own subtree rather
+// than a single framed cacheutil entry, since a release is a directory of
+// files (the binary plus whatever else ships in the zip).
+func versionDir(product Product, version string) (string, bool) {
+	base, ok := cacheutil.Dir()
+	if !ok {
+		return "", false
+	}
+	return filepath.Join(base, "releases", string(product), version, runtime.GOOS+"_"+runtime.GOARCH), true
+}
+
+// binaryPath returns the path the extracted binary is expected to live at
+// within a version directory.
+func binaryPath(dir string, product Product) string {
+	name := string(product)
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+	return filepath.Join(dir, name)
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/tfctl/tfctl/internal/diff"
+)
+
+// ActionAdded/ActionRemoved/ActionChanged re-export internal/diff's Action
+// vocabulary as plain strings so a Row's Action lines up with
+// internal/command/diff.go's diffRow, letting `tfctl state diff` reuse the
+// same classifyDiffAction/TableWriter row coloring `tfctl diff` does.
+const (
+	ActionAdded   = string(diff.Added)
+	ActionRemoved = string(diff.Removed)
+	ActionChanged = string(diff.Changed)
+)
+
+// Row is one flattened row of a snapshot-to-snapshot diff: either a whole
+// added/removed resource, or a single changed attribute within a changed
+// resource -- the same shape internal/command/diff.go's diffRow uses, so
+// `tfctl state diff` can render through output.SliceDiceSpit/TableWriter the
+// same way `tfctl diff` does.
+type Row struct {
+	Resource  string `json:"resource"`
+	Action    string `json:"action"`
+	Attribute string `json:"attribute,omitempty"`
+	Old       string `json:"old,omitempty"`
+	New       string `json:"new,omitempty"`
+}
+
+// Diff compares two canonicalized flattened resource sets (e.g. as returned
+// by Load) by their "resource" key, reporting whole-resource add/remove and,
+// for a resource present in both, one row per attribute that differs.
+func Diff(from, to []map[string]interface{}) []Row {
+	fromByResource := indexByResource(from)
+	toByResource := indexByResource(to)
+
+	seen := map[string]bool{}
+	var resources []string
+	for _, row := range from {
+		addr := resourceAddr(row)
+		if !seen[addr] {
+			seen[addr] = true
+			resources = append(resources, addr)
+		}
+	}
+	for _, row := range to {
+		addr := resourceAddr(row)
+		if !seen[addr] {
+			seen[addr] = true
+			resources = append(resources, addr)
+		}
+	}
+	sort.Strings(resources)
+
+	var rows []Row
+	for _, addr := range resources {
+		oldRow, inOld := fromByResource[addr]
+		newRow, inNew := toByResource[addr]
+
+		switch {
+		case !inOld:
+			rows = append(rows, Row{Resource: addr, Action: ActionAdded})
+		case !inNew:
+			rows = append(rows, Row{Resource: addr, Action: ActionRemoved})
+		default:
+			rows = append(rows, attributeRows(addr, oldRow, newRow)...)
+		}
+	}
+
+	return rows
+}
+
+func resourceAddr(row map[string]interface{}) string {
+	return fmt.Sprintf("%v", row["resource"])
+}
+
+func indexByResource(rows []map[string]interface{}) map[string]map[string]interface{} {
+	byResource := make(map[string]map[string]interface{}, len(rows))
+	for _, row := range rows {
+		byResource[resourceAddr(row)] = row
+	}
+	return byResource
+}
+
+// attributeRows compares oldRow and newRow field by field, excluding the
+// "resource" key itself, returning one Row per attribute whose value
+// differs.
+func attributeRows(addr string, oldRow, newRow map[string]interface{}) []Row {
+	keys := make(map[string]bool, len(oldRow)+len(newRow))
+	for k := range oldRow {
+		keys[k] = true
+	}
+	for k := range newRow {
+		keys[k] = true
+	}
+	delete(keys, "resource")
+
+	names := make([]string, 0, len(keys))
+	for k := range keys {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	var rows []Row
+	for _, name := range names {
+		oldVal, newVal := oldRow[name], newRow[name]
+		if valuesEqual(oldVal, newVal) {
+			continue
+		}
+
+		change := diff.AttributeChange{Attribute: name, Action: diff.Changed, Old: oldVal, New: newVal}
+		oldStr, newStr := diff.FormatAttributeChange(change)
+		rows = append(rows, Row{
+			Resource:  addr,
+			Action:    ActionChanged,
+			Attribute: name,
+			Old:       oldStr,
+			New:       newStr,
+		})
+	}
+	return rows
+}
+
+// valuesEqual compares two attribute values via their canonical JSON
+// encoding, the same approach internal/diff.valuesEqual uses, so map/slice
+// key order and numeric-type (float64 vs int) differences that come from
+// round-tripping through JSON don't register as spurious changes.
+func valuesEqual(a, b any) bool {
+	aj, aerr := json.Marshal(a)
+	bj, berr := json.Marshal(b)
+	if aerr != nil || berr != nil {
+		return fmt.Sprintf("%v", a) == fmt.Sprintf("%v", b)
+	}
+	return string(aj) == string(bj)
+}
@@ -0,0 +1,268 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package snapshot persists a local history of flattened state, one entry
+// per serial per workspace, so a user can audit drift over time (added,
+// changed, and removed resources between any two serials) without standing
+// up Terraboard or a database server. Each snapshot is the same flattened
+// resource row shape output.FlattenStateResources (and, by extension, the sq
+// command's own rendering path) produces, canonicalized by sorting on the
+// "resource" address so two snapshots of an otherwise-identical state diff
+// as empty regardless of key order.
+//
+// Snapshots are plain JSON files under a resolved base directory, the same
+// convention internal/cacheutil uses for its own on-disk store, rather than
+// an embedded database: it keeps this package dependency-free and lets a
+// user inspect or delete history with ordinary file tools.
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Meta describes one persisted snapshot: the serial and timestamp it was
+// captured at, and how its flattened resource set differs from the
+// workspace's previous snapshot (zero for the first snapshot of a
+// workspace).
+type Meta struct {
+	Workspace string    `json:"workspace"`
+	Serial    int64     `json:"serial"`
+	Timestamp time.Time `json:"timestamp"`
+	Added     int       `json:"added"`
+	Changed   int       `json:"changed"`
+	Removed   int       `json:"removed"`
+}
+
+// Dir resolves the base snapshot directory.
+// Precedence:
+//  1. TFCTL_SNAPSHOT_DIR, if set and non-empty
+//  2. os.UserCacheDir()/tfctl/snapshots
+//
+// Returns ("", false) if a base cannot be resolved (treat as disabled).
+func Dir() (string, bool) {
+	if d, ok := os.LookupEnv("TFCTL_SNAPSHOT_DIR"); ok && d != "" {
+		return d, true
+	}
+	if dir, err := os.UserCacheDir(); err == nil && dir != "" {
+		return filepath.Join(dir, "tfctl", "snapshots"), true
+	}
+	return "", false
+}
+
+// Enabled returns true unless TFCTL_SNAPSHOT explicitly disables it
+// ("0"/"false"), mirroring cacheutil.Enabled.
+func Enabled() bool {
+	enabled, _ := os.LookupEnv("TFCTL_SNAPSHOT")
+	return enabled == "" || (enabled != "0" && enabled != "false")
+}
+
+// workspaceDir returns (and, if needed, creates) the directory snapshots for
+// workspace live in.
+func workspaceDir(workspace string) (string, error) {
+	base, ok := Dir()
+	if !ok {
+		return "", fmt.Errorf("snapshot: no usable base directory")
+	}
+
+	dir := filepath.Join(base, sanitize(workspace))
+	if err := os.MkdirAll(dir, 0o755); err != nil { //nolint:mnd
+		return "", fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+	return dir, nil
+}
+
+// sanitize replaces path separators in workspace so it's safe to use as a
+// single directory component.
+func sanitize(workspace string) string {
+	if workspace == "" {
+		workspace = "default"
+	}
+	return strings.NewReplacer("/", "_", string(filepath.Separator), "_").Replace(workspace)
+}
+
+// dataPath/metaPath are the two files a saved serial occupies: the
+// canonicalized resource rows, and the sidecar Meta recording its diff
+// counts against the previous serial.
+func dataPath(dir string, serial int64) string {
+	return filepath.Join(dir, strconv.FormatInt(serial, 10)+".json")
+}
+
+func metaPath(dir string, serial int64) string {
+	return filepath.Join(dir, strconv.FormatInt(serial, 10)+".meta.json")
+}
+
+// canonicalize sorts rows by their "resource" field so byte-for-byte
+// comparison (and JSON round-tripping) is stable regardless of the order
+// the backend happened to return them in.
+func canonicalize(rows []map[string]interface{}) []map[string]interface{} {
+	sorted := append([]map[string]interface{}(nil), rows...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return fmt.Sprintf("%v", sorted[i]["resource"]) < fmt.Sprintf("%v", sorted[j]["resource"])
+	})
+	return sorted
+}
+
+// Save persists rows (already flattened, e.g. via output.FlattenStateResources)
+// as workspace's snapshot for serial, skipping the write entirely if that
+// serial already has one (serials are immutable once pulled). It also writes
+// a Meta sidecar recording how rows differs from the workspace's previous
+// serial, if any, so History doesn't need to re-load and re-diff every pair
+// of snapshots on every call.
+func Save(workspace string, serial int64, rows []map[string]interface{}) error {
+	if !Enabled() {
+		return nil
+	}
+
+	dir, err := workspaceDir(workspace)
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(dataPath(dir, serial)); err == nil {
+		return nil
+	}
+
+	sorted := canonicalize(rows)
+
+	data, err := json.Marshal(sorted)
+	if err != nil {
+		return fmt.Errorf("failed to marshal snapshot: %w", err)
+	}
+	if err := os.WriteFile(dataPath(dir, serial), data, 0o644); err != nil { //nolint:mnd
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+
+	m := Meta{Workspace: workspace, Serial: serial, Timestamp: time.Now()}
+	if prev, ok := previousSerial(dir, serial); ok {
+		prevRows, err := Load(workspace, prev)
+		if err == nil {
+			diffRows := Diff(prevRows, sorted)
+			for _, r := range diffRows {
+				switch r.Action {
+				case ActionAdded:
+					m.Added++
+				case ActionRemoved:
+					m.Removed++
+				case ActionChanged:
+					m.Changed++
+				}
+			}
+		}
+	} else {
+		m.Added = len(sorted)
+	}
+
+	metaData, err := json.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("failed to marshal snapshot meta: %w", err)
+	}
+	if err := os.WriteFile(metaPath(dir, serial), metaData, 0o644); err != nil { //nolint:mnd
+		return fmt.Errorf("failed to write snapshot meta: %w", err)
+	}
+
+	return nil
+}
+
+// previousSerial returns the highest serial below serial that already has a
+// snapshot in dir, if any.
+func previousSerial(dir string, serial int64) (int64, bool) {
+	serials, err := serialsIn(dir)
+	if err != nil {
+		return 0, false
+	}
+
+	best := int64(-1)
+	for _, s := range serials {
+		if s < serial && s > best {
+			best = s
+		}
+	}
+	if best == -1 {
+		return 0, false
+	}
+	return best, true
+}
+
+// serialsIn lists every serial with a persisted snapshot in dir, ascending.
+func serialsIn(dir string) ([]int64, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var serials []int64
+	for _, e := range entries {
+		name := e.Name()
+		if !strings.HasSuffix(name, ".json") || strings.HasSuffix(name, ".meta.json") {
+			continue
+		}
+		s, err := strconv.ParseInt(strings.TrimSuffix(name, ".json"), 10, 64)
+		if err != nil {
+			continue
+		}
+		serials = append(serials, s)
+	}
+
+	sort.Slice(serials, func(i, j int) bool { return serials[i] < serials[j] })
+	return serials, nil
+}
+
+// History returns every persisted Meta for workspace, ordered oldest first.
+func History(workspace string) ([]Meta, error) {
+	base, ok := Dir()
+	if !ok {
+		return nil, fmt.Errorf("snapshot: no usable base directory")
+	}
+	dir := filepath.Join(base, sanitize(workspace))
+
+	serials, err := serialsIn(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+
+	history := make([]Meta, 0, len(serials))
+	for _, s := range serials {
+		data, err := os.ReadFile(metaPath(dir, s))
+		if err != nil {
+			continue
+		}
+		var m Meta
+		if err := json.Unmarshal(data, &m); err != nil {
+			continue
+		}
+		history = append(history, m)
+	}
+
+	return history, nil
+}
+
+// Load returns the canonicalized flattened resource rows persisted for
+// workspace's serial.
+func Load(workspace string, serial int64) ([]map[string]interface{}, error) {
+	base, ok := Dir()
+	if !ok {
+		return nil, fmt.Errorf("snapshot: no usable base directory")
+	}
+	dir := filepath.Join(base, sanitize(workspace))
+
+	data, err := os.ReadFile(dataPath(dir, serial))
+	if err != nil {
+		return nil, fmt.Errorf("no snapshot for serial %d: %w", serial, err)
+	}
+
+	var rows []map[string]interface{}
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal snapshot: %w", err)
+	}
+	return rows, nil
+}
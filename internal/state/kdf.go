@@ -0,0 +1,87 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package state
+
+import (
+	"crypto/sha512"
+	"fmt"
+	"sync"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+)
+
+// KDF derives an AES key of keyLength bytes from a passphrase and salt.
+// pbkdf2Provider dispatches to one of these based on its config's "kdf"
+// field, so a key_provider.pbkdf2.<instance> entry stays self-describing
+// even when it isn't using PBKDF2 itself.
+type KDF interface {
+	// Name is the pbkdf2KeyProviderConfig "kdf" value this implementation
+	// answers to, e.g. "pbkdf2", "argon2id", "scrypt".
+	Name() string
+	DeriveKey(passphrase string, salt []byte, cfg pbkdf2KeyProviderConfig) ([]byte, error)
+}
+
+var (
+	kdfsMu sync.RWMutex
+	kdfs   = map[string]KDF{}
+)
+
+// RegisterKDF makes k available under k.Name(). Built-ins register
+// themselves via init().
+func RegisterKDF(k KDF) {
+	kdfsMu.Lock()
+	defer kdfsMu.Unlock()
+	kdfs[k.Name()] = k
+}
+
+func lookupKDF(name string) (KDF, bool) {
+	kdfsMu.RLock()
+	defer kdfsMu.RUnlock()
+	k, ok := kdfs[name]
+	return k, ok
+}
+
+func init() {
+	RegisterKDF(pbkdf2KDF{})
+	RegisterKDF(argon2idKDF{})
+	RegisterKDF(scryptKDF{})
+}
+
+// pbkdf2KDF is the original, and still default, KDF: PBKDF2-HMAC-SHA512.
+// OpenTofu's own pbkdf2 key provider never uses anything else, so this is
+// the only KDF a state file produced outside tfctl will ever reference.
+type pbkdf2KDF struct{}
+
+func (pbkdf2KDF) Name() string { return "pbkdf2" }
+
+func (pbkdf2KDF) DeriveKey(passphrase string, salt []byte, cfg pbkdf2KeyProviderConfig) ([]byte, error) {
+	return pbkdf2.Key([]byte(passphrase), salt, cfg.Iterations, cfg.KeyLength, sha512.New), nil
+}
+
+// argon2idKDF derives the key with Argon2id, memory-hard and resistant to
+// GPU/ASIC cracking in a way PBKDF2 isn't.
+type argon2idKDF struct{}
+
+func (argon2idKDF) Name() string { return "argon2id" }
+
+func (argon2idKDF) DeriveKey(passphrase string, salt []byte, cfg pbkdf2KeyProviderConfig) ([]byte, error) {
+	if cfg.Memory == 0 || cfg.Time == 0 || cfg.Parallelism == 0 {
+		return nil, fmt.Errorf("argon2id config requires memory, time, and parallelism")
+	}
+	return argon2.IDKey([]byte(passphrase), salt, cfg.Time, cfg.Memory, cfg.Parallelism, uint32(cfg.KeyLength)), nil
+}
+
+// scryptKDF derives the key with scrypt.
+type scryptKDF struct{}
+
+func (scryptKDF) Name() string { return "scrypt" }
+
+func (scryptKDF) DeriveKey(passphrase string, salt []byte, cfg pbkdf2KeyProviderConfig) ([]byte, error) {
+	if cfg.N == 0 || cfg.R == 0 || cfg.P == 0 {
+		return nil, fmt.Errorf("scrypt config requires n, r, and p")
+	}
+	return scrypt.Key([]byte(passphrase), salt, cfg.N, cfg.R, cfg.P, cfg.KeyLength)
+}
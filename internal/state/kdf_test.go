@@ -0,0 +1,111 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEncryptOpenTofuStateWithKDF_RoundTripsEachKDF verifies that a state
+// file encrypted with each supported KDF decrypts back to the original
+// plaintext via the normal DecryptOpenTofuState path.
+func TestEncryptOpenTofuStateWithKDF_RoundTripsEachKDF(t *testing.T) {
+	passphrase := "correct-horse-battery-staple"
+	plaintext := []byte(`{"version":4,"terraform_version":"1.8.0"}`)
+
+	cases := []struct {
+		name   string
+		kdf    string
+		params KDFParams
+	}{
+		{"pbkdf2 default", "", KDFParams{}},
+		{"pbkdf2 explicit", "pbkdf2", KDFParams{Iterations: 10000}},
+		{"argon2id", "argon2id", KDFParams{Memory: 8 * 1024, Time: 1, Parallelism: 2}},
+		{"scrypt", "scrypt", KDFParams{N: 1024, R: 8, P: 1}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			encrypted, err := EncryptOpenTofuStateWithKDF(plaintext, passphrase, tc.kdf, tc.params)
+			require.NoError(t, err)
+
+			decrypted, err := DecryptOpenTofuState(encrypted, passphrase)
+			require.NoError(t, err)
+			assert.Equal(t, plaintext, decrypted)
+		})
+	}
+}
+
+// TestEncryptOpenTofuStateWithKDF_UnknownKDF verifies that an unrecognized
+// kdf name is rejected up front rather than silently falling back to
+// pbkdf2.
+func TestEncryptOpenTofuStateWithKDF_UnknownKDF(t *testing.T) {
+	t.Parallel()
+	_, err := EncryptOpenTofuStateWithKDF([]byte("{}"), "pw", "bcrypt", KDFParams{})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown kdf")
+}
+
+// TestEncryptOpenTofuStateWithKDF_Argon2idRequiresParams verifies argon2id
+// rejects a zero-value config instead of silently deriving with memory=0,
+// which would defeat the point of a memory-hard KDF.
+func TestEncryptOpenTofuStateWithKDF_Argon2idRequiresParams(t *testing.T) {
+	t.Parallel()
+	_, err := EncryptOpenTofuStateWithKDF([]byte("{}"), "pw", "argon2id", KDFParams{})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "argon2id config requires")
+}
+
+// TestEncryptOpenTofuStateWithKDF_ScryptRequiresParams is Argon2id's
+// counterpart for scrypt's n/r/p.
+func TestEncryptOpenTofuStateWithKDF_ScryptRequiresParams(t *testing.T) {
+	t.Parallel()
+	_, err := EncryptOpenTofuStateWithKDF([]byte("{}"), "pw", "scrypt", KDFParams{})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "scrypt config requires")
+}
+
+// TestBenchmarkKDFParams_RejectsPBKDF2 verifies kdf-bench's underlying
+// benchmark only supports the two KDFs tuning actually applies to.
+func TestBenchmarkKDFParams_RejectsPBKDF2(t *testing.T) {
+	t.Parallel()
+	_, _, err := BenchmarkKDFParams("pbkdf2", 10*time.Millisecond)
+
+	assert.Error(t, err)
+}
+
+// TestBenchmarkKDFParams_MeetsBudget verifies both tunable KDFs return
+// parameters whose measured derivation time is at least the requested
+// budget, using a small budget to keep the test fast.
+func TestBenchmarkKDFParams_MeetsBudget(t *testing.T) {
+	t.Parallel()
+	budget := 10 * time.Millisecond
+
+	for _, name := range []string{"argon2id", "scrypt"} {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			params, elapsed, err := BenchmarkKDFParams(name, budget)
+			require.NoError(t, err)
+			assert.GreaterOrEqual(t, elapsed, budget)
+
+			kdf, ok := lookupKDF(name)
+			require.True(t, ok)
+			_, err = kdf.DeriveKey("pw", make([]byte, 16), pbkdf2KeyProviderConfig{
+				KeyLength: 32, Memory: params.Memory, Time: params.Time,
+				Parallelism: params.Parallelism, N: params.N, R: params.R, P: params.P,
+			})
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package state
+
+import (
+	"fmt"
+	"runtime"
+	"time"
+)
+
+// benchSalt and benchPassphrase are only ever used to time a KDF's DeriveKey
+// call; their values don't matter, only that they're a realistic size.
+var (
+	benchSalt       = make([]byte, 16)
+	benchPassphrase = "tfctl-kdf-bench"
+)
+
+// BenchmarkKDFParams measures candidate parameter sets for name
+// ("argon2id" or "scrypt") on this host and returns the first one whose
+// derivation time meets or exceeds budget, so `tfctl state kdf-bench`
+// can suggest parameters calibrated to the caller's hardware rather than
+// a copy-pasted default that might be far too fast (weak) or far too slow
+// (unusable) on any given machine.
+func BenchmarkKDFParams(name string, budget time.Duration) (KDFParams, time.Duration, error) {
+	switch name {
+	case "argon2id":
+		return benchmarkArgon2id(budget)
+	case "scrypt":
+		return benchmarkScrypt(budget)
+	default:
+		return KDFParams{}, 0, fmt.Errorf("kdf-bench only supports argon2id and scrypt, got %q", name)
+	}
+}
+
+// benchmarkArgon2id holds memory and parallelism fixed at OWASP-baseline
+// values and doubles the time (pass) cost until the derivation takes at
+// least budget.
+func benchmarkArgon2id(budget time.Duration) (KDFParams, time.Duration, error) {
+	kdf, _ := lookupKDF("argon2id")
+
+	params := KDFParams{
+		Memory:      64 * 1024, // 64 MiB
+		Parallelism: uint8(runtime.NumCPU()),
+		Time:        1,
+	}
+	if params.Parallelism == 0 {
+		params.Parallelism = 1
+	}
+
+	for {
+		elapsed, err := timeDeriveKey(kdf, params)
+		if err != nil {
+			return KDFParams{}, 0, err
+		}
+		if elapsed >= budget || params.Time >= 1<<20 {
+			return params, elapsed, nil
+		}
+		params.Time *= 2
+	}
+}
+
+// benchmarkScrypt holds r and p fixed and doubles N (the CPU/memory cost
+// factor) until the derivation takes at least budget.
+func benchmarkScrypt(budget time.Duration) (KDFParams, time.Duration, error) {
+	kdf, _ := lookupKDF("scrypt")
+
+	params := KDFParams{
+		N: 16384, // scrypt's own recommended minimum (2^14)
+		R: 8,
+		P: 1,
+	}
+
+	for {
+		elapsed, err := timeDeriveKey(kdf, params)
+		if err != nil {
+			return KDFParams{}, 0, err
+		}
+		if elapsed >= budget || params.N >= 1<<22 {
+			return params, elapsed, nil
+		}
+		params.N *= 2
+	}
+}
+
+func timeDeriveKey(kdf KDF, params KDFParams) (time.Duration, error) {
+	cfg := pbkdf2KeyProviderConfig{
+		KeyLength:   32,
+		Memory:      params.Memory,
+		Time:        params.Time,
+		Parallelism: params.Parallelism,
+		N:           params.N,
+		R:           params.R,
+		P:           params.P,
+	}
+
+	start := time.Now()
+	if _, err := kdf.DeriveKey(benchPassphrase, benchSalt, cfg); err != nil {
+		return 0, fmt.Errorf("failed to benchmark %s: %w", kdf.Name(), err)
+	}
+	return time.Since(start), nil
+}
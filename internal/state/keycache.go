@@ -0,0 +1,121 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package state
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/zalando/go-keyring"
+
+	"github.com/tfctl/tfctl/internal/config"
+)
+
+// keyCacheService is the OS keyring "service" name every cached DEK is
+// stored under; the fingerprint (see keyCacheFingerprint) is the keyring
+// "user" within it.
+const keyCacheService = "tfctl-state-keys"
+
+// defaultKeyCacheTTL is used when state.key_cache.ttl-minutes isn't
+// configured.
+const defaultKeyCacheTTL = 60 * time.Minute
+
+// keyCacheEntry is the JSON blob stored as the keyring secret: the derived
+// DEK itself plus when it stops being trusted. The passphrase that produced
+// it is never stored.
+type keyCacheEntry struct {
+	Key       []byte    `json:"key"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// KeyCacheEnabled reports whether decryptWithKeychain should consult the OS
+// keyring before running a KDF, per state.key_cache.enabled (default true)
+// and the --no-key-cache flag/TFCTL_NO_KEY_CACHE env var callers thread
+// through via disabledByFlag.
+func KeyCacheEnabled(disabledByFlag bool) bool {
+	if disabledByFlag {
+		return false
+	}
+	enabled, _ := config.GetBool("state.key_cache.enabled", true)
+	return enabled
+}
+
+// keyCacheTTL resolves state.key_cache.ttl-minutes, falling back to
+// defaultKeyCacheTTL.
+func keyCacheTTL() time.Duration {
+	minutes, err := config.GetInt("state.key_cache.ttl-minutes")
+	if err != nil || minutes <= 0 {
+		return defaultKeyCacheTTL
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+// keyCacheFingerprint derives the keyring lookup key from the key provider's
+// name and config plus a hash of the passphrase -- never the passphrase
+// itself, so a stolen keyring entry doesn't also leak the secret that
+// produced it.
+func keyCacheFingerprint(providerName string, providerConfig json.RawMessage, passphrase string) string {
+	passphraseHash := sha256.Sum256([]byte(passphrase))
+
+	h := sha256.New()
+	h.Write([]byte(providerName))
+	h.Write(providerConfig)
+	h.Write(passphraseHash[:])
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// keyCacheGet looks up a previously cached DEK for (providerName,
+// providerConfig, passphrase). A miss (not found, corrupt, or expired) is
+// reported as ok=false rather than an error -- the caller always has the KDF
+// fallback available.
+func keyCacheGet(providerName string, providerConfig json.RawMessage, passphrase string) (key []byte, ok bool) {
+	fp := keyCacheFingerprint(providerName, providerConfig, passphrase)
+
+	raw, err := keyring.Get(keyCacheService, fp)
+	if err != nil {
+		return nil, false
+	}
+
+	var entry keyCacheEntry
+	if err := json.Unmarshal([]byte(raw), &entry); err != nil {
+		return nil, false
+	}
+
+	if time.Now().After(entry.ExpiresAt) {
+		_ = keyring.Delete(keyCacheService, fp)
+		return nil, false
+	}
+
+	return entry.Key, true
+}
+
+// keyCachePut stores key under the fingerprint for (providerName,
+// providerConfig, passphrase), expiring after keyCacheTTL(). A failure to
+// write to the keyring (e.g. no keyring available, common in CI) is
+// swallowed: the cache is strictly an optimization over the KDF.
+func keyCachePut(providerName string, providerConfig json.RawMessage, passphrase string, key []byte) {
+	fp := keyCacheFingerprint(providerName, providerConfig, passphrase)
+
+	entry := keyCacheEntry{Key: key, ExpiresAt: time.Now().Add(keyCacheTTL())}
+	blob, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	_ = keyring.Set(keyCacheService, fp, string(blob))
+}
+
+// PurgeKeyCache deletes every DEK tfctl has cached in the OS keyring, for
+// `tfctl state keys purge`. go-keyring has no "list all users for a
+// service" API, so this relies on DeleteAll rather than iterating
+// fingerprints one at a time.
+func PurgeKeyCache() error {
+	if err := keyring.DeleteAll(keyCacheService); err != nil && err != keyring.ErrNotFound {
+		return fmt.Errorf("failed to purge key cache: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package state
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// KeyProvider derives the AES key used to decrypt an OpenTofu-encrypted
+// state or plan file from one meta.key_provider.<name>.<instance> entry.
+// config is that entry's raw JSON value, exactly as it appears in the state
+// file -- each provider decides its own shape.
+type KeyProvider interface {
+	// Name is the <name> segment of meta.key_provider.<name>.<instance>,
+	// e.g. "pbkdf2", "aws_kms".
+	Name() string
+	DeriveKey(ctx context.Context, config json.RawMessage, passphrase string) ([]byte, error)
+}
+
+var (
+	keyProvidersMu sync.RWMutex
+	keyProviders   = map[string]KeyProvider{}
+)
+
+// RegisterKeyProvider makes p available to DecryptOpenTofuState under
+// p.Name(). Built-in providers register themselves via init(); callers
+// embedding this package can register additional ones (e.g. an
+// organization-specific KMS) the same way.
+func RegisterKeyProvider(p KeyProvider) {
+	keyProvidersMu.Lock()
+	defer keyProvidersMu.Unlock()
+	keyProviders[p.Name()] = p
+}
+
+func lookupKeyProvider(name string) (KeyProvider, bool) {
+	keyProvidersMu.RLock()
+	defer keyProvidersMu.RUnlock()
+	p, ok := keyProviders[name]
+	return p, ok
+}
+
+func init() {
+	RegisterKeyProvider(pbkdf2Provider{})
+	RegisterKeyProvider(staticProvider{})
+	RegisterKeyProvider(awsKMSProvider{})
+	RegisterKeyProvider(gcpKMSProvider{})
+	RegisterKeyProvider(openbaoProvider{})
+	RegisterKeyProvider(externalProvider{})
+	RegisterKeyProvider(fallbackProvider{})
+}
+
+// pbkdf2KeyProviderConfig is the base64-encoded JSON payload of a
+// meta.key_provider.pbkdf2.<instance> entry: how to derive the AES key from
+// the passphrase. Despite the provider's name, KDF selects which actual KDF
+// ran -- "pbkdf2" (the default, and the only one OpenTofu itself ever
+// writes), "argon2id", or "scrypt" -- with Iterations/HashFunc only
+// consulted for pbkdf2 and Memory/Time/Parallelism/N/R/P only for the
+// others, so the config stays self-describing regardless of which KDF
+// produced it.
+type pbkdf2KeyProviderConfig struct {
+	Salt       string `json:"salt"`
+	Iterations int    `json:"iterations"`
+	HashFunc   string `json:"hash_function"`
+	KeyLength  int    `json:"key_length"`
+	KDF        string `json:"kdf,omitempty"`
+
+	// Argon2id parameters.
+	Memory      uint32 `json:"memory,omitempty"`
+	Time        uint32 `json:"time,omitempty"`
+	Parallelism uint8  `json:"parallelism,omitempty"`
+
+	// scrypt parameters.
+	N int `json:"n,omitempty"`
+	R int `json:"r,omitempty"`
+	P int `json:"p,omitempty"`
+}
+
+type pbkdf2Provider struct{}
+
+func (pbkdf2Provider) Name() string { return "pbkdf2" }
+
+// DeriveKey decodes a base64 pbkdf2KeyProviderConfig blob and derives the AES
+// key from it and the passphrase, dispatching to the KDF named by the
+// config's "kdf" field (defaulting to "pbkdf2" when absent, for state files
+// written before KDF selection existed).
+func (pbkdf2Provider) DeriveKey(ctx context.Context, config json.RawMessage, passphrase string) ([]byte, error) {
+	var blob string
+	if err := json.Unmarshal(config, &blob); err != nil {
+		return nil, fmt.Errorf("failed to parse pbkdf2 key provider config: %w", err)
+	}
+
+	kpConfigJSON, err := base64.StdEncoding.DecodeString(blob)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode key provider config: %w", err)
+	}
+
+	var kpConfig pbkdf2KeyProviderConfig
+	if err := json.Unmarshal(kpConfigJSON, &kpConfig); err != nil {
+		return nil, fmt.Errorf("failed to parse key provider config: %w", err)
+	}
+
+	salt, err := base64.StdEncoding.DecodeString(kpConfig.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode salt: %w", err)
+	}
+
+	kdfName := kpConfig.KDF
+	if kdfName == "" {
+		kdfName = "pbkdf2"
+	}
+	kdf, ok := lookupKDF(kdfName)
+	if !ok {
+		return nil, fmt.Errorf("unknown kdf %q", kdfName)
+	}
+
+	return kdf.DeriveKey(passphrase, salt, kpConfig)
+}
+
+// staticProvider reads the AES key directly from config, hex-encoded, with
+// no derivation -- OpenTofu's "static" key provider, used mostly for tests
+// and CI where a managed key provider would be overkill.
+type staticProvider struct{}
+
+func (staticProvider) Name() string { return "static" }
+
+func (staticProvider) DeriveKey(ctx context.Context, config json.RawMessage, passphrase string) ([]byte, error) {
+	var hexKey string
+	if err := json.Unmarshal(config, &hexKey); err != nil {
+		return nil, fmt.Errorf("failed to parse static key provider config: %w", err)
+	}
+
+	key, err := hex.DecodeString(hexKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode static key: %w", err)
+	}
+	return key, nil
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package state
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// externalKeyProviderConfig is a meta.key_provider.external.<instance>
+// payload: the binary tfctl shells out to for the AES key, mirroring the
+// {Command, Args} shape internal/aws.ExecCredentialSource already uses for
+// external AWS credential processes -- deliberately the only concrete way
+// tfctl integrates with a secrets system it doesn't otherwise support,
+// rather than vendoring another cloud SDK.
+type externalKeyProviderConfig struct {
+	Command string   `json:"command"`
+	Args    []string `json:"args"`
+}
+
+type externalProvider struct{}
+
+func (externalProvider) Name() string { return "external" }
+
+// DeriveKey runs the configured command with config itself passed verbatim
+// as stdin, and expects {"keys":{"encryption_key":"<base64 AES key>"}} on
+// stdout.
+func (externalProvider) DeriveKey(ctx context.Context, config json.RawMessage, passphrase string) ([]byte, error) {
+	var cfg externalKeyProviderConfig
+	if err := json.Unmarshal(config, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse external key provider config: %w", err)
+	}
+	if cfg.Command == "" {
+		return nil, fmt.Errorf("external key provider config has no command")
+	}
+
+	cmd := exec.CommandContext(ctx, cfg.Command, cfg.Args...)
+	cmd.Stdin = bytes.NewReader(config)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("external key provider command %q failed: %w (%s)",
+			cfg.Command, err, strings.TrimSpace(stderr.String()))
+	}
+
+	var resp struct {
+		Keys struct {
+			EncryptionKey string `json:"encryption_key"`
+		} `json:"keys"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse external key provider output: %w", err)
+	}
+	if resp.Keys.EncryptionKey == "" {
+		return nil, fmt.Errorf("external key provider %q returned no keys.encryption_key", cfg.Command)
+	}
+
+	key, err := base64.StdEncoding.DecodeString(resp.Keys.EncryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode external key provider encryption_key: %w", err)
+	}
+	return key, nil
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package state
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// fallbackKeyProviderSpec names one child provider and its config, used for
+// both Primary and Fallback below.
+type fallbackKeyProviderSpec struct {
+	Name   string          `json:"name"`
+	Config json.RawMessage `json:"config"`
+}
+
+// fallbackKeyProviderConfig is the meta.key_provider.fallback.<instance>
+// payload: OpenTofu's "fallback" provider, which tries Primary and only
+// consults Fallback if Primary's DeriveKey fails -- e.g. a kms provider with
+// a static provider behind it for disaster recovery.
+type fallbackKeyProviderConfig struct {
+	Primary  fallbackKeyProviderSpec `json:"primary"`
+	Fallback fallbackKeyProviderSpec `json:"fallback"`
+}
+
+type fallbackProvider struct{}
+
+func (fallbackProvider) Name() string { return "fallback" }
+
+// DeriveKey tries the primary child provider first, falling back to the
+// fallback child provider only if the primary returns an error.
+func (fallbackProvider) DeriveKey(ctx context.Context, config json.RawMessage, passphrase string) ([]byte, error) {
+	var cfg fallbackKeyProviderConfig
+	if err := json.Unmarshal(config, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse fallback key provider config: %w", err)
+	}
+
+	primary, ok := lookupKeyProvider(cfg.Primary.Name)
+	if !ok {
+		return nil, fmt.Errorf("fallback key provider: unknown primary provider %q", cfg.Primary.Name)
+	}
+
+	key, primaryErr := primary.DeriveKey(ctx, cfg.Primary.Config, passphrase)
+	if primaryErr == nil {
+		return key, nil
+	}
+
+	if cfg.Fallback.Name == "" {
+		return nil, fmt.Errorf("fallback key provider: primary %q failed and no fallback configured: %w", cfg.Primary.Name, primaryErr)
+	}
+
+	fallback, ok := lookupKeyProvider(cfg.Fallback.Name)
+	if !ok {
+		return nil, fmt.Errorf("fallback key provider: primary %q failed (%w) and fallback provider %q is unknown", cfg.Primary.Name, primaryErr, cfg.Fallback.Name)
+	}
+
+	key, err := fallback.DeriveKey(ctx, cfg.Fallback.Config, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("fallback key provider: primary %q failed (%w) and fallback %q also failed: %w", cfg.Primary.Name, primaryErr, cfg.Fallback.Name, err)
+	}
+	return key, nil
+}
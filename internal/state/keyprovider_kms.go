@@ -0,0 +1,123 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package state
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	kms "cloud.google.com/go/kms/apiv1"
+	kmspb "cloud.google.com/go/kms/apiv1/kmspb"
+
+	awsv2 "github.com/aws/aws-sdk-go-v2/aws"
+	kmsv2 "github.com/aws/aws-sdk-go-v2/service/kms"
+
+	"github.com/tfctl/tfctl/internal/aws"
+)
+
+// awsKMSConfig is the meta.key_provider.aws_kms.<instance> payload: the KMS
+// key id and the ciphertext blob KMS.Decrypt unwraps into the plaintext
+// data key.
+type awsKMSConfig struct {
+	KMSKeyID       string `json:"kms_key_id"`
+	Region         string `json:"region"`
+	CiphertextBlob string `json:"ciphertext_blob"`
+}
+
+type awsKMSProvider struct{}
+
+func (awsKMSProvider) Name() string { return "aws_kms" }
+
+// DeriveKey calls KMS Decrypt on the wrapped data key, via the same
+// internal/aws config-loading path the s3 backend uses. The plaintext KMS
+// returns is the AES key itself -- unlike pbkdf2, aws_kms doesn't derive
+// further from a passphrase, so passphrase is unused here.
+func (awsKMSProvider) DeriveKey(ctx context.Context, config json.RawMessage, passphrase string) ([]byte, error) {
+	var cfg awsKMSConfig
+	if err := json.Unmarshal(config, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse aws_kms key provider config: %w", err)
+	}
+
+	ciphertext, err := base64.StdEncoding.DecodeString(cfg.CiphertextBlob)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode aws_kms ciphertext_blob: %w", err)
+	}
+
+	var opts []aws.Option
+	if cfg.Region != "" {
+		opts = append(opts, aws.WithRegion(cfg.Region))
+	}
+	awsCfg, err := aws.LoadAWSConfig(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+
+	out, err := aws.NewKMS(awsCfg).Decrypt(ctx, &kmsv2.DecryptInput{
+		CiphertextBlob: ciphertext,
+		KeyId:          awsv2.String(cfg.KMSKeyID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt aws_kms data key: %w", err)
+	}
+
+	return out.Plaintext, nil
+}
+
+// gcpKMSConfig is the meta.key_provider.gcp_kms.<instance> payload: the KMS
+// key's resource name and the ciphertext blob Decrypt unwraps into the
+// plaintext data key, mirroring awsKMSConfig's shape for GCP KMS.
+type gcpKMSConfig struct {
+	KMSEncryptionKey string `json:"kms_encryption_key"`
+	CiphertextBlob   string `json:"ciphertext_blob"`
+}
+
+type gcpKMSProvider struct{}
+
+func (gcpKMSProvider) Name() string { return "gcp_kms" }
+
+// DeriveKey calls GCP KMS Decrypt on the wrapped data key, authenticating via
+// application default credentials -- the same default internal/backend/gcs
+// falls back to when no explicit credentials file is configured. The
+// plaintext KMS returns is the AES key itself, so passphrase is unused.
+func (gcpKMSProvider) DeriveKey(ctx context.Context, config json.RawMessage, passphrase string) ([]byte, error) {
+	var cfg gcpKMSConfig
+	if err := json.Unmarshal(config, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse gcp_kms key provider config: %w", err)
+	}
+
+	ciphertext, err := base64.StdEncoding.DecodeString(cfg.CiphertextBlob)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode gcp_kms ciphertext_blob: %w", err)
+	}
+
+	client, err := kms.NewKeyManagementClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCP KMS client: %w", err)
+	}
+	defer client.Close()
+
+	resp, err := client.Decrypt(ctx, &kmspb.DecryptRequest{
+		Name:       cfg.KMSEncryptionKey,
+		Ciphertext: ciphertext,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt gcp_kms data key: %w", err)
+	}
+
+	return resp.Plaintext, nil
+}
+
+// openbaoProvider is a registration stub: OpenTofu supports openbao as a key
+// provider, but tfctl doesn't have a read path for it yet. Registering it
+// means a state file referencing it surfaces a clear "not yet implemented"
+// error instead of "unknown key provider".
+type openbaoProvider struct{}
+
+func (openbaoProvider) Name() string { return "openbao" }
+
+func (openbaoProvider) DeriveKey(ctx context.Context, config json.RawMessage, passphrase string) ([]byte, error) {
+	return nil, fmt.Errorf("openbao key provider is not yet implemented")
+}
@@ -7,71 +7,241 @@ import (
 	"context"
 	"crypto/aes"
 	"crypto/cipher"
-	"crypto/sha512"
+	"crypto/rand"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"os"
 	"os/signal"
+	"regexp"
+	"sort"
 	"syscall"
 
-	"golang.org/x/crypto/pbkdf2"
 	"golang.org/x/term"
 
 	"github.com/apex/log"
 	"github.com/urfave/cli/v3"
 
 	"github.com/tfctl/tfctl/internal/backend"
+	"github.com/tfctl/tfctl/internal/config"
 )
 
-// DecryptOpenTofuState decrypts an encrypted OpenTofu state file using the
-// provided passphrase.
+// defaultPBKDF2Iterations is used by EncryptOpenTofuState when no iteration
+// count is requested; it matches OpenTofu's own pbkdf2 key provider default.
+const defaultPBKDF2Iterations = 600000
+
+// keyProviderEntryPattern matches a generic meta key provider entry,
+// meta.key_provider.<type>.<instance>, e.g. "key_provider.pbkdf2.mykey" or
+// "key_provider.aws_kms.prod". The meta key name (the <instance> segment)
+// is user-chosen; only <type> selects a KeyProvider.
+var keyProviderEntryPattern = regexp.MustCompile(`^key_provider\.([^.]+)\.([^.]+)$`)
+
+// openTofuEnvelope is the on-disk shape of an OpenTofu-encrypted state or
+// plan file: a flat meta object (one entry per configured key provider
+// instance, keyed by "key_provider.<type>.<instance>", plus the legacy
+// "key_provider.keys" rotation list) and the encrypted payload.
+type openTofuEnvelope struct {
+	Meta          map[string]json.RawMessage `json:"meta"`
+	EncryptedData string                     `json:"encrypted_data"`
+}
+
+// DecryptOpenTofuState decrypts an encrypted OpenTofu state or plan file
+// using the provided passphrase. It's a thin wrapper over
+// DecryptOpenTofuStateWithCache for callers that don't need a context or
+// control over the key cache.
 func DecryptOpenTofuState(stateData []byte, passphrase string) ([]byte, error) {
-	var state struct {
-		Meta struct {
-			Key string `json:"key_provider.pbkdf2.mykey"`
-		} `json:"meta"`
-		EncryptedData string `json:"encrypted_data"`
-	}
+	return DecryptOpenTofuStateWithCache(stateData, passphrase, false)
+}
 
-	if err := json.Unmarshal(stateData, &state); err != nil {
+// DecryptOpenTofuStateWithCache is DecryptOpenTofuState with explicit
+// control over the OS keyring DEK cache: cacheDisabled true corresponds to
+// --no-key-cache, bypassing both the cache lookup and the write-back
+// regardless of the state.key_cache.enabled config knob.
+func DecryptOpenTofuStateWithCache(stateData []byte, passphrase string, cacheDisabled bool) ([]byte, error) {
+	var envelope openTofuEnvelope
+	if err := json.Unmarshal(stateData, &envelope); err != nil {
 		return nil, fmt.Errorf("failed to parse state: %w", err)
 	}
 
-	// Decode key provider config
-	keyProviderConfig, err := base64.StdEncoding.DecodeString(state.Meta.Key)
+	return decryptWithKeychain(context.Background(), envelope.Meta, envelope.EncryptedData, passphrase, cacheDisabled)
+}
+
+// decryptWithKeychain builds the keychain implied by meta -- every
+// key_provider.<type>.<instance> entry, in sorted-key order, plus (for
+// backward compatibility) any legacy "key_provider.keys" blobs appended to
+// whichever provider type was matched first -- and tries each in turn
+// against encryptedData until one succeeds, for key rotation support. Each
+// candidate's DEK is looked up in (and, on a KDF miss, written back to) the
+// OS keyring cache unless cacheDisabled or state.key_cache.enabled is
+// false, so the common case of re-reading the same state file doesn't re-run
+// an expensive KDF every time.
+func decryptWithKeychain(ctx context.Context, meta map[string]json.RawMessage, encryptedData string, passphrase string, cacheDisabled bool) ([]byte, error) {
+	type candidate struct {
+		providerType string
+		config       json.RawMessage
+	}
+
+	names := make([]string, 0, len(meta))
+	for name := range meta {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var candidates []candidate
+	var primaryProviderType string
+	for _, name := range names {
+		m := keyProviderEntryPattern.FindStringSubmatch(name)
+		if m == nil {
+			continue
+		}
+		candidates = append(candidates, candidate{providerType: m[1], config: meta[name]})
+		if primaryProviderType == "" {
+			primaryProviderType = m[1]
+		}
+	}
+
+	if raw, ok := meta["key_provider.keys"]; ok && primaryProviderType != "" {
+		var blobs []string
+		if err := json.Unmarshal(raw, &blobs); err == nil {
+			for _, blob := range blobs {
+				if cfg, err := json.Marshal(blob); err == nil {
+					candidates = append(candidates, candidate{providerType: primaryProviderType, config: cfg})
+				}
+			}
+		}
+	}
+
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no key provider configured")
+	}
+
+	cacheEnabled := KeyCacheEnabled(cacheDisabled)
+
+	var lastErr error
+	for _, c := range candidates {
+		var key []byte
+		var cacheHit bool
+		if cacheEnabled {
+			key, cacheHit = keyCacheGet(c.providerType, c.config, passphrase)
+		}
+
+		if !cacheHit {
+			provider, ok := lookupKeyProvider(c.providerType)
+			if !ok {
+				lastErr = fmt.Errorf("unknown key provider %q", c.providerType)
+				continue
+			}
+
+			derived, err := provider.DeriveKey(ctx, c.config, passphrase)
+			if err != nil {
+				lastErr = err
+				continue
+			}
+			key = derived
+
+			if cacheEnabled {
+				keyCachePut(c.providerType, c.config, passphrase, key)
+			}
+		}
+
+		plaintext, err := defaultMethod.Decrypt(encryptedData, key)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		return plaintext, nil
+	}
+
+	return nil, lastErr
+}
+
+// EncryptOpenTofuState encrypts plaintext state/plan data into the same
+// pbkdf2 + AES-256-GCM envelope that DecryptOpenTofuState reads, so a single
+// round trip (DecryptOpenTofuState then EncryptOpenTofuState) can be used by
+// write-back commands without changing the envelope's key provider.
+func EncryptOpenTofuState(plaintext []byte, passphrase string) ([]byte, error) {
+	return EncryptOpenTofuStateWithKDF(plaintext, passphrase, "pbkdf2", KDFParams{Iterations: defaultPBKDF2Iterations})
+}
+
+// KDFParams carries the parameters EncryptOpenTofuStateWithKDF needs for
+// whichever KDF it's asked to use -- only the fields relevant to the chosen
+// kdf are consulted: Iterations for "pbkdf2", Memory/Time/Parallelism for
+// "argon2id", N/R/P for "scrypt". State kdf-bench suggests values for
+// Argon2id/scrypt targeting a wall-clock budget on the current host.
+type KDFParams struct {
+	Iterations  int
+	Memory      uint32
+	Time        uint32
+	Parallelism uint8
+	N           int
+	R           int
+	P           int
+}
+
+// EncryptOpenTofuStateWithKDF is EncryptOpenTofuState with an explicit KDF
+// choice and parameters, so a pbkdf2 key provider instance can be written
+// using Argon2id or scrypt instead of PBKDF2-SHA512, while remaining
+// self-describing: the kdf name and its parameters are persisted alongside
+// the salt, so DecryptOpenTofuState (via pbkdf2Provider.DeriveKey) knows how
+// to reverse it without any out-of-band configuration.
+func EncryptOpenTofuStateWithKDF(plaintext []byte, passphrase string, kdfName string, params KDFParams) ([]byte, error) {
+	if kdfName == "" {
+		kdfName = "pbkdf2"
+	}
+	kdf, ok := lookupKDF(kdfName)
+	if !ok {
+		return nil, fmt.Errorf("unknown kdf %q", kdfName)
+	}
+	if kdfName == "pbkdf2" && params.Iterations == 0 {
+		params.Iterations = defaultPBKDF2Iterations
+	}
+
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	kpConfig := pbkdf2KeyProviderConfig{
+		Salt:        base64.StdEncoding.EncodeToString(salt),
+		KeyLength:   32,
+		KDF:         kdfName,
+		Iterations:  params.Iterations,
+		HashFunc:    "sha512",
+		Memory:      params.Memory,
+		Time:        params.Time,
+		Parallelism: params.Parallelism,
+		N:           params.N,
+		R:           params.R,
+		P:           params.P,
+	}
+
+	key, err := kdf.DeriveKey(passphrase, salt, kpConfig)
 	if err != nil {
-		return nil, fmt.Errorf("failed to decode key provider config: %w", err)
+		return nil, fmt.Errorf("failed to derive key: %w", err)
 	}
 
-	var kpConfig struct {
-		Salt       string `json:"salt"`
-		Iterations int    `json:"iterations"`
-		HashFunc   string `json:"hash_function"`
-		KeyLength  int    `json:"key_length"`
+	kpConfigJSON, err := json.Marshal(kpConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal key provider config: %w", err)
 	}
 
-	if err = json.Unmarshal(keyProviderConfig, &kpConfig); err != nil {
-		return nil, fmt.Errorf("failed to parse key provider config: %w", err)
+	encryptedData, err := defaultMethod.Encrypt(plaintext, key)
+	if err != nil {
+		return nil, err
 	}
 
-	// Decode salt
-	salt, err := base64.StdEncoding.DecodeString(kpConfig.Salt)
+	blob, err := json.Marshal(base64.StdEncoding.EncodeToString(kpConfigJSON))
 	if err != nil {
-		return nil, fmt.Errorf("failed to decode salt: %w", err)
+		return nil, fmt.Errorf("failed to marshal key provider blob: %w", err)
 	}
 
-	// Generate key using configured PBKDF2 parameters
-	key := pbkdf2.Key(
-		[]byte(passphrase),
-		salt,
-		kpConfig.Iterations,
-		kpConfig.KeyLength,
-		sha512.New,
-	)
+	envelope := openTofuEnvelope{
+		Meta:          map[string]json.RawMessage{"key_provider.pbkdf2.mykey": blob},
+		EncryptedData: encryptedData,
+	}
 
-	// Decrypt the state data using the derived key
-	return decryptState(state.EncryptedData, key)
+	return json.Marshal(envelope)
 }
 
 // GetPassphrase prompts interactively for a passphrase without echoing input.
@@ -122,11 +292,10 @@ loop:
 // LoadStateData loads and optionally decrypts a state document from the
 // detected backend at the provided rootDir.
 func LoadStateData(ctx context.Context, cmd *cli.Command, rootDir string) (map[string]interface{}, error) {
-	// Check to make sure the target directory looks like it might be a legit TF workspace.
-	tfConfigFile := fmt.Sprintf("%s/.terraform/terraform.tfstate", rootDir)
-	if _, err := os.Stat(tfConfigFile); err != nil {
-		return nil, fmt.Errorf("terraform config file not found: %s", tfConfigFile)
-	}
+	// We used to hard-fail here if .terraform/terraform.tfstate was missing,
+	// but backend.NewBackend can now discover a cloud/remote backend straight
+	// from the root module's HCL (or via --no-init) when that file is absent,
+	// so the check is left to it instead.
 
 	// Figure out what type of Backend we're in.
 	be, err := backend.NewBackend(ctx, *cmd)
@@ -154,12 +323,19 @@ func LoadStateData(ctx context.Context, cmd *cli.Command, rootDir string) (map[s
 				passphrase = os.Getenv("TF_VAR_passphrase")
 			}
 
+			// Next, look for an "encryption.passphrase" entry in tfctl.yaml,
+			// mirroring OpenTofu's own terraform { encryption { ... } } block, so
+			// users don't have to pass --passphrase on every command.
+			if passphrase == "" {
+				passphrase, _ = config.GetString("encryption.passphrase")
+			}
+
 			// Finally, prompt for passphrase
 			if passphrase == "" {
 				passphrase, _ = GetPassphrase()
 			}
 
-			doc, err = DecryptOpenTofuState(doc, passphrase)
+			doc, err = DecryptOpenTofuStateWithCache(doc, passphrase, cmd.Bool("no-key-cache"))
 			if err != nil {
 				return nil, fmt.Errorf("failed to decrypt: %w", err)
 			}
@@ -215,3 +391,26 @@ func decryptState(encryptedData string, derivedKey []byte) ([]byte, error) {
 
 	return plaintext, nil
 }
+
+// encryptState is decryptState's inverse: it AES-256-GCM-encrypts plaintext
+// under derivedKey and returns the base64-encoded nonce||ciphertext||tag.
+func encryptState(plaintext []byte, derivedKey []byte) (string, error) {
+	block, err := aes.NewCipher(derivedKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	aesGCM, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", fmt.Errorf("failed to create GCM: %w", err)
+	}
+
+	nonce := make([]byte, aesGCM.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	ciphertext := aesGCM.Seal(nonce, nonce, plaintext, nil)
+
+	return base64.StdEncoding.EncodeToString(ciphertext), nil
+}
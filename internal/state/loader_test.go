@@ -295,6 +295,80 @@ func TestDecryptOpenTofuState_LongPassphrase(t *testing.T) {
 	assert.Equal(t, plaintext, result)
 }
 
+// TestDecryptOpenTofuState_KeyRotation_FallbackSucceeds verifies that when
+// meta lists more than one key_provider.pbkdf2.<instance> entry, a primary
+// entry that doesn't match the encrypted payload's key is skipped in favor
+// of a later one (sorted by meta key name) that does -- the key rotation
+// case.
+func TestDecryptOpenTofuState_KeyRotation_FallbackSucceeds(t *testing.T) {
+	t.Parallel()
+	passphrase := "test-passphrase"
+	plaintext := []byte(`{"version":4}`)
+
+	primarySalt := []byte("primary-salt-001")
+	fallbackSalt := []byte("fallback-salt-02")
+
+	// Encrypt the payload under the fallback salt's key, simulating a state
+	// file written after the primary key was rotated out.
+	fallbackKey := pbkdf2.Key([]byte(passphrase), fallbackSalt, 200000, 32, sha512.New)
+	block, err := aes.NewCipher(fallbackKey)
+	require.NoError(t, err)
+	aesGCM, err := cipher.NewGCM(block)
+	require.NoError(t, err)
+	nonce := make([]byte, aesGCM.NonceSize())
+	ciphertext := aesGCM.Seal(nonce, nonce, plaintext, nil)
+
+	mkBlob := func(salt []byte) string {
+		kpConfigJSON, err := json.Marshal(map[string]interface{}{
+			"salt":          base64.StdEncoding.EncodeToString(salt),
+			"iterations":    200000,
+			"hash_function": "sha512",
+			"key_length":    32,
+		})
+		require.NoError(t, err)
+		return base64.StdEncoding.EncodeToString(kpConfigJSON)
+	}
+
+	stateJSON := map[string]interface{}{
+		"meta": map[string]interface{}{
+			// Sorted before "zzz_fallback", so it's tried first and fails.
+			"key_provider.pbkdf2.aaa_primary":  mkBlob(primarySalt),
+			"key_provider.pbkdf2.zzz_fallback": mkBlob(fallbackSalt),
+		},
+		"encrypted_data": base64.StdEncoding.EncodeToString(ciphertext),
+	}
+
+	stateData, err := json.Marshal(stateJSON)
+	require.NoError(t, err)
+
+	result, err := DecryptOpenTofuState(stateData, passphrase)
+
+	assert.NoError(t, err)
+	assert.Equal(t, plaintext, result)
+}
+
+// TestDecryptOpenTofuState_UnknownKeyProvider verifies that an unrecognized
+// key_provider.<type>.<instance> type surfaces a clear error instead of a
+// generic decryption failure.
+func TestDecryptOpenTofuState_UnknownKeyProvider(t *testing.T) {
+	t.Parallel()
+	stateJSON := map[string]interface{}{
+		"meta": map[string]interface{}{
+			"key_provider.nonesuch.mykey": "anything",
+		},
+		"encrypted_data": "dGVzdA==",
+	}
+
+	stateData, err := json.Marshal(stateJSON)
+	require.NoError(t, err)
+
+	result, err := DecryptOpenTofuState(stateData, "passphrase")
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), `unknown key provider "nonesuch"`)
+}
+
 // createEncryptedStateFile is a helper that creates a properly encrypted
 // OpenTofu state file for testing.
 func createEncryptedStateFile(
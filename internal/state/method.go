@@ -0,0 +1,62 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package state
+
+import "sync"
+
+// EncryptionMethod implements one OpenTofu state/plan encryption method
+// (meta's "aes_gcm" today). Decrypt/Encrypt work on the already-derived AES
+// key a KeyProvider produced; a method never sees the passphrase.
+type EncryptionMethod interface {
+	Name() string
+	Decrypt(encryptedData string, key []byte) ([]byte, error)
+	Encrypt(plaintext []byte, key []byte) (string, error)
+}
+
+var (
+	methodsMu sync.RWMutex
+	methods   = map[string]EncryptionMethod{}
+)
+
+// RegisterEncryptionMethod makes m available under m.Name(). aes_gcm
+// registers itself via init(); future AEADs (e.g. an external-nonce/AAD
+// variant) register the same way.
+func RegisterEncryptionMethod(m EncryptionMethod) {
+	methodsMu.Lock()
+	defer methodsMu.Unlock()
+	methods[m.Name()] = m
+}
+
+func lookupEncryptionMethod(name string) (EncryptionMethod, bool) {
+	methodsMu.RLock()
+	defer methodsMu.RUnlock()
+	m, ok := methods[name]
+	return m, ok
+}
+
+func init() {
+	RegisterEncryptionMethod(aesGCMMethod{})
+}
+
+// aesGCMMethod is OpenTofu's default (and currently only) method: AES-256 in
+// GCM mode, with the nonce prepended to the ciphertext rather than passed as
+// external AAD.
+type aesGCMMethod struct{}
+
+func (aesGCMMethod) Name() string { return "aes_gcm" }
+
+func (aesGCMMethod) Decrypt(encryptedData string, key []byte) ([]byte, error) {
+	return decryptState(encryptedData, key)
+}
+
+func (aesGCMMethod) Encrypt(plaintext []byte, key []byte) (string, error) {
+	return encryptState(plaintext, key)
+}
+
+// defaultMethod is used directly rather than looked up by name: meta doesn't
+// carry a method.<name>.<instance> selector in any state file tfctl has
+// seen in practice, so there's nothing yet to dispatch on. The registry
+// still exists so a future method can be added without another interface
+// change.
+var defaultMethod EncryptionMethod = aesGCMMethod{}
@@ -0,0 +1,85 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package svutil
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors for the structured error types below, so a caller can
+// tell "user typo" from "empty workspace" etc. with errors.Is rather than
+// parsing message text. Each concrete type's Unwrap returns the matching
+// sentinel; the human-readable Error() text is unchanged from what
+// resolveCSVSpec/resolveNumericSpec/resolveIDSpec have always returned.
+var (
+	ErrOutOfRange     = errors.New("out of range")
+	ErrInvalidSpec    = errors.New("invalid spec")
+	ErrSerialNotFound = errors.New("serial not found")
+	ErrIDNotFound     = errors.New("ID not found")
+	ErrAmbiguousID    = errors.New("ambiguous ID")
+)
+
+// OutOfRangeError is returned when a CSV~N or relative numeric index falls
+// outside the bounds of the versions slice it's being resolved against.
+type OutOfRangeError struct {
+	Spec   string
+	Index  int
+	Length int
+}
+
+func (e *OutOfRangeError) Error() string {
+	return fmt.Sprintf("index %d out of range for versions of length %d", e.Index, e.Length)
+}
+
+func (e *OutOfRangeError) Unwrap() error { return ErrOutOfRange }
+
+// InvalidSpecError is returned when a spec is malformed for the kind it was
+// dispatched as (e.g. a "CSV~" spec missing its index, or a non-numeric
+// one). Reason names which part failed ("invalid CSV spec format",
+// "invalid CSV index").
+type InvalidSpecError struct {
+	Spec   string
+	Reason string
+}
+
+func (e *InvalidSpecError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Reason, e.Spec)
+}
+
+func (e *InvalidSpecError) Unwrap() error { return ErrInvalidSpec }
+
+// SerialNotFoundError is returned when no state version has the requested
+// serial number.
+type SerialNotFoundError struct {
+	Spec   string
+	Serial int64
+}
+
+func (e *SerialNotFoundError) Error() string {
+	return fmt.Sprintf("failed to find state version with serial %d", e.Serial)
+}
+
+func (e *SerialNotFoundError) Unwrap() error { return ErrSerialNotFound }
+
+// IDNotFoundError is returned when no state version's ID matches the spec,
+// whichever of the three ID-matching modes (prefix, glob, regex) it was
+// tried under; Kind records which.
+type IDNotFoundError struct {
+	Spec string
+	Kind string // "ID prefix", "glob", or "regex"
+}
+
+func (e *IDNotFoundError) Error() string {
+	switch e.Kind {
+	case "glob":
+		return fmt.Sprintf("failed to find state version matching glob %q", e.Spec)
+	case "regex":
+		return fmt.Sprintf("failed to find state version matching regex %q", e.Spec)
+	default:
+		return fmt.Sprintf("failed to find state version with ID prefix: %s", e.Spec)
+	}
+}
+
+func (e *IDNotFoundError) Unwrap() error { return ErrIDNotFound }
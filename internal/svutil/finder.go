@@ -6,9 +6,13 @@ package svutil
 import (
 	"fmt"
 	"os"
+	"path"
+	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
+	getter "github.com/hashicorp/go-getter"
 	"github.com/hashicorp/go-tfe"
 )
 
@@ -18,69 +22,206 @@ import (
 // effectively makes it most recent first.
 func Resolve(versions []*tfe.StateVersion, specs ...string) ([]*tfe.StateVersion, error) {
 	var result = []*tfe.StateVersion{}
+	seen := map[string]bool{}
 
 	// specs is going to be zero or more (almost certainly max=2) SV specs.  A
 	// spec could be -
-	//   empty  - the CSV.
-	//   sv-id  - the SV with that ID.
-	//   CSV~1  - the -1 SV.
-	//   serial - the specific serial number.
-	//   url    - the SV URL to download.
-	//   file   - the SV file to read.
+	//   empty   - the CSV.
+	//   sv-id   - the SV with that ID.
+	//   CSV~1   - the -1 SV.
+	//   @T      - newest SV created at or before T.
+	//   serial  - the specific serial number.
+	//   url     - the SV URL to download.
+	//   file    - the SV file to read.
+	//   A..B    - a range, inclusive of both endpoints.
+	//   A..<B   - a range, inclusive of A but not B.
+	//   ..B/A.. - an open-ended range, from newest/to oldest.
+	// A and B in a range spec can themselves be any of the non-range kinds
+	// above; see resolveRangeSpec.
 
 	// Short ciruit if no spec was provided and return the most recent.
 	if len(specs) == 0 {
 		specs = []string{"CSV~0"}
 	}
 
-	// Process each spec and resolve to a StateVersion.
+	// Process each spec, resolving it to one (plain spec) or more (range
+	// spec) StateVersions, and append each one at most once overall so that
+	// overlapping specs (e.g. "CSV~0..CSV~3", "CSV~2") don't duplicate
+	// entries in the combined result.
 	for _, spec := range specs {
-		sv, err := resolveSpec(spec, versions)
-		if err != nil {
-			return nil, err
+		var resolved []*tfe.StateVersion
+
+		if left, right, halfOpen, ok := splitRangeSpec(spec); ok {
+			svs, err := resolveRangeSpec(spec, left, right, halfOpen, versions)
+			if err != nil {
+				return nil, err
+			}
+			resolved = svs
+		} else {
+			sv, err := resolveSpec(spec, versions)
+			if err != nil {
+				return nil, err
+			}
+			resolved = []*tfe.StateVersion{sv}
+		}
+
+		for _, sv := range resolved {
+			if seen[sv.ID] {
+				continue
+			}
+			seen[sv.ID] = true
+			result = append(result, sv)
 		}
-		result = append(result, sv)
 	}
 
 	return result, nil
 }
 
-// resolveSpec takes a single spec string and returns the matching
-// StateVersion. Specs can be:
+// resolveSpec takes a single, non-range spec string and returns the
+// matching StateVersion. Range specs (A..B and friends) are detected and
+// expanded a level up in Resolve, via splitRangeSpec/resolveRangeSpec,
+// since they can resolve to more than one StateVersion; resolveSpec is also
+// what resolveRangeSpec calls to resolve each range endpoint. Dispatch is
+// driven by the registry (see resolver.go): the first registered Resolver
+// whose Match returns true handles the spec, trying the most recently
+// Register'd ones first. Specs can be:
 //   - CSV~N: relative index (negative means recent)
+//   - @T: newest SV created at or before T (RFC3339, date, or -24h/-7d)
 //   - numeric serial: find SV with that serial number
+//   - URL: download from http(s)/s3/gs and read the cached copy
 //   - file path: read from local file
-//   - ID prefix: find first SV matching that ID prefix
+//   - anything else: an ID spec - glob (contains * or ?) via path.Match,
+//     regex ("/pattern/") via regexp, or otherwise a prefix match, erroring
+//     with *AmbiguousIDError if more than one SV shares the prefix
+//
+// A spec matching none of the registered Resolvers falls through to
+// resolveIDSpec, same as it always has.
 func resolveSpec(spec string, versions []*tfe.StateVersion) (*tfe.StateVersion, error) {
-	switch {
-	case strings.HasPrefix(strings.ToUpper(spec), "CSV~"):
-		return resolveCSVSpec(spec, versions)
+	for _, r := range registry {
+		if r.Match(spec) {
+			return r.Resolve(spec, versions)
+		}
+	}
 
-	case isNumeric(spec):
-		return resolveNumericSpec(spec, versions)
+	return resolveIDSpec(spec, versions)
+}
 
-	case isFilePath(spec):
-		return resolveFileSpec(spec)
+// splitRangeSpec detects an A..B / A..<B / ..B / A.. range spec and splits
+// it into its (possibly empty, for the open-ended forms) endpoints. ok is
+// false for anything that isn't shaped like a range, including a relative
+// file path such as "../state.json" or "foo/../bar.json" - a real range
+// endpoint never starts or ends right at the ".." with a path separator, so
+// that's used to tell the two apart.
+func splitRangeSpec(spec string) (left, right string, halfOpen, ok bool) {
+	idx := strings.Index(spec, "..")
+	if idx < 0 {
+		return "", "", false, false
+	}
 
-	default:
-		return resolveIDSpec(spec, versions)
+	left = spec[:idx]
+	rest := spec[idx+2:]
+	if strings.HasPrefix(rest, "<") {
+		halfOpen = true
+		rest = rest[1:]
+	}
+	right = rest
+
+	if strings.HasSuffix(left, "/") || strings.HasPrefix(right, "/") {
+		return "", "", false, false
 	}
+
+	return left, right, halfOpen, true
+}
+
+// resolveRangeSpec expands a range spec (already split by splitRangeSpec)
+// into the contiguous, inclusive sub-slice of versions it spans. Each
+// endpoint is resolved through resolveSpec, so any plain spec kind works on
+// either side; an empty endpoint means "the newest version" (left) or "the
+// oldest version" (right). halfOpen excludes the right-hand endpoint as
+// written, regardless of whether it ends up being the newer or older side
+// once both endpoints are located. versions is newest-first, so the
+// returned slice is too.
+//
+// An endpoint that doesn't correspond to an actual position in versions -
+// a file path or URL spec, which synthesizes a StateVersion that was never
+// in the list - leaves the range with no well-defined position to start or
+// end at, and is reported by name rather than silently treated as index 0.
+func resolveRangeSpec(spec, left, right string, halfOpen bool, versions []*tfe.StateVersion) ([]*tfe.StateVersion, error) {
+	if left == "" && right == "" {
+		return nil, fmt.Errorf("empty range spec: %s", spec)
+	}
+
+	lo := 0
+	if left != "" {
+		sv, err := resolveSpec(left, versions)
+		if err != nil {
+			return nil, fmt.Errorf("range %q: left endpoint %q: %w", spec, left, err)
+		}
+		idx, ok := indexOfVersion(sv, versions)
+		if !ok {
+			return nil, fmt.Errorf("range %q: left endpoint %q has no position among versions (file/URL specs can't anchor a range)", spec, left)
+		}
+		lo = idx
+	}
+
+	hi := len(versions) - 1
+	if right != "" {
+		sv, err := resolveSpec(right, versions)
+		if err != nil {
+			return nil, fmt.Errorf("range %q: right endpoint %q: %w", spec, right, err)
+		}
+		idx, ok := indexOfVersion(sv, versions)
+		if !ok {
+			return nil, fmt.Errorf("range %q: right endpoint %q has no position among versions (file/URL specs can't anchor a range)", spec, right)
+		}
+		hi = idx
+	}
+
+	minIdx, maxIdx := lo, hi
+	if minIdx > maxIdx {
+		minIdx, maxIdx = maxIdx, minIdx
+	}
+	if halfOpen {
+		if hi == maxIdx {
+			maxIdx--
+		} else {
+			minIdx++
+		}
+	}
+	if minIdx > maxIdx {
+		return nil, fmt.Errorf("range %q: empty after resolving endpoints", spec)
+	}
+
+	return append([]*tfe.StateVersion{}, versions[minIdx:maxIdx+1]...), nil
+}
+
+// indexOfVersion returns target's position within versions, matched by
+// identity - every existing resolveSpec path but file/URL specs returns a
+// pointer taken directly from versions, so this also doubles as the "does
+// this endpoint even have a position" check resolveRangeSpec needs.
+func indexOfVersion(target *tfe.StateVersion, versions []*tfe.StateVersion) (int, bool) {
+	for i, v := range versions {
+		if v == target {
+			return i, true
+		}
+	}
+	return 0, false
 }
 
 // resolveCSVSpec handles CSV~N format specs.
 func resolveCSVSpec(spec string, versions []*tfe.StateVersion) (*tfe.StateVersion, error) {
 	parts := strings.Split(spec, "~")
 	if len(parts) != 2 {
-		return nil, fmt.Errorf("invalid CSV spec format: %s", spec)
+		return nil, &InvalidSpecError{Spec: spec, Reason: "invalid CSV spec format"}
 	}
 
 	index, err := strconv.Atoi(parts[1])
 	if err != nil {
-		return nil, fmt.Errorf("invalid CSV index: %s", parts[1])
+		return nil, &InvalidSpecError{Spec: parts[1], Reason: "invalid CSV index"}
 	}
 
 	if index < 0 || index > len(versions)-1 {
-		return nil, fmt.Errorf("index %d out of range for versions of length %d", index, len(versions))
+		return nil, &OutOfRangeError{Spec: spec, Index: index, Length: len(versions)}
 	}
 
 	return versions[index], nil
@@ -94,7 +235,7 @@ func resolveNumericSpec(spec string, versions []*tfe.StateVersion) (*tfe.StateVe
 		// <= 0 means it's a relative index into the version list
 		index := -i
 		if index > len(versions)-1 {
-			return nil, fmt.Errorf("index %d out of range for versions of length %d", index, len(versions))
+			return nil, &OutOfRangeError{Spec: spec, Index: index, Length: len(versions)}
 		}
 		return versions[index], nil
 	}
@@ -106,7 +247,80 @@ func resolveNumericSpec(spec string, versions []*tfe.StateVersion) (*tfe.StateVe
 		}
 	}
 
-	return nil, fmt.Errorf("failed to find state version with serial %d", i)
+	return nil, &SerialNotFoundError{Spec: spec, Serial: int64(i)}
+}
+
+// resolveTimeSpec handles @T specs, returning the newest version whose
+// CreatedAt is at or before the instant T denotes. T may be an RFC3339
+// timestamp ("@2024-01-15T10:30:00Z"), a date ("@2024-01-15", midnight
+// UTC), or a negative Go-style duration relative to now ("@-24h", "@-7d" -
+// "d" isn't a unit time.ParseDuration understands, so it's handled
+// separately as a count of 24h days).
+func resolveTimeSpec(spec string, versions []*tfe.StateVersion) (*tfe.StateVersion, error) {
+	raw := strings.TrimPrefix(spec, "@")
+
+	t, err := parseTimeSpecInstant(raw)
+	if err != nil {
+		return nil, fmt.Errorf("unparseable timestamp %q: %w", raw, err)
+	}
+
+	var best *tfe.StateVersion
+	for _, v := range versions {
+		if v.CreatedAt.After(t) {
+			continue
+		}
+		if best == nil || v.CreatedAt.After(best.CreatedAt) {
+			best = v
+		}
+	}
+	if best == nil {
+		return nil, fmt.Errorf("no version at or before %s", t.Format(time.RFC3339))
+	}
+
+	return best, nil
+}
+
+// parseTimeSpecInstant parses the portion of an @T spec after the leading
+// "@" into an absolute instant, trying (in order) a relative duration, a
+// date-only timestamp, and a full RFC3339 timestamp.
+func parseTimeSpecInstant(raw string) (time.Time, error) {
+	if d, ok := parseRelativeDuration(raw); ok {
+		return time.Now().Add(d), nil
+	}
+
+	if t, err := time.Parse("2006-01-02", raw); err == nil {
+		return t, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expected RFC3339, YYYY-MM-DD, or a relative duration like -24h/-7d: %w", err)
+	}
+	return t, nil
+}
+
+// parseRelativeDuration parses a signed Go-style duration, additionally
+// accepting a "d" (day, 24h) unit time.ParseDuration itself doesn't support.
+// Only strings starting with "-" or "+" are considered durations at all, so
+// a bare date never gets misread as one.
+func parseRelativeDuration(raw string) (time.Duration, bool) {
+	if raw == "" || (raw[0] != '-' && raw[0] != '+') {
+		return 0, false
+	}
+
+	if strings.HasSuffix(raw, "d") {
+		n, err := strconv.Atoi(strings.TrimSuffix(raw, "d"))
+		if err != nil {
+			return 0, false
+		}
+		return time.Duration(n) * 24 * time.Hour, true
+	}
+
+	d, err := time.ParseDuration(raw)
+	if err != nil {
+		return 0, false
+	}
+	return d, true
 }
 
 // resolveFileSpec handles file path specs.
@@ -118,15 +332,136 @@ func resolveFileSpec(spec string) (*tfe.StateVersion, error) {
 	}, nil
 }
 
-// resolveIDSpec handles state version ID prefix specs.
+// resolveURLSpec handles http(s)/s3/gs URL specs. It streams the state
+// document to a temp file via go-getter (the same getter already used to
+// materialize local.BackendLocal's Source) rather than buffering the whole
+// body in memory, and returns a synthetic StateVersion mirroring
+// resolveFileSpec's shape, with JSONDownloadURL pointing at the cached copy
+// instead of the original spec.
+func resolveURLSpec(spec string) (*tfe.StateVersion, error) {
+	tmp, err := os.CreateTemp("", "tfctl-state-*.json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp file for downloaded state: %w", err)
+	}
+	tmp.Close()
+
+	client := &getter.Client{
+		Src:  spec,
+		Dst:  tmp.Name(),
+		Mode: getter.ClientModeFile,
+	}
+	if err := client.Get(); err != nil {
+		return nil, fmt.Errorf("failed to download state from %s: %w", spec, err)
+	}
+
+	return &tfe.StateVersion{
+		ID:              spec,
+		Serial:          0,
+		JSONDownloadURL: tmp.Name(),
+	}, nil
+}
+
+// AmbiguousIDError is returned by resolveIDSpec when a plain prefix spec
+// (no glob/regex metacharacters) matches more than one StateVersion, so a
+// caller can surface a disambiguation prompt instead of silently acting on
+// whichever candidate happened to come first.
+type AmbiguousIDError struct {
+	Spec       string
+	Candidates []*tfe.StateVersion
+}
+
+// Error implements error.
+func (e *AmbiguousIDError) Error() string {
+	ids := make([]string, len(e.Candidates))
+	for i, v := range e.Candidates {
+		ids[i] = v.ID
+	}
+	return fmt.Sprintf("ambiguous ID prefix %q matches %d state versions: %s", e.Spec, len(e.Candidates), strings.Join(ids, ", "))
+}
+
+// Unwrap exposes ErrAmbiguousID so callers can test for ambiguity with
+// errors.Is without caring about the exact Candidates it carries.
+func (e *AmbiguousIDError) Unwrap() error { return ErrAmbiguousID }
+
+// resolveIDSpec handles state version ID specs: a spec containing a glob
+// metacharacter (* or ?) is matched as a shell-style glob; a spec wrapped
+// in slashes ("/pattern/") is matched as a regex; anything else is a plain
+// prefix match, which errors with *AmbiguousIDError rather than silently
+// returning the first hit when more than one version shares the prefix.
 func resolveIDSpec(spec string, versions []*tfe.StateVersion) (*tfe.StateVersion, error) {
+	switch {
+	case isRegexIDSpec(spec):
+		return resolveIDSpecByRegex(spec, versions)
+	case isGlobIDSpec(spec):
+		return resolveIDSpecByGlob(spec, versions)
+	default:
+		return resolveIDSpecByPrefix(spec, versions)
+	}
+}
+
+// isGlobIDSpec reports whether spec contains a glob metacharacter path.Match
+// understands.
+func isGlobIDSpec(spec string) bool {
+	return strings.ContainsAny(spec, "*?")
+}
+
+// isRegexIDSpec reports whether spec is wrapped in slashes, e.g. "/^sv-/".
+func isRegexIDSpec(spec string) bool {
+	return len(spec) >= 2 && strings.HasPrefix(spec, "/") && strings.HasSuffix(spec, "/")
+}
+
+// resolveIDSpecByGlob handles a spec containing * or ?, matched against ID
+// with path.Match (the same glob dialect shell filename expansion uses;
+// ID never contains "/", so path.Match's directory-boundary behavior
+// doesn't come into play).
+func resolveIDSpecByGlob(spec string, versions []*tfe.StateVersion) (*tfe.StateVersion, error) {
 	for _, v := range versions {
-		if strings.HasPrefix(v.ID, spec) {
+		matched, err := path.Match(spec, v.ID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid glob pattern %q: %w", spec, err)
+		}
+		if matched {
 			return v, nil
 		}
 	}
+	return nil, &IDNotFoundError{Spec: spec, Kind: "glob"}
+}
 
-	return nil, fmt.Errorf("failed to find state version with ID prefix: %s", spec)
+// resolveIDSpecByRegex handles a "/pattern/"-wrapped spec, matched against
+// ID.
+func resolveIDSpecByRegex(spec string, versions []*tfe.StateVersion) (*tfe.StateVersion, error) {
+	pattern := spec[1 : len(spec)-1]
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regex pattern %q: %w", pattern, err)
+	}
+
+	for _, v := range versions {
+		if re.MatchString(v.ID) {
+			return v, nil
+		}
+	}
+	return nil, &IDNotFoundError{Spec: pattern, Kind: "regex"}
+}
+
+// resolveIDSpecByPrefix handles a plain ID prefix spec.
+func resolveIDSpecByPrefix(spec string, versions []*tfe.StateVersion) (*tfe.StateVersion, error) {
+	var matches []*tfe.StateVersion
+	for _, v := range versions {
+		if strings.HasPrefix(v.ID, spec) {
+			matches = append(matches, v)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, &IDNotFoundError{Spec: spec, Kind: "ID prefix"}
+	case 1:
+		return matches[0], nil
+	default:
+		return nil, &AmbiguousIDError{Spec: spec, Candidates: matches}
+	}
 }
 
 // isNumeric checks if a string is a numeric value.
@@ -135,6 +470,18 @@ func isNumeric(s string) bool {
 	return err == nil
 }
 
+// isURLSpec checks if a string is a URL resolveURLSpec knows how to
+// download: plain http(s), or the s3/gs schemes go-getter's S3 and GCS
+// getters already understand.
+func isURLSpec(s string) bool {
+	for _, scheme := range []string{"http://", "https://", "s3://", "gs://"} {
+		if strings.HasPrefix(s, scheme) {
+			return true
+		}
+	}
+	return false
+}
+
 // isFilePath checks if a string is a valid file path.
 func isFilePath(s string) bool {
 	_, err := os.Stat(s)
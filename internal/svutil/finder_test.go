@@ -5,9 +5,11 @@
 package svutil
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/go-tfe"
 	"github.com/stretchr/testify/assert"
@@ -39,17 +41,34 @@ func makeStateVersions() []*tfe.StateVersion {
 	}
 }
 
+// makeTimedStateVersions creates a test slice of StateVersions with
+// CreatedAt set, newest first, for exercising resolveTimeSpec.
+func makeTimedStateVersions() []*tfe.StateVersion {
+	base := time.Date(2024, time.January, 10, 0, 0, 0, 0, time.UTC)
+	return []*tfe.StateVersion{
+		{ID: "sv-t-003", Serial: 103, CreatedAt: base.AddDate(0, 0, 10)},
+		{ID: "sv-t-002", Serial: 102, CreatedAt: base.AddDate(0, 0, 5)},
+		{ID: "sv-t-001", Serial: 101, CreatedAt: base},
+	}
+}
+
 func TestResolve(t *testing.T) {
 	versions := makeStateVersions()
 
+	tmpFile, err := os.CreateTemp("", "svutil-resolve-range-*.json")
+	assert.NoError(t, err)
+	defer os.Remove(tmpFile.Name())
+	tmpFile.Close()
+
 	tests := []struct {
-		name      string
-		versions  []*tfe.StateVersion
-		specs     []string
-		wantCount int
-		wantIDs   []string
-		wantErr   bool
-		errMsg    string
+		name         string
+		versions     []*tfe.StateVersion
+		specs        []string
+		wantCount    int
+		wantIDs      []string
+		wantErr      bool
+		errMsg       string
+		wantSentinel error
 	}{
 		{
 			name:      "no specs defaults to CSV~0",
@@ -92,28 +111,31 @@ func TestResolve(t *testing.T) {
 			wantErr:   false,
 		},
 		{
-			name:      "invalid CSV spec format",
-			versions:  versions,
-			specs:     []string{"CSV~1~2"},
-			wantCount: 0,
-			wantErr:   true,
-			errMsg:    "invalid CSV spec format",
+			name:         "invalid CSV spec format",
+			versions:     versions,
+			specs:        []string{"CSV~1~2"},
+			wantCount:    0,
+			wantErr:      true,
+			errMsg:       "invalid CSV spec format",
+			wantSentinel: ErrInvalidSpec,
 		},
 		{
-			name:      "CSV spec with non-numeric index",
-			versions:  versions,
-			specs:     []string{"CSV~abc"},
-			wantCount: 0,
-			wantErr:   true,
-			errMsg:    "invalid CSV index",
+			name:         "CSV spec with non-numeric index",
+			versions:     versions,
+			specs:        []string{"CSV~abc"},
+			wantCount:    0,
+			wantErr:      true,
+			errMsg:       "invalid CSV index",
+			wantSentinel: ErrInvalidSpec,
 		},
 		{
-			name:      "CSV spec index out of range",
-			versions:  versions,
-			specs:     []string{"CSV~99"},
-			wantCount: 0,
-			wantErr:   true,
-			errMsg:    "out of range",
+			name:         "CSV spec index out of range",
+			versions:     versions,
+			specs:        []string{"CSV~99"},
+			wantCount:    0,
+			wantErr:      true,
+			errMsg:       "out of range",
+			wantSentinel: ErrOutOfRange,
 		},
 		{
 			name:      "serial number lookup",
@@ -132,20 +154,22 @@ func TestResolve(t *testing.T) {
 			wantErr:   false,
 		},
 		{
-			name:      "serial number not found",
-			versions:  versions,
-			specs:     []string{"999"},
-			wantCount: 0,
-			wantErr:   true,
-			errMsg:    "failed to find state version with serial",
+			name:         "serial number not found",
+			versions:     versions,
+			specs:        []string{"999"},
+			wantCount:    0,
+			wantErr:      true,
+			errMsg:       "failed to find state version with serial",
+			wantSentinel: ErrSerialNotFound,
 		},
 		{
-			name:      "ID prefix match",
-			versions:  versions,
-			specs:     []string{"sv-00"},
-			wantCount: 1,
-			wantIDs:   []string{"sv-001"},
-			wantErr:   false,
+			name:         "ID prefix match ambiguous",
+			versions:     versions,
+			specs:        []string{"sv-00"},
+			wantCount:    0,
+			wantErr:      true,
+			errMsg:       "ambiguous ID prefix",
+			wantSentinel: ErrAmbiguousID,
 		},
 		{
 			name:      "ID prefix match with longer prefix",
@@ -156,20 +180,22 @@ func TestResolve(t *testing.T) {
 			wantErr:   false,
 		},
 		{
-			name:      "ID prefix match ambiguous",
-			versions:  versions,
-			specs:     []string{"sv-"},
-			wantCount: 1,
-			wantIDs:   []string{"sv-001"},
-			wantErr:   false,
+			name:         "ID prefix match all ambiguous",
+			versions:     versions,
+			specs:        []string{"sv-"},
+			wantCount:    0,
+			wantErr:      true,
+			errMsg:       "ambiguous ID prefix",
+			wantSentinel: ErrAmbiguousID,
 		},
 		{
-			name:      "ID prefix not found",
-			versions:  versions,
-			specs:     []string{"sv-xyz"},
-			wantCount: 0,
-			wantErr:   true,
-			errMsg:    "failed to find state version with ID prefix",
+			name:         "ID prefix not found",
+			versions:     versions,
+			specs:        []string{"sv-xyz"},
+			wantCount:    0,
+			wantErr:      true,
+			errMsg:       "failed to find state version with ID prefix",
+			wantSentinel: ErrIDNotFound,
 		},
 		{
 			name:      "relative index positive zeros",
@@ -188,20 +214,22 @@ func TestResolve(t *testing.T) {
 			wantErr:   false,
 		},
 		{
-			name:      "relative index negative out of range",
-			versions:  versions,
-			specs:     []string{"-99"},
-			wantCount: 0,
-			wantErr:   true,
-			errMsg:    "out of range",
+			name:         "relative index negative out of range",
+			versions:     versions,
+			specs:        []string{"-99"},
+			wantCount:    0,
+			wantErr:      true,
+			errMsg:       "out of range",
+			wantSentinel: ErrOutOfRange,
 		},
 		{
-			name:      "empty versions list with CSV spec",
-			versions:  []*tfe.StateVersion{},
-			specs:     []string{"CSV~0"},
-			wantCount: 0,
-			wantErr:   true,
-			errMsg:    "out of range",
+			name:         "empty versions list with CSV spec",
+			versions:     []*tfe.StateVersion{},
+			specs:        []string{"CSV~0"},
+			wantCount:    0,
+			wantErr:      true,
+			errMsg:       "out of range",
+			wantSentinel: ErrOutOfRange,
 		},
 		{
 			name:      "single version in list",
@@ -212,12 +240,110 @@ func TestResolve(t *testing.T) {
 			wantErr:   false,
 		},
 		{
-			name:      "single version out of range",
-			versions:  []*tfe.StateVersion{versions[0]},
-			specs:     []string{"CSV~1"},
+			name:         "single version out of range",
+			versions:     []*tfe.StateVersion{versions[0]},
+			specs:        []string{"CSV~1"},
+			wantCount:    0,
+			wantErr:      true,
+			errMsg:       "out of range",
+			wantSentinel: ErrOutOfRange,
+		},
+		{
+			name:      "inclusive CSV range",
+			versions:  versions,
+			specs:     []string{"CSV~0..CSV~2"},
+			wantCount: 3,
+			wantIDs:   []string{"sv-001", "sv-002", "sv-003"},
+			wantErr:   false,
+		},
+		{
+			name:      "half-open CSV range excludes right endpoint",
+			versions:  versions,
+			specs:     []string{"CSV~0..<CSV~2"},
+			wantCount: 2,
+			wantIDs:   []string{"sv-001", "sv-002"},
+			wantErr:   false,
+		},
+		{
+			name:      "inclusive range given in reverse order normalizes",
+			versions:  versions,
+			specs:     []string{"CSV~2..CSV~0"},
+			wantCount: 3,
+			wantIDs:   []string{"sv-001", "sv-002", "sv-003"},
+			wantErr:   false,
+		},
+		{
+			name:      "half-open range given in reverse order still excludes the written right endpoint",
+			versions:  versions,
+			specs:     []string{"CSV~2..<CSV~0"},
+			wantCount: 2,
+			wantIDs:   []string{"sv-002", "sv-003"},
+			wantErr:   false,
+		},
+		{
+			name:      "serial number range",
+			versions:  versions,
+			specs:     []string{"100..102"},
+			wantCount: 3,
+			wantIDs:   []string{"sv-001", "sv-002", "sv-003"},
+			wantErr:   false,
+		},
+		{
+			name:      "ID prefix range",
+			versions:  versions,
+			specs:     []string{"sv-001..sv-003"},
+			wantCount: 3,
+			wantIDs:   []string{"sv-001", "sv-002", "sv-003"},
+			wantErr:   false,
+		},
+		{
+			name:      "open-start range from newest",
+			versions:  versions,
+			specs:     []string{"..CSV~1"},
+			wantCount: 2,
+			wantIDs:   []string{"sv-001", "sv-002"},
+			wantErr:   false,
+		},
+		{
+			name:      "open-end range through oldest",
+			versions:  versions,
+			specs:     []string{"CSV~2.."},
+			wantCount: 2,
+			wantIDs:   []string{"sv-003", "sv-alpha-001"},
+			wantErr:   false,
+		},
+		{
+			name:      "range combined with overlapping spec dedups",
+			versions:  versions,
+			specs:     []string{"CSV~0..CSV~2", "CSV~1"},
+			wantCount: 3,
+			wantIDs:   []string{"sv-001", "sv-002", "sv-003"},
+			wantErr:   false,
+		},
+		{
+			name:      "empty range is rejected",
+			versions:  versions,
+			specs:     []string{".."},
 			wantCount: 0,
 			wantErr:   true,
-			errMsg:    "out of range",
+			errMsg:    "empty range",
+		},
+		{
+			name:      "range mixing file-path endpoint with list-relative endpoint is rejected",
+			versions:  versions,
+			specs:     []string{fmt.Sprintf("%s..CSV~0", tmpFile.Name())},
+			wantCount: 0,
+			wantErr:   true,
+			errMsg:    "no well-defined position",
+		},
+		{
+			name:         "relative path with .. is not mistaken for a range",
+			versions:     versions,
+			specs:        []string{"../nonexistent/state.json"},
+			wantCount:    0,
+			wantErr:      true,
+			errMsg:       "failed to find state version with ID prefix",
+			wantSentinel: ErrIDNotFound,
 		},
 	}
 
@@ -229,6 +355,9 @@ func TestResolve(t *testing.T) {
 				if tt.errMsg != "" {
 					assert.Contains(t, err.Error(), tt.errMsg)
 				}
+				if tt.wantSentinel != nil {
+					assert.ErrorIs(t, err, tt.wantSentinel)
+				}
 				assert.Nil(t, got)
 			} else {
 				assert.NoError(t, err, "unexpected error")
@@ -246,12 +375,13 @@ func TestResolveCSVSpec(t *testing.T) {
 	versions := makeStateVersions()
 
 	tests := []struct {
-		name     string
-		spec     string
-		versions []*tfe.StateVersion
-		wantID   string
-		wantErr  bool
-		errMsg   string
+		name         string
+		spec         string
+		versions     []*tfe.StateVersion
+		wantID       string
+		wantErr      bool
+		errMsg       string
+		wantSentinel error
 	}{
 		{
 			name:     "valid index 0",
@@ -268,46 +398,52 @@ func TestResolveCSVSpec(t *testing.T) {
 			wantErr:  false,
 		},
 		{
-			name:     "index out of range",
-			spec:     "CSV~100",
-			versions: versions,
-			wantErr:  true,
-			errMsg:   "out of range",
+			name:         "index out of range",
+			spec:         "CSV~100",
+			versions:     versions,
+			wantErr:      true,
+			errMsg:       "out of range",
+			wantSentinel: ErrOutOfRange,
 		},
 		{
-			name:     "missing tilde",
-			spec:     "CSV0",
-			versions: versions,
-			wantErr:  true,
-			errMsg:   "invalid CSV spec format",
+			name:         "missing tilde",
+			spec:         "CSV0",
+			versions:     versions,
+			wantErr:      true,
+			errMsg:       "invalid CSV spec format",
+			wantSentinel: ErrInvalidSpec,
 		},
 		{
-			name:     "non-numeric index",
-			spec:     "CSV~abc",
-			versions: versions,
-			wantErr:  true,
-			errMsg:   "invalid CSV index",
+			name:         "non-numeric index",
+			spec:         "CSV~abc",
+			versions:     versions,
+			wantErr:      true,
+			errMsg:       "invalid CSV index",
+			wantSentinel: ErrInvalidSpec,
 		},
 		{
-			name:     "negative index",
-			spec:     "CSV~-1",
-			versions: versions,
-			wantErr:  true,
-			errMsg:   "out of range",
+			name:         "negative index",
+			spec:         "CSV~-1",
+			versions:     versions,
+			wantErr:      true,
+			errMsg:       "out of range",
+			wantSentinel: ErrOutOfRange,
 		},
 		{
-			name:     "multiple tildes",
-			spec:     "CSV~1~2",
-			versions: versions,
-			wantErr:  true,
-			errMsg:   "invalid CSV spec format",
+			name:         "multiple tildes",
+			spec:         "CSV~1~2",
+			versions:     versions,
+			wantErr:      true,
+			errMsg:       "invalid CSV spec format",
+			wantSentinel: ErrInvalidSpec,
 		},
 		{
-			name:     "empty versions list",
-			spec:     "CSV~0",
-			versions: []*tfe.StateVersion{},
-			wantErr:  true,
-			errMsg:   "out of range",
+			name:         "empty versions list",
+			spec:         "CSV~0",
+			versions:     []*tfe.StateVersion{},
+			wantErr:      true,
+			errMsg:       "out of range",
+			wantSentinel: ErrOutOfRange,
 		},
 	}
 
@@ -320,6 +456,9 @@ func TestResolveCSVSpec(t *testing.T) {
 				if tt.errMsg != "" {
 					assert.Contains(t, err.Error(), tt.errMsg)
 				}
+				if tt.wantSentinel != nil {
+					assert.ErrorIs(t, err, tt.wantSentinel)
+				}
 			} else {
 				assert.NoError(t, err)
 				assert.NotNil(t, got)
@@ -333,12 +472,13 @@ func TestResolveNumericSpec(t *testing.T) {
 	versions := makeStateVersions()
 
 	tests := []struct {
-		name     string
-		spec     string
-		versions []*tfe.StateVersion
-		wantID   string
-		wantErr  bool
-		errMsg   string
+		name         string
+		spec         string
+		versions     []*tfe.StateVersion
+		wantID       string
+		wantErr      bool
+		errMsg       string
+		wantSentinel error
 	}{
 		{
 			name:     "zero means index 0",
@@ -355,11 +495,12 @@ func TestResolveNumericSpec(t *testing.T) {
 			wantErr:  false,
 		},
 		{
-			name:     "negative index out of range",
-			spec:     "-99",
-			versions: versions,
-			wantErr:  true,
-			errMsg:   "out of range",
+			name:         "negative index out of range",
+			spec:         "-99",
+			versions:     versions,
+			wantErr:      true,
+			errMsg:       "out of range",
+			wantSentinel: ErrOutOfRange,
 		},
 		{
 			name:     "positive number is serial lookup",
@@ -376,31 +517,117 @@ func TestResolveNumericSpec(t *testing.T) {
 			wantErr:  false,
 		},
 		{
-			name:     "serial not found",
+			name:         "serial not found",
+			versions:     versions,
+			spec:         "999",
+			wantErr:      true,
+			errMsg:       "failed to find state version with serial",
+			wantSentinel: ErrSerialNotFound,
+		},
+		{
+			name:         "negative with empty list",
+			spec:         "-1",
+			versions:     []*tfe.StateVersion{},
+			wantErr:      true,
+			errMsg:       "out of range",
+			wantSentinel: ErrOutOfRange,
+		},
+		{
+			name:         "positive serial with empty list",
+			spec:         "100",
+			versions:     []*tfe.StateVersion{},
+			wantErr:      true,
+			errMsg:       "failed to find state version with serial",
+			wantSentinel: ErrSerialNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveNumericSpec(tt.spec, tt.versions)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+				if tt.errMsg != "" {
+					assert.Contains(t, err.Error(), tt.errMsg)
+				}
+				if tt.wantSentinel != nil {
+					assert.ErrorIs(t, err, tt.wantSentinel)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, got)
+				assert.Equal(t, tt.wantID, got.ID)
+			}
+		})
+	}
+}
+
+func TestResolveTimeSpec(t *testing.T) {
+	versions := makeTimedStateVersions()
+
+	tests := []struct {
+		name     string
+		spec     string
+		versions []*tfe.StateVersion
+		wantID   string
+		wantErr  bool
+		errMsg   string
+	}{
+		{
+			name:     "date-only exact match",
+			spec:     "@2024-01-15",
+			versions: versions,
+			wantID:   "sv-t-002",
+			wantErr:  false,
+		},
+		{
+			name:     "RFC3339 between versions picks the older one",
+			spec:     "@2024-01-16T00:00:00Z",
+			versions: versions,
+			wantID:   "sv-t-002",
+			wantErr:  false,
+		},
+		{
+			name:     "RFC3339 exact match on the newest version",
+			spec:     "@2024-01-20T00:00:00Z",
+			versions: versions,
+			wantID:   "sv-t-003",
+			wantErr:  false,
+		},
+		{
+			name:     "before every version",
+			spec:     "@2023-01-01",
 			versions: versions,
-			spec:     "999",
 			wantErr:  true,
-			errMsg:   "failed to find state version with serial",
+			errMsg:   "no version at or before",
 		},
 		{
-			name:     "negative with empty list",
-			spec:     "-1",
-			versions: []*tfe.StateVersion{},
+			name:     "relative hours resolves to the newest version",
+			spec:     "@-1h",
+			versions: versions,
+			wantID:   "sv-t-003",
+			wantErr:  false,
+		},
+		{
+			name:     "relative days far enough back finds nothing",
+			spec:     "@-36500d",
+			versions: versions,
 			wantErr:  true,
-			errMsg:   "out of range",
+			errMsg:   "no version at or before",
 		},
 		{
-			name:     "positive serial with empty list",
-			spec:     "100",
-			versions: []*tfe.StateVersion{},
+			name:     "unparseable timestamp",
+			spec:     "@not-a-date",
+			versions: versions,
 			wantErr:  true,
-			errMsg:   "failed to find state version with serial",
+			errMsg:   "unparseable timestamp",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := resolveNumericSpec(tt.spec, tt.versions)
+			got, err := resolveTimeSpec(tt.spec, tt.versions)
 			if tt.wantErr {
 				assert.Error(t, err)
 				assert.Nil(t, got)
@@ -474,12 +701,13 @@ func TestResolveIDSpec(t *testing.T) {
 	versions := makeStateVersions()
 
 	tests := []struct {
-		name     string
-		spec     string
-		versions []*tfe.StateVersion
-		wantID   string
-		wantErr  bool
-		errMsg   string
+		name         string
+		spec         string
+		versions     []*tfe.StateVersion
+		wantID       string
+		wantErr      bool
+		errMsg       string
+		wantSentinel error
 	}{
 		{
 			name:     "exact ID match",
@@ -489,18 +717,20 @@ func TestResolveIDSpec(t *testing.T) {
 			wantErr:  false,
 		},
 		{
-			name:     "prefix match",
-			spec:     "sv-00",
-			versions: versions,
-			wantID:   "sv-001",
-			wantErr:  false,
+			name:         "prefix match ambiguous",
+			spec:         "sv-00",
+			versions:     versions,
+			wantErr:      true,
+			errMsg:       "ambiguous ID prefix",
+			wantSentinel: ErrAmbiguousID,
 		},
 		{
-			name:     "partial prefix match",
-			spec:     "sv-",
-			versions: versions,
-			wantID:   "sv-001",
-			wantErr:  false,
+			name:         "partial prefix match ambiguous",
+			spec:         "sv-",
+			versions:     versions,
+			wantErr:      true,
+			errMsg:       "ambiguous ID prefix",
+			wantSentinel: ErrAmbiguousID,
 		},
 		{
 			name:     "longer prefix match",
@@ -517,39 +747,88 @@ func TestResolveIDSpec(t *testing.T) {
 			wantErr:  false,
 		},
 		{
-			name:     "ID not found",
-			spec:     "sv-xyz",
-			versions: versions,
-			wantErr:  true,
-			errMsg:   "failed to find state version with ID prefix",
+			name:         "ID not found",
+			spec:         "sv-xyz",
+			versions:     versions,
+			wantErr:      true,
+			errMsg:       "failed to find state version with ID prefix",
+			wantSentinel: ErrIDNotFound,
 		},
 		{
-			name:     "single character prefix",
-			spec:     "s",
+			name:         "single character prefix ambiguous",
+			spec:         "s",
+			versions:     versions,
+			wantErr:      true,
+			errMsg:       "ambiguous ID prefix",
+			wantSentinel: ErrAmbiguousID,
+		},
+		{
+			name:         "empty spec ambiguous",
+			spec:         "",
+			versions:     versions,
+			wantErr:      true,
+			errMsg:       "ambiguous ID prefix",
+			wantSentinel: ErrAmbiguousID,
+		},
+		{
+			name:     "glob match returns first hit",
+			spec:     "sv-00?",
 			versions: versions,
 			wantID:   "sv-001",
 			wantErr:  false,
 		},
 		{
-			name:     "empty spec",
-			spec:     "",
+			name:     "glob match unique",
+			spec:     "sv-alpha-*",
+			versions: versions,
+			wantID:   "sv-alpha-001",
+			wantErr:  false,
+		},
+		{
+			name:         "glob no match",
+			spec:         "sv-zzz-*",
+			versions:     versions,
+			wantErr:      true,
+			errMsg:       "failed to find state version matching glob",
+			wantSentinel: ErrIDNotFound,
+		},
+		{
+			name:     "regex match",
+			spec:     "/^sv-00[12]$/",
 			versions: versions,
 			wantID:   "sv-001",
 			wantErr:  false,
 		},
 		{
-			name:     "empty versions list",
-			spec:     "sv-001",
-			versions: []*tfe.StateVersion{},
-			wantErr:  true,
-			errMsg:   "failed to find state version with ID prefix",
+			name:         "regex no match",
+			spec:         "/^nope$/",
+			versions:     versions,
+			wantErr:      true,
+			errMsg:       "failed to find state version matching regex",
+			wantSentinel: ErrIDNotFound,
 		},
 		{
-			name:     "case sensitive match",
-			spec:     "SV",
+			name:     "invalid regex",
+			spec:     "/[/",
 			versions: versions,
 			wantErr:  true,
-			errMsg:   "failed to find state version with ID prefix",
+			errMsg:   "invalid regex pattern",
+		},
+		{
+			name:         "empty versions list",
+			spec:         "sv-001",
+			versions:     []*tfe.StateVersion{},
+			wantErr:      true,
+			errMsg:       "failed to find state version with ID prefix",
+			wantSentinel: ErrIDNotFound,
+		},
+		{
+			name:         "case sensitive match",
+			spec:         "SV",
+			versions:     versions,
+			wantErr:      true,
+			errMsg:       "failed to find state version with ID prefix",
+			wantSentinel: ErrIDNotFound,
 		},
 	}
 
@@ -562,6 +841,9 @@ func TestResolveIDSpec(t *testing.T) {
 				if tt.errMsg != "" {
 					assert.Contains(t, err.Error(), tt.errMsg)
 				}
+				if tt.wantSentinel != nil {
+					assert.ErrorIs(t, err, tt.wantSentinel)
+				}
 			} else {
 				assert.NoError(t, err)
 				assert.NotNil(t, got)
@@ -724,6 +1006,20 @@ func TestResolveSpec(t *testing.T) {
 			wantErr:  true,
 			errMsg:   "invalid CSV index",
 		},
+		{
+			name:     "time spec dispatch",
+			spec:     "@2099-01-01",
+			versions: versions,
+			wantID:   "sv-001",
+			wantErr:  false,
+		},
+		{
+			name:     "unparseable time spec dispatch",
+			spec:     "@not-a-date",
+			versions: versions,
+			wantErr:  true,
+			errMsg:   "unparseable timestamp",
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,62 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package svutil
+
+import (
+	"strings"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+// Resolver matches and resolves a single spec kind. Match is tried against
+// the raw spec string; Resolve is only called once Match has returned true.
+type Resolver interface {
+	Match(spec string) bool
+	Resolve(spec string, versions []*tfe.StateVersion) (*tfe.StateVersion, error)
+}
+
+// resolverFunc adapts a pair of plain functions to the Resolver interface,
+// so the built-in resolvers below don't each need their own named type.
+type resolverFunc struct {
+	match   func(string) bool
+	resolve func(string, []*tfe.StateVersion) (*tfe.StateVersion, error)
+}
+
+func (r resolverFunc) Match(spec string) bool { return r.match(spec) }
+
+func (r resolverFunc) Resolve(spec string, versions []*tfe.StateVersion) (*tfe.StateVersion, error) {
+	return r.resolve(spec, versions)
+}
+
+// registry holds the Resolvers resolveSpec consults, most recently
+// registered first. Register prepends rather than appends, so a caller
+// registering a new scheme - or a replacement Match for an existing one -
+// always gets first refusal ahead of the built-ins populated at init time.
+var registry []Resolver
+
+// Register adds r to the front of the resolver registry resolveSpec
+// consults, ahead of every previously registered Resolver including the
+// built-ins. This lets external callers add new spec schemes (e.g.
+// "run:<run-id>", resolved by calling the TFE API to map a run to its state
+// version) or override a built-in's behavior, without editing svutil.
+func Register(r Resolver) {
+	registry = append([]Resolver{r}, registry...)
+}
+
+// init populates the default registry with the built-in resolvers, in the
+// same priority resolveSpec's dispatch used before this registry existed:
+// CSV~N, @T, numeric serial, URL, then file path. A plain ID spec (prefix,
+// glob, or regex) matches none of these and falls through to resolveIDSpec,
+// which resolveSpec calls directly rather than carrying it in the registry.
+func init() {
+	Register(resolverFunc{match: isFilePath, resolve: func(spec string, _ []*tfe.StateVersion) (*tfe.StateVersion, error) {
+		return resolveFileSpec(spec)
+	}})
+	Register(resolverFunc{match: isURLSpec, resolve: func(spec string, _ []*tfe.StateVersion) (*tfe.StateVersion, error) {
+		return resolveURLSpec(spec)
+	}})
+	Register(resolverFunc{match: isNumeric, resolve: resolveNumericSpec})
+	Register(resolverFunc{match: func(spec string) bool { return strings.HasPrefix(spec, "@") }, resolve: resolveTimeSpec})
+	Register(resolverFunc{match: func(spec string) bool { return strings.HasPrefix(strings.ToUpper(spec), "CSV~") }, resolve: resolveCSVSpec})
+}
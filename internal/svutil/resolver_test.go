@@ -0,0 +1,91 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+package svutil
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/stretchr/testify/assert"
+)
+
+// withRegistry runs fn against a temporary copy of the package registry,
+// restoring the real one afterward, so a test can Register scratch
+// resolvers without leaking them into other tests.
+func withRegistry(t *testing.T, fn func()) {
+	t.Helper()
+	saved := append([]Resolver{}, registry...)
+	t.Cleanup(func() { registry = saved })
+	fn()
+}
+
+func TestRegisterOrdering(t *testing.T) {
+	withRegistry(t, func() {
+		var order []string
+
+		Register(resolverFunc{
+			match: func(spec string) bool { return spec == "multi" },
+			resolve: func(spec string, versions []*tfe.StateVersion) (*tfe.StateVersion, error) {
+				order = append(order, "first")
+				return &tfe.StateVersion{ID: "first"}, nil
+			},
+		})
+		Register(resolverFunc{
+			match: func(spec string) bool { return spec == "multi" },
+			resolve: func(spec string, versions []*tfe.StateVersion) (*tfe.StateVersion, error) {
+				order = append(order, "second")
+				return &tfe.StateVersion{ID: "second"}, nil
+			},
+		})
+
+		got, err := resolveSpec("multi", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "second", got.ID)
+		assert.Equal(t, []string{"second"}, order)
+	})
+}
+
+func TestRegisterOverridesBuiltin(t *testing.T) {
+	withRegistry(t, func() {
+		Register(resolverFunc{
+			match: isNumeric,
+			resolve: func(spec string, versions []*tfe.StateVersion) (*tfe.StateVersion, error) {
+				return &tfe.StateVersion{ID: "overridden:" + spec}, nil
+			},
+		})
+
+		got, err := resolveSpec("101", makeStateVersions())
+		assert.NoError(t, err)
+		assert.Equal(t, "overridden:101", got.ID)
+	})
+}
+
+func TestRegisterCustomScheme(t *testing.T) {
+	withRegistry(t, func() {
+		Register(resolverFunc{
+			match: func(spec string) bool { return strings.HasPrefix(spec, "run:") },
+			resolve: func(spec string, versions []*tfe.StateVersion) (*tfe.StateVersion, error) {
+				runID := strings.TrimPrefix(spec, "run:")
+				return &tfe.StateVersion{
+					ID:     "sv-for-" + runID,
+					Serial: 999,
+				}, nil
+			},
+		})
+
+		got, err := resolveSpec("run:run-abc123", makeStateVersions())
+		assert.NoError(t, err)
+		assert.Equal(t, "sv-for-run-abc123", got.ID)
+		assert.Equal(t, int64(999), got.Serial)
+	})
+}
+
+func TestResolveSpecFallsThroughToIDSpec(t *testing.T) {
+	withRegistry(t, func() {
+		got, err := resolveSpec("sv-alpha", makeStateVersions())
+		assert.NoError(t, err)
+		assert.Equal(t, "sv-alpha-001", got.ID)
+	})
+}
@@ -7,13 +7,21 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"sort"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/tfctl/tfctl/internal/cacheutil"
 	"github.com/tfctl/tfctl/internal/command"
 	"github.com/tfctl/tfctl/internal/config"
 	"github.com/tfctl/tfctl/internal/log"
 	"github.com/tfctl/tfctl/internal/version"
+
+	// Blank-imported for their init() side effects, which register filter
+	// virtual keys (see internal/filters.Register).
+	_ "github.com/tfctl/tfctl/internal/hungarian"
 )
 
 var ctx = context.Background()
@@ -22,6 +30,35 @@ func main() {
 	os.Exit(realMain())
 }
 
+// installSignalContext wraps ctx so SIGINT/SIGTERM cancel it, letting an
+// in-flight command return its partial results instead of dying mid-write.
+// signal.NotifyContext stops relaying the signal to us as soon as the first
+// one cancels ctx, so a second Ctrl-C falls through to the OS's default
+// disposition and kills the process immediately - exactly the "one to ask
+// nicely, two to force it" behavior, for free, with no extra bookkeeping.
+func installSignalContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+}
+
+// resolveTimeout returns the duration requested by --timeout (anywhere in
+// args) or TFCTL_TIMEOUT, preferring the flag. It's parsed out of the raw
+// args the same way handleVersion/hasCacheStatsFlag are, since it needs to
+// take effect before the subcommand's own flag set is built.
+func resolveTimeout(args []string) (time.Duration, error) {
+	for i, a := range args {
+		if a == "--timeout" && i+1 < len(args) {
+			return time.ParseDuration(args[i+1])
+		}
+		if val, ok := strings.CutPrefix(a, "--timeout="); ok {
+			return time.ParseDuration(val)
+		}
+	}
+	if val := os.Getenv("TFCTL_TIMEOUT"); val != "" {
+		return time.ParseDuration(val)
+	}
+	return 0, nil
+}
+
 func realMain() int {
 	log.InitLogger()
 
@@ -32,6 +69,10 @@ func realMain() int {
 		return 0
 	}
 
+	if explainArgs, ok := extractExplainConfigFlag(args); ok {
+		return runExplainConfig(explainArgs)
+	}
+
 	args = handleNakedCommand(args)
 
 	// If --help appears anywhere, skip command processing and let the CLI handle it.
@@ -52,12 +93,28 @@ func realMain() int {
 
 // initAndRunApp initializes the app and runs it, returning the exit code.
 func initAndRunApp(args []string) int {
+	// Let operators cap the LRU sweep's budgets without a config file entry.
+	config.BindEnv("cache.max-entries", "TFCTL_CACHE_MAX_ENTRIES")
+	config.BindEnv("cache.max-bytes", "TFCTL_CACHE_MAX_BYTES")
+
 	// Pre-create cache directory when caching is enabled.
 	if _, ok, err := cacheutil.EnsureBaseDir(); err != nil && ok {
 		fmt.Fprintln(os.Stderr, err)
 		log.Debugf("cache ensure err: err=%v", err)
 	}
 
+	ctx, stop := installSignalContext(ctx)
+	defer stop()
+
+	if timeout, err := resolveTimeout(args); err != nil {
+		fmt.Fprintln(os.Stderr, fmt.Errorf("invalid --timeout: %w", err))
+		return 1
+	} else if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
 	app, err := command.InitApp(ctx, args)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
@@ -65,15 +122,42 @@ func initAndRunApp(args []string) int {
 		return 1
 	}
 
-	if err := app.Run(ctx, args); err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		log.Debugf("app run err: err=%v", err)
+	runErr := app.Run(ctx, args)
+
+	if hasCacheStatsFlag(args) {
+		printCacheStats()
+	}
+
+	if runErr != nil {
+		fmt.Fprintln(os.Stderr, runErr)
+		log.Debugf("app run err: err=%v", runErr)
 		return 2
 	}
 
 	return 0
 }
 
+// hasCacheStatsFlag reports whether --cache-stats appears anywhere in args.
+// It's checked directly against raw args, the same way handleVersion checks
+// for --version, since it needs to fire after app.Run regardless of which
+// subcommand's flag set actually owns it.
+func hasCacheStatsFlag(args []string) bool {
+	for _, a := range args {
+		if a == "--cache-stats" {
+			return true
+		}
+	}
+	return false
+}
+
+// printCacheStats prints this process's cache hit/miss/revalidation counts
+// and cumulative bytes served from cache, as tracked by cacheutil.
+func printCacheStats() {
+	s := cacheutil.CounterSnapshot()
+	fmt.Fprintf(os.Stdout, "cache: hits=%d misses=%d revalidations=%d bytes=%d\n",
+		s.Hits, s.Misses, s.Revalidations, s.Bytes)
+}
+
 // handleVersion checks for --version/-v and returns whether it was handled.
 func handleVersion(args []string) bool {
 	for _, a := range args {
@@ -122,6 +206,7 @@ func processCommandArgs(args []string) []string {
 		}
 
 		args = injectConfigSet(args, "defaults", insertIdx)
+		args = injectEnvVars(args, args[1], insertIdx)
 		args = injectExplicitSet(args)
 		args = deduplicateFlags(args)
 
@@ -135,20 +220,123 @@ func processCommandArgs(args []string) []string {
 	}
 }
 
-// injectConfigSet retrieves the config slice for the given key, expands each
-// entry by whitespace, and inserts the resulting args at the specified index.
+// maxSetDepth bounds how many levels deep a "@set" entry inside another set
+// can recurse, guarding against a runaway chain even when no literal cycle
+// exists.
+const maxSetDepth = 10
+
+// injectConfigSet retrieves the config slice for the given key, resolves any
+// set composition within it (see resolveSet), and inserts the resulting
+// flags at the specified index. Any "!--flag" removal entries found along
+// the way are applied to args[:insertIdx] only, so a set can turn off a flag
+// injected by an earlier (lower-precedence) set without touching the
+// original command-line args that follow insertIdx.
 func injectConfigSet(args []string, key string, insertIdx int) []string {
-	entries, _ := config.GetStringSlice(key)
-	if len(entries) == 0 {
+	flags, removals := resolveSet(key, map[string]bool{}, 0)
+	if len(flags) == 0 && len(removals) == 0 {
 		return args
 	}
 
-	var expanded []string
+	suffix := args[insertIdx:]
+	prefix := stripFlags(args[:insertIdx], removals)
+
+	return append(prefix, append(flags, suffix...)...)
+}
+
+// resolveSet expands the config set named by key into its flag tokens,
+// recursively expanding any entry that itself starts with "@" (set
+// composition: "@other" pulls in the contents of the set named "other" in
+// the same namespace as key) and collecting, rather than expanding, any
+// entry starting with "!" (e.g. "!--titles") into removals, for the caller
+// to strip out of any earlier-injected flags. path tracks the sets currently
+// being expanded on this call stack to detect cycles (A references B
+// references A); maxSetDepth bounds the recursion even for non-cyclical but
+// excessively long chains.
+func resolveSet(key string, path map[string]bool, depth int) (flags []string, removals []string) {
+	if path[key] {
+		log.Debugf("set composition cycle detected at %s, skipping", key)
+		return nil, nil
+	}
+	if depth > maxSetDepth {
+		log.Debugf("set composition exceeded max depth (%d) at %s, skipping", maxSetDepth, key)
+		return nil, nil
+	}
+	path[key] = true
+	defer delete(path, key)
+
+	entries, _ := config.GetStringSlice(key)
+	ns := setNamespace(key)
+
 	for _, entry := range entries {
-		expanded = append(expanded, strings.Fields(entry)...)
+		for _, field := range strings.Fields(entry) {
+			switch {
+			case strings.HasPrefix(field, "@"):
+				subFlags, subRemovals := resolveSet(setKey(ns, strings.TrimPrefix(field, "@")), path, depth+1)
+				flags = append(flags, subFlags...)
+				removals = append(removals, subRemovals...)
+			case strings.HasPrefix(field, "!"):
+				removals = append(removals, strings.TrimPrefix(field, "!"))
+			default:
+				flags = append(flags, field)
+			}
+		}
+	}
+
+	return flags, removals
+}
+
+// setNamespace returns the portion of a config set key before its final
+// dot-separated segment (e.g. "mq" for "mq.defaults"), or "" for an
+// unnamespaced key like "defaults" or "nostate".
+func setNamespace(key string) string {
+	if i := strings.LastIndex(key, "."); i >= 0 {
+		return key[:i]
+	}
+	return ""
+}
+
+// setKey rebuilds a config set key from a namespace (as returned by
+// setNamespace) and a bare set name, so a "@name" reference found inside one
+// set resolves within the same namespace it was found in.
+func setKey(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + "." + name
+}
+
+// stripFlags removes every occurrence of any flag named in removals from
+// args, handling both "--flag value" and "--flag=value" forms, the same way
+// deduplicateFlags recognizes a flag's value.
+func stripFlags(args []string, removals []string) []string {
+	if len(removals) == 0 {
+		return args
+	}
+
+	remove := make(map[string]bool, len(removals))
+	for _, r := range removals {
+		remove[r] = true
+	}
+
+	var result []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		name := arg
+		if before, _, ok := strings.Cut(arg, "="); ok {
+			name = before
+		}
+
+		if remove[name] {
+			if !strings.Contains(arg, "=") && i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+				i++
+			}
+			continue
+		}
+
+		result = append(result, arg)
 	}
 
-	return append(args[:insertIdx], append(expanded, args[insertIdx:]...)...)
+	return result
 }
 
 // deduplicateFlags removes duplicate flags from args, keeping the last
@@ -229,30 +417,249 @@ func isExistingFile(path string) bool {
 }
 
 // injectExplicitSet handles the @set logic for all commands, expanding set
-// arguments at the @set position.
+// arguments at the @set position. "@set1+set2" composes multiple explicit
+// sets in order, and each set's own "@other"/"!--flag" entries are resolved
+// via resolveSet exactly as they are for the injected cmd.defaults/
+// nostate/defaults sets.
 func injectExplicitSet(args []string) []string {
 	// Look for an explicit @set argument starting from starting idx.
 	idx := 2
-	set := ""
-	setIdx := len(args)
+	setIdx := -1
+	var names []string
 
 	for i, a := range args[idx:] {
 		if strings.HasPrefix(a, "@") {
-			set = strings.TrimPrefix(a, "@")
-			setIdx = 2 + i
+			names = strings.Split(strings.TrimPrefix(a, "@"), "+")
+			setIdx = idx + i
 			args = append(args[:setIdx], args[setIdx+1:]...)
 			break
 		}
 	}
 
-	if set != "" {
-		setArgs, _ := config.GetStringSlice(args[1] + "." + set)
-		for _, arg := range setArgs {
-			parts := strings.Fields(arg)
-			args = append(args[:setIdx], append(parts, args[setIdx:]...)...)
-			setIdx += len(parts)
+	if setIdx < 0 {
+		return args
+	}
+
+	var flags, removals []string
+	for _, name := range names {
+		f, r := resolveSet(args[1]+"."+name, map[string]bool{}, 0)
+		flags = append(flags, f...)
+		removals = append(removals, r...)
+	}
+
+	suffix := args[setIdx:]
+	prefix := stripFlags(args[:setIdx], removals)
+
+	return append(prefix, append(flags, suffix...)...)
+}
+
+// FlagSource produces a command's flag tokens for one precedence tier.
+// processCommandArgs walks sources in increasing precedence (Defaults,
+// ConfigFile, EnvVars, then the user's own CLI args, which already win by
+// virtue of being spliced in last - see injectConfigSet/injectEnvVars); this
+// interface exists so --explain-config can name and report on each tier
+// independently of that splicing mechanics.
+type FlagSource interface {
+	Name() string
+	Flags(cmdName string) []string
+}
+
+// defaultsSource represents the CLI's own built-in flag defaults (each
+// flag's Value in internal/command/flags.go). It contributes no tokens of
+// its own - urfave/cli applies them automatically when a flag is absent -
+// but is named here so --explain-config has a label for "nothing else set
+// this flag".
+type defaultsSource struct{}
+
+func (defaultsSource) Name() string          { return "defaults" }
+func (defaultsSource) Flags(string) []string { return nil }
+
+// configFileSource resolves the config-file sets (<cmd>.defaults, nostate,
+// defaults) the same way processCommandArgs always has, via resolveSet.
+type configFileSource struct{}
+
+func (configFileSource) Name() string { return "config" }
+
+func (configFileSource) Flags(cmdName string) []string {
+	var flags []string
+
+	f, _ := resolveSet(cmdName+".defaults", map[string]bool{}, 0)
+	flags = append(flags, f...)
+
+	if cmdName != "sq" {
+		f, _ = resolveSet("nostate", map[string]bool{}, 0)
+		flags = append(flags, f...)
+	}
+
+	f, _ = resolveSet("defaults", map[string]bool{}, 0)
+	flags = append(flags, f...)
+
+	return flags
+}
+
+// envVarsSource resolves TFCTL_<CMD>_<FLAG> environment variables for a
+// command via envVarFlags.
+type envVarsSource struct{}
+
+func (envVarsSource) Name() string                  { return "env" }
+func (envVarsSource) Flags(cmdName string) []string { return envVarFlags(cmdName) }
+
+// cliSource wraps the literal command-line tokens the user passed after the
+// RootDir/command position.
+type cliSource struct{ tokens []string }
+
+func (cliSource) Name() string            { return "cli" }
+func (s cliSource) Flags(string) []string { return s.tokens }
+
+// envVarFlags returns flag tokens derived from TFCTL_<CMD>_<FLAG>
+// environment variables for the given command, e.g. TFCTL_SQ_OUTPUT=json
+// becomes ["--output", "json"] for the "sq" command. This generalizes the
+// TFCTL_WORKSPACE convention workspaceFlag already uses (see
+// internal/command/flags.go) to any flag, so a CI/CD pipeline can override
+// a default without editing a committed tfctl config file. Results are
+// sorted by flag name for deterministic output (env var iteration order is
+// unspecified).
+func envVarFlags(cmdName string) []string {
+	prefix := "TFCTL_" + strings.ToUpper(cmdName) + "_"
+
+	type pair struct{ name, value string }
+	var pairs []pair
+
+	for _, e := range os.Environ() {
+		name, value, ok := strings.Cut(e, "=")
+		if !ok || !strings.HasPrefix(name, prefix) || value == "" {
+			continue
+		}
+		flagName := strings.ReplaceAll(strings.ToLower(strings.TrimPrefix(name, prefix)), "_", "-")
+		pairs = append(pairs, pair{flagName, value})
+	}
+
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i].name < pairs[j].name })
+
+	flags := make([]string, 0, len(pairs)*2)
+	for _, p := range pairs {
+		flags = append(flags, "--"+p.name, p.value)
+	}
+
+	return flags
+}
+
+// injectEnvVars splices envVarFlags(cmdName) in at insertIdx, the same way
+// injectConfigSet splices in a config-file set. It sits between the
+// config-file tiers and the user's own CLI args in processCommandArgs, so a
+// TFCTL_<CMD>_<FLAG> env var overrides a config-file default but is itself
+// overridden by an explicit command-line flag.
+func injectEnvVars(args []string, cmdName string, insertIdx int) []string {
+	flags := envVarFlags(cmdName)
+	if len(flags) == 0 {
+		return args
+	}
+
+	suffix := args[insertIdx:]
+	prefix := args[:insertIdx]
+
+	return append(prefix, append(flags, suffix...)...)
+}
+
+// extractExplainConfigFlag reports whether --explain-config appears
+// anywhere in args, returning args with it removed. It's checked directly
+// against raw args the same way handleVersion/hasCacheStatsFlag are, since
+// it needs to short-circuit before normal command processing rather than
+// being owned by a particular subcommand's flag set.
+func extractExplainConfigFlag(args []string) ([]string, bool) {
+	for i, a := range args {
+		if a == "--explain-config" {
+			rest := append([]string{}, args[:i]...)
+			rest = append(rest, args[i+1:]...)
+			return rest, true
+		}
+	}
+	return args, false
+}
+
+// runExplainConfig prints, for the command named in args, which FlagSource
+// won each flag and what the other sources contributed, then returns
+// without running the command.
+func runExplainConfig(args []string) int {
+	args = handleNakedCommand(args)
+
+	if len(args) < 2 || strings.HasPrefix(args[1], "-") {
+		fmt.Fprintln(os.Stderr, "--explain-config requires a command, e.g. tfctl sq --explain-config /path/to/iac")
+		return 1
+	}
+	cmdName := args[1]
+
+	insertIdx := 2
+	if len(args) > 2 {
+		if isExistingFile(args[2]) {
+			insertIdx = 3
+		} else if stat, err := os.Stat(args[2]); err == nil && stat.IsDir() {
+			insertIdx = 3
 		}
 	}
 
-	return args
+	fmt.Fprint(os.Stdout, explainConfig(cmdName, args[insertIdx:]))
+	return 0
+}
+
+// explainConfig renders the per-flag source attribution runExplainConfig
+// prints: for each flag set by any source, which source's value wins (the
+// highest-precedence one to set it) and what the other sources offered.
+func explainConfig(cmdName string, cliArgs []string) string {
+	sources := []FlagSource{
+		defaultsSource{},
+		configFileSource{},
+		envVarsSource{},
+		cliSource{tokens: cliArgs},
+	}
+
+	type contribution struct {
+		source string
+		tokens []string
+	}
+	perFlag := map[string][]contribution{}
+	var order []string
+
+	for _, src := range sources {
+		flags := src.Flags(cmdName)
+		for i := 0; i < len(flags); i++ {
+			arg := flags[i]
+			if !strings.HasPrefix(arg, "-") {
+				continue
+			}
+			name := strings.TrimLeft(arg, "-")
+			if before, _, ok := strings.Cut(name, "="); ok {
+				name = before
+			}
+
+			tokens := []string{arg}
+			if !strings.Contains(arg, "=") && i+1 < len(flags) && !strings.HasPrefix(flags[i+1], "-") {
+				i++
+				tokens = append(tokens, flags[i])
+			}
+
+			if len(perFlag[name]) == 0 {
+				order = append(order, name)
+			}
+			perFlag[name] = append(perFlag[name], contribution{src.Name(), tokens})
+		}
+	}
+
+	sort.Strings(order)
+
+	var b strings.Builder
+	for _, name := range order {
+		contributions := perFlag[name]
+		winner := contributions[len(contributions)-1]
+		fmt.Fprintf(&b, "--%s: %s wins (%s)\n", name, winner.source, strings.Join(winner.tokens, " "))
+		for _, c := range contributions[:len(contributions)-1] {
+			fmt.Fprintf(&b, "    overridden: %s contributed %s\n", c.source, strings.Join(c.tokens, " "))
+		}
+	}
+
+	if len(order) == 0 {
+		fmt.Fprintf(&b, "no flags set by config, env, or cli for %q\n", cmdName)
+	}
+
+	return b.String()
 }
@@ -5,7 +5,9 @@
 package main
 
 import (
+	"os"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -224,3 +226,239 @@ func splitFields(s string) []string {
 
 	return result
 }
+
+func TestStripFlags(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		removals []string
+		expected []string
+	}{
+		{
+			name:     "no removals",
+			args:     []string{"tfctl", "sq", "--titles"},
+			removals: nil,
+			expected: []string{"tfctl", "sq", "--titles"},
+		},
+		{
+			name:     "removes boolean flag",
+			args:     []string{"tfctl", "sq", "--titles", "--debug"},
+			removals: []string{"--titles"},
+			expected: []string{"tfctl", "sq", "--debug"},
+		},
+		{
+			name:     "removes flag with separate value",
+			args:     []string{"tfctl", "sq", "--output", "json", "--titles"},
+			removals: []string{"--output"},
+			expected: []string{"tfctl", "sq", "--titles"},
+		},
+		{
+			name:     "removes flag with equals value",
+			args:     []string{"tfctl", "sq", "--output=json", "--titles"},
+			removals: []string{"--output"},
+			expected: []string{"tfctl", "sq", "--titles"},
+		},
+		{
+			name:     "removes multiple occurrences",
+			args:     []string{"tfctl", "sq", "--titles", "--output", "json", "--titles"},
+			removals: []string{"--titles"},
+			expected: []string{"tfctl", "sq", "--output", "json"},
+		},
+		{
+			name:     "flag not present is a no-op",
+			args:     []string{"tfctl", "sq", "--debug"},
+			removals: []string{"--titles"},
+			expected: []string{"tfctl", "sq", "--debug"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := stripFlags(tt.args, tt.removals)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("stripFlags(%v, %v) = %v, want %v", tt.args, tt.removals, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSetNamespaceAndSetKey(t *testing.T) {
+	tests := []struct {
+		name      string
+		key       string
+		namespace string
+		setName   string
+		rebuilt   string
+	}{
+		{name: "namespaced key", key: "mq.defaults", namespace: "mq", setName: "other", rebuilt: "mq.other"},
+		{name: "unnamespaced key", key: "defaults", namespace: "", setName: "other", rebuilt: "other"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ns := setNamespace(tt.key)
+			if ns != tt.namespace {
+				t.Errorf("setNamespace(%q) = %q, want %q", tt.key, ns, tt.namespace)
+			}
+			if got := setKey(ns, tt.setName); got != tt.rebuilt {
+				t.Errorf("setKey(%q, %q) = %q, want %q", ns, tt.setName, got, tt.rebuilt)
+			}
+		})
+	}
+}
+
+func TestResolveSetComposition(t *testing.T) {
+	// resolveSetTestable mirrors resolveSet's logic against an in-memory
+	// lookup instead of the global config, so set composition ("@other"),
+	// removal entries ("!--flag"), cycle detection, and max-depth can be
+	// exercised without touching package-level config state.
+	tests := []struct {
+		name             string
+		sets             map[string][]string
+		key              string
+		expectedFlags    []string
+		expectedRemovals []string
+	}{
+		{
+			name:          "simple set, no composition",
+			sets:          map[string][]string{"mq.defaults": {"--debug"}},
+			key:           "mq.defaults",
+			expectedFlags: []string{"--debug"},
+		},
+		{
+			name: "composes a referenced set",
+			sets: map[string][]string{
+				"mq.defaults": {"--debug", "@filters"},
+				"mq.filters":  {"--env prod"},
+			},
+			key:           "mq.defaults",
+			expectedFlags: []string{"--debug", "--env", "prod"},
+		},
+		{
+			name: "referenced set resolves within the same namespace",
+			sets: map[string][]string{
+				"defaults": {"@filters"},
+				"filters":  {"--env prod"},
+			},
+			key:           "defaults",
+			expectedFlags: []string{"--env", "prod"},
+		},
+		{
+			name: "removal entry collected separately from flags",
+			sets: map[string][]string{
+				"mq.quiet": {"!--titles", "--debug"},
+			},
+			key:              "mq.quiet",
+			expectedFlags:    []string{"--debug"},
+			expectedRemovals: []string{"--titles"},
+		},
+		{
+			name: "cycle stops expansion instead of recursing forever",
+			sets: map[string][]string{
+				"mq.a": {"--a", "@b"},
+				"mq.b": {"--b", "@a"},
+			},
+			key:           "mq.a",
+			expectedFlags: []string{"--a", "--b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lookup := func(key string) []string { return tt.sets[key] }
+			flags, removals := resolveSetTestable(lookup, tt.key, map[string]bool{}, 0)
+			if !reflect.DeepEqual(flags, tt.expectedFlags) {
+				t.Errorf("flags = %v, want %v", flags, tt.expectedFlags)
+			}
+			if !reflect.DeepEqual(removals, tt.expectedRemovals) {
+				t.Errorf("removals = %v, want %v", removals, tt.expectedRemovals)
+			}
+		})
+	}
+}
+
+// resolveSetTestable is a test-friendly version of resolveSet that accepts a
+// lookup function directly instead of reading from global config.
+func resolveSetTestable(lookup func(string) []string, key string, path map[string]bool, depth int) (flags []string, removals []string) {
+	if path[key] || depth > maxSetDepth {
+		return nil, nil
+	}
+	path[key] = true
+	defer delete(path, key)
+
+	ns := setNamespace(key)
+	for _, entry := range lookup(key) {
+		for _, field := range splitFields(entry) {
+			switch {
+			case strings.HasPrefix(field, "@"):
+				subFlags, subRemovals := resolveSetTestable(lookup, setKey(ns, strings.TrimPrefix(field, "@")), path, depth+1)
+				flags = append(flags, subFlags...)
+				removals = append(removals, subRemovals...)
+			case strings.HasPrefix(field, "!"):
+				removals = append(removals, strings.TrimPrefix(field, "!"))
+			default:
+				flags = append(flags, field)
+			}
+		}
+	}
+
+	return flags, removals
+}
+
+func TestEnvVarFlags(t *testing.T) {
+	for _, k := range os.Environ() {
+		if name, _, ok := strings.Cut(k, "="); ok && strings.HasPrefix(name, "TFCTL_SQ_") {
+			t.Fatalf("unexpected TFCTL_SQ_* env var already set: %s", name)
+		}
+	}
+
+	t.Setenv("TFCTL_SQ_OUTPUT", "json")
+	t.Setenv("TFCTL_SQ_NO_CREDENTIALS_HELPER", "true")
+	t.Setenv("TFCTL_MQ_OUTPUT", "csv")
+	t.Setenv("TFCTL_SQ_EMPTY", "")
+
+	flags := envVarFlags("sq")
+	expected := []string{"--no-credentials-helper", "true", "--output", "json"}
+	if !reflect.DeepEqual(flags, expected) {
+		t.Errorf("envVarFlags(\"sq\") = %v, want %v", flags, expected)
+	}
+}
+
+func TestInjectEnvVars(t *testing.T) {
+	t.Setenv("TFCTL_SQ_OUTPUT", "json")
+
+	args := []string{"tfctl", "sq", "--titles"}
+	result := injectEnvVars(args, "sq", 2)
+	expected := []string{"tfctl", "sq", "--output", "json", "--titles"}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("injectEnvVars(%v) = %v, want %v", args, result, expected)
+	}
+}
+
+func TestInjectEnvVarsNoop(t *testing.T) {
+	args := []string{"tfctl", "sq", "--titles"}
+	result := injectEnvVars(args, "sq", 2)
+	if !reflect.DeepEqual(result, args) {
+		t.Errorf("injectEnvVars(%v) = %v, want unchanged", args, result)
+	}
+}
+
+func TestExplainConfig(t *testing.T) {
+	t.Setenv("TFCTL_SQ_OUTPUT", "json")
+
+	out := explainConfig("sq", []string{"--output", "text"})
+
+	if !strings.Contains(out, "--output: cli wins (--output text)") {
+		t.Errorf("explainConfig output missing cli-wins line: %s", out)
+	}
+	if !strings.Contains(out, "overridden: env contributed --output json") {
+		t.Errorf("explainConfig output missing overridden env contribution: %s", out)
+	}
+}
+
+func TestExplainConfigNoFlags(t *testing.T) {
+	out := explainConfig("sq", nil)
+	if !strings.Contains(out, `no flags set by config, env, or cli for "sq"`) {
+		t.Errorf("explainConfig output = %q, want the no-flags message", out)
+	}
+}
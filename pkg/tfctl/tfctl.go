@@ -0,0 +1,99 @@
+// Copyright (c) 2026 Steve Taranto <staranto@gmail.com>.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package tfctl is the public, stable surface for embedding tfctl as a
+// library -- listing workspaces, pulling state, flattening it, and
+// rendering it -- without depending on urfave/cli or any of the internal
+// packages' *cli.Command plumbing. The tfctl binary's own command package
+// is itself a thin adapter over the same internal/backend/remote and
+// internal/output calls this package wraps.
+//
+// Only the remote (TFC/TFE) backend is exposed here so far; the other
+// backend types (local, s3, consul, ...) share internal/backend.Backend's
+// shape but aren't yet wired through a cli.Command-free constructor like
+// remote.New.
+package tfctl
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/tidwall/gjson"
+
+	"github.com/tfctl/tfctl/internal/attrs"
+	"github.com/tfctl/tfctl/internal/backend/remote"
+	"github.com/tfctl/tfctl/internal/filters"
+	"github.com/tfctl/tfctl/internal/output"
+)
+
+// Config configures a Client constructed by New.
+type Config = remote.Config
+
+// RenderOptions controls Render's table formatting.
+type RenderOptions = output.RenderOptions
+
+// Client is a handle to one remote (TFC/TFE) workspace or organization,
+// built from a Config rather than a *cli.Command.
+type Client struct {
+	be *remote.BackendRemote
+}
+
+// New constructs a Client from cfg.
+func New(ctx context.Context, cfg Config) (*Client, error) {
+	be, err := remote.New(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{be: be}, nil
+}
+
+// Workspaces resolves the workspace(s) cfg targets: a single workspace if
+// Config.Workspace (or a prefixed/env workspace under RootDir) names one,
+// or every workspace matching workspaces.tags/workspaces.project under a
+// RootDir-loaded backend config otherwise.
+func (c *Client) Workspaces(ctx context.Context) ([]*tfe.Workspace, error) {
+	return c.be.ResolveWorkspaces(ctx)
+}
+
+// State pulls the current (or Config.SvOverride-pinned) state document as
+// raw JSON bytes, exactly as the backend returns it -- still encrypted, if
+// the workspace uses OpenTofu state encryption.
+func (c *Client) State() ([]byte, error) {
+	return c.be.State()
+}
+
+// Flatten parses a state document (as returned by State) into the same
+// per-instance row shape the sq command renders: one row per resource
+// instance, with module/mode/index folded into a single "resource" field.
+// ok is false if raw isn't a state document.
+func Flatten(raw []byte) (rows []map[string]interface{}, ok bool) {
+	return output.FlattenStateResources(raw)
+}
+
+// Filter applies a tfctl filter expression (the same syntax as the --filter
+// flag) to rows already produced by Flatten, projecting them down to al's
+// included attributes in the process. It round-trips rows through JSON to
+// build the gjson.Result FilterDataset expects, since FilterDataset has no
+// plain-map entry point of its own.
+func Filter(rows []map[string]interface{}, al attrs.AttrList, spec string) ([]map[string]interface{}, error) {
+	data, err := json.Marshal(rows)
+	if err != nil {
+		return nil, err
+	}
+	return filters.FilterDataset(gjson.ParseBytes(data), al, spec), nil
+}
+
+// Sort orders rows in place per spec, the same comma-separated
+// attribute[:desc] syntax as the --sort flag.
+func Sort(rows []map[string]interface{}, spec string) {
+	output.SortDataset(rows, spec)
+}
+
+// Render writes rows as a table to w per opts. For other formats (csv, tsv,
+// ndjson, parquet, markdown, html), use output.Encoders[format].Encode
+// directly -- those encoders already take no *cli.Command.
+func Render(rows []map[string]interface{}, al attrs.AttrList, opts RenderOptions, w io.Writer) {
+	output.Render(rows, al, opts, w, nil)
+}